@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
 	admin "google.golang.org/api/admin/directory/v1"
 	analyticsadmin "google.golang.org/api/analyticsadmin/v1beta"
 	analyticsdata "google.golang.org/api/analyticsdata/v1beta"
@@ -45,9 +46,11 @@ type IO struct {
 
 type (
 	AdminDirectoryServiceFactory func(context.Context, string) (*admin.Service, error)
+	AdminDataTransferFactory     func(context.Context, string) (*datatransfer.Service, error)
 	AppScriptServiceFactory      func(context.Context, string) (*script.Service, error)
 	AnalyticsAdminServiceFactory func(context.Context, string) (*analyticsadmin.Service, error)
 	AnalyticsDataServiceFactory  func(context.Context, string) (*analyticsdata.Service, error)
+	BatchHTTPClientFactory       func(context.Context, string, googleauth.Service) (*http.Client, error)
 	CalendarServiceFactory       func(context.Context, string) (*calendar.Service, error)
 	ChatServiceFactory           func(context.Context, string) (*chat.Service, error)
 	ClassroomServiceFactory      func(context.Context, string) (*classroom.Service, error)
@@ -70,6 +73,7 @@ type (
 	SlidesServiceFactory         func(context.Context, string) (*slides.Service, error)
 	TasksServiceFactory          func(context.Context, string) (*tasks.Service, error)
 	YouTubeServiceFactory        func(context.Context, string) (*youtube.Service, error)
+	YouTubeHTTPClientFactory     func(context.Context, string) (*http.Client, error)
 	ZoomMeetingClientFactory     func(context.Context, string) (ZoomMeetingClient, error)
 	DriveDownloadFunc            func(context.Context, *drive.Service, string) (*http.Response, error)
 	DriveExportFunc              func(context.Context, *drive.Service, string, string) (*http.Response, error)
@@ -92,9 +96,11 @@ type ZoomMeetingClient interface {
 type Services struct {
 	AdminDirectory  AdminDirectoryServiceFactory
 	AdminOrgUnit    AdminDirectoryServiceFactory
+	AdminTransfer   AdminDataTransferFactory
 	AppScript       AppScriptServiceFactory
 	AnalyticsAdmin  AnalyticsAdminServiceFactory
 	AnalyticsData   AnalyticsDataServiceFactory
+	Batch           BatchHTTPClientFactory
 	Calendar        CalendarServiceFactory
 	Chat            ChatServiceFactory
 	Classroom       ClassroomServiceFactory
@@ -114,6 +120,7 @@ type Services struct {
 	PeopleDirectory PeopleServiceFactory
 	PeopleOther     PeopleServiceFactory
 	Photos          PhotosServiceFactory
+	PhotosWrite     PhotosServiceFactory
 	PhotosPicker    PhotosPickerServiceFactory
 	SearchConsole   SearchConsoleServiceFactory
 	Sheets          SheetsServiceFactory
@@ -124,6 +131,7 @@ type Services struct {
 	YouTubeAccount  YouTubeServiceFactory
 	YouTubeComments YouTubeServiceFactory
 	YouTubeWrite    YouTubeServiceFactory
+	YouTubeUpload   YouTubeHTTPClientFactory
 	Zoom            ZoomMeetingClientFactory
 	DriveDownload   DriveDownloadFunc
 	DriveExport     DriveExportFunc
@@ -0,0 +1,241 @@
+// Package audit records mutating Google API requests to JSONL files, syslog,
+// or an HTTP collector, and reads the JSONL log back.
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steipete/gogcli/internal/filelock"
+)
+
+const (
+	lockTimeout = 5 * time.Second
+	httpTimeout = 5 * time.Second
+	// maxLineBytes bounds one JSONL entry when reading the log.
+	maxLineBytes = 4 << 20
+)
+
+// Entry is one audited API request.
+type Entry struct {
+	Time    time.Time `json:"time"`
+	Command string    `json:"command,omitempty"`
+	Account string    `json:"account,omitempty"`
+	Service string    `json:"service,omitempty"`
+	// Method is the HTTP method; APIMethod is the request path with resource
+	// IDs replaced by {id}, e.g. "drive/v3/files/{id}/permissions/{id}".
+	Method     string   `json:"method"`
+	APIMethod  string   `json:"api_method"`
+	URL        string   `json:"url"`
+	Resources  []string `json:"resources,omitempty"`
+	Status     int      `json:"status,omitempty"`
+	RequestID  string   `json:"request_id,omitempty"`
+	Error      string   `json:"error,omitempty"`
+	Batch      bool     `json:"batch,omitempty"`
+	DurationMS int64    `json:"duration_ms"`
+	// RequestBytes is always recorded; Body only when bodies are enabled.
+	RequestBytes int             `json:"request_bytes,omitempty"`
+	Body         json.RawMessage `json:"body,omitempty"`
+}
+
+// Sink stores entries.
+type Sink interface {
+	Write(ctx context.Context, entry Entry) error
+}
+
+// Options configures a Logger.
+type Options struct {
+	Sinks []Sink
+	// Bodies records redacted JSON request bodies.
+	Bodies bool
+}
+
+// Logger fans entries out to its sinks. Sinks are resolved on first use so
+// commands that never write pay nothing.
+type Logger struct {
+	command string
+	resolve func() (Options, error)
+
+	once sync.Once
+	opts Options
+}
+
+// New returns a logger for command. resolve may return no sinks, which
+// disables auditing.
+func New(command string, resolve func() (Options, error)) *Logger {
+	return &Logger{command: command, resolve: resolve}
+}
+
+func (l *Logger) options() Options {
+	l.once.Do(func() {
+		if l.resolve == nil {
+			return
+		}
+		opts, err := l.resolve()
+		if err != nil {
+			slog.Warn("audit log disabled", "err", err)
+			return
+		}
+		l.opts = opts
+	})
+	return l.opts
+}
+
+// Enabled reports whether any sink is configured.
+func (l *Logger) Enabled() bool {
+	return l != nil && len(l.options().Sinks) > 0
+}
+
+// Bodies reports whether request bodies should be recorded.
+func (l *Logger) Bodies() bool {
+	return l != nil && l.options().Bodies
+}
+
+// Record writes entry to every sink. The API call already happened, so sink
+// failures are logged rather than returned.
+func (l *Logger) Record(ctx context.Context, entry Entry) {
+	if !l.Enabled() {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now().UTC()
+	}
+	if entry.Command == "" {
+		entry.Command = l.command
+	}
+	for _, sink := range l.options().Sinks {
+		if err := sink.Write(ctx, entry); err != nil {
+			slog.Warn("audit sink write failed", "err", err)
+		}
+	}
+}
+
+// FileSink appends JSON lines to a file shared by concurrent gog processes.
+type FileSink struct {
+	Path string
+}
+
+func (s FileSink) Write(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0o700); err != nil {
+		return fmt.Errorf("create audit log directory: %w", err)
+	}
+	return filelock.Shared(s.Path+".lock", lockTimeout).WithExclusive(func() error {
+		f, err := os.OpenFile(s.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return fmt.Errorf("open audit log: %w", err)
+		}
+		defer f.Close()
+		if _, err := f.Write(append(line, '\n')); err != nil {
+			return fmt.Errorf("write audit log: %w", err)
+		}
+		return nil
+	})
+}
+
+// HTTPSink POSTs each entry as JSON to a collector.
+type HTTPSink struct {
+	URL    string
+	Header map[string]string
+	Client *http.Client
+}
+
+func (s HTTPSink) Write(ctx context.Context, entry Entry) error {
+	body, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), httpTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("build audit request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range s.Header {
+		req.Header.Set(k, v)
+	}
+	client := s.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send audit entry: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("send audit entry: %s", resp.Status)
+	}
+	return nil
+}
+
+// Filter selects entries from a log file.
+type Filter struct {
+	Since   time.Time
+	Account string
+	Service string
+}
+
+func (f Filter) match(e Entry) bool {
+	if !f.Since.IsZero() && e.Time.Before(f.Since) {
+		return false
+	}
+	if f.Account != "" && !strings.EqualFold(e.Account, f.Account) {
+		return false
+	}
+	if f.Service != "" && !strings.EqualFold(e.Service, f.Service) {
+		return false
+	}
+	return true
+}
+
+// ReadFile returns the entries in a JSONL log that match filter, oldest
+// first. A missing file has no entries.
+func ReadFile(path string, filter Filter) ([]Entry, error) {
+	f, err := os.Open(path) //nolint:gosec // user-configured audit log path
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("open audit log: %w", err)
+	}
+	defer f.Close()
+
+	var out []Entry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxLineBytes)
+	for line := 1; scanner.Scan(); line++ {
+		data := bytes.TrimSpace(scanner.Bytes())
+		if len(data) == 0 {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+		if filter.match(entry) {
+			out = append(out, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read audit log: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,106 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDescribePath(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		path      string
+		method    string
+		resources string
+	}{
+		{"/drive/v3/files/F1/permissions/P1", "drive/v3/files/{id}/permissions/{id}", "files/F1,permissions/P1"},
+		{"/gmail/v1/users/me/messages/M1/modify", "gmail/v1/users/{id}/messages/{id}/modify", "users/me,messages/M1"},
+		{"/v4/spreadsheets/S1:batchUpdate", "v4/spreadsheets/{id}:batchUpdate", "spreadsheets/S1"},
+		{"/calendar/v3/calendars/a%40b.com/events", "calendar/v3/calendars/{id}/events", "calendars/a@b.com"},
+		{"/upload/drive/v3/files", "upload/drive/v3/files", ""},
+		{"/oauth2/token", "oauth2/token", ""},
+	}
+	for _, tt := range tests {
+		method, resources := DescribePath(tt.path)
+		if method != tt.method || strings.Join(resources, ",") != tt.resources {
+			t.Errorf("DescribePath(%q) = %q %v", tt.path, method, resources)
+		}
+	}
+}
+
+func TestFileSinkAndReadFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "logs", "audit.jsonl")
+	now := time.Now().UTC()
+	logger := New("drive delete", func() (Options, error) {
+		return Options{Sinks: []Sink{FileSink{Path: path}}}, nil
+	})
+	logger.Record(context.Background(), Entry{Time: now.Add(-48 * time.Hour), Account: "a@b.com", Service: "drive", Method: "DELETE"})
+	logger.Record(context.Background(), Entry{Time: now, Account: "a@b.com", Service: "drive", Method: "DELETE"})
+	logger.Record(context.Background(), Entry{Time: now, Account: "c@b.com", Service: "gmail", Method: "POST", Command: "gmail send"})
+
+	all, err := ReadFile(path, Filter{})
+	if err != nil || len(all) != 3 || all[0].Command != "drive delete" || all[2].Command != "gmail send" {
+		t.Fatalf("all = %+v, %v", all, err)
+	}
+	recent, err := ReadFile(path, Filter{Since: now.Add(-time.Hour), Service: "DRIVE"})
+	if err != nil || len(recent) != 1 || recent[0].Account != "a@b.com" {
+		t.Fatalf("recent = %+v, %v", recent, err)
+	}
+	byAccount, err := ReadFile(path, Filter{Account: "c@b.com"})
+	if err != nil || len(byAccount) != 1 {
+		t.Fatalf("by account = %+v, %v", byAccount, err)
+	}
+
+	missing, err := ReadFile(filepath.Join(t.TempDir(), "none.jsonl"), Filter{})
+	if err != nil || missing != nil {
+		t.Fatalf("missing = %+v, %v", missing, err)
+	}
+}
+
+func TestLoggerWithoutSinksIsDisabled(t *testing.T) {
+	t.Parallel()
+
+	calls := 0
+	logger := New("x", func() (Options, error) {
+		calls++
+		return Options{}, nil
+	})
+	logger.Record(context.Background(), Entry{Method: "DELETE"})
+	if logger.Enabled() || calls != 1 {
+		t.Fatalf("enabled = %v, resolve calls = %d", logger.Enabled(), calls)
+	}
+
+	var nilLogger *Logger
+	if nilLogger.Enabled() {
+		t.Fatal("nil logger enabled")
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	t.Parallel()
+
+	var got Entry
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer collector" {
+			t.Errorf("headers = %v", r.Header)
+		}
+		_ = json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	sink := HTTPSink{URL: srv.URL, Header: map[string]string{"Authorization": "Bearer collector"}, Client: srv.Client()}
+	if err := sink.Write(context.Background(), Entry{Method: "PATCH", APIMethod: "drive/v3/files/{id}"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if got.Method != "PATCH" || got.APIMethod != "drive/v3/files/{id}" {
+		t.Fatalf("got = %+v", got)
+	}
+}
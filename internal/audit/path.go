@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+var versionSegment = regexp.MustCompile(`^v\d+[a-z0-9]*$`)
+
+// DescribePath splits a Google API URL path into a method template and the
+// resources it names. Path segments after the API version alternate between
+// collections and IDs, so
+//
+//	/drive/v3/files/F1/permissions/P1
+//
+// becomes "drive/v3/files/{id}/permissions/{id}" with resources
+// ["files/F1", "permissions/P1"]. A trailing ":verb" stays in the template.
+// Paths without a version segment are returned unchanged.
+func DescribePath(path string) (string, []string) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	version := -1
+	for i, seg := range segments {
+		if versionSegment.MatchString(seg) {
+			version = i
+			break
+		}
+	}
+	if version < 0 {
+		return strings.Trim(path, "/"), nil
+	}
+
+	template := append([]string(nil), segments[:version+1]...)
+	var resources []string
+	rest := segments[version+1:]
+	for i := 0; i < len(rest); i++ {
+		collection := unescape(rest[i])
+		if i+1 >= len(rest) {
+			template = append(template, collection)
+			break
+		}
+		id, verb, _ := strings.Cut(unescape(rest[i+1]), ":")
+		i++
+		part := collection + "/{id}"
+		if verb != "" {
+			part += ":" + verb
+		}
+		template = append(template, part)
+		resources = append(resources, collection+"/"+id)
+	}
+	return strings.Join(template, "/"), resources
+}
+
+func unescape(seg string) string {
+	if out, err := url.PathUnescape(seg); err == nil {
+		return out
+	}
+	return seg
+}
@@ -0,0 +1,35 @@
+//go:build !windows && !plan9
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/syslog"
+)
+
+// SyslogSink sends entries as JSON to the local syslog daemon.
+type SyslogSink struct {
+	w *syslog.Writer
+}
+
+// NewSyslogSink connects to syslog with tag.
+func NewSyslogSink(tag string) (*SyslogSink, error) {
+	w, err := syslog.New(syslog.LOG_NOTICE|syslog.LOG_AUTH, tag)
+	if err != nil {
+		return nil, fmt.Errorf("connect to syslog: %w", err)
+	}
+	return &SyslogSink{w: w}, nil
+}
+
+func (s *SyslogSink) Write(_ context.Context, entry Entry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("encode audit entry: %w", err)
+	}
+	if err := s.w.Notice(string(line)); err != nil {
+		return fmt.Errorf("write syslog: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build windows || plan9
+
+package audit
+
+import (
+	"context"
+	"errors"
+)
+
+var errSyslogUnsupported = errors.New("syslog audit sink is not supported on this platform")
+
+// SyslogSink is unavailable on this platform.
+type SyslogSink struct{}
+
+// NewSyslogSink always fails on this platform.
+func NewSyslogSink(string) (*SyslogSink, error) {
+	return nil, errSyslogUnsupported
+}
+
+func (*SyslogSink) Write(context.Context, Entry) error {
+	return errSyslogUnsupported
+}
@@ -0,0 +1,116 @@
+// Package authbroker hands short-lived, scope-narrowed Google access tokens
+// to gog processes over a unix socket, so callers never read refresh tokens
+// or open the keyring themselves.
+package authbroker
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	tokenPath = "/v1/token"
+
+	// DefaultTTL caps how long a caller may use a minted token when the
+	// policy does not say otherwise.
+	DefaultTTL = 10 * time.Minute
+)
+
+// TokenRequest asks the broker for an access token.
+type TokenRequest struct {
+	Account string   `json:"account"`
+	Service string   `json:"service"`
+	Scopes  []string `json:"scopes"`
+}
+
+// TokenResponse carries a minted access token. ExpiresAt is the earlier of
+// Google's expiry and the caller's TTL.
+type TokenResponse struct {
+	AccessToken string    `json:"access_token"`
+	ExpiresAt   time.Time `json:"expires_at"`
+	Scopes      []string  `json:"scopes"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Error is a request the broker refused or failed to serve.
+type Error struct {
+	Status  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return "auth broker: " + e.Message
+}
+
+// Policy decides which callers may mint which tokens.
+type Policy struct {
+	// Callers are matched by peer uid in order; the first match applies.
+	// With no callers, only the broker's own uid is served, without limits.
+	Callers []CallerPolicy
+	// DefaultTTL applies to callers without their own TTL.
+	DefaultTTL time.Duration
+}
+
+// CallerPolicy limits one caller. Empty lists allow everything.
+type CallerPolicy struct {
+	Name string
+	// UID matches the caller's unix user id; nil matches any user.
+	UID      *int
+	Accounts []string
+	Services []string
+	Scopes   []string
+	TTL      time.Duration
+}
+
+func (p Policy) match(uid int, ownUID int) (CallerPolicy, bool) {
+	if len(p.Callers) == 0 {
+		return CallerPolicy{Name: "owner"}, uid == ownUID
+	}
+
+	for _, caller := range p.Callers {
+		if caller.UID == nil || *caller.UID == uid {
+			return caller, true
+		}
+	}
+
+	return CallerPolicy{}, false
+}
+
+func (p Policy) ttl(caller CallerPolicy) time.Duration {
+	switch {
+	case caller.TTL > 0:
+		return caller.TTL
+	case p.DefaultTTL > 0:
+		return p.DefaultTTL
+	default:
+		return DefaultTTL
+	}
+}
+
+// authorize explains why req is denied, or returns "".
+func (c CallerPolicy) authorize(req TokenRequest) string {
+	if len(c.Accounts) > 0 && !slices.ContainsFunc(c.Accounts, func(account string) bool {
+		return account == "*" || strings.EqualFold(account, req.Account)
+	}) {
+		return fmt.Sprintf("account %s is not allowed", req.Account)
+	}
+
+	if len(c.Services) > 0 && !slices.Contains(c.Services, req.Service) {
+		return fmt.Sprintf("service %s is not allowed", req.Service)
+	}
+
+	if len(c.Scopes) > 0 {
+		for _, scope := range req.Scopes {
+			if !slices.Contains(c.Scopes, scope) {
+				return fmt.Sprintf("scope %s is not allowed", scope)
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,154 @@
+//go:build linux || darwin
+
+package authbroker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) lines(t *testing.T) []AuditEntry {
+	t.Helper()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []AuditEntry
+	for _, line := range strings.Split(strings.TrimSpace(b.buf.String()), "\n") {
+		var entry AuditEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("audit line %q: %v", line, err)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+func TestBroker_MintsNarrowedTokensPerPolicy(t *testing.T) {
+	// Unix socket paths are limited to ~100 bytes, so avoid t.TempDir().
+	dir, err := os.MkdirTemp("", "gogb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, "run", "broker.sock")
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	uid := os.Getuid()
+	mints := 0
+	var audit syncBuffer
+	srv := &Server{
+		Mint: func(_ context.Context, account, service string, scopes []string) (*oauth2.Token, error) {
+			mints++
+			return &oauth2.Token{AccessToken: "at-" + account + "-" + service + "-" + strings.Join(scopes, ","), Expiry: now.Add(time.Hour)}, nil
+		},
+		Policy: Policy{Callers: []CallerPolicy{{
+			Name:     "agent",
+			UID:      &uid,
+			Accounts: []string{"a@example.com"},
+			Services: []string{"drive"},
+			Scopes:   []string{"drive.readonly", "drive.file"},
+			TTL:      5 * time.Minute,
+		}}},
+		Audit: &audit,
+		Now:   func() time.Time { return now },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ln, err := Listen(ctx, socket, 0o600)
+	if err != nil {
+		t.Fatalf("Listen: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- srv.Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+
+	info, err := os.Stat(socket)
+	if err != nil || info.Mode().Perm() != 0o600 {
+		t.Fatalf("socket mode = %v, %v", info, err)
+	}
+	if _, err := Listen(ctx, socket, 0o600); err == nil || !errors.Is(err, errSocketInUse) {
+		t.Fatalf("second Listen err = %v", err)
+	}
+
+	client := NewClient(socket)
+	ts, err := client.TokenSource(ctx, "A@example.com", "drive", []string{"drive.readonly"})
+	if err != nil {
+		t.Fatalf("TokenSource: %v", err)
+	}
+	tok, err := ts.Token()
+	if err != nil {
+		t.Fatalf("Token: %v", err)
+	}
+	if tok.AccessToken != "at-A@example.com-drive-drive.readonly" || !tok.Expiry.Equal(now.Add(5*time.Minute)) {
+		t.Fatalf("token = %+v", tok)
+	}
+	if _, err := client.Token(ctx, TokenRequest{Account: "a@example.com", Service: "drive", Scopes: []string{"drive.readonly"}}); err != nil {
+		t.Fatalf("cached Token: %v", err)
+	}
+	if mints != 1 {
+		t.Fatalf("mints = %d, want cached second token", mints)
+	}
+
+	for _, req := range []TokenRequest{
+		{Account: "a@example.com", Service: "drive", Scopes: []string{"drive"}},
+		{Account: "a@example.com", Service: "gmail", Scopes: []string{"drive.file"}},
+		{Account: "b@example.com", Service: "drive", Scopes: []string{"drive.file"}},
+	} {
+		_, err := client.Token(ctx, req)
+		var brokerErr *Error
+		if !errors.As(err, &brokerErr) || brokerErr.Status != http.StatusForbidden {
+			t.Fatalf("Token(%+v) err = %v", req, err)
+		}
+	}
+
+	entries := audit.lines(t)
+	if len(entries) != 5 {
+		t.Fatalf("audit entries = %+v", entries)
+	}
+	if !entries[0].Allowed || entries[0].Caller != "agent" || entries[0].UID != uid || entries[0].PID != os.Getpid() {
+		t.Fatalf("allowed entry = %+v", entries[0])
+	}
+	if entries[2].Allowed || entries[2].Reason != "scope drive is not allowed" {
+		t.Fatalf("denied entry = %+v", entries[2])
+	}
+}
+
+func TestListen_RejectsSharedSocketDirectory(t *testing.T) {
+	dir, err := os.MkdirTemp("", "gogb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	if err := os.Chmod(dir, 0o777); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Listen(context.Background(), filepath.Join(dir, "broker.sock"), 0o600)
+	if err == nil || !strings.Contains(err.Error(), "writable by group or others") {
+		t.Fatalf("err = %v", err)
+	}
+}
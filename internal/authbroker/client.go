@@ -0,0 +1,103 @@
+package authbroker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const clientTimeout = 30 * time.Second
+
+// Client requests tokens from a broker socket.
+type Client struct {
+	socket string
+	http   *http.Client
+}
+
+// NewClient returns a client for the broker listening on socket.
+func NewClient(socket string) *Client {
+	return &Client{
+		socket: socket,
+		http: &http.Client{
+			Timeout: clientTimeout,
+			Transport: &http.Transport{
+				DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+					var dialer net.Dialer
+					return dialer.DialContext(ctx, "unix", socket)
+				},
+			},
+		},
+	}
+}
+
+// Token asks the broker for a token.
+func (c *Client) Token(ctx context.Context, req TokenRequest) (*oauth2.Token, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("encode broker request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://gog-broker"+tokenPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("build broker request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("auth broker at %s: %w", c.socket, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxRequestBytes))
+	if err != nil {
+		return nil, fmt.Errorf("read broker response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var failure errorResponse
+		if json.Unmarshal(data, &failure) != nil || failure.Error == "" {
+			failure.Error = strings.TrimSpace(string(data))
+		}
+		return nil, &Error{Status: resp.StatusCode, Message: failure.Error}
+	}
+
+	var out TokenResponse
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("decode broker response: %w", err)
+	}
+
+	return &oauth2.Token{AccessToken: out.AccessToken, TokenType: "Bearer", Expiry: out.ExpiresAt}, nil
+}
+
+// TokenSource returns a source that asks the broker again once the current
+// token's TTL runs out. The socket is checked before first use.
+func (c *Client) TokenSource(ctx context.Context, account, service string, scopes []string) (oauth2.TokenSource, error) {
+	if err := checkSocket(c.socket); err != nil {
+		return nil, err
+	}
+
+	return oauth2.ReuseTokenSource(nil, &brokerTokenSource{
+		ctx:    ctx,
+		client: c,
+		req:    TokenRequest{Account: account, Service: service, Scopes: scopes},
+	}), nil
+}
+
+type brokerTokenSource struct {
+	ctx    context.Context //nolint:containedctx // oauth2.TokenSource has no context parameter
+	client *Client
+	req    TokenRequest
+}
+
+func (s *brokerTokenSource) Token() (*oauth2.Token, error) {
+	return s.client.Token(s.ctx, s.req)
+}
@@ -0,0 +1,38 @@
+//go:build darwin
+
+package authbroker
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+func peerCredentials(conn net.Conn) (int, int, error) {
+	raw, err := rawUnixConn(conn)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var cred *unix.Xucred
+
+	var pid int
+
+	var credErr error
+
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptXucred(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERCRED)
+		if credErr == nil {
+			pid, _ = unix.GetsockoptInt(int(fd), unix.SOL_LOCAL, unix.LOCAL_PEERPID)
+		}
+	}); err != nil {
+		return 0, 0, fmt.Errorf("peer credentials: %w", err)
+	}
+
+	if credErr != nil {
+		return 0, 0, fmt.Errorf("peer credentials: %w", credErr)
+	}
+
+	return int(cred.Uid), pid, nil
+}
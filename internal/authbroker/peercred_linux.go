@@ -0,0 +1,33 @@
+//go:build linux
+
+package authbroker
+
+import (
+	"fmt"
+	"net"
+
+	"golang.org/x/sys/unix"
+)
+
+func peerCredentials(conn net.Conn) (int, int, error) {
+	raw, err := rawUnixConn(conn)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	var cred *unix.Ucred
+
+	var credErr error
+
+	if err := raw.Control(func(fd uintptr) {
+		cred, credErr = unix.GetsockoptUcred(int(fd), unix.SOL_SOCKET, unix.SO_PEERCRED)
+	}); err != nil {
+		return 0, 0, fmt.Errorf("peer credentials: %w", err)
+	}
+
+	if credErr != nil {
+		return 0, 0, fmt.Errorf("peer credentials: %w", credErr)
+	}
+
+	return int(cred.Uid), int(cred.Pid), nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package authbroker
+
+import (
+	"errors"
+	"net"
+)
+
+var errPeerCredentialsUnsupported = errors.New("peer credentials are not supported on this platform")
+
+func peerCredentials(net.Conn) (int, int, error) {
+	return 0, 0, errPeerCredentialsUnsupported
+}
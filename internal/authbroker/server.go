@@ -0,0 +1,296 @@
+package authbroker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+var (
+	errSocketInUse    = errors.New("another broker is already serving this socket")
+	errNotSocket      = errors.New("path exists and is not a unix socket")
+	errNoPeerCreds    = errors.New("caller credentials unavailable")
+	maxRequestBytes   = int64(64 << 10)
+	readHeaderTimeout = 5 * time.Second
+)
+
+// MintFunc returns an access token for account limited to scopes. service is
+// the gog service label, such as "gmail" or "drive".
+type MintFunc func(ctx context.Context, account, service string, scopes []string) (*oauth2.Token, error)
+
+// AuditEntry is one line of the broker audit log.
+type AuditEntry struct {
+	Time      time.Time  `json:"time"`
+	Caller    string     `json:"caller,omitempty"`
+	UID       int        `json:"uid"`
+	PID       int        `json:"pid,omitempty"`
+	Account   string     `json:"account,omitempty"`
+	Service   string     `json:"service,omitempty"`
+	Scopes    []string   `json:"scopes,omitempty"`
+	Allowed   bool       `json:"allowed"`
+	Reason    string     `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// Server mints tokens for callers on a unix socket.
+type Server struct {
+	Mint   MintFunc
+	Policy Policy
+	// Audit receives one JSON line per request; nil disables auditing.
+	Audit io.Writer
+	Now   func() time.Time
+
+	mu      sync.Mutex
+	auditMu sync.Mutex
+	cache   map[string]*oauth2.Token
+}
+
+type peerContextKey struct{}
+
+type peer struct {
+	UID int
+	PID int
+	err error
+}
+
+// Listen creates a unix socket at path with mode. The parent directory is
+// created 0700 if missing and must be owned by the current user and not
+// writable by group or others; a stale socket from a dead broker is replaced.
+func Listen(ctx context.Context, path string, mode os.FileMode) (net.Listener, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create socket directory: %w", err)
+	}
+
+	if err := checkSocketDir(dir); err != nil {
+		return nil, err
+	}
+
+	if info, err := os.Lstat(path); err == nil {
+		if info.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s: %w", path, errNotSocket)
+		}
+
+		var dialer net.Dialer
+		if conn, dialErr := dialer.DialContext(ctx, "unix", path); dialErr == nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("%s: %w", path, errSocketInUse)
+		}
+
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+
+	var lc net.ListenConfig
+
+	ln, err := lc.Listen(ctx, "unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("listen on %s: %w", path, err)
+	}
+
+	if err := os.Chmod(path, mode); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("chmod socket: %w", err)
+	}
+
+	return ln, nil
+}
+
+// Serve answers token requests on ln until ctx is done.
+func (s *Server) Serve(ctx context.Context, ln net.Listener) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST "+tokenPath, s.handleToken)
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: readHeaderTimeout,
+		ConnContext: func(ctx context.Context, conn net.Conn) context.Context {
+			uid, pid, err := peerCredentials(conn)
+			return context.WithValue(ctx, peerContextKey{}, peer{UID: uid, PID: pid, err: err})
+		},
+	}
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+		defer cancel()
+		_ = srv.Shutdown(shutdownCtx)
+		return nil
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	}
+}
+
+func (s *Server) handleToken(w http.ResponseWriter, r *http.Request) {
+	now := s.now()
+	p, _ := r.Context().Value(peerContextKey{}).(peer)
+	entry := AuditEntry{Time: now, UID: p.UID, PID: p.PID}
+
+	if p.err != nil {
+		entry.Reason = fmt.Sprintf("%v: %v", errNoPeerCreds, p.err)
+		s.audit(entry)
+		writeError(w, http.StatusForbidden, errNoPeerCreds.Error())
+		return
+	}
+
+	var req TokenRequest
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBytes)).Decode(&req); err != nil {
+		entry.Reason = "invalid request"
+		s.audit(entry)
+		writeError(w, http.StatusBadRequest, "invalid request: "+err.Error())
+		return
+	}
+
+	req.Account = strings.TrimSpace(req.Account)
+	req.Scopes = normalizeScopes(req.Scopes)
+	entry.Account, entry.Service, entry.Scopes = req.Account, req.Service, req.Scopes
+
+	if req.Account == "" || req.Service == "" || len(req.Scopes) == 0 {
+		entry.Reason = "account, service, and scopes are required"
+		s.audit(entry)
+		writeError(w, http.StatusBadRequest, entry.Reason)
+		return
+	}
+
+	caller, ok := s.Policy.match(p.UID, os.Getuid())
+	if !ok {
+		entry.Reason = fmt.Sprintf("no policy for uid %d", p.UID)
+		s.audit(entry)
+		writeError(w, http.StatusForbidden, entry.Reason)
+		return
+	}
+	entry.Caller = caller.Name
+
+	if reason := caller.authorize(req); reason != "" {
+		entry.Reason = reason
+		s.audit(entry)
+		writeError(w, http.StatusForbidden, reason)
+		return
+	}
+
+	ttl := s.Policy.ttl(caller)
+
+	tok, err := s.token(r.Context(), req, now, ttl)
+	if err != nil {
+		entry.Reason = err.Error()
+		s.audit(entry)
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	expiresAt := now.Add(ttl)
+	if !tok.Expiry.IsZero() && tok.Expiry.Before(expiresAt) {
+		expiresAt = tok.Expiry
+	}
+
+	entry.Allowed = true
+	entry.ExpiresAt = &expiresAt
+	s.audit(entry)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(TokenResponse{AccessToken: tok.AccessToken, ExpiresAt: expiresAt, Scopes: req.Scopes})
+}
+
+// token reuses a cached token while it outlives the caller's TTL, so
+// repeated short-lived requests do not each hit Google's token endpoint.
+func (s *Server) token(ctx context.Context, req TokenRequest, now time.Time, ttl time.Duration) (*oauth2.Token, error) {
+	key := strings.ToLower(req.Account) + "\x00" + req.Service + "\x00" + strings.Join(req.Scopes, " ")
+
+	s.mu.Lock()
+	cached := s.cache[key]
+	s.mu.Unlock()
+
+	if cached != nil && (cached.Expiry.IsZero() || cached.Expiry.After(now.Add(ttl))) {
+		return cached, nil
+	}
+
+	tok, err := s.Mint(ctx, req.Account, req.Service, req.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("mint token: %w", err)
+	}
+
+	s.mu.Lock()
+	if s.cache == nil {
+		s.cache = map[string]*oauth2.Token{}
+	}
+	s.cache[key] = tok
+	s.mu.Unlock()
+
+	return tok, nil
+}
+
+func (s *Server) audit(entry AuditEntry) {
+	if s.Audit == nil {
+		return
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	s.auditMu.Lock()
+	defer s.auditMu.Unlock()
+	_, _ = s.Audit.Write(append(line, '\n'))
+}
+
+func (s *Server) now() time.Time {
+	if s.Now != nil {
+		return s.Now()
+	}
+
+	return time.Now()
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(errorResponse{Error: message})
+}
+
+func normalizeScopes(scopes []string) []string {
+	out := make([]string, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			out = append(out, scope)
+		}
+	}
+
+	slices.Sort(out)
+
+	return slices.Compact(out)
+}
+
+func rawUnixConn(conn net.Conn) (syscall.RawConn, error) {
+	uc, ok := conn.(*net.UnixConn)
+	if !ok {
+		return nil, fmt.Errorf("%w: not a unix socket connection", errNoPeerCreds)
+	}
+
+	raw, err := uc.SyscallConn()
+	if err != nil {
+		return nil, fmt.Errorf("peer credentials: %w", err)
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package authbroker
+
+func checkSocketDir(string) error {
+	return errPeerCredentialsUnsupported
+}
+
+func checkSocket(string) error {
+	return nil
+}
@@ -0,0 +1,50 @@
+//go:build unix
+
+package authbroker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+)
+
+func checkSocketDir(dir string) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("socket directory: %w", err)
+	}
+
+	if st, ok := info.Sys().(*syscall.Stat_t); ok && int(st.Uid) != os.Getuid() {
+		return fmt.Errorf("socket directory %s is owned by uid %d, not the current user", dir, st.Uid)
+	}
+
+	if info.Mode().Perm()&0o022 != 0 {
+		return fmt.Errorf("socket directory %s is writable by group or others; run chmod go-w on it", dir)
+	}
+
+	return nil
+}
+
+// checkSocket refuses sockets that other users could have swapped in.
+func checkSocket(path string) error {
+	info, err := os.Lstat(path)
+	if err != nil {
+		return fmt.Errorf("auth broker socket: %w", err)
+	}
+
+	if info.Mode()&os.ModeSocket == 0 {
+		return fmt.Errorf("auth broker socket %s: %w", path, errNotSocket)
+	}
+
+	dir, err := os.Stat(filepath.Dir(path))
+	if err != nil {
+		return fmt.Errorf("auth broker socket directory: %w", err)
+	}
+
+	if dir.Mode().Perm()&0o002 != 0 && dir.Mode()&os.ModeSticky == 0 {
+		return fmt.Errorf("auth broker socket directory %s is writable by others", filepath.Dir(path))
+	}
+
+	return nil
+}
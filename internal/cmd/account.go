@@ -14,27 +14,30 @@ import (
 const (
 	accessTokenPlaceholderAccount = "access-token-user"
 	adcPlaceholderAccount         = "adc"
+	externalAccountPlaceholder    = "external-account"
 	directAccessTokenWarning      = "Note: Using direct access token (expires in ~1 hour; no auto-refresh)" //nolint:gosec // user-facing warning text, not a credential
 )
 
 func requireAccount(flags *RootFlags) (string, error) {
-	// In ADC mode the service account authenticates as itself — no user email
-	// or keyring lookup is needed. We still accept --account/GOG_ACCOUNT as an
-	// optional label (e.g. for logging), but it is not required.
-	if isADCAuthMode(flags) {
+	// In ADC and external-account modes the principal authenticates as
+	// itself — no user email or keyring lookup is needed. We still accept
+	// --account/GOG_ACCOUNT as an optional label (e.g. for logging), but it is
+	// not required.
+	if isKeylessAuthMode(flags) {
+		placeholder := keylessPlaceholderAccount(flags)
 		if v := flagAccount(flags); v != "" {
 			if shouldAutoSelectAccount(v) {
-				return adcPlaceholderAccount, nil
+				return placeholder, nil
 			}
 			return v, nil
 		}
 		if v := strings.TrimSpace(os.Getenv("GOG_ACCOUNT")); v != "" {
 			if shouldAutoSelectAccount(v) {
-				return adcPlaceholderAccount, nil
+				return placeholder, nil
 			}
 			return v, nil
 		}
-		return adcPlaceholderAccount, nil
+		return placeholder, nil
 	}
 
 	client := config.DefaultClientName
@@ -55,6 +58,11 @@ func requireAccount(flags *RootFlags) (string, error) {
 		return finalizeRequiredAccount(flags, accessTokenPlaceholderAccount), nil
 	}
 
+	// Broker clients cannot read the keyring to infer a default account.
+	if flags != nil && flags.brokerSocket != "" {
+		return "", usage("missing --account (or set GOG_ACCOUNT); required with GOG_BROKER_SOCKET")
+	}
+
 	if account, ok := inferredStoredAccount(client, flags); ok {
 		return account, nil
 	}
@@ -62,8 +70,15 @@ func requireAccount(flags *RootFlags) (string, error) {
 	return "", usage("missing --account (or set GOG_ACCOUNT, set default via `gog auth manage`, or store exactly one token)")
 }
 
-func isADCAuthMode(flags *RootFlags) bool {
-	return flags != nil && flags.authMode == googleapi.AuthModeADC
+func isKeylessAuthMode(flags *RootFlags) bool {
+	return flags != nil && flags.authMode.Keyless()
+}
+
+func keylessPlaceholderAccount(flags *RootFlags) string {
+	if flags != nil && flags.authMode == googleapi.AuthModeExternalAccount {
+		return externalAccountPlaceholder
+	}
+	return adcPlaceholderAccount
 }
 
 func configuredAccount(flags *RootFlags) (string, bool, error) {
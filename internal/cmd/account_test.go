@@ -173,6 +173,18 @@ func TestRequireAccount_ADCEnvAutoUsesPlaceholder(t *testing.T) {
 	}
 }
 
+func TestRequireAccount_ExternalAccountUsesPlaceholder(t *testing.T) {
+	t.Setenv("GOG_ACCOUNT", "")
+
+	got, err := requireAccount(&RootFlags{authMode: googleapi.AuthModeExternalAccount})
+	if err != nil {
+		t.Fatalf("err: %v", err)
+	}
+	if got != externalAccountPlaceholder {
+		t.Fatalf("got %q", got)
+	}
+}
+
 func TestRequireAccount_Missing(t *testing.T) {
 	t.Setenv("GOG_ACCOUNT", "")
 	flags := &RootFlags{}
@@ -112,6 +112,10 @@ func wrapAdminOrgUnitDirectoryError(err error, account string) error {
 	return wrapAdminDirectoryErrorWithScopes(err, account, "admin.directory.orgunit scope")
 }
 
+func wrapAdminDataTransferError(err error, account string) error {
+	return wrapAdminDirectoryErrorWithScopes(err, account, "admin.datatransfer scope")
+}
+
 func wrapAdminDirectoryErrorWithScopes(err error, account, scopes string) error {
 	errStr := err.Error()
 	if strings.Contains(errStr, "accessNotConfigured") ||
@@ -16,11 +16,18 @@ import (
 
 // AdminUsersCmd manages Workspace users.
 type AdminUsersCmd struct {
-	List    AdminUsersListCmd    `cmd:"" name:"list" aliases:"ls" help:"List users in a domain"`
-	Get     AdminUsersGetCmd     `cmd:"" name:"get" aliases:"info,show" help:"Get user details"`
-	Create  AdminUsersCreateCmd  `cmd:"" name:"create" aliases:"add,new" help:"Create a new user"`
-	Delete  AdminUsersDeleteCmd  `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a user account"`
-	Suspend AdminUsersSuspendCmd `cmd:"" name:"suspend" help:"Suspend a user account"`
+	List      AdminUsersListCmd      `cmd:"" name:"list" aliases:"ls" help:"List users in a domain"`
+	Get       AdminUsersGetCmd       `cmd:"" name:"get" aliases:"info,show" help:"Get user details"`
+	Create    AdminUsersCreateCmd    `cmd:"" name:"create" aliases:"add,new" help:"Create a new user"`
+	Update    AdminUsersUpdateCmd    `cmd:"" name:"update" aliases:"edit,set" help:"Update a user's name, email, password, or org unit"`
+	Delete    AdminUsersDeleteCmd    `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a user account"`
+	Undelete  AdminUsersUndeleteCmd  `cmd:"" name:"undelete" aliases:"restore" help:"Restore a recently deleted user"`
+	Suspend   AdminUsersSuspendCmd   `cmd:"" name:"suspend" help:"Suspend a user account"`
+	Unsuspend AdminUsersUnsuspendCmd `cmd:"" name:"unsuspend" aliases:"reactivate" help:"Unsuspend a user account"`
+	Aliases   AdminUsersAliasesCmd   `cmd:"" name:"aliases" aliases:"alias" help:"Manage a user's email aliases"`
+	Import    AdminUsersImportCmd    `cmd:"" name:"import" help:"Create users (and group memberships) from a CSV file"`
+	Sync      AdminUsersSyncCmd      `cmd:"" name:"sync" help:"Create and update users from a CSV file; --prune suspends users missing from it"`
+	Offboard  AdminUsersOffboardCmd  `cmd:"" name:"offboard" help:"Suspend a user, hand over their Drive, and remove them from groups"`
 }
 
 type AdminUsersListCmd struct {
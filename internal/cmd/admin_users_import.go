@@ -0,0 +1,392 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	admin "google.golang.org/api/admin/directory/v1"
+	ggoogleapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+var sleepBeforeAdminRetry = func(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// AdminUsersSourceFlags are shared by `admin users import` and `sync`.
+type AdminUsersSourceFlags struct {
+	File           string `arg:"" name:"csv" help:"CSV with an email column and optional given_name, family_name, password, org_unit, groups, recovery_email, recovery_phone, change_password, hash_function (- for stdin)"`
+	Domain         string `name:"domain" help:"Only accept rows in this domain (required for --prune)"`
+	CreateOrgUnits bool   `name:"create-org-units" help:"Create org units named in the file that do not exist yet"`
+	Concurrency    int    `name:"concurrency" aliases:"parallel" help:"Users processed in parallel" default:"4"`
+	Retries        int    `name:"retries" help:"Retries per call for rate limits, server errors, and newly created users that are not visible yet" default:"3"`
+	Report         string `name:"report" help:"Write a per-row result CSV, including generated passwords (mode 0600)"`
+}
+
+type AdminUsersImportCmd struct {
+	Source         AdminUsersSourceFlags `embed:""`
+	UpdateExisting bool                  `name:"update-existing" help:"Update names, org unit, and recovery details of users that already exist"`
+}
+
+func (c *AdminUsersImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return runAdminUsersImport(ctx, flags, "admin.users.import", c.Source, c.UpdateExisting, false)
+}
+
+type AdminUsersSyncCmd struct {
+	Source AdminUsersSourceFlags `embed:""`
+	Prune  bool                  `name:"prune" help:"Suspend active users in --domain that are missing from the file (super admins and the calling account are kept)"`
+}
+
+func (c *AdminUsersSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Prune && strings.TrimSpace(c.Source.Domain) == "" {
+		return usage("--prune requires --domain")
+	}
+	return runAdminUsersImport(ctx, flags, "admin.users.sync", c.Source, true, c.Prune)
+}
+
+// adminImportResult is one row of the result report.
+type adminImportResult struct {
+	Line        int      `json:"line,omitempty"`
+	Email       string   `json:"email"`
+	Action      string   `json:"action"`
+	Status      string   `json:"status"`
+	GroupsAdded []string `json:"groups_added,omitempty"`
+	Password    string   `json:"password,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+func runAdminUsersImport(ctx context.Context, flags *RootFlags, op string, source AdminUsersSourceFlags, update, prune bool) error {
+	u := ui.FromContext(ctx)
+	if source.Concurrency < 1 {
+		return usage("--concurrency must be >= 1")
+	}
+	if source.Retries < 0 {
+		return usage("--retries must be >= 0")
+	}
+	domain := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(source.Domain), "@"))
+	data, err := readTextInput(ctx, strings.TrimSpace(source.File))
+	if err != nil {
+		return err
+	}
+	rows, invalid, err := parseAdminUsersCSV(bytes.NewReader(data), domain)
+	if err != nil {
+		return err
+	}
+	if len(invalid) > 0 {
+		for _, row := range invalid {
+			u.Err().Linef("line %d\t%s\t%s", row.Line, row.Email, row.Error)
+		}
+		return usagef("%d invalid row%s; nothing was changed", len(invalid), pluralS(len(invalid)))
+	}
+
+	account, err := requireAdminAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := adminDirectoryService(ctx, account)
+	if err != nil {
+		return wrapAdminDirectoryError(err, account)
+	}
+	existing, err := listAdminImportUsers(ctx, svc, domain)
+	if err != nil {
+		return wrapAdminDirectoryError(err, account)
+	}
+
+	var orgUnits []string
+	var ouSvc *admin.Service
+	if adminImportNeedsOrgUnits(rows) {
+		ouSvc, err = adminOrgUnitDirectoryService(ctx, account)
+		if err != nil {
+			return wrapAdminOrgUnitDirectoryError(err, account)
+		}
+		resp, listErr := ouSvc.Orgunits.List(adminCustomerID).Type("all").Context(ctx).Do()
+		if listErr != nil {
+			return wrapAdminOrgUnitDirectoryError(listErr, account)
+		}
+		for _, ou := range resp.OrganizationUnits {
+			if ou != nil {
+				orgUnits = append(orgUnits, ou.OrgUnitPath)
+			}
+		}
+	}
+
+	plan := newAdminImportPlan(rows, existing, orgUnits, update, prune, account)
+	if len(plan.CreateOrgUnits) > 0 && !source.CreateOrgUnits {
+		return usagef("org unit%s not found: %s (create them or pass --create-org-units)", pluralS(len(plan.CreateOrgUnits)), strings.Join(plan.CreateOrgUnits, ", "))
+	}
+	if dryRunErr := dryRunExit(ctx, flags, op, plan.dryRunPayload()); dryRunErr != nil {
+		return dryRunErr
+	}
+	if suspends := plan.suspends(); len(suspends) > 0 {
+		action := fmt.Sprintf("suspend %d user%s missing from %s", len(suspends), pluralS(len(suspends)), source.File)
+		if confirmErr := confirmDestructiveChecked(ctx, flags, action); confirmErr != nil {
+			return confirmErr
+		}
+	}
+
+	for _, ouPath := range plan.CreateOrgUnits {
+		ouPlan, planErr := newAdminOrgUnitCreatePlan(adminOrgUnitCreateInput{Name: path.Base(ouPath), Parent: path.Dir(ouPath)})
+		if planErr != nil {
+			return planErr
+		}
+		if _, createErr := ouSvc.Orgunits.Insert(adminCustomerID, ouPlan.Request).Context(ctx).Do(); createErr != nil {
+			return wrapAdminOrgUnitDirectoryError(fmt.Errorf("create org unit %s: %w", ouPath, createErr), account)
+		}
+	}
+
+	results := applyAdminImportPlan(ctx, svc, plan, source.Concurrency, source.Retries)
+	failed := 0
+	for _, result := range results {
+		if result.Status == "failed" {
+			failed++
+		}
+	}
+	if source.Report != "" {
+		if reportErr := writeAdminImportReport(source.Report, results); reportErr != nil {
+			return reportErr
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"results":           results,
+			"counts":            plan.counts(),
+			"failed":            failed,
+			"created_org_units": plan.CreateOrgUnits,
+		}); err != nil {
+			return err
+		}
+	} else {
+		for _, ouPath := range plan.CreateOrgUnits {
+			u.Err().Linef("Created org unit %s", ouPath)
+		}
+		writeAdminImportTable(ctx, results)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d user%s failed", failed, len(results), pluralS(len(results)))
+	}
+	return nil
+}
+
+func adminImportNeedsOrgUnits(rows []adminImportRow) bool {
+	for _, row := range rows {
+		if row.Plan.User.OrgUnitPath != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func listAdminImportUsers(ctx context.Context, svc *admin.Service, domain string) ([]*admin.User, error) {
+	call := svc.Users.List().MaxResults(500)
+	if domain != "" {
+		call = call.Domain(domain)
+	} else {
+		call = call.Customer(adminCustomerID)
+	}
+	var users []*admin.User
+	err := call.Pages(ctx, func(resp *admin.Users) error {
+		users = append(users, resp.Users...)
+		return nil
+	})
+	return users, err
+}
+
+// applyAdminImportPlan runs every item with at most concurrency in flight and
+// returns results in plan order. A failed row does not stop the others.
+func applyAdminImportPlan(ctx context.Context, svc *admin.Service, plan adminImportPlan, concurrency, retries int) []adminImportResult {
+	results := make([]adminImportResult, len(plan.Items))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, item := range plan.Items {
+		wg.Add(1)
+		go func(idx int, item *adminImportItem) {
+			defer wg.Done()
+			result := adminImportResult{Line: item.Line, Email: item.Email, Action: item.Action}
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+				err := applyAdminImportItem(ctx, svc, item, retries, &result)
+				result.Status = "ok"
+				if err != nil {
+					result.Status, result.Error = "failed", err.Error()
+				}
+			case <-ctx.Done():
+				result.Status, result.Error = "failed", ctx.Err().Error()
+			}
+			results[idx] = result
+		}(i, item)
+	}
+	wg.Wait()
+	return results
+}
+
+func applyAdminImportItem(ctx context.Context, svc *admin.Service, item *adminImportItem, retries int, result *adminImportResult) error {
+	switch item.Action {
+	case adminImportCreate:
+		password := item.row.Plan.Password
+		if item.row.Plan.GeneratePassword {
+			generated, err := generateAdminUserPassword(16)
+			if err != nil {
+				return fmt.Errorf("generate password: %w", err)
+			}
+			password = generated
+			result.Password = generated
+		}
+		// A create that failed with a 5xx may still have been applied, so only
+		// rate limits are retried, and not by the transport either.
+		insertCtx := googleapi.WithoutRetries(ctx)
+		err := retryAdminCall(ctx, retries, adminRetryRateLimit, func() error {
+			_, err := svc.Users.Insert(item.row.Plan.insertRequest(password)).Context(insertCtx).Do()
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	case adminImportUpdate:
+		err := retryAdminCall(ctx, retries, adminRetryTransient, func() error {
+			_, err := svc.Users.Patch(item.Email, item.patch).Context(ctx).Do()
+			return err
+		})
+		if err != nil {
+			return err
+		}
+	case adminImportSuspend:
+		return retryAdminCall(ctx, retries, adminRetryTransient, func() error {
+			_, err := svc.Users.Patch(item.Email, &admin.User{Suspended: true}).Context(ctx).Do()
+			return err
+		})
+	}
+
+	membershipRetry := adminRetryTransient
+	if item.Action == adminImportCreate {
+		membershipRetry = adminRetryNotFound
+	}
+	for _, group := range item.Groups {
+		err := retryAdminCall(ctx, retries, membershipRetry, func() error {
+			_, err := svc.Members.Insert(group, &admin.Member{Email: item.Email, Role: adminRoleMember}).Context(ctx).Do()
+			return err
+		})
+		var apiErr *ggoogleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+			continue // already a member
+		}
+		if err != nil {
+			return fmt.Errorf("add to %s: %w", group, err)
+		}
+		result.GroupsAdded = append(result.GroupsAdded, group)
+	}
+	return nil
+}
+
+// adminRetry selects which API failures retryAdminCall retries.
+type adminRetry int
+
+const (
+	// adminRetryTransient retries rate limits and server errors.
+	adminRetryTransient adminRetry = iota
+	// adminRetryNotFound also retries 404s. Directory writes are eventually
+	// consistent, so a user created moments ago can still be "not found" for
+	// membership calls.
+	adminRetryNotFound
+	// adminRetryRateLimit retries only rate limits, for calls that must not
+	// be repeated after a server error.
+	adminRetryRateLimit
+)
+
+// retryAdminCall retries failures allowed by policy with backoff.
+func retryAdminCall(ctx context.Context, retries int, policy adminRetry, call func() error) error {
+	delay := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= retries {
+			return err
+		}
+		var apiErr *ggoogleapi.Error
+		if !errors.As(err, &apiErr) {
+			return err
+		}
+		retryable := apiErr.Code == http.StatusTooManyRequests ||
+			(policy != adminRetryRateLimit && apiErr.Code >= 500) ||
+			(policy == adminRetryNotFound && apiErr.Code == http.StatusNotFound)
+		if !retryable {
+			return err
+		}
+		if sleepErr := sleepBeforeAdminRetry(ctx, delay); sleepErr != nil {
+			return sleepErr
+		}
+		delay = min(delay*2, 8*time.Second)
+	}
+}
+
+func writeAdminImportTable(ctx context.Context, results []adminImportResult) {
+	u := ui.FromContext(ctx)
+	passwords := false
+	for _, r := range results {
+		passwords = passwords || r.Password != ""
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	header := "LINE\tEMAIL\tACTION\tSTATUS\tGROUPS_ADDED"
+	if passwords {
+		header += "\tPASSWORD"
+	}
+	fmt.Fprintln(w, header+"\tERROR")
+	for _, r := range results {
+		line := ""
+		if r.Line > 0 {
+			line = strconv.Itoa(r.Line)
+		}
+		cells := []string{line, r.Email, r.Action, r.Status, strings.Join(r.GroupsAdded, ",")}
+		if passwords {
+			cells = append(cells, r.Password)
+		}
+		cells = append(cells, r.Error)
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+	}
+	if len(results) == 0 {
+		u.Err().Println("No users in file")
+	}
+}
+
+func writeAdminImportReport(reportPath string, results []adminImportResult) error {
+	expanded, err := config.ExpandPath(reportPath)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	_ = w.Write([]string{"line", "email", "action", "status", "groups_added", "password", "error"})
+	for _, r := range results {
+		line := ""
+		if r.Line > 0 {
+			line = strconv.Itoa(r.Line)
+		}
+		_ = w.Write([]string{line, r.Email, r.Action, r.Status, strings.Join(r.GroupsAdded, ";"), r.Password, r.Error})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+	// The report can hold passwords: write a fresh 0600 file rather than
+	// reusing an existing file's mode.
+	return config.WriteFileAtomic(expanded, buf.Bytes(), 0o600)
+}
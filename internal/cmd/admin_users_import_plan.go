@@ -0,0 +1,330 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+const (
+	adminImportCreate    = "create"
+	adminImportUpdate    = "update"
+	adminImportUnchanged = "unchanged"
+	adminImportSuspend   = "suspend"
+)
+
+// adminImportColumns maps accepted header spellings to the canonical column.
+var adminImportColumns = map[string]string{
+	"email":           "email",
+	"primary_email":   "email",
+	"primaryemail":    "email",
+	"user":            "email",
+	"given_name":      "given_name",
+	"given":           "given_name",
+	"first_name":      "given_name",
+	"firstname":       "given_name",
+	"family_name":     "family_name",
+	"family":          "family_name",
+	"last_name":       "family_name",
+	"lastname":        "family_name",
+	"password":        "password",
+	"org_unit":        "org_unit",
+	"org_unit_path":   "org_unit",
+	"orgunit":         "org_unit",
+	"ou":              "org_unit",
+	"groups":          "groups",
+	"group":           "groups",
+	"recovery_email":  "recovery_email",
+	"recovery_phone":  "recovery_phone",
+	"change_password": "change_password",
+	"hash_function":   "hash_function",
+}
+
+// adminImportRow is one validated CSV row. Line is the 1-based CSV line.
+type adminImportRow struct {
+	Line   int
+	Plan   adminUserCreatePlan
+	Groups []string
+}
+
+// adminImportRowError reports a row that failed validation.
+type adminImportRowError struct {
+	Line  int    `json:"line"`
+	Email string `json:"email,omitempty"`
+	Error string `json:"error"`
+}
+
+// parseAdminUsersCSV reads users.csv. Every row is validated with the same
+// rules as `admin users create`; all problems are returned together so a file
+// can be fixed in one pass.
+func parseAdminUsersCSV(reader io.Reader, domain string) ([]adminImportRow, []adminImportRowError, error) {
+	cr := csv.NewReader(reader)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil, usage("csv is empty")
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		key := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		key = strings.NewReplacer(" ", "_", "-", "_").Replace(key)
+		columns[i] = adminImportColumns[key]
+	}
+	if !slices.Contains(columns, "email") {
+		return nil, nil, usage("csv needs an email column")
+	}
+
+	var rows []adminImportRow
+	var invalid []adminImportRowError
+	seen := map[string]int{}
+	for line := 2; ; line++ {
+		record, readErr := cr.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return nil, nil, fmt.Errorf("read csv line %d: %w", line, readErr)
+		}
+		values := map[string]string{}
+		empty := true
+		for i, cell := range record {
+			if i < len(columns) && columns[i] != "" {
+				values[columns[i]] = strings.TrimSpace(cell)
+				empty = empty && strings.TrimSpace(cell) == ""
+			}
+		}
+		if empty {
+			continue
+		}
+
+		email := strings.ToLower(values["email"])
+		fail := func(err error) {
+			invalid = append(invalid, adminImportRowError{Line: line, Email: email, Error: err.Error()})
+		}
+		if domain != "" && email != "" && !strings.HasSuffix(email, "@"+domain) {
+			fail(fmt.Errorf("email is not in domain %s", domain))
+			continue
+		}
+		if first, dup := seen[email]; dup && email != "" {
+			fail(fmt.Errorf("duplicate of line %d", first))
+			continue
+		}
+		seen[email] = line
+
+		changePwd := false
+		if raw := values["change_password"]; raw != "" {
+			changePwd, err = strconv.ParseBool(raw)
+			if err != nil {
+				fail(fmt.Errorf("invalid change_password %q (expected true/false)", raw))
+				continue
+			}
+		}
+		orgUnit := ""
+		if raw := values["org_unit"]; raw != "" {
+			orgUnit = "/" + normalizeAdminOrgUnitPath(raw)
+		}
+		plan, planErr := newAdminUserCreatePlan(adminUserCreateInput{
+			Email:         email,
+			GivenName:     values["given_name"],
+			FamilyName:    values["family_name"],
+			Password:      values["password"],
+			ChangePwd:     changePwd,
+			OrgUnit:       orgUnit,
+			RecoveryEmail: values["recovery_email"],
+			RecoveryPhone: values["recovery_phone"],
+			HashFunction:  values["hash_function"],
+		})
+		if planErr != nil {
+			fail(planErr)
+			continue
+		}
+		groups, groupErr := parseAdminImportGroups(values["groups"])
+		if groupErr != nil {
+			fail(groupErr)
+			continue
+		}
+		rows = append(rows, adminImportRow{Line: line, Plan: plan, Groups: groups})
+	}
+	return rows, invalid, nil
+}
+
+// parseAdminImportGroups splits a groups cell; ";" and "|" both separate
+// entries so the cell never needs CSV quoting.
+func parseAdminImportGroups(raw string) ([]string, error) {
+	fields := strings.FieldsFunc(raw, func(r rune) bool { return r == ';' || r == '|' })
+	var groups []string
+	for _, field := range fields {
+		group := strings.ToLower(strings.TrimSpace(field))
+		if group == "" || slices.Contains(groups, group) {
+			continue
+		}
+		if err := validatePlainEmail("groups", group); err != nil {
+			return nil, err
+		}
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+
+// adminImportItem is the planned work for one user.
+type adminImportItem struct {
+	Line     int      `json:"line,omitempty"`
+	Email    string   `json:"email"`
+	Action   string   `json:"action"`
+	Fields   []string `json:"fields,omitempty"`
+	OrgUnit  string   `json:"org_unit,omitempty"`
+	Groups   []string `json:"groups,omitempty"`
+	Password string   `json:"password,omitempty"`
+	row      *adminImportRow
+	patch    *admin.User
+}
+
+type adminImportPlan struct {
+	Items          []*adminImportItem
+	CreateOrgUnits []string
+}
+
+// newAdminImportPlan compares the CSV rows with the directory. Existing users
+// are only changed when update is set (sync), and only for columns that have
+// a value. Users in the directory but not in the file are suspended when prune
+// is set, except protected ones (super admins and the calling account).
+func newAdminImportPlan(rows []adminImportRow, existing []*admin.User, orgUnits []string, update, prune bool, protected string) adminImportPlan {
+	byEmail := map[string]*admin.User{}
+	for _, user := range existing {
+		if user == nil {
+			continue
+		}
+		byEmail[strings.ToLower(user.PrimaryEmail)] = user
+		for _, alias := range user.Aliases {
+			byEmail[strings.ToLower(alias)] = user
+		}
+	}
+	knownOU := map[string]bool{"/": true}
+	for _, path := range orgUnits {
+		knownOU["/"+normalizeAdminOrgUnitPath(path)] = true
+	}
+
+	var plan adminImportPlan
+	inFile := map[string]bool{}
+	for i := range rows {
+		row := &rows[i]
+		want := row.Plan.User
+		item := &adminImportItem{Line: row.Line, Email: row.Plan.Email, OrgUnit: want.OrgUnitPath, Groups: row.Groups, row: row}
+		if want.OrgUnitPath != "" {
+			plan.CreateOrgUnits = appendMissingOrgUnits(plan.CreateOrgUnits, want.OrgUnitPath, knownOU)
+		}
+
+		current := byEmail[row.Plan.Email]
+		if current == nil {
+			item.Action = adminImportCreate
+			if row.Plan.GeneratePassword {
+				item.Password = "generated"
+			}
+			plan.Items = append(plan.Items, item)
+			continue
+		}
+		inFile[strings.ToLower(current.PrimaryEmail)] = true
+		item.Email = current.PrimaryEmail
+		item.Action = adminImportUnchanged
+		if update {
+			item.patch, item.Fields = adminImportPatch(want, current)
+			if item.patch != nil {
+				item.Action = adminImportUpdate
+			}
+		}
+		plan.Items = append(plan.Items, item)
+	}
+
+	if prune {
+		for _, user := range existing {
+			if user == nil || user.Suspended || user.IsAdmin {
+				continue
+			}
+			email := strings.ToLower(user.PrimaryEmail)
+			if inFile[email] || strings.EqualFold(email, protected) {
+				continue
+			}
+			plan.Items = append(plan.Items, &adminImportItem{Email: user.PrimaryEmail, Action: adminImportSuspend})
+		}
+	}
+	return plan
+}
+
+// appendMissingOrgUnits adds path and any missing parents, parents first.
+func appendMissingOrgUnits(missing []string, path string, known map[string]bool) []string {
+	parts := strings.Split(normalizeAdminOrgUnitPath(path), "/")
+	for i := range parts {
+		prefix := "/" + strings.Join(parts[:i+1], "/")
+		if known[prefix] {
+			continue
+		}
+		known[prefix] = true
+		missing = append(missing, prefix)
+	}
+	return missing
+}
+
+func adminImportPatch(want, current *admin.User) (*admin.User, []string) {
+	patch := &admin.User{}
+	var fields []string
+	if current.Name == nil || want.Name.GivenName != current.Name.GivenName || want.Name.FamilyName != current.Name.FamilyName {
+		patch.Name = want.Name
+		fields = append(fields, "name")
+	}
+	if want.OrgUnitPath != "" && !strings.EqualFold(want.OrgUnitPath, current.OrgUnitPath) {
+		patch.OrgUnitPath = want.OrgUnitPath
+		fields = append(fields, "org_unit")
+	}
+	if want.RecoveryEmail != "" && want.RecoveryEmail != current.RecoveryEmail {
+		patch.RecoveryEmail = want.RecoveryEmail
+		fields = append(fields, "recovery_email")
+	}
+	if want.RecoveryPhone != "" && want.RecoveryPhone != current.RecoveryPhone {
+		patch.RecoveryPhone = want.RecoveryPhone
+		fields = append(fields, "recovery_phone")
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return patch, fields
+}
+
+func (p adminImportPlan) counts() map[string]int {
+	counts := map[string]int{}
+	for _, item := range p.Items {
+		counts[item.Action]++
+	}
+	return counts
+}
+
+func (p adminImportPlan) suspends() []string {
+	var out []string
+	for _, item := range p.Items {
+		if item.Action == adminImportSuspend {
+			out = append(out, item.Email)
+		}
+	}
+	return out
+}
+
+func (p adminImportPlan) dryRunPayload() map[string]any {
+	items := p.Items
+	if items == nil {
+		items = []*adminImportItem{}
+	}
+	return map[string]any{
+		"users":            items,
+		"counts":           p.counts(),
+		"create_org_units": p.CreateOrgUnits,
+	}
+}
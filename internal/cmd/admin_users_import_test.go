@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	admin "google.golang.org/api/admin/directory/v1"
+)
+
+func TestParseAdminUsersCSV(t *testing.T) {
+	csvText := "\ufeffEmail,First Name,Last Name,OU,Groups,change_password\n" +
+		"Ada@Example.com,Ada,Lovelace,Engineering/Compilers,eng@example.com; all@example.com,true\n" +
+		",,,,,\n" +
+		"grace@example.com,Grace,,,,\n" +
+		"ada@example.com,Ada,Again,,,\n" +
+		"bob@other.com,Bob,Builder,,,\n" +
+		"carol@example.com,Carol,Jones,,not-an-email,\n" +
+		"dave@example.com,Dave,Smith,,,maybe\n"
+	rows, invalid, err := parseAdminUsersCSV(strings.NewReader(csvText), "example.com")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(rows) != 1 {
+		t.Fatalf("rows = %#v", rows)
+	}
+	ada := rows[0]
+	if ada.Line != 2 || ada.Plan.Email != "ada@example.com" || ada.Plan.User.OrgUnitPath != "/Engineering/Compilers" ||
+		!ada.Plan.User.ChangePasswordAtNextLogin || !ada.Plan.GeneratePassword {
+		t.Fatalf("ada = %#v / %#v", ada, ada.Plan.User)
+	}
+	if !slices.Equal(ada.Groups, []string{"eng@example.com", "all@example.com"}) {
+		t.Fatalf("groups = %v", ada.Groups)
+	}
+
+	var got []string
+	for _, e := range invalid {
+		got = append(got, e.Error)
+	}
+	want := []string{"--family required", "duplicate of line 2", "email is not in domain example.com", "invalid groups", "invalid change_password"}
+	if len(got) != len(want) {
+		t.Fatalf("invalid = %v", got)
+	}
+	for i := range want {
+		if !strings.Contains(got[i], want[i]) {
+			t.Fatalf("invalid[%d] = %q, want %q", i, got[i], want[i])
+		}
+	}
+
+	if _, _, err := parseAdminUsersCSV(strings.NewReader("name\nx\n"), ""); err == nil {
+		t.Fatal("expected missing email column error")
+	}
+}
+
+func TestNewAdminImportPlan(t *testing.T) {
+	rows, invalid, err := parseAdminUsersCSV(strings.NewReader(
+		"email,given_name,family_name,org_unit\n"+
+			"new@example.com,New,User,/Sales/EMEA/UK\n"+
+			"ada@example.com,Ada,King,/Engineering\n"+
+			"grace@example.com,Grace,Hopper,\n"), "")
+	if err != nil || len(invalid) != 0 {
+		t.Fatalf("parse: %v %v", err, invalid)
+	}
+	existing := []*admin.User{
+		{PrimaryEmail: "Ada@example.com", Name: &admin.UserName{GivenName: "Ada", FamilyName: "Lovelace"}, OrgUnitPath: "/Engineering"},
+		{PrimaryEmail: "grace.hopper@example.com", Aliases: []string{"grace@example.com"}, Name: &admin.UserName{GivenName: "Grace", FamilyName: "Hopper"}},
+		{PrimaryEmail: "leaver@example.com"},
+		{PrimaryEmail: "gone@example.com", Suspended: true},
+		{PrimaryEmail: "root@example.com", IsAdmin: true},
+		{PrimaryEmail: "admin@example.com"},
+	}
+
+	plan := newAdminImportPlan(rows, existing, []string{"/Engineering", "/Sales"}, true, true, "admin@example.com")
+	var actions []string
+	for _, item := range plan.Items {
+		actions = append(actions, item.Email+"="+item.Action+strings.Join(item.Fields, ","))
+	}
+	want := []string{
+		"new@example.com=create",
+		"Ada@example.com=updatename",
+		"grace.hopper@example.com=unchanged",
+		"leaver@example.com=suspend",
+	}
+	if !slices.Equal(actions, want) {
+		t.Fatalf("actions = %v", actions)
+	}
+	if !slices.Equal(plan.CreateOrgUnits, []string{"/Sales/EMEA", "/Sales/EMEA/UK"}) {
+		t.Fatalf("org units = %v", plan.CreateOrgUnits)
+	}
+
+	importOnly := newAdminImportPlan(rows, existing, []string{"/Engineering", "/Sales/EMEA/UK"}, false, false, "")
+	if got := importOnly.counts(); got[adminImportCreate] != 1 || got[adminImportUnchanged] != 2 || len(importOnly.Items) != 3 {
+		t.Fatalf("import counts = %v", got)
+	}
+}
+
+func TestAdminUsersSync_AppliesWithRetriesAndReport(t *testing.T) {
+	origSleep := sleepBeforeAdminRetry
+	sleepBeforeAdminRetry = func(context.Context, time.Duration) error { return nil }
+	t.Cleanup(func() { sleepBeforeAdminRetry = origSleep })
+
+	var mu sync.Mutex
+	memberAttempts := map[string]int{}
+	var created, suspended []string
+	svc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/users"):
+			if r.URL.Query().Get("domain") != "example.com" {
+				t.Errorf("domain = %q", r.URL.Query().Get("domain"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"users": []map[string]any{
+				{"primaryEmail": "ada@example.com", "name": map[string]any{"givenName": "Ada", "familyName": "Lovelace"}},
+				{"primaryEmail": "leaver@example.com"},
+				{"primaryEmail": "admin@example.com"},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/users"):
+			var body admin.User
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body.PrimaryEmail)
+			if body.Password == "" || !body.ChangePasswordAtNextLogin {
+				t.Errorf("insert body = %#v", body)
+			}
+			_ = json.NewEncoder(w).Encode(body)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/members"):
+			group := strings.Split(strings.Split(r.URL.Path, "/groups/")[1], "/")[0]
+			memberAttempts[group]++
+			switch {
+			case group == "eng@example.com" && memberAttempts[group] == 1:
+				http.Error(w, `{"error":{"code":404,"message":"Resource Not Found: memberKey"}}`, http.StatusNotFound)
+			case group == "all@example.com":
+				http.Error(w, `{"error":{"code":409,"message":"Member already exists."}}`, http.StatusConflict)
+			default:
+				_ = json.NewEncoder(w).Encode(map[string]any{"email": "x"})
+			}
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/users/leaver@example.com"):
+			suspended = append(suspended, "leaver@example.com")
+			_ = json.NewEncoder(w).Encode(map[string]any{"primaryEmail": "leaver@example.com", "suspended": true})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+
+	dir := t.TempDir()
+	csvPath := filepath.Join(dir, "users.csv")
+	reportPath := filepath.Join(dir, "report.csv")
+	if err := os.WriteFile(reportPath, []byte("stale\n"), 0o644); err != nil { //nolint:gosec // checks the report tightens an existing file
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(csvPath, []byte("email,given_name,family_name,groups\n"+
+		"new@example.com,New,User,eng@example.com;all@example.com\n"+
+		"ada@example.com,Ada,Lovelace,\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	args := []string{"--account", "admin@example.com", "--json", "admin", "users", "sync", csvPath, "--domain", "example.com", "--prune", "--report", reportPath}
+	noForce := executeWithAdminDirectoryTestServiceFactory(t, append(args, "--no-input"), fixedAdminTestService(svc))
+	if noForce.err == nil || len(suspended) != 0 {
+		t.Fatalf("prune without --force should not run: %v %v", noForce.err, suspended)
+	}
+
+	result := executeWithAdminDirectoryTestServiceFactory(t, append(args, "--force"), fixedAdminTestService(svc))
+	if result.err != nil {
+		t.Fatalf("sync: %v\nstderr=%s", result.err, result.stderr)
+	}
+	if !slices.Equal(created, []string{"new@example.com"}) || !slices.Equal(suspended, []string{"leaver@example.com"}) {
+		t.Fatalf("created=%v suspended=%v", created, suspended)
+	}
+	if memberAttempts["eng@example.com"] != 2 {
+		t.Fatalf("eng attempts = %d", memberAttempts["eng@example.com"])
+	}
+
+	var out struct {
+		Results []adminImportResult `json:"results"`
+		Failed  int                 `json:"failed"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &out); err != nil {
+		t.Fatalf("json: %v\n%s", err, result.stdout)
+	}
+	if out.Failed != 0 || len(out.Results) != 3 {
+		t.Fatalf("results = %#v", out)
+	}
+	first := out.Results[0]
+	if first.Action != adminImportCreate || first.Status != "ok" || first.Password == "" || !slices.Equal(first.GroupsAdded, []string{"eng@example.com"}) {
+		t.Fatalf("first result = %#v", first)
+	}
+	if out.Results[1].Action != adminImportUnchanged || out.Results[2].Action != adminImportSuspend {
+		t.Fatalf("results = %#v", out.Results)
+	}
+
+	report, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Generated passwords may contain commas or quotes, so read the report as CSV.
+	rows, err := csv.NewReader(bytes.NewReader(report)).ReadAll()
+	if err != nil {
+		t.Fatalf("report csv: %v\n%s", err, report)
+	}
+	wantHeader := []string{"line", "email", "action", "status", "groups_added", "password", "error"}
+	wantFirst := []string{"2", "new@example.com", "create", "ok", "eng@example.com", first.Password, ""}
+	if len(rows) < 2 || !slices.Equal(rows[0], wantHeader) || !slices.Equal(rows[1], wantFirst) {
+		t.Fatalf("report = %q", report)
+	}
+	if info, _ := os.Stat(reportPath); info.Mode().Perm() != 0o600 {
+		t.Fatalf("report mode = %v", info.Mode().Perm())
+	}
+}
+
+func TestAdminUsersImport_DoesNotRetryCreateAfterServerError(t *testing.T) {
+	origSleep := sleepBeforeAdminRetry
+	sleepBeforeAdminRetry = func(context.Context, time.Duration) error { return nil }
+	t.Cleanup(func() { sleepBeforeAdminRetry = origSleep })
+
+	var mu sync.Mutex
+	inserts := map[string]int{}
+	svc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/users"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"users": []map[string]any{}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/users"):
+			var body admin.User
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			inserts[body.PrimaryEmail]++
+			switch {
+			case body.PrimaryEmail == "flaky@example.com":
+				http.Error(w, `{"error":{"code":503,"message":"backend error"}}`, http.StatusServiceUnavailable)
+			case body.PrimaryEmail == "busy@example.com" && inserts[body.PrimaryEmail] == 1:
+				http.Error(w, `{"error":{"code":429,"message":"rate limited"}}`, http.StatusTooManyRequests)
+			default:
+				_ = json.NewEncoder(w).Encode(body)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.String())
+			http.NotFound(w, r)
+		}
+	}))
+
+	csvPath := filepath.Join(t.TempDir(), "users.csv")
+	if err := os.WriteFile(csvPath, []byte("email,given_name,family_name\n"+
+		"flaky@example.com,Flaky,User\n"+
+		"busy@example.com,Busy,User\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result := executeWithAdminDirectoryTestServiceFactory(t, []string{"--account", "admin@example.com", "--json", "admin", "users", "import", csvPath}, fixedAdminTestService(svc))
+	if result.err == nil {
+		t.Fatalf("expected the failed create to fail the run\nstdout=%s", result.stdout)
+	}
+	if inserts["flaky@example.com"] != 1 || inserts["busy@example.com"] != 2 {
+		t.Fatalf("inserts = %v", inserts)
+	}
+}
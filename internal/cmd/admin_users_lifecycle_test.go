@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/app"
+)
+
+func TestAdminUsersUpdate_SendsOnlyProvidedFields(t *testing.T) {
+	var body map[string]any
+	svc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch || !strings.HasSuffix(r.URL.Path, "/users/ada@example.com") {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"primaryEmail": "ada@example.com", "orgUnitPath": "/Eng"})
+	}))
+
+	result := executeWithAdminDirectoryTestServiceFactory(t, []string{
+		"--account", "admin@example.com", "--json", "admin", "users", "update", "ada@example.com",
+		"--ou", "/Eng", "--no-archived", "--recovery-phone", "",
+	}, fixedAdminTestService(svc))
+	if result.err != nil {
+		t.Fatalf("update: %v\nstderr=%s", result.err, result.stderr)
+	}
+	if body["orgUnitPath"] != "/Eng" || body["archived"] != false || body["recoveryPhone"] != "" {
+		t.Fatalf("patch body = %#v", body)
+	}
+	for _, absent := range []string{"name", "password", "changePasswordAtNextLogin", "suspended"} {
+		if _, ok := body[absent]; ok {
+			t.Fatalf("unexpected %s in patch body %#v", absent, body)
+		}
+	}
+
+	empty := executeWithAdminDirectoryTestServiceFactory(t, []string{
+		"--account", "admin@example.com", "admin", "users", "update", "ada@example.com",
+	}, unexpectedAdminTestService(t, "update without flags must not call the API"))
+	if empty.err == nil || !strings.Contains(empty.err.Error(), "no updates specified") {
+		t.Fatalf("expected usage error, got %v", empty.err)
+	}
+}
+
+func TestAdminUsersUnsuspendAndUndelete(t *testing.T) {
+	var unsuspend map[string]any
+	var undeletePath string
+	svc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPatch:
+			_ = json.NewDecoder(r.Body).Decode(&unsuspend)
+			_ = json.NewEncoder(w).Encode(map[string]any{"primaryEmail": "ada@example.com", "suspended": false})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/users"):
+			if r.URL.Query().Get("showDeleted") != "true" {
+				t.Errorf("showDeleted = %q", r.URL.Query().Get("showDeleted"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"users": []map[string]any{
+				{"id": "other", "primaryEmail": "bob@example.com"},
+				{"id": "123", "primaryEmail": "Ada@example.com"},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/undelete"):
+			undeletePath = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	runtime := &app.Runtime{Services: app.Services{AdminDirectory: fixedAdminTestService(svc)}}
+
+	result := executeWithTestRuntime(t, []string{"--account", "admin@example.com", "--json", "admin", "users", "unsuspend", "ada@example.com"}, runtime)
+	if result.err != nil {
+		t.Fatalf("unsuspend: %v", result.err)
+	}
+	if suspended, ok := unsuspend["suspended"]; !ok || suspended != false {
+		t.Fatalf("unsuspend body = %#v", unsuspend)
+	}
+
+	result = executeWithTestRuntime(t, []string{"--account", "admin@example.com", "--json", "admin", "users", "undelete", "ada@example.com"}, runtime)
+	if result.err != nil {
+		t.Fatalf("undelete: %v", result.err)
+	}
+	if !strings.HasSuffix(undeletePath, "/users/123/undelete") {
+		t.Fatalf("undelete path = %q", undeletePath)
+	}
+}
+
+func TestAdminUsersAliases(t *testing.T) {
+	var inserted map[string]any
+	var deletedPath string
+	svc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"aliases": []map[string]any{
+				{"alias": "ada.l@example.com", "primaryEmail": "ada@example.com"},
+			}})
+		case http.MethodPost:
+			_ = json.NewDecoder(r.Body).Decode(&inserted)
+			_ = json.NewEncoder(w).Encode(inserted)
+		case http.MethodDelete:
+			deletedPath = r.URL.Path
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	runtime := &app.Runtime{Services: app.Services{AdminDirectory: fixedAdminTestService(svc)}}
+
+	list := executeWithTestRuntime(t, []string{"--account", "admin@example.com", "--json", "admin", "users", "aliases", "list", "ada@example.com"}, runtime)
+	if list.err != nil {
+		t.Fatalf("list: %v", list.err)
+	}
+	var parsed struct {
+		Aliases []string `json:"aliases"`
+	}
+	if err := json.Unmarshal([]byte(list.stdout), &parsed); err != nil || len(parsed.Aliases) != 1 || parsed.Aliases[0] != "ada.l@example.com" {
+		t.Fatalf("list output = %s (%v)", list.stdout, err)
+	}
+
+	add := executeWithTestRuntime(t, []string{"--account", "admin@example.com", "admin", "users", "aliases", "add", "ada@example.com", "lovelace@example.com"}, runtime)
+	if add.err != nil || inserted["alias"] != "lovelace@example.com" {
+		t.Fatalf("add: %v body=%#v", add.err, inserted)
+	}
+
+	remove := executeWithTestRuntime(t, []string{"--account", "admin@example.com", "--force", "admin", "users", "aliases", "remove", "ada@example.com", "lovelace@example.com"}, runtime)
+	if remove.err != nil || !strings.HasSuffix(deletedPath, "/users/ada@example.com/aliases/lovelace@example.com") {
+		t.Fatalf("remove: %v path=%q", remove.err, deletedPath)
+	}
+}
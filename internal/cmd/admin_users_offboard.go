@@ -0,0 +1,425 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/gmail/v1"
+	ggoogleapi "google.golang.org/api/googleapi"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	offboardGmailAutoreply = "gmail.autoreply"
+	offboardGmailForward   = "gmail.forward"
+	offboardSuspend        = "suspend"
+	offboardSignOut        = "sign_out"
+	offboardDriveTransfer  = "drive.transfer"
+	offboardGroupRemove    = "group.remove"
+
+	offboardDone    = "done"
+	offboardFailed  = "failed"
+	offboardSkipped = "skipped"
+	offboardPending = "pending"
+
+	forwardingAccepted = "accepted"
+
+	driveTransferApplication = "Drive and Docs"
+)
+
+var driveTransferPrivacyLevels = []string{"PRIVATE", "SHARED"}
+
+// AdminUsersOffboardCmd runs the usual leaver checklist for one user.
+type AdminUsersOffboardCmd struct {
+	User               string `arg:"" name:"user" help:"User email to offboard"`
+	TransferTo         string `name:"transfer-to" aliases:"manager" help:"Who receives the user's Drive files (default: the manager on the user's directory record)"`
+	NoTransfer         bool   `name:"no-transfer" help:"Skip the Drive ownership transfer"`
+	Autoreply          string `name:"autoreply" help:"Auto-reply message (HTML) set on the user's mailbox"`
+	AutoreplySubject   string `name:"autoreply-subject" help:"Auto-reply subject" default:"No longer with the company"`
+	Forward            string `name:"forward" help:"Forward new mail to this address"`
+	ForwardDisposition string `name:"forward-disposition" help:"What happens to forwarded mail: leaveInInbox|archive|trash|markRead" enum:"leaveInInbox,archive,trash,markRead" default:"leaveInInbox"`
+	KeepGroups         bool   `name:"keep-groups" help:"Leave group memberships in place"`
+}
+
+// adminOffboardStep is one action in the offboarding plan. Status and Error
+// are filled in as the plan runs.
+type adminOffboardStep struct {
+	Action string         `json:"action"`
+	Target string         `json:"target,omitempty"`
+	Detail map[string]any `json:"detail,omitempty"`
+	Status string         `json:"status,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+type adminOffboardPlan struct {
+	User         string
+	UserID       string
+	TransferTo   string
+	TransferToID string
+	Steps        []*adminOffboardStep
+}
+
+type adminOffboardInput struct {
+	TransferTo         string
+	NoTransfer         bool
+	Autoreply          string
+	AutoreplySubject   string
+	Forward            string
+	ForwardDisposition string
+	KeepGroups         bool
+}
+
+func (c *AdminUsersOffboardCmd) Run(ctx context.Context, flags *RootFlags) error {
+	userEmail := strings.TrimSpace(c.User)
+	if userEmail == "" {
+		return usage("user email required")
+	}
+	input := adminOffboardInput{
+		TransferTo:         strings.TrimSpace(c.TransferTo),
+		NoTransfer:         c.NoTransfer,
+		Autoreply:          strings.TrimSpace(c.Autoreply),
+		AutoreplySubject:   strings.TrimSpace(c.AutoreplySubject),
+		Forward:            strings.TrimSpace(c.Forward),
+		ForwardDisposition: c.ForwardDisposition,
+		KeepGroups:         c.KeepGroups,
+	}
+	if input.NoTransfer && input.TransferTo != "" {
+		return usage("--transfer-to and --no-transfer are mutually exclusive")
+	}
+	if input.Forward != "" {
+		if err := validateGmailSettingsEmail("--forward", input.Forward); err != nil {
+			return err
+		}
+	}
+
+	account, err := requireAdminAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := adminDirectoryService(ctx, account)
+	if err != nil {
+		return wrapAdminDirectoryError(err, account)
+	}
+
+	user, err := svc.Users.Get(userEmail).Context(ctx).Do()
+	if err != nil {
+		return wrapAdminDirectoryError(err, account)
+	}
+	var recipient *admin.User
+	if !input.NoTransfer {
+		to := firstNonEmpty(input.TransferTo, adminUserManager(user))
+		if to == "" {
+			return usagef("%s has no manager on record; pass --transfer-to or --no-transfer", user.PrimaryEmail)
+		}
+		recipient, err = svc.Users.Get(to).Context(ctx).Do()
+		if err != nil {
+			return wrapAdminDirectoryError(fmt.Errorf("transfer recipient %s: %w", to, err), account)
+		}
+	}
+	var groups []*admin.Group
+	if !input.KeepGroups {
+		err = svc.Groups.List().UserKey(user.PrimaryEmail).Context(ctx).Pages(ctx, func(resp *admin.Groups) error {
+			groups = append(groups, resp.Groups...)
+			return nil
+		})
+		if err != nil {
+			return wrapAdminDirectoryError(err, account)
+		}
+	}
+
+	plan := newAdminOffboardPlan(input, user, recipient, groups)
+	if plan.hasGmailSteps() {
+		if err := requireOffboardMailboxDelegation(ctx, plan.User); err != nil {
+			return err
+		}
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "admin.users.offboard", plan.payload()); dryRunErr != nil {
+		return dryRunErr
+	}
+	if confirmErr := confirmDestructiveChecked(ctx, flags, plan.summary()); confirmErr != nil {
+		return confirmErr
+	}
+
+	runErr := plan.run(ctx, account, svc)
+	pending := plan.pendingForward()
+	if runErr == nil && pending != nil {
+		runErr = fmt.Errorf("offboard %s: forwarding to %s is pending verification, so auto-forwarding was not enabled", plan.User, pending.Target)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		payload := plan.payload()
+		payload["completed"] = runErr == nil
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload); err != nil {
+			return err
+		}
+		return runErr
+	}
+	u := ui.FromContext(ctx)
+	for _, step := range plan.Steps {
+		u.Out().Linef("%s\t%s\t%s", step.Status, step.Action, step.Target)
+	}
+	return runErr
+}
+
+// requireOffboardMailboxDelegation fails before anything changes when the
+// Gmail steps cannot act as the leaver: mailbox settings are per user, so
+// they need a service-account key with domain-wide delegation registered for
+// that user rather than the admin's own credentials.
+func requireOffboardMailboxDelegation(ctx context.Context, user string) error {
+	store, err := commandServiceAccountStore(ctx)
+	if err != nil {
+		return err
+	}
+	_, ok, err := store.Existing(user, false)
+	if err != nil || ok {
+		return err
+	}
+	return &ExitError{Code: exitCodeConfig, Err: fmt.Errorf(
+		"--autoreply and --forward act as %s and need a domain-wide delegation service account for that user; configure it with: gog auth service-account set %s --key <service-account.json>, or drop those flags",
+		user, user,
+	)}
+}
+
+// newAdminOffboardPlan orders the steps so mailbox settings are written while
+// the account can still be impersonated, then locks the account before its
+// data and memberships are handed over.
+func newAdminOffboardPlan(input adminOffboardInput, user, recipient *admin.User, groups []*admin.Group) *adminOffboardPlan {
+	plan := &adminOffboardPlan{User: user.PrimaryEmail, UserID: user.Id}
+	add := func(action, target string, detail map[string]any) {
+		plan.Steps = append(plan.Steps, &adminOffboardStep{Action: action, Target: target, Detail: detail})
+	}
+
+	if input.Autoreply != "" {
+		add(offboardGmailAutoreply, plan.User, map[string]any{
+			"subject": input.AutoreplySubject,
+			"body":    input.Autoreply,
+		})
+	}
+	if input.Forward != "" {
+		add(offboardGmailForward, input.Forward, map[string]any{
+			"disposition": input.ForwardDisposition,
+		})
+	}
+	if !user.Suspended {
+		add(offboardSuspend, plan.User, nil)
+	}
+	add(offboardSignOut, plan.User, nil)
+	if recipient != nil {
+		plan.TransferTo, plan.TransferToID = recipient.PrimaryEmail, recipient.Id
+		add(offboardDriveTransfer, plan.TransferTo, map[string]any{
+			"application":    driveTransferApplication,
+			"privacy_levels": driveTransferPrivacyLevels,
+		})
+	}
+	for _, group := range groups {
+		if group != nil && group.Email != "" {
+			add(offboardGroupRemove, group.Email, nil)
+		}
+	}
+	return plan
+}
+
+// adminUserManager returns the first manager relation on a directory user.
+func adminUserManager(user *admin.User) string {
+	relations, ok := user.Relations.([]any)
+	if !ok {
+		return ""
+	}
+	for _, raw := range relations {
+		relation, ok := raw.(map[string]any)
+		if !ok {
+			continue
+		}
+		if kind, _ := relation["type"].(string); strings.EqualFold(kind, "manager") {
+			if value, _ := relation["value"].(string); strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		}
+	}
+	return ""
+}
+
+func (p *adminOffboardPlan) payload() map[string]any {
+	return map[string]any{
+		"user":        p.User,
+		"user_id":     p.UserID,
+		"transfer_to": p.TransferTo,
+		"steps":       p.Steps,
+	}
+}
+
+func (p *adminOffboardPlan) hasGmailSteps() bool {
+	for _, step := range p.Steps {
+		if step.Action == offboardGmailAutoreply || step.Action == offboardGmailForward {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *adminOffboardPlan) pendingForward() *adminOffboardStep {
+	for _, step := range p.Steps {
+		if step.Action == offboardGmailForward && step.Status == offboardPending {
+			return step
+		}
+	}
+	return nil
+}
+
+func (p *adminOffboardPlan) summary() string {
+	var parts []string
+	groups := 0
+	for _, step := range p.Steps {
+		switch step.Action {
+		case offboardGroupRemove:
+			groups++
+		case offboardDriveTransfer:
+			parts = append(parts, "transfer Drive to "+step.Target)
+		default:
+			parts = append(parts, strings.ReplaceAll(step.Action, "_", " "))
+		}
+	}
+	if groups > 0 {
+		parts = append(parts, fmt.Sprintf("remove from %d group%s", groups, pluralS(groups)))
+	}
+	return fmt.Sprintf("offboard %s (%s)", p.User, strings.Join(parts, ", "))
+}
+
+// run executes the steps in order and stops at the first failure; the steps
+// after it are marked skipped so the output shows exactly what happened. A
+// forwarding address that still awaits verification leaves its step pending
+// and the rest of the plan runs.
+func (p *adminOffboardPlan) run(ctx context.Context, account string, svc *admin.Service) error {
+	var gmailSvc *gmail.Service
+	for i, step := range p.Steps {
+		var err error
+		switch step.Action {
+		case offboardGmailAutoreply, offboardGmailForward:
+			if gmailSvc == nil {
+				// Mailbox settings are per user, so this acts as the offboarded
+				// account rather than the admin.
+				gmailSvc, err = gmailService(ctx, p.User)
+			}
+			if err == nil {
+				err = p.runGmailStep(ctx, gmailSvc, step)
+			}
+		case offboardSuspend:
+			_, err = svc.Users.Patch(p.User, &admin.User{Suspended: true}).Context(ctx).Do()
+			err = wrapOffboardDirectoryError(err, account)
+		case offboardSignOut:
+			err = wrapOffboardDirectoryError(svc.Users.SignOut(p.User).Context(ctx).Do(), account)
+		case offboardDriveTransfer:
+			err = p.runDriveTransfer(ctx, account, step)
+		case offboardGroupRemove:
+			err = svc.Members.Delete(step.Target, p.User).Context(ctx).Do()
+			if isGoogleNotFound(err) {
+				err = nil
+			}
+			err = wrapOffboardDirectoryError(err, account)
+		}
+		if err != nil {
+			step.Status, step.Error = offboardFailed, err.Error()
+			for _, rest := range p.Steps[i+1:] {
+				rest.Status = offboardSkipped
+			}
+			return fmt.Errorf("offboard %s: %s %s: %w", p.User, step.Action, step.Target, err)
+		}
+		if step.Status == "" {
+			step.Status = offboardDone
+		}
+	}
+	return nil
+}
+
+func (p *adminOffboardPlan) runGmailStep(ctx context.Context, svc *gmail.Service, step *adminOffboardStep) error {
+	if step.Action == offboardGmailAutoreply {
+		body, _ := step.Detail["body"].(string)
+		subject, _ := step.Detail["subject"].(string)
+		_, err := updateGmailVacation(ctx, svc, func(vacation *gmail.VacationSettings) {
+			vacation.EnableAutoReply = true
+			vacation.ResponseSubject = subject
+			vacation.ResponseBodyHtml = body
+			vacation.ResponseBodyPlainText = stripHTML(body)
+			vacation.StartTime, vacation.EndTime = 0, 0
+			vacation.RestrictToContacts, vacation.RestrictToDomain = false, false
+		})
+		return err
+	}
+
+	address, err := svc.Users.Settings.ForwardingAddresses.Create("me", &gmail.ForwardingAddress{ForwardingEmail: step.Target}).Context(ctx).Do()
+	var apiErr *ggoogleapi.Error
+	if err != nil && errors.As(err, &apiErr) && apiErr.Code == http.StatusConflict {
+		address, err = svc.Users.Settings.ForwardingAddresses.Get("me", step.Target).Context(ctx).Do()
+	}
+	if err != nil {
+		return err
+	}
+	// Gmail only forwards to accepted addresses; an address outside the
+	// domain gets a confirmation email and stays pending until it is answered.
+	if address.VerificationStatus != forwardingAccepted {
+		step.Status = offboardPending
+		step.Detail["verification_status"] = address.VerificationStatus
+		return nil
+	}
+	disposition, _ := step.Detail["disposition"].(string)
+	_, err = updateGmailAutoForwarding(ctx, svc, func(autoForward *gmail.AutoForwarding) {
+		autoForward.Enabled = true
+		autoForward.EmailAddress = step.Target
+		autoForward.Disposition = disposition
+	})
+	return err
+}
+
+func (p *adminOffboardPlan) runDriveTransfer(ctx context.Context, account string, step *adminOffboardStep) error {
+	svc, err := adminDataTransferService(ctx, account)
+	if err != nil {
+		return wrapAdminDataTransferError(err, account)
+	}
+	var app *datatransfer.Application
+	err = svc.Applications.List().Pages(ctx, func(resp *datatransfer.ApplicationsListResponse) error {
+		for _, candidate := range resp.Applications {
+			if candidate != nil && app == nil && strings.EqualFold(candidate.Name, driveTransferApplication) {
+				app = candidate
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return wrapAdminDataTransferError(err, account)
+	}
+	if app == nil {
+		return fmt.Errorf("data transfer application %q not found", driveTransferApplication)
+	}
+
+	transfer, err := svc.Transfers.Insert(&datatransfer.DataTransfer{
+		OldOwnerUserId: p.UserID,
+		NewOwnerUserId: p.TransferToID,
+		ApplicationDataTransfers: []*datatransfer.ApplicationDataTransfer{{
+			ApplicationId: app.Id,
+			ApplicationTransferParams: []*datatransfer.ApplicationTransferParam{{
+				Key:   "PRIVACY_LEVEL",
+				Value: driveTransferPrivacyLevels,
+			}},
+		}},
+	}).Context(ctx).Do()
+	if err != nil {
+		return wrapAdminDataTransferError(err, account)
+	}
+	step.Detail["transfer_id"] = transfer.Id
+	step.Detail["transfer_status"] = transfer.OverallTransferStatusCode
+	return nil
+}
+
+func wrapOffboardDirectoryError(err error, account string) error {
+	if err == nil {
+		return nil
+	}
+	return wrapAdminDirectoryError(err, account)
+}
@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
+	admin "google.golang.org/api/admin/directory/v1"
+	"google.golang.org/api/gmail/v1"
+
+	"github.com/steipete/gogcli/internal/app"
+	"github.com/steipete/gogcli/internal/config"
+)
+
+func TestNewAdminOffboardPlan(t *testing.T) {
+	user := &admin.User{
+		PrimaryEmail: "leaver@example.com",
+		Id:           "u1",
+		Relations:    []any{map[string]any{"type": "manager", "value": "boss@example.com"}},
+	}
+	if got := adminUserManager(user); got != "boss@example.com" {
+		t.Fatalf("manager = %q", got)
+	}
+
+	plan := newAdminOffboardPlan(adminOffboardInput{
+		Autoreply:          "I have left",
+		AutoreplySubject:   "Gone",
+		Forward:            "boss@example.com",
+		ForwardDisposition: "archive",
+	}, user, &admin.User{PrimaryEmail: "boss@example.com", Id: "m1"}, []*admin.Group{
+		{Email: "eng@example.com"}, nil, {Email: "all@example.com"},
+	})
+	var actions []string
+	for _, step := range plan.Steps {
+		actions = append(actions, step.Action+":"+step.Target)
+	}
+	want := []string{
+		"gmail.autoreply:leaver@example.com",
+		"gmail.forward:boss@example.com",
+		"suspend:leaver@example.com",
+		"sign_out:leaver@example.com",
+		"drive.transfer:boss@example.com",
+		"group.remove:eng@example.com",
+		"group.remove:all@example.com",
+	}
+	if strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Fatalf("steps = %v", actions)
+	}
+	if plan.TransferToID != "m1" {
+		t.Fatalf("transfer id = %q", plan.TransferToID)
+	}
+	if got := plan.summary(); got != "offboard leaver@example.com (gmail.autoreply, gmail.forward, suspend, sign out, transfer Drive to boss@example.com, remove from 2 groups)" {
+		t.Fatalf("summary = %q", got)
+	}
+
+	suspended := newAdminOffboardPlan(adminOffboardInput{}, &admin.User{PrimaryEmail: "x@example.com", Suspended: true}, nil, nil)
+	if len(suspended.Steps) != 1 || suspended.Steps[0].Action != offboardSignOut {
+		t.Fatalf("already suspended plan = %#v", suspended.Steps)
+	}
+}
+
+func TestAdminUsersOffboard_DryRunAndExecute(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	record := func(r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+	}
+
+	adminSvc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/users/leaver@example.com"):
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"id": "u1", "primaryEmail": "leaver@example.com",
+				"relations": []map[string]any{{"type": "manager", "value": "boss@example.com"}},
+			})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/users/boss@example.com"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "m1", "primaryEmail": "boss@example.com"})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/groups"):
+			if r.URL.Query().Get("userKey") != "leaver@example.com" {
+				t.Errorf("groups userKey = %q", r.URL.Query().Get("userKey"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"groups": []map[string]any{{"email": "eng@example.com"}, {"email": "gone@example.com"}}})
+		case r.Method == http.MethodPatch && strings.HasSuffix(r.URL.Path, "/users/leaver@example.com"):
+			record(r)
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if body["suspended"] != true {
+				t.Errorf("suspend body = %#v", body)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"primaryEmail": "leaver@example.com", "suspended": true})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/users/leaver@example.com/signOut"):
+			record(r)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete && strings.Contains(r.URL.Path, "/members/leaver@example.com"):
+			record(r)
+			if strings.Contains(r.URL.Path, "/groups/gone@example.com/") {
+				http.Error(w, `{"error":{"code":404,"message":"Resource Not Found: memberKey"}}`, http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected admin request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+
+	var transfer datatransfer.DataTransfer
+	transferSvc, closeTransfer := newGoogleTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/applications"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"applications": []map[string]any{
+				{"id": "111", "name": "Calendar"},
+				{"id": "55656082996", "name": "Drive and Docs"},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/transfers"):
+			record(r)
+			_ = json.NewDecoder(r.Body).Decode(&transfer)
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "t1", "overallTransferStatusCode": "new"})
+		default:
+			t.Errorf("unexpected transfer request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}), datatransfer.NewService)
+	t.Cleanup(closeTransfer)
+
+	var vacation gmail.VacationSettings
+	var forwarding gmail.AutoForwarding
+	gmailSvc, closeGmail := newGoogleTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/settings/vacation"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"enableAutoReply": false, "endTime": "1"})
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/settings/vacation"):
+			record(r)
+			_ = json.NewDecoder(r.Body).Decode(&vacation)
+			_ = json.NewEncoder(w).Encode(vacation)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/settings/forwardingAddresses"):
+			record(r)
+			http.Error(w, `{"error":{"code":409,"message":"Already exists"}}`, http.StatusConflict)
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/settings/forwardingAddresses/boss@example.com"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"forwardingEmail": "boss@example.com", "verificationStatus": "accepted"})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/settings/autoForwarding"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"enabled": false})
+		case r.Method == http.MethodPut && strings.HasSuffix(r.URL.Path, "/settings/autoForwarding"):
+			record(r)
+			_ = json.NewDecoder(r.Body).Decode(&forwarding)
+			_ = json.NewEncoder(w).Encode(forwarding)
+		default:
+			t.Errorf("unexpected gmail request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}), gmail.NewService)
+	t.Cleanup(closeGmail)
+
+	var gmailAccount string
+	runtime := &app.Runtime{Services: app.Services{
+		AdminDirectory: fixedAdminTestService(adminSvc),
+		AdminTransfer: func(context.Context, string) (*datatransfer.Service, error) {
+			return transferSvc, nil
+		},
+		Gmail: func(_ context.Context, account string) (*gmail.Service, error) {
+			gmailAccount = account
+			return gmailSvc, nil
+		},
+	}, ServiceAccounts: offboardServiceAccounts(t, "leaver@example.com")}
+	args := []string{"--account", "admin@example.com", "--json", "admin", "users", "offboard", "leaver@example.com",
+		"--autoreply", "<p>I have left</p>", "--forward", "boss@example.com"}
+
+	dry := executeWithTestRuntime(t, append(args, "--dry-run"), runtime)
+	if dry.err != nil && ExitCode(dry.err) != 0 {
+		t.Fatalf("dry run: %v\nstderr=%s", dry.err, dry.stderr)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("dry run mutated: %v", calls)
+	}
+	var planned struct {
+		Op      string `json:"op"`
+		Request struct {
+			TransferTo string               `json:"transfer_to"`
+			Steps      []*adminOffboardStep `json:"steps"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(dry.stdout), &planned); err != nil {
+		t.Fatalf("dry run json: %v\n%s", err, dry.stdout)
+	}
+	if planned.Op != "admin.users.offboard" || planned.Request.TransferTo != "boss@example.com" || len(planned.Request.Steps) != 7 {
+		t.Fatalf("dry run plan = %s", dry.stdout)
+	}
+
+	result := executeWithTestRuntime(t, append(args, "--force"), runtime)
+	if result.err != nil {
+		t.Fatalf("offboard: %v\nstderr=%s", result.err, result.stderr)
+	}
+	wantCalls := []string{
+		"PUT /gmail/v1/users/me/settings/vacation",
+		"POST /gmail/v1/users/me/settings/forwardingAddresses",
+		"PUT /gmail/v1/users/me/settings/autoForwarding",
+		"PATCH /admin/directory/v1/users/leaver@example.com",
+		"POST /admin/directory/v1/users/leaver@example.com/signOut",
+		"POST /admin/datatransfer/v1/transfers",
+		"DELETE /admin/directory/v1/groups/eng@example.com/members/leaver@example.com",
+		"DELETE /admin/directory/v1/groups/gone@example.com/members/leaver@example.com",
+	}
+	if strings.Join(calls, "\n") != strings.Join(wantCalls, "\n") {
+		t.Fatalf("calls:\n%s", strings.Join(calls, "\n"))
+	}
+	if gmailAccount != "leaver@example.com" {
+		t.Fatalf("gmail account = %q", gmailAccount)
+	}
+	if !vacation.EnableAutoReply || vacation.ResponseBodyPlainText != "I have left" || vacation.EndTime != 0 {
+		t.Fatalf("vacation = %#v", vacation)
+	}
+	if !forwarding.Enabled || forwarding.EmailAddress != "boss@example.com" || forwarding.Disposition != "leaveInInbox" {
+		t.Fatalf("forwarding = %#v", forwarding)
+	}
+	if transfer.OldOwnerUserId != "u1" || transfer.NewOwnerUserId != "m1" ||
+		len(transfer.ApplicationDataTransfers) != 1 || transfer.ApplicationDataTransfers[0].ApplicationId != 55656082996 {
+		t.Fatalf("transfer = %#v", transfer)
+	}
+
+	var done struct {
+		Completed bool                 `json:"completed"`
+		Steps     []*adminOffboardStep `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &done); err != nil {
+		t.Fatalf("result json: %v\n%s", err, result.stdout)
+	}
+	if !done.Completed {
+		t.Fatalf("not completed: %s", result.stdout)
+	}
+	for _, step := range done.Steps {
+		if step.Status != offboardDone {
+			t.Fatalf("step %s status %q", step.Action, step.Status)
+		}
+	}
+}
+
+func offboardServiceAccounts(t *testing.T, emails ...string) *config.ServiceAccountStore {
+	t.Helper()
+	root := t.TempDir()
+	store := config.NewServiceAccountStore(config.Layout{
+		ConfigDir:      filepath.Join(root, "config"),
+		DataDir:        filepath.Join(root, "data"),
+		ExplicitConfig: true,
+		ExplicitData:   true,
+	})
+	for _, email := range emails {
+		if _, err := store.Write(email, []byte(`{"type":"service_account"}`)); err != nil {
+			t.Fatalf("write service account: %v", err)
+		}
+	}
+	return store
+}
+
+func TestAdminUsersOffboard_RequiresDelegationForMailboxSteps(t *testing.T) {
+	var mutations []string
+	adminSvc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			mutations = append(mutations, r.Method+" "+r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "u1", "primaryEmail": "leaver@example.com"})
+	}))
+	runtime := &app.Runtime{Services: app.Services{
+		AdminDirectory: fixedAdminTestService(adminSvc),
+		Gmail: func(context.Context, string) (*gmail.Service, error) {
+			t.Fatal("gmail service built without delegation")
+			return nil, nil
+		},
+	}, ServiceAccounts: offboardServiceAccounts(t, "admin@example.com")}
+	args := []string{"--account", "admin@example.com", "admin", "users", "offboard", "leaver@example.com",
+		"--no-transfer", "--keep-groups", "--forward", "boss@example.com"}
+
+	for _, extra := range []string{"--dry-run", "--force"} {
+		result := executeWithTestRuntime(t, append(args, extra), runtime)
+		if ExitCode(result.err) != exitCodeConfig || !strings.Contains(result.err.Error(), "gog auth service-account set leaver@example.com") {
+			t.Fatalf("%s: err = %v", extra, result.err)
+		}
+	}
+	if len(mutations) != 0 {
+		t.Fatalf("mutated without delegation: %v", mutations)
+	}
+}
+
+func TestAdminUsersOffboard_ReportsPendingForwarding(t *testing.T) {
+	var calls []string
+	adminSvc := newAdminTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			calls = append(calls, r.Method+" "+r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "u1", "primaryEmail": "leaver@example.com"})
+	}))
+	gmailSvc, closeGmail := newGoogleTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/settings/forwardingAddresses") {
+			_ = json.NewEncoder(w).Encode(map[string]any{"forwardingEmail": "outside@example.net", "verificationStatus": "pending"})
+			return
+		}
+		t.Errorf("unexpected gmail request %s %s", r.Method, r.URL.Path)
+		http.NotFound(w, r)
+	}), gmail.NewService)
+	t.Cleanup(closeGmail)
+	runtime := &app.Runtime{Services: app.Services{
+		AdminDirectory: fixedAdminTestService(adminSvc),
+		Gmail: func(context.Context, string) (*gmail.Service, error) {
+			return gmailSvc, nil
+		},
+	}, ServiceAccounts: offboardServiceAccounts(t, "leaver@example.com")}
+
+	result := executeWithTestRuntime(t, []string{"--account", "admin@example.com", "--json", "admin", "users", "offboard", "leaver@example.com",
+		"--no-transfer", "--keep-groups", "--forward", "outside@example.net", "--force"}, runtime)
+	if result.err == nil || !strings.Contains(result.err.Error(), "pending verification") {
+		t.Fatalf("err = %v", result.err)
+	}
+	wantCalls := []string{
+		"POST /gmail/v1/users/me/settings/forwardingAddresses",
+		"PATCH /admin/directory/v1/users/leaver@example.com",
+		"POST /admin/directory/v1/users/leaver@example.com/signOut",
+	}
+	if strings.Join(calls, "\n") != strings.Join(wantCalls, "\n") {
+		t.Fatalf("calls:\n%s", strings.Join(calls, "\n"))
+	}
+
+	var done struct {
+		Completed bool                 `json:"completed"`
+		Steps     []*adminOffboardStep `json:"steps"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &done); err != nil {
+		t.Fatalf("result json: %v\n%s", err, result.stdout)
+	}
+	if done.Completed || len(done.Steps) != 3 || done.Steps[0].Status != offboardPending ||
+		done.Steps[0].Detail["verification_status"] != "pending" || done.Steps[1].Status != offboardDone {
+		t.Fatalf("result = %s", result.stdout)
+	}
+}
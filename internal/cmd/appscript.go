@@ -12,10 +12,14 @@ import (
 )
 
 type AppScriptCmd struct {
-	Get     AppScriptGetCmd     `cmd:"" name:"get" aliases:"info,show" help:"Get Apps Script project metadata"`
-	Content AppScriptContentCmd `cmd:"" name:"content" aliases:"cat" help:"Get Apps Script project content"`
-	Run     AppScriptRunCmd     `cmd:"" name:"run" help:"Run a deployed Apps Script function"`
-	Create  AppScriptCreateCmd  `cmd:"" name:"create" aliases:"new" help:"Create an Apps Script project"`
+	Get         AppScriptGetCmd         `cmd:"" name:"get" aliases:"info,show" help:"Get Apps Script project metadata"`
+	Content     AppScriptContentCmd     `cmd:"" name:"content" aliases:"cat" help:"Get Apps Script project content"`
+	Pull        AppScriptPullCmd        `cmd:"" name:"pull" aliases:"clone" help:"Download project files (.gs, .html, appsscript.json) into a directory"`
+	Push        AppScriptPushCmd        `cmd:"" name:"push" help:"Replace project content with local files, showing a diff first"`
+	Versions    AppScriptVersionsCmd    `cmd:"" name:"versions" aliases:"version" help:"Create and list project versions"`
+	Deployments AppScriptDeploymentsCmd `cmd:"" name:"deployments" aliases:"deployment,deploy" help:"Manage project deployments"`
+	Run         AppScriptRunCmd         `cmd:"" name:"run" help:"Run a deployed Apps Script function"`
+	Create      AppScriptCreateCmd      `cmd:"" name:"create" aliases:"new" help:"Create an Apps Script project"`
 }
 
 type AppScriptGetCmd struct {
@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	scriptapi "google.golang.org/api/script/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type AppScriptVersionsCmd struct {
+	Create AppScriptVersionsCreateCmd `cmd:"" name:"create" aliases:"new" help:"Create an immutable version from the current project content"`
+	List   AppScriptVersionsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List project versions"`
+}
+
+type AppScriptVersionsCreateCmd struct {
+	ScriptID    string `arg:"" name:"scriptId" help:"Script ID"`
+	Description string `name:"description" aliases:"desc" help:"Version description"`
+}
+
+func (c *AppScriptVersionsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	scriptID := strings.TrimSpace(normalizeGoogleID(c.ScriptID))
+	if scriptID == "" {
+		return usage("empty scriptId")
+	}
+	description := strings.TrimSpace(c.Description)
+
+	if dryRunErr := dryRunExit(ctx, flags, "appscript.versions.create", map[string]any{
+		"script_id":   scriptID,
+		"description": description,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+	version, err := svc.Projects.Versions.Create(scriptID, &scriptapi.Version{Description: description}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"version": version})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Linef("version\t%d", version.VersionNumber)
+	if version.Description != "" {
+		u.Out().Linef("description\t%s", version.Description)
+	}
+	if version.CreateTime != "" {
+		u.Out().Linef("created\t%s", version.CreateTime)
+	}
+	return nil
+}
+
+type AppScriptVersionsListCmd struct {
+	ScriptID string `arg:"" name:"scriptId" help:"Script ID"`
+	Max      int64  `name:"max" aliases:"limit" help:"Max results" default:"50"`
+	Page     string `name:"page" aliases:"cursor" help:"Page token"`
+	All      bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+}
+
+func (c *AppScriptVersionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	scriptID := strings.TrimSpace(normalizeGoogleID(c.ScriptID))
+	if scriptID == "" {
+		return usage("empty scriptId")
+	}
+	if c.Max <= 0 {
+		return usage("max must be > 0")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	versions, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, func(pageToken string) ([]*scriptapi.Version, string, error) {
+		call := svc.Projects.Versions.List(scriptID).PageSize(c.Max).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, callErr := call.Do()
+		if callErr != nil {
+			return nil, "", callErr
+		}
+		return resp.Versions, resp.NextPageToken, nil
+	}))
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"versions":      versions,
+			"nextPageToken": nextPageToken,
+		})
+	}
+	u := ui.FromContext(ctx)
+	if len(versions) == 0 {
+		u.Err().Println("No versions")
+		return nil
+	}
+	if err := outfmt.WriteTable(ctx, stdoutWriter(ctx), versions, []outfmt.Column[*scriptapi.Version]{
+		{Header: "VERSION", Value: func(v *scriptapi.Version) string { return strconv.FormatInt(v.VersionNumber, 10) }},
+		{Header: "CREATED", Value: func(v *scriptapi.Version) string { return v.CreateTime }},
+		{Header: "DESCRIPTION", Value: func(v *scriptapi.Version) string { return v.Description }},
+	}); err != nil {
+		return err
+	}
+	printNextPageHintWithAll(u, nextPageToken, "--all")
+	return nil
+}
+
+type AppScriptDeploymentsCmd struct {
+	Create AppScriptDeploymentsCreateCmd `cmd:"" name:"create" aliases:"new" help:"Deploy a project version"`
+	Update AppScriptDeploymentsUpdateCmd `cmd:"" name:"update" aliases:"edit" help:"Point a deployment at another version or change its description"`
+	List   AppScriptDeploymentsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List deployments"`
+	Delete AppScriptDeploymentsDeleteCmd `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a deployment"`
+}
+
+type AppScriptDeploymentsCreateCmd struct {
+	ScriptID    string `arg:"" name:"scriptId" help:"Script ID"`
+	Version     int64  `name:"version-number" required:"" help:"Version number to deploy (see appscript versions create)"`
+	Description string `name:"description" aliases:"desc" help:"Deployment description"`
+}
+
+func (c *AppScriptDeploymentsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	scriptID := strings.TrimSpace(normalizeGoogleID(c.ScriptID))
+	if scriptID == "" {
+		return usage("empty scriptId")
+	}
+	if c.Version <= 0 {
+		return usage("--version-number must be > 0")
+	}
+	config := &scriptapi.DeploymentConfig{
+		ScriptId:         scriptID,
+		VersionNumber:    c.Version,
+		Description:      strings.TrimSpace(c.Description),
+		ManifestFileName: appScriptManifestName,
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "appscript.deployments.create", map[string]any{
+		"script_id":   scriptID,
+		"version":     config.VersionNumber,
+		"description": config.Description,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+	deployment, err := svc.Projects.Deployments.Create(scriptID, config).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return writeAppScriptDeployment(ctx, deployment)
+}
+
+type AppScriptDeploymentsUpdateCmd struct {
+	ScriptID     string  `arg:"" name:"scriptId" help:"Script ID"`
+	DeploymentID string  `arg:"" name:"deploymentId" help:"Deployment ID"`
+	Version      int64   `name:"version-number" help:"Version number to deploy (default: keep the current version)"`
+	Description  *string `name:"description" aliases:"desc" help:"Deployment description (default: keep the current description)"`
+}
+
+func (c *AppScriptDeploymentsUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	scriptID := strings.TrimSpace(normalizeGoogleID(c.ScriptID))
+	deploymentID := strings.TrimSpace(c.DeploymentID)
+	if scriptID == "" || deploymentID == "" {
+		return usage("required: scriptId and deploymentId")
+	}
+	if c.Version < 0 {
+		return usage("--version-number must be > 0")
+	}
+	if c.Version == 0 && c.Description == nil {
+		return usage("required: --version-number or --description")
+	}
+	payload := map[string]any{
+		"script_id":     scriptID,
+		"deployment_id": deploymentID,
+	}
+	if c.Version > 0 {
+		payload["version"] = c.Version
+	}
+	if c.Description != nil {
+		payload["description"] = strings.TrimSpace(*c.Description)
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "appscript.deployments.update", payload); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	// updateDeployment replaces the whole config, so start from the current one.
+	current, err := svc.Projects.Deployments.Get(scriptID, deploymentID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	config := &scriptapi.DeploymentConfig{ScriptId: scriptID, ManifestFileName: appScriptManifestName}
+	if current.DeploymentConfig != nil {
+		config.VersionNumber = current.DeploymentConfig.VersionNumber
+		config.Description = current.DeploymentConfig.Description
+		config.ManifestFileName = firstNonEmpty(current.DeploymentConfig.ManifestFileName, appScriptManifestName)
+	}
+	if c.Version > 0 {
+		config.VersionNumber = c.Version
+	}
+	if c.Description != nil {
+		config.Description = strings.TrimSpace(*c.Description)
+	}
+
+	deployment, err := svc.Projects.Deployments.Update(scriptID, deploymentID, &scriptapi.UpdateDeploymentRequest{
+		DeploymentConfig: config,
+	}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	return writeAppScriptDeployment(ctx, deployment)
+}
+
+type AppScriptDeploymentsListCmd struct {
+	ScriptID string `arg:"" name:"scriptId" help:"Script ID"`
+	Max      int64  `name:"max" aliases:"limit" help:"Max results" default:"50"`
+	Page     string `name:"page" aliases:"cursor" help:"Page token"`
+	All      bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+}
+
+func (c *AppScriptDeploymentsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	scriptID := strings.TrimSpace(normalizeGoogleID(c.ScriptID))
+	if scriptID == "" {
+		return usage("empty scriptId")
+	}
+	if c.Max <= 0 {
+		return usage("max must be > 0")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	deployments, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, func(pageToken string) ([]*scriptapi.Deployment, string, error) {
+		call := svc.Projects.Deployments.List(scriptID).PageSize(c.Max).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, callErr := call.Do()
+		if callErr != nil {
+			return nil, "", callErr
+		}
+		return resp.Deployments, resp.NextPageToken, nil
+	}))
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"deployments":   deployments,
+			"nextPageToken": nextPageToken,
+		})
+	}
+	u := ui.FromContext(ctx)
+	if len(deployments) == 0 {
+		u.Err().Println("No deployments")
+		return nil
+	}
+	if err := outfmt.WriteTable(ctx, stdoutWriter(ctx), deployments, []outfmt.Column[*scriptapi.Deployment]{
+		{Header: "ID", Value: func(d *scriptapi.Deployment) string { return d.DeploymentId }},
+		{Header: "VERSION", Value: appScriptDeploymentVersion},
+		{Header: "UPDATED", Value: func(d *scriptapi.Deployment) string { return d.UpdateTime }},
+		{Header: "DESCRIPTION", Value: func(d *scriptapi.Deployment) string {
+			if d.DeploymentConfig == nil {
+				return ""
+			}
+			return d.DeploymentConfig.Description
+		}},
+	}); err != nil {
+		return err
+	}
+	printNextPageHintWithAll(u, nextPageToken, "--all")
+	return nil
+}
+
+type AppScriptDeploymentsDeleteCmd struct {
+	ScriptID     string `arg:"" name:"scriptId" help:"Script ID"`
+	DeploymentID string `arg:"" name:"deploymentId" help:"Deployment ID"`
+}
+
+func (c *AppScriptDeploymentsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	scriptID := strings.TrimSpace(normalizeGoogleID(c.ScriptID))
+	deploymentID := strings.TrimSpace(c.DeploymentID)
+	if scriptID == "" || deploymentID == "" {
+		return usage("required: scriptId and deploymentId")
+	}
+	if confirmErr := dryRunAndConfirmDestructive(ctx, flags, "appscript.deployments.delete", map[string]any{
+		"script_id":     scriptID,
+		"deployment_id": deploymentID,
+	}, "delete Apps Script deployment "+deploymentID); confirmErr != nil {
+		return confirmErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.Projects.Deployments.Delete(scriptID, deploymentID).Context(ctx).Do(); err != nil {
+		return err
+	}
+	return writeResult(ctx, ui.FromContext(ctx),
+		kv("deleted", true),
+		kv("deployment_id", deploymentID),
+	)
+}
+
+func writeAppScriptDeployment(ctx context.Context, deployment *scriptapi.Deployment) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"deployment": deployment})
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Linef("deployment_id\t%s", deployment.DeploymentId)
+	if version := appScriptDeploymentVersion(deployment); version != "" {
+		u.Out().Linef("version\t%s", version)
+	}
+	if deployment.DeploymentConfig != nil && deployment.DeploymentConfig.Description != "" {
+		u.Out().Linef("description\t%s", deployment.DeploymentConfig.Description)
+	}
+	for _, entry := range deployment.EntryPoints {
+		if entry != nil && entry.WebApp != nil && entry.WebApp.Url != "" {
+			u.Out().Linef("web_app_url\t%s", entry.WebApp.Url)
+		}
+	}
+	return nil
+}
+
+func appScriptDeploymentVersion(deployment *scriptapi.Deployment) string {
+	if deployment == nil || deployment.DeploymentConfig == nil {
+		return ""
+	}
+	if deployment.DeploymentConfig.VersionNumber == 0 {
+		return "HEAD"
+	}
+	return strconv.FormatInt(deployment.DeploymentConfig.VersionNumber, 10)
+}
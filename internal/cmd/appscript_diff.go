@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	scriptapi "google.golang.org/api/script/v1"
+)
+
+const (
+	appScriptChangeAdded     = "added"
+	appScriptChangeModified  = "modified"
+	appScriptChangeRemoved   = "removed"
+	appScriptChangeUnchanged = "unchanged"
+
+	// Above this many line pairs the diff falls back to replacing the whole
+	// file instead of computing a line-level LCS.
+	appScriptDiffMaxCells = 4_000_000
+	appScriptDiffContext  = 3
+)
+
+type appScriptFileChange struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Status string `json:"status"`
+	Diff   string `json:"diff,omitempty"`
+}
+
+// diffAppScriptFiles compares the remote project with the local files that
+// would replace it. updateContent replaces the whole project, so remote files
+// missing locally are reported as removed.
+func diffAppScriptFiles(remote, local []*scriptapi.File) []appScriptFileChange {
+	remoteByName := make(map[string]*scriptapi.File, len(remote))
+	for _, file := range remote {
+		if file != nil {
+			remoteByName[file.Name] = file
+		}
+	}
+	changes := make([]appScriptFileChange, 0, len(local)+len(remote))
+	seen := make(map[string]bool, len(local))
+	for _, file := range local {
+		seen[file.Name] = true
+		label := appScriptDiffLabel(file)
+		before, ok := remoteByName[file.Name]
+		switch {
+		case !ok:
+			changes = append(changes, appScriptFileChange{Name: file.Name, Type: file.Type, Status: appScriptChangeAdded,
+				Diff: unifiedLineDiff("/dev/null", "b/"+label, "", file.Source)})
+		case before.Source == file.Source && before.Type == file.Type:
+			changes = append(changes, appScriptFileChange{Name: file.Name, Type: file.Type, Status: appScriptChangeUnchanged})
+		default:
+			changes = append(changes, appScriptFileChange{Name: file.Name, Type: file.Type, Status: appScriptChangeModified,
+				Diff: unifiedLineDiff("a/"+appScriptDiffLabel(before), "b/"+label, before.Source, file.Source)})
+		}
+	}
+	for _, file := range remote {
+		if file == nil || seen[file.Name] {
+			continue
+		}
+		changes = append(changes, appScriptFileChange{Name: file.Name, Type: file.Type, Status: appScriptChangeRemoved,
+			Diff: unifiedLineDiff("a/"+appScriptDiffLabel(file), "/dev/null", file.Source, "")})
+	}
+	return changes
+}
+
+func appScriptDiffLabel(file *scriptapi.File) string {
+	if rel, err := appScriptLocalPath(file); err == nil {
+		return rel
+	}
+	return file.Name
+}
+
+func countAppScriptChanges(changes []appScriptFileChange) map[string]int {
+	counts := map[string]int{}
+	for _, change := range changes {
+		counts[change.Status]++
+	}
+	return counts
+}
+
+// unifiedLineDiff renders a unified diff with three lines of context.
+func unifiedLineDiff(fromName, toName, before, after string) string {
+	if before == after {
+		return ""
+	}
+	a := splitDiffLines(before)
+	b := splitDiffLines(after)
+	ops := diffLineOps(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", fromName, toName)
+	for start := 0; start < len(ops); {
+		for start < len(ops) && ops[start].kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+		lo := max(0, start-appScriptDiffContext)
+		hi := start
+		for hi < len(ops) {
+			if ops[hi].kind != ' ' {
+				hi++
+				continue
+			}
+			run := hi
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-hi > 2*appScriptDiffContext {
+				hi = min(run, hi+appScriptDiffContext)
+				break
+			}
+			hi = run
+		}
+		writeDiffHunk(&sb, ops[lo:hi])
+		start = hi
+	}
+	return sb.String()
+}
+
+type diffLineOp struct {
+	kind byte
+	text string
+	aPos int
+	bPos int
+}
+
+func writeDiffHunk(sb *strings.Builder, ops []diffLineOp) {
+	aStart, bStart, aLen, bLen := 0, 0, 0, 0
+	for i, op := range ops {
+		if i == 0 {
+			aStart, bStart = op.aPos, op.bPos
+		}
+		if op.kind != '+' {
+			aLen++
+		}
+		if op.kind != '-' {
+			bLen++
+		}
+	}
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", diffRange(aStart, aLen), diffRange(bStart, bLen))
+	for _, op := range ops {
+		sb.WriteByte(op.kind)
+		sb.WriteString(op.text)
+		sb.WriteByte('\n')
+	}
+}
+
+func diffRange(start, length int) string {
+	if length == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	if length == 1 {
+		return fmt.Sprintf("%d", start+1)
+	}
+	return fmt.Sprintf("%d,%d", start+1, length)
+}
+
+func splitDiffLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLineOps aligns a and b on their longest common subsequence. aPos and
+// bPos record the zero-based line positions used for hunk headers.
+func diffLineOps(a, b []string) []diffLineOp {
+	if len(a)*len(b) > appScriptDiffMaxCells {
+		ops := make([]diffLineOp, 0, len(a)+len(b))
+		for i, line := range a {
+			ops = append(ops, diffLineOp{kind: '-', text: line, aPos: i})
+		}
+		for j, line := range b {
+			ops = append(ops, diffLineOp{kind: '+', text: line, aPos: len(a), bPos: j})
+		}
+		return ops
+	}
+
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	ops := make([]diffLineOp, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			ops = append(ops, diffLineOp{kind: ' ', text: a[i], aPos: i, bPos: j})
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffLineOp{kind: '-', text: a[i], aPos: i, bPos: j})
+			i++
+		default:
+			ops = append(ops, diffLineOp{kind: '+', text: b[j], aPos: i, bPos: j})
+			j++
+		}
+	}
+	return ops
+}
@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	scriptapi "google.golang.org/api/script/v1"
+)
+
+const (
+	appScriptManifestName = "appsscript"
+	appScriptProjectFile  = ".clasp.json"
+	// appScriptPullFile records which local files the last pull wrote, so a
+	// later pull only ever deletes files gog created.
+	appScriptPullFile = ".gog-pull.json"
+
+	appScriptTypeServerJS = "SERVER_JS"
+	appScriptTypeHTML     = "HTML"
+	appScriptTypeJSON     = "JSON"
+)
+
+// appScriptProject is the subset of clasp's .clasp.json that gog reads and
+// writes, so a directory pulled by either tool can be pushed by the other.
+type appScriptProject struct {
+	ScriptID string `json:"scriptId"`
+}
+
+type appScriptPullManifest struct {
+	ScriptID string   `json:"scriptId"`
+	Files    []string `json:"files"`
+}
+
+// appScriptLocalPath maps a project file to its path inside a local checkout:
+// SERVER_JS files become .gs, HTML files .html, and the manifest
+// appsscript.json. Slashes in project file names become directories.
+func appScriptLocalPath(file *scriptapi.File) (string, error) {
+	if file == nil || strings.TrimSpace(file.Name) == "" {
+		return "", errors.New("project file without a name")
+	}
+	var ext string
+	switch file.Type {
+	case appScriptTypeServerJS:
+		ext = ".gs"
+	case appScriptTypeHTML:
+		ext = ".html"
+	case appScriptTypeJSON:
+		if file.Name != appScriptManifestName {
+			return "", fmt.Errorf("unexpected JSON file %q", file.Name)
+		}
+		ext = ".json"
+	default:
+		return "", fmt.Errorf("unsupported file type %q for %q", file.Type, file.Name)
+	}
+	clean := path.Clean(file.Name)
+	if path.IsAbs(clean) || clean == ".." || strings.HasPrefix(clean, "../") {
+		return "", fmt.Errorf("unsafe project file name %q", file.Name)
+	}
+	return clean + ext, nil
+}
+
+// appScriptFileForPath is the inverse of appScriptLocalPath. ok is false for
+// files that are not part of an Apps Script project (README.md, .clasp.json).
+func appScriptFileForPath(rel string) (name string, fileType string, ok bool) {
+	rel = filepath.ToSlash(rel)
+	ext := strings.ToLower(path.Ext(rel))
+	base := strings.TrimSuffix(rel, path.Ext(rel))
+	switch ext {
+	case ".gs", ".js":
+		return base, appScriptTypeServerJS, true
+	case ".html":
+		return base, appScriptTypeHTML, true
+	case ".json":
+		if base == appScriptManifestName {
+			return base, appScriptTypeJSON, true
+		}
+	}
+	return "", "", false
+}
+
+// appScriptLocalPaths lists the slash-separated paths under dir that map to
+// project files, in path order. Hidden files and node_modules are ignored.
+func appScriptLocalPaths(dir string) ([]string, error) {
+	var rels []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if p != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, relErr := filepath.Rel(dir, p)
+		if relErr != nil {
+			return relErr
+		}
+		if _, _, ok := appScriptFileForPath(rel); ok {
+			rels = append(rels, filepath.ToSlash(rel))
+		}
+		return nil
+	})
+	return rels, err
+}
+
+// readAppScriptDir loads the project files under dir. order lists remote file
+// names so existing files keep their position (which decides global code
+// evaluation order); new files follow in path order.
+func readAppScriptDir(dir string, order []string) ([]*scriptapi.File, error) {
+	rels, err := appScriptLocalPaths(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []*scriptapi.File
+	paths := map[string]string{}
+	for _, rel := range rels {
+		name, fileType, _ := appScriptFileForPath(rel)
+		if previous, dup := paths[name]; dup {
+			return nil, usagef("%s and %s both map to project file %q", previous, rel, name)
+		}
+		paths[name] = rel
+		data, readErr := os.ReadFile(filepath.Join(dir, filepath.FromSlash(rel))) //nolint:gosec // path is inside the user-supplied project directory
+		if readErr != nil {
+			return nil, readErr
+		}
+		files = append(files, &scriptapi.File{Name: name, Type: fileType, Source: string(data)})
+	}
+	if _, ok := paths[appScriptManifestName]; !ok {
+		return nil, usagef("%s is missing %s.json", dir, appScriptManifestName)
+	}
+
+	rank := make(map[string]int, len(order))
+	for i, name := range order {
+		rank[name] = i
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		ri, iok := rank[files[i].Name]
+		rj, jok := rank[files[j].Name]
+		switch {
+		case iok && jok:
+			return ri < rj
+		case iok != jok:
+			return iok
+		default:
+			return paths[files[i].Name] < paths[files[j].Name]
+		}
+	})
+	return files, nil
+}
+
+// appScriptPullPaths picks the local path for each project file. A SERVER_JS
+// file that is already checked out as .js (clasp's default) keeps that name;
+// everything else uses appScriptLocalPath. Only those candidate paths are
+// looked at, never the rest of dir.
+func appScriptPullPaths(dir string, files []*scriptapi.File) ([]string, error) {
+	rels := make([]string, 0, len(files))
+	for _, file := range files {
+		rel, err := appScriptLocalPath(file)
+		if err != nil {
+			return nil, err
+		}
+		if file.Type == appScriptTypeServerJS {
+			js := strings.TrimSuffix(rel, ".gs") + ".js"
+			if appScriptFileExists(filepath.Join(dir, filepath.FromSlash(js))) && !appScriptFileExists(filepath.Join(dir, filepath.FromSlash(rel))) {
+				rel = js
+			}
+		}
+		rels = append(rels, rel)
+	}
+	return rels, nil
+}
+
+// staleAppScriptPaths lists files the previous pull of scriptID wrote that
+// this pull no longer writes and that still exist. Files the pull manifest
+// does not list are never stale.
+func staleAppScriptPaths(dir, scriptID string, rels []string) ([]string, error) {
+	manifest, err := readAppScriptPullManifest(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if manifest.ScriptID != scriptID {
+		return nil, nil
+	}
+	pulled := make(map[string]bool, len(rels))
+	for _, rel := range rels {
+		pulled[rel] = true
+	}
+	var stale []string
+	for _, rel := range manifest.Files {
+		if !pulled[rel] && appScriptFileExists(filepath.Join(dir, filepath.FromSlash(rel))) {
+			stale = append(stale, rel)
+		}
+	}
+	return stale, nil
+}
+
+func appScriptFileExists(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && !info.IsDir()
+}
+
+func removeAppScriptFiles(dir string, rels []string) error {
+	for _, rel := range rels {
+		if err := os.Remove(filepath.Join(dir, filepath.FromSlash(rel))); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeAppScriptFiles(dir string, files []*scriptapi.File, rels []string) error {
+	for i, file := range files {
+		target := filepath.Join(dir, filepath.FromSlash(rels[i]))
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, []byte(file.Source), 0o600); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func readAppScriptProject(dir string) (appScriptProject, error) {
+	var project appScriptProject
+	data, err := os.ReadFile(filepath.Join(dir, appScriptProjectFile)) //nolint:gosec // path is inside the user-supplied project directory
+	if err != nil {
+		return project, err
+	}
+	if err := json.Unmarshal(data, &project); err != nil {
+		return project, fmt.Errorf("parse %s: %w", appScriptProjectFile, err)
+	}
+	return project, nil
+}
+
+func writeAppScriptProject(dir string, project appScriptProject) error {
+	data, err := json.MarshalIndent(project, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, appScriptProjectFile), append(data, '\n'), 0o600)
+}
+
+func readAppScriptPullManifest(dir string) (appScriptPullManifest, error) {
+	var manifest appScriptPullManifest
+	data, err := os.ReadFile(filepath.Join(dir, appScriptPullFile)) //nolint:gosec // path is inside the user-supplied project directory
+	if err != nil {
+		return manifest, err
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("parse %s: %w", appScriptPullFile, err)
+	}
+	return manifest, nil
+}
+
+func writeAppScriptPullManifest(dir string, manifest appScriptPullManifest) error {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, appScriptPullFile), append(data, '\n'), 0o600)
+}
+
+func appScriptFileNames(files []*scriptapi.File) []string {
+	names := make([]string, 0, len(files))
+	for _, file := range files {
+		if file != nil {
+			names = append(names, file.Name)
+		}
+	}
+	return names
+}
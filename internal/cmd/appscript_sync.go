@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	scriptapi "google.golang.org/api/script/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type AppScriptPullCmd struct {
+	ScriptID string `arg:"" name:"scriptId" help:"Script ID"`
+	Dir      string `arg:"" name:"dir" help:"Local directory to write the project files into" default:"."`
+	Version  int64  `name:"version-number" help:"Pull a saved version instead of the current HEAD content"`
+	Delete   bool   `name:"delete" help:"Delete files an earlier pull wrote that are no longer in the project (asks first)"`
+}
+
+func (c *AppScriptPullCmd) Run(ctx context.Context, flags *RootFlags) error {
+	scriptID := strings.TrimSpace(normalizeGoogleID(c.ScriptID))
+	if scriptID == "" {
+		return usage("empty scriptId")
+	}
+	if c.Version < 0 {
+		return usage("--version-number must be > 0")
+	}
+	dir, err := config.ExpandPath(strings.TrimSpace(c.Dir))
+	if err != nil {
+		return err
+	}
+	if existing, readErr := readAppScriptProject(dir); readErr == nil && existing.ScriptID != "" && existing.ScriptID != scriptID && (flags == nil || !flags.Force) {
+		return usagef("%s is linked to script %s; pass --force to overwrite it", dir, existing.ScriptID)
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+	call := svc.Projects.GetContent(scriptID).Context(ctx)
+	if c.Version > 0 {
+		call = call.VersionNumber(c.Version)
+	}
+	content, err := call.Do()
+	if err != nil {
+		return err
+	}
+
+	rels, err := appScriptPullPaths(dir, content.Files)
+	if err != nil {
+		return err
+	}
+	// Files the previous pull wrote but this one does not were deleted or
+	// renamed remotely. They are only reported unless --delete is set.
+	stale, err := staleAppScriptPaths(dir, scriptID, rels)
+	if err != nil {
+		return err
+	}
+	var removed []string
+	if c.Delete {
+		removed, stale = stale, nil
+	}
+	if err := dryRunExit(ctx, flags, "appscript.pull", map[string]any{
+		"script_id": scriptID,
+		"dir":       dir,
+		"version":   c.Version,
+		"files":     rels,
+		"removed":   removed,
+		"stale":     stale,
+	}); err != nil {
+		return err
+	}
+	if len(removed) > 0 {
+		action := fmt.Sprintf("pull into %s and delete %d local file(s) missing remotely (%s)", dir, len(removed), strings.Join(removed, ", "))
+		if err := confirmDestructiveChecked(ctx, flags, action); err != nil {
+			return err
+		}
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	if err := writeAppScriptFiles(dir, content.Files, rels); err != nil {
+		return err
+	}
+	if err := removeAppScriptFiles(dir, removed); err != nil {
+		return err
+	}
+	// Kept stale files stay in the manifest so a later --delete finds them.
+	manifest := appScriptPullManifest{ScriptID: scriptID, Files: append(append([]string{}, rels...), stale...)}
+	if err := writeAppScriptPullManifest(dir, manifest); err != nil {
+		return err
+	}
+	if err := writeAppScriptProject(dir, appScriptProject{ScriptID: scriptID}); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"script_id": scriptID,
+			"dir":       dir,
+			"version":   c.Version,
+			"files":     rels,
+			"removed":   removed,
+			"stale":     stale,
+		})
+	}
+
+	u := ui.FromContext(ctx)
+	u.Out().Linef("script_id\t%s", scriptID)
+	u.Out().Linef("dir\t%s", dir)
+	for _, rel := range rels {
+		u.Out().Linef("file\t%s", rel)
+	}
+	for _, rel := range removed {
+		u.Out().Linef("removed\t%s", rel)
+	}
+	for _, rel := range stale {
+		u.Out().Linef("stale\t%s", rel)
+	}
+	if len(stale) > 0 {
+		u.Err().Linef("%d local file(s) no longer in the project; pass --delete to remove them", len(stale))
+	}
+	return nil
+}
+
+type AppScriptPushCmd struct {
+	Dir      string `arg:"" name:"dir" help:"Local project directory (with appsscript.json)" default:"."`
+	ScriptID string `name:"script-id" help:"Script ID (default: scriptId from .clasp.json in dir)"`
+	Diff     bool   `name:"diff" negatable:"" default:"true" help:"Print a unified diff of changed files"`
+}
+
+func (c *AppScriptPushCmd) Run(ctx context.Context, flags *RootFlags) error {
+	dir, err := config.ExpandPath(strings.TrimSpace(c.Dir))
+	if err != nil {
+		return err
+	}
+	scriptID, err := c.resolveScriptID(dir)
+	if err != nil {
+		return err
+	}
+	// Validate the local tree before touching the network.
+	if _, err := readAppScriptDir(dir, nil); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := appScriptService(ctx, account)
+	if err != nil {
+		return err
+	}
+	remote, err := svc.Projects.GetContent(scriptID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	local, err := readAppScriptDir(dir, appScriptFileNames(remote.Files))
+	if err != nil {
+		return err
+	}
+	changes := diffAppScriptFiles(remote.Files, local)
+	counts := countAppScriptChanges(changes)
+
+	if !outfmt.IsJSON(ctx) && !outfmt.IsPlain(ctx) && c.Diff {
+		writeAppScriptDiffPreview(ui.FromContext(ctx), changes)
+	}
+	if err := dryRunExit(ctx, flags, "appscript.push", appScriptPushPayload(scriptID, dir, changes, counts, outfmt.IsJSON(ctx))); err != nil {
+		return err
+	}
+	if counts[appScriptChangeAdded]+counts[appScriptChangeModified]+counts[appScriptChangeRemoved] == 0 {
+		return writeAppScriptPushResult(ctx, scriptID, changes, counts, false)
+	}
+	if counts[appScriptChangeRemoved] > 0 {
+		action := fmt.Sprintf("push %s and delete %d remote file(s) missing locally", dir, counts[appScriptChangeRemoved])
+		if err := confirmDestructiveChecked(ctx, flags, action); err != nil {
+			return err
+		}
+	}
+
+	if _, err := svc.Projects.UpdateContent(scriptID, &scriptapi.Content{ScriptId: scriptID, Files: local}).Context(ctx).Do(); err != nil {
+		return err
+	}
+	return writeAppScriptPushResult(ctx, scriptID, changes, counts, true)
+}
+
+func (c *AppScriptPushCmd) resolveScriptID(dir string) (string, error) {
+	if id := strings.TrimSpace(normalizeGoogleID(c.ScriptID)); id != "" {
+		return id, nil
+	}
+	project, err := readAppScriptProject(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", usagef("no %s in %s; pass --script-id or run `gog appscript pull` first", appScriptProjectFile, dir)
+	}
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(project.ScriptID) == "" {
+		return "", usagef("%s in %s has no scriptId", appScriptProjectFile, dir)
+	}
+	return strings.TrimSpace(project.ScriptID), nil
+}
+
+func writeAppScriptDiffPreview(u *ui.UI, changes []appScriptFileChange) {
+	for _, change := range changes {
+		if change.Diff != "" {
+			u.Out().Print(change.Diff)
+		}
+	}
+}
+
+func appScriptPushPayload(scriptID, dir string, changes []appScriptFileChange, counts map[string]int, withDiff bool) map[string]any {
+	files := changes
+	if !withDiff {
+		files = make([]appScriptFileChange, len(changes))
+		for i, change := range changes {
+			change.Diff = ""
+			files[i] = change
+		}
+	}
+	return map[string]any{
+		"script_id": scriptID,
+		"dir":       dir,
+		"files":     files,
+		"added":     counts[appScriptChangeAdded],
+		"modified":  counts[appScriptChangeModified],
+		"removed":   counts[appScriptChangeRemoved],
+		"unchanged": counts[appScriptChangeUnchanged],
+	}
+}
+
+func writeAppScriptPushResult(ctx context.Context, scriptID string, changes []appScriptFileChange, counts map[string]int, pushed bool) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"script_id": scriptID,
+			"pushed":    pushed,
+			"files":     changes,
+			"added":     counts[appScriptChangeAdded],
+			"modified":  counts[appScriptChangeModified],
+			"removed":   counts[appScriptChangeRemoved],
+			"unchanged": counts[appScriptChangeUnchanged],
+		})
+	}
+
+	u := ui.FromContext(ctx)
+	u.Out().Linef("script_id\t%s", scriptID)
+	u.Out().Linef("pushed\t%t", pushed)
+	for _, change := range changes {
+		if change.Status != appScriptChangeUnchanged {
+			u.Out().Linef("%s\t%s", change.Status, change.Name)
+		}
+	}
+	if !pushed {
+		u.Err().Println("No changes")
+	}
+	return nil
+}
@@ -0,0 +1,256 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	scriptapi "google.golang.org/api/script/v1"
+)
+
+type appScriptTestServer struct {
+	mu         sync.Mutex
+	files      []*scriptapi.File
+	updated    *scriptapi.Content
+	deployment *scriptapi.UpdateDeploymentRequest
+}
+
+func (s *appScriptTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/script123/content":
+			_ = json.NewEncoder(w).Encode(map[string]any{"scriptId": "script123", "files": s.files})
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/projects/script123/content":
+			s.updated = &scriptapi.Content{}
+			_ = json.NewDecoder(r.Body).Decode(s.updated)
+			_ = json.NewEncoder(w).Encode(s.updated)
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/script123/deployments/dep1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"deploymentId": "dep1", "deploymentConfig": map[string]any{
+				"versionNumber": 2, "description": "prod", "manifestFileName": "appsscript",
+			}})
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/projects/script123/deployments/dep1":
+			s.deployment = &scriptapi.UpdateDeploymentRequest{}
+			_ = json.NewDecoder(r.Body).Decode(s.deployment)
+			_ = json.NewEncoder(w).Encode(map[string]any{"deploymentId": "dep1", "deploymentConfig": s.deployment.DeploymentConfig})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func runAppScriptTest(t *testing.T, srv *appScriptTestServer, args ...string) executeTestResult {
+	t.Helper()
+	server := httptest.NewServer(srv.handler(t))
+	t.Cleanup(server.Close)
+	return executeWithAppScriptTestService(t, append([]string{"--account", "a@b.com"}, args...), newAppScriptTestService(t, server))
+}
+
+func TestAppScriptLocalPathMapping(t *testing.T) {
+	tests := []struct {
+		file *scriptapi.File
+		want string
+	}{
+		{&scriptapi.File{Name: "Code", Type: "SERVER_JS"}, "Code.gs"},
+		{&scriptapi.File{Name: "ui/Sidebar", Type: "HTML"}, "ui/Sidebar.html"},
+		{&scriptapi.File{Name: "appsscript", Type: "JSON"}, "appsscript.json"},
+	}
+	for _, tt := range tests {
+		got, err := appScriptLocalPath(tt.file)
+		if err != nil || got != tt.want {
+			t.Fatalf("appScriptLocalPath(%s) = %q, %v; want %q", tt.file.Name, got, err, tt.want)
+		}
+		name, fileType, ok := appScriptFileForPath(got)
+		if !ok || name != tt.file.Name || fileType != tt.file.Type {
+			t.Fatalf("appScriptFileForPath(%q) = %q, %q, %v", got, name, fileType, ok)
+		}
+	}
+	if _, err := appScriptLocalPath(&scriptapi.File{Name: "../escape", Type: "SERVER_JS"}); err == nil {
+		t.Fatal("expected unsafe name error")
+	}
+	if _, _, ok := appScriptFileForPath("README.md"); ok {
+		t.Fatal("README.md should not map to a project file")
+	}
+}
+
+func TestUnifiedLineDiff(t *testing.T) {
+	before := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	after := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	got := unifiedLineDiff("a/Code.gs", "b/Code.gs", before, after)
+	want := "--- a/Code.gs\n+++ b/Code.gs\n" +
+		"@@ -1,5 +1,5 @@\n a\n-b\n+B\n c\n d\n e\n" +
+		"@@ -8,3 +8,4 @@\n h\n i\n j\n+k\n"
+	if got != want {
+		t.Fatalf("diff mismatch\n got:\n%s\nwant:\n%s", got, want)
+	}
+	if got := unifiedLineDiff("/dev/null", "b/New.gs", "", "x\n"); !strings.Contains(got, "@@ -0,0 +1 @@\n+x\n") {
+		t.Fatalf("added diff = %q", got)
+	}
+}
+
+func TestExecute_AppScriptPullPush(t *testing.T) {
+	srv := &appScriptTestServer{files: []*scriptapi.File{
+		{Name: "appsscript", Type: "JSON", Source: "{\"timeZone\":\"UTC\"}\n"},
+		{Name: "Code", Type: "SERVER_JS", Source: "function main() {}\n"},
+		{Name: "Old", Type: "SERVER_JS", Source: "function old() {}\n"},
+	}}
+	dir := filepath.Join(t.TempDir(), "proj")
+
+	result := runAppScriptTest(t, srv, "appscript", "pull", "script123", dir)
+	if result.err != nil {
+		t.Fatalf("pull: %v", result.err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "Code.gs"))
+	if err != nil || string(data) != "function main() {}\n" {
+		t.Fatalf("Code.gs = %q, %v", data, err)
+	}
+	project, err := readAppScriptProject(dir)
+	if err != nil || project.ScriptID != "script123" {
+		t.Fatalf("project = %#v, %v", project, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "Code.gs"), []byte("function main() { run(); }\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(dir, "Old.gs")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "Sidebar.html"), []byte("<p>hi</p>\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result = runAppScriptTest(t, srv, "--dry-run", "appscript", "push", dir)
+	if result.err != nil {
+		t.Fatalf("push dry-run: %v", result.err)
+	}
+	if srv.updated != nil {
+		t.Fatal("dry-run must not update content")
+	}
+	for _, want := range []string{"-function main() {}", "+function main() { run(); }", "+++ /dev/null", "+<p>hi</p>", "Dry run: would appscript.push"} {
+		if !strings.Contains(result.stdout, want) {
+			t.Fatalf("dry-run output missing %q:\n%s", want, result.stdout)
+		}
+	}
+
+	result = runAppScriptTest(t, srv, "--json", "--force", "appscript", "push", dir)
+	if result.err != nil {
+		t.Fatalf("push: %v", result.err)
+	}
+	if srv.updated == nil {
+		t.Fatal("expected updateContent")
+	}
+	if got := appScriptFileNames(srv.updated.Files); !reflect.DeepEqual(got, []string{"appsscript", "Code", "Sidebar"}) {
+		t.Fatalf("pushed files = %#v", got)
+	}
+	var payload struct {
+		Added, Modified, Removed int
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &payload); err != nil || payload.Added != 1 || payload.Modified != 1 || payload.Removed != 1 {
+		t.Fatalf("payload = %#v, %v\n%s", payload, err, result.stdout)
+	}
+}
+
+func TestExecute_AppScriptPushRequiresConfirmationForRemovals(t *testing.T) {
+	srv := &appScriptTestServer{files: []*scriptapi.File{
+		{Name: "appsscript", Type: "JSON", Source: "{}"},
+		{Name: "Old", Type: "SERVER_JS", Source: "x"},
+	}}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "appsscript.json"), []byte("{}"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result := runAppScriptTest(t, srv, "--no-input", "appscript", "push", dir, "--script-id", "script123")
+	if result.err == nil || !strings.Contains(result.err.Error(), "without --force") {
+		t.Fatalf("err = %v", result.err)
+	}
+	if srv.updated != nil {
+		t.Fatal("push must not update without confirmation")
+	}
+}
+
+func TestExecute_AppScriptPullDeletesOnlyFilesItWrote(t *testing.T) {
+	srv := &appScriptTestServer{files: []*scriptapi.File{
+		{Name: "appsscript", Type: "JSON", Source: "{}"},
+		{Name: "Code", Type: "SERVER_JS", Source: "function main() {}\n"},
+		{Name: "Gone", Type: "SERVER_JS", Source: "x\n"},
+	}}
+	dir := t.TempDir()
+	for name, body := range map[string]string{"foo.js": "local\n", "Code.js": "old\n", "web/index.html": "<p>site</p>\n", "build/app.js": "bundle\n"} {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(target, []byte(body), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(dir, filepath.FromSlash(name)))
+		return err == nil
+	}
+
+	if result := runAppScriptTest(t, srv, "--force", "appscript", "pull", "script123", dir); result.err != nil {
+		t.Fatalf("first pull: %v", result.err)
+	}
+	// Code keeps its clasp-style .js name; nothing else is touched.
+	if data, _ := os.ReadFile(filepath.Join(dir, "Code.js")); string(data) != "function main() {}\n" || exists("Code.gs") {
+		t.Fatalf("Code.js = %q, Code.gs exists = %t", data, exists("Code.gs"))
+	}
+	if !exists("Gone.gs") || !exists("foo.js") || !exists("web/index.html") || !exists("build/app.js") {
+		t.Fatal("first pull removed files")
+	}
+
+	srv.files = srv.files[:2]
+	result := runAppScriptTest(t, srv, "--json", "--force", "appscript", "pull", "script123", dir)
+	var payload struct {
+		Removed []string `json:"removed"`
+		Stale   []string `json:"stale"`
+	}
+	if result.err != nil || json.Unmarshal([]byte(result.stdout), &payload) != nil || !reflect.DeepEqual(payload.Stale, []string{"Gone.gs"}) || !exists("Gone.gs") {
+		t.Fatalf("pull without --delete: %v %#v\n%s", result.err, payload, result.stdout)
+	}
+
+	result = runAppScriptTest(t, srv, "--dry-run", "appscript", "pull", "script123", dir, "--delete")
+	if result.err != nil || !strings.Contains(result.stdout, "Gone.gs") || !exists("Gone.gs") {
+		t.Fatalf("dry-run: %v\n%s", result.err, result.stdout)
+	}
+
+	result = runAppScriptTest(t, srv, "--no-input", "appscript", "pull", "script123", dir, "--delete")
+	if result.err == nil || !strings.Contains(result.err.Error(), "without --force") || !exists("Gone.gs") {
+		t.Fatalf("pull without confirmation: %v", result.err)
+	}
+
+	result = runAppScriptTest(t, srv, "--json", "--force", "appscript", "pull", "script123", dir, "--delete")
+	payload.Removed, payload.Stale = nil, nil
+	if result.err != nil || json.Unmarshal([]byte(result.stdout), &payload) != nil || !reflect.DeepEqual(payload.Removed, []string{"Gone.gs"}) {
+		t.Fatalf("pull --delete: %v %#v\n%s", result.err, payload, result.stdout)
+	}
+	if exists("Gone.gs") || !exists("foo.js") || !exists("web/index.html") || !exists("build/app.js") || !exists("Code.js") {
+		t.Fatal("pull --delete removed the wrong files")
+	}
+}
+
+func TestExecute_AppScriptDeploymentsUpdateKeepsConfig(t *testing.T) {
+	srv := &appScriptTestServer{}
+	result := runAppScriptTest(t, srv, "appscript", "deployments", "update", "script123", "dep1", "--version-number", "3")
+	if result.err != nil {
+		t.Fatalf("update: %v", result.err)
+	}
+	config := srv.deployment.DeploymentConfig
+	if config.VersionNumber != 3 || config.Description != "prod" || config.ManifestFileName != "appsscript" {
+		t.Fatalf("deployment config = %#v", config)
+	}
+	if !strings.Contains(result.stdout, "version\t3") {
+		t.Fatalf("out = %q", result.stdout)
+	}
+}
@@ -39,6 +39,36 @@ func rewriteDesirePathArgs(model *kong.Application, args []string) []string {
 	return out
 }
 
+// checkOutputAfterOutFlag rejects `--output` written after a command with a
+// local `--out` file path. Those commands used to accept `--output` as the
+// file flag, so parsing it as the global format would silently write a file
+// named after the old path argument, or ignore the path entirely.
+func checkOutputAfterOutFlag(model *kong.Application, args []string) error {
+	if model == nil || model.Node == nil {
+		return nil
+	}
+	for i, arg := range args {
+		if arg == "--" {
+			return nil
+		}
+		if arg != "--output" && !strings.HasPrefix(arg, "--output=") {
+			continue
+		}
+		if node := commandNodeBefore(model.Node, args[:i]); nodeHasLocalFlag(node, "out") {
+			return usagef("--output after %q is the output format, not the file path; use --out PATH for the file, or put --output before the command", commandNodeName(node))
+		}
+	}
+	return nil
+}
+
+func commandNodeName(node *kong.Node) string {
+	var names []string
+	for current := node; current != nil && current.Type == kong.CommandNode; current = current.Parent {
+		names = append([]string{current.Name}, names...)
+	}
+	return strings.Join(names, " ")
+}
+
 func commandHasLocalFlagBefore(model *kong.Application, args []string, flagIndex int, flagName string) bool {
 	if model == nil || model.Node == nil || flagIndex < 0 || flagIndex > len(args) {
 		return false
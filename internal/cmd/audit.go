@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/audit"
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/timeparse"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	auditLogName      = "audit.jsonl"
+	defaultSyslogTag  = "gog"
+	defaultAuditSince = 24 * time.Hour
+)
+
+type AuditCmd struct {
+	Log AuditLogCmd `cmd:"" name:"log" help:"Show audited mutating API requests"`
+}
+
+type AuditLogCmd struct {
+	Since     string `name:"since" help:"Earliest entry (RFC3339, date, or Go duration: 1h, 168h). Default: 24h."`
+	Service   string `name:"service" help:"Only entries for this service (drive, gmail, calendar, ...)"`
+	File      string `name:"file" help:"Audit log to read (default: the configured audit log)"`
+	Max       int    `name:"max" aliases:"limit" help:"Show at most this many of the newest entries (0 = all)" default:"0"`
+	FailEmpty bool   `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+func (c *AuditLogCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Max < 0 {
+		return usage("max must be >= 0")
+	}
+
+	since := time.Now().Add(-defaultAuditSince)
+	if strings.TrimSpace(c.Since) != "" {
+		result, err := timeparse.ParseSince(c.Since, time.Now(), time.Local)
+		if err != nil {
+			return usagef("invalid --since value: %v", err)
+		}
+		since = result.Time
+	}
+
+	path := strings.TrimSpace(c.File)
+	if path == "" {
+		cfg, err := readCommandConfig(ctx)
+		if err != nil {
+			return err
+		}
+		var enabled bool
+		path, enabled, err = auditLogPath(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		if !enabled {
+			return usage("audit log is not enabled (set audit.enabled in config.json or GOG_AUDIT_LOG)")
+		}
+	}
+
+	entries, err := audit.ReadFile(path, audit.Filter{
+		Since:   since,
+		Account: strings.TrimSpace(flags.Account),
+		Service: strings.TrimSpace(c.Service),
+	})
+	if err != nil {
+		return err
+	}
+	if c.Max > 0 && len(entries) > c.Max {
+		entries = entries[len(entries)-c.Max:]
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if entries == nil {
+			entries = []audit.Entry{}
+		}
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"entries": entries,
+			"since":   since.UTC().Format(time.RFC3339),
+			"file":    path,
+		}); err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return failEmptyExit(c.FailEmpty)
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		ui.FromContext(ctx).Err().Println("No audit entries")
+		return failEmptyExit(c.FailEmpty)
+	}
+	return outfmt.WriteTable(ctx, stdoutWriter(ctx), entries, auditEntryColumns())
+}
+
+func auditEntryColumns() []outfmt.Column[audit.Entry] {
+	return []outfmt.Column[audit.Entry]{
+		{Header: "TIME", Value: func(e audit.Entry) string { return e.Time.Local().Format(time.RFC3339) }},
+		{Header: "COMMAND", Value: func(e audit.Entry) string { return e.Command }},
+		{Header: "ACCOUNT", Value: func(e audit.Entry) string { return e.Account }},
+		{Header: "METHOD", Value: func(e audit.Entry) string { return e.Method + " " + e.APIMethod }},
+		{Header: "RESOURCES", Value: func(e audit.Entry) string { return strings.Join(e.Resources, ",") }},
+		{Header: "STATUS", Value: auditEntryStatus},
+		{Header: "REQUEST_ID", Value: func(e audit.Entry) string { return e.RequestID }},
+	}
+}
+
+func auditEntryStatus(e audit.Entry) string {
+	if e.Error != "" {
+		return "error: " + e.Error
+	}
+	return strconv.Itoa(e.Status)
+}
+
+// commandAuditLogger records mutating API requests for command. Config is
+// only read once the first mutating request is made.
+func commandAuditLogger(ctx context.Context, command string) *audit.Logger {
+	return audit.New(command, func() (audit.Options, error) {
+		cfg, err := readCommandConfig(ctx)
+		if err != nil {
+			return audit.Options{}, err
+		}
+		return auditOptions(ctx, cfg)
+	})
+}
+
+func auditOptions(ctx context.Context, cfg config.File) (audit.Options, error) {
+	var opts audit.Options
+	path, enabled, err := auditLogPath(ctx, cfg)
+	if err != nil {
+		return opts, err
+	}
+	if enabled {
+		opts.Sinks = append(opts.Sinks, audit.FileSink{Path: path})
+	}
+
+	if cfg.Audit == nil {
+		return opts, nil
+	}
+	opts.Bodies = cfg.Audit.Bodies
+	if cfg.Audit.Syslog {
+		tag := strings.TrimSpace(cfg.Audit.SyslogTag)
+		if tag == "" {
+			tag = defaultSyslogTag
+		}
+		sink, err := audit.NewSyslogSink(tag)
+		if err != nil {
+			return opts, err
+		}
+		opts.Sinks = append(opts.Sinks, sink)
+	}
+	if url := strings.TrimSpace(cfg.Audit.HTTPURL); url != "" {
+		opts.Sinks = append(opts.Sinks, audit.HTTPSink{URL: url, Header: cfg.Audit.HTTPHeaders})
+	}
+	return opts, nil
+}
+
+// auditLogPath resolves the JSONL audit log: GOG_AUDIT_LOG, then audit.file,
+// then audit.jsonl in the state directory when audit.enabled is set.
+func auditLogPath(ctx context.Context, cfg config.File) (string, bool, error) {
+	if path := strings.TrimSpace(os.Getenv("GOG_AUDIT_LOG")); path != "" {
+		return path, true, nil
+	}
+	if cfg.Audit == nil {
+		return "", false, nil
+	}
+	if path := strings.TrimSpace(cfg.Audit.File); path != "" {
+		return path, true, nil
+	}
+	if !cfg.Audit.Enabled {
+		return "", false, nil
+	}
+	layout, err := commandLayout(ctx, config.PathKindState)
+	if err != nil {
+		return "", false, err
+	}
+	return filepath.Join(layout.StateDir, auditLogName), true, nil
+}
+
+func readCommandConfig(ctx context.Context) (config.File, error) {
+	store, err := commandConfigStore(ctx)
+	if err != nil {
+		return config.File{}, err
+	}
+	return store.Read()
+}
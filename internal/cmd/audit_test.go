@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/gogcli/internal/app"
+	"github.com/steipete/gogcli/internal/audit"
+	"github.com/steipete/gogcli/internal/config"
+)
+
+func TestAuditOptions_FromConfigAndEnv(t *testing.T) {
+	t.Setenv("GOG_AUDIT_LOG", "")
+
+	stateDir := t.TempDir()
+	store := config.NewConfigStore(config.Layout{ConfigDir: t.TempDir(), StateDir: stateDir})
+	ctx := app.WithRuntime(context.Background(), &app.Runtime{Config: store})
+
+	opts, err := auditOptions(ctx, config.File{})
+	if err != nil || len(opts.Sinks) != 0 {
+		t.Fatalf("default opts = %+v, %v", opts, err)
+	}
+
+	opts, err = auditOptions(ctx, config.File{Audit: &config.AuditConfig{Enabled: true, Bodies: true, HTTPURL: "https://collector.example/audit"}})
+	if err != nil || len(opts.Sinks) != 2 || !opts.Bodies {
+		t.Fatalf("enabled opts = %+v, %v", opts, err)
+	}
+	if file, ok := opts.Sinks[0].(audit.FileSink); !ok || file.Path != filepath.Join(stateDir, auditLogName) {
+		t.Fatalf("file sink = %#v", opts.Sinks[0])
+	}
+
+	t.Setenv("GOG_AUDIT_LOG", "/tmp/gog-audit.jsonl")
+	path, enabled, err := auditLogPath(ctx, config.File{})
+	if err != nil || !enabled || path != "/tmp/gog-audit.jsonl" {
+		t.Fatalf("env path = %q %v %v", path, enabled, err)
+	}
+}
+
+func TestAuditLog_JSONFiltersSince(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	t.Setenv("GOG_AUDIT_LOG", path)
+
+	sink := audit.FileSink{Path: path}
+	for _, entry := range []audit.Entry{
+		{Time: time.Now().Add(-3 * time.Hour), Command: "drive delete", Method: "DELETE", APIMethod: "drive/v3/files/{id}"},
+		{Time: time.Now(), Command: "gmail send", Service: "gmail", Method: "POST", APIMethod: "gmail/v1/users/{id}/messages/send", Status: 200},
+	} {
+		if err := sink.Write(context.Background(), entry); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := Execute([]string{"--json", "audit", "log", "--since", "1h"}); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+
+	var payload struct {
+		Entries []audit.Entry `json:"entries"`
+		File    string        `json:"file"`
+	}
+	if err := json.Unmarshal([]byte(out), &payload); err != nil {
+		t.Fatalf("decode %q: %v", out, err)
+	}
+	if payload.File != path || len(payload.Entries) != 1 || payload.Entries[0].Command != "gmail send" {
+		t.Fatalf("payload = %+v", payload)
+	}
+}
+
+func TestAuditLog_NotEnabled(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GOG_AUDIT_LOG", "")
+
+	var err error
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"audit", "log"})
+	})
+	if err == nil || !strings.Contains(err.Error(), "not enabled") {
+		t.Fatalf("err = %v", err)
+	}
+}
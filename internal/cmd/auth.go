@@ -105,6 +105,7 @@ type AuthCmd struct {
 	Manage      AuthManageCmd         `cmd:"" name:"manage" help:"Open interactive accounts manager in browser" aliases:"login"`
 	ServiceAcct AuthServiceAccountCmd `cmd:"" name:"service-account" help:"Configure service account (Workspace only; domain-wide delegation)"`
 	Keep        AuthKeepCmd           `cmd:"" name:"keep" help:"Configure service account for Google Keep (Workspace only)"`
+	Broker      AuthBrokerCmd         `cmd:"" name:"broker" help:"Run a local credential broker so other gog processes never see refresh tokens"`
 }
 
 func parseAuthServices(servicesCSV string) ([]googleauth.Service, error) {
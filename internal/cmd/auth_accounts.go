@@ -4,14 +4,18 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"log/slog"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/steipete/gogcli/internal/authclient"
 	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/googleauth"
 	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ratelimit"
 	"github.com/steipete/gogcli/internal/ui"
 )
 
@@ -82,12 +86,23 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 		}
 	}
 
+	quota := []ratelimit.Usage{}
+	if account != "" {
+		usage, usageErr := googleapi.RateLimiterFromContext(ctx).Usage(account)
+		if usageErr != nil {
+			slog.Debug("quota usage unavailable", "err", usageErr)
+		} else if usage != nil {
+			quota = usage
+		}
+	}
+
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
 			"config": map[string]any{
 				"path":   configPath,
 				"exists": configExists,
 			},
+			"quota": quota,
 			"keyring": map[string]any{
 				"backend": backendInfo.Value,
 				"source":  backendInfo.Source,
@@ -121,10 +136,25 @@ func (c *AuthStatusCmd) Run(ctx context.Context, flags *RootFlags) error {
 		if serviceAccountPath != "" {
 			u.Out().Linef("service_account_path\t%s", serviceAccountPath)
 		}
+		for _, usage := range quota {
+			u.Out().Linef("quota_%s\t%s", usage.Service, formatQuotaUsage(usage))
+		}
 	}
 	return nil
 }
 
+func formatQuotaUsage(usage ratelimit.Usage) string {
+	out := strconv.FormatInt(usage.Used, 10)
+	if usage.Daily > 0 {
+		out += "/" + strconv.FormatInt(usage.Daily, 10)
+	}
+	out += " calls on " + usage.Day + " (Pacific)"
+	if usage.QPS > 0 {
+		out += ", " + strconv.FormatFloat(usage.QPS, 'f', -1, 64) + " qps"
+	}
+	return out
+}
+
 func (c *AuthListCmd) Run(ctx context.Context, _ *RootFlags) error {
 	u := ui.FromContext(ctx)
 	store, err := openAuthSecretsStore(ctx)
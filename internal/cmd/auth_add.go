@@ -19,13 +19,14 @@ type AuthAddCmd struct {
 	Email        string        `arg:"" name:"email" help:"Email"`
 	Manual       bool          `name:"manual" help:"Browserless auth flow (paste redirect URL)"`
 	Remote       bool          `name:"remote" help:"Remote/server-friendly manual flow (print URL, then exchange code)"`
+	Device       bool          `name:"device" help:"Device-code flow: enter a short code on any browser (needs a \"TVs and Limited Input devices\" OAuth client)"`
 	Step         int           `name:"step" help:"Remote auth step: 1=print URL, 2=exchange code"`
 	ListenAddr   string        `name:"listen-addr" help:"Address to listen on for OAuth callback (for example 0.0.0.0 or 0.0.0.0:8080)"`
 	RedirectHost string        `name:"redirect-host" help:"Hostname for OAuth callback in browser flows; builds https://{host}/oauth2/callback"`
 	RedirectURI  string        `name:"redirect-uri" help:"Override OAuth redirect URI for manual/remote flows (for example https://host.example/oauth2/callback)"`
 	AuthURL      string        `name:"auth-url" help:"Redirect URL from browser (manual flow; required for --remote --step 2)"`
 	AuthCode     string        `name:"auth-code" hidden:"" help:"UNSAFE: Authorization code from browser (manual flow; skips state check; not valid with --remote)"`
-	Timeout      time.Duration `name:"timeout" help:"Authorization timeout (manual flows default to 5m)"`
+	Timeout      time.Duration `name:"timeout" help:"Authorization timeout (manual flows default to 5m, device flow to 15m)"`
 	ForceConsent bool          `name:"force-consent" help:"Force consent screen to obtain a refresh token"`
 	ServicesCSV  string        `name:"services" help:"Services to authorize: user|all-user or comma-separated ${auth_services}; explicit opt-in: photospicker; all means all default user OAuth services. Workspace service-account-only services: admin, groups, keep" default:"user"`
 	DriveScope   string        `name:"drive-scope" help:"Drive scope mode: full|readonly|file" enum:"full,readonly,file" default:"full"`
@@ -147,6 +148,9 @@ func (c *AuthAddCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if c.Step != 0 && !c.Remote {
 		return usage("--step requires --remote")
 	}
+	if c.Device && (c.Manual || c.Remote || authURL != "" || authCode != "" || redirectURI != "" || strings.TrimSpace(c.ListenAddr) != "") {
+		return usage("--device cannot be combined with --manual, --remote, --auth-url, --auth-code, --listen-addr, --redirect-host, or --redirect-uri")
+	}
 
 	manual := c.isManualFlow(authURL, authCode)
 
@@ -200,6 +204,9 @@ func (c *AuthAddCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if timeout == 0 && manual {
 		timeout = 5 * time.Minute
 	}
+	if timeout == 0 && c.Device {
+		timeout = 15 * time.Minute
+	}
 
 	if dryRunErr := dryRunExit(ctx, flags, "auth.add", map[string]any{
 		"email":         strings.TrimSpace(c.Email),
@@ -208,6 +215,7 @@ func (c *AuthAddCmd) Run(ctx context.Context, flags *RootFlags) error {
 		"scopes":        scopes,
 		"manual":        c.Manual,
 		"remote":        c.Remote,
+		"device":        c.Device,
 		"step":          c.Step,
 		"listen_addr":   strings.TrimSpace(c.ListenAddr),
 		"redirect_host": strings.TrimSpace(c.RedirectHost),
@@ -229,6 +237,7 @@ func (c *AuthAddCmd) Run(ctx context.Context, flags *RootFlags) error {
 		Services:                    services,
 		Scopes:                      scopes,
 		Manual:                      manual,
+		Device:                      c.Device,
 		ForceConsent:                c.ForceConsent,
 		DisableIncludeGrantedScopes: disableIncludeGrantedScopes,
 		Timeout:                     timeout,
@@ -1177,3 +1177,40 @@ func containsStringInSlice(items []string, want string) bool {
 	}
 	return false
 }
+
+func TestAuthAddCmd_DeviceFlow(t *testing.T) {
+	store := newMemSecretsStore()
+	var gotOpts googleauth.AuthorizeOptions
+	runtime := runtimeWithAuthTestOperations(
+		func() (secrets.Store, error) { return store, nil },
+		func(_ context.Context, opts googleauth.AuthorizeOptions) (string, error) {
+			gotOpts = opts
+			return "rt", nil
+		},
+		func(context.Context) error { return nil },
+		func(context.Context, string, string, []string, time.Duration) (googleauth.Identity, error) {
+			return googleauth.Identity{Email: "user@example.com"}, nil
+		},
+	)
+
+	_ = captureStdout(t, func() {
+		_ = captureStderr(t, func() {
+			if err := executeWithRuntime([]string{"--json", "auth", "add", "user@example.com", "--services", "drive", "--drive-scope", "file", "--device"}, runtime); err != nil {
+				t.Fatalf("Execute: %v", err)
+			}
+		})
+	})
+	if !gotOpts.Device || gotOpts.Manual || gotOpts.Timeout != 15*time.Minute {
+		t.Fatalf("options = %+v", gotOpts)
+	}
+	if tok, err := store.GetToken(config.DefaultClientName, "user@example.com"); err != nil || tok.RefreshToken != "rt" {
+		t.Fatalf("stored token = %+v, %v", tok, err)
+	}
+
+	_ = captureStderr(t, func() {
+		err := executeWithRuntime([]string{"auth", "add", "user@example.com", "--device", "--manual"}, runtime)
+		if ExitCode(err) != 2 || !strings.Contains(err.Error(), "--device cannot be combined") {
+			t.Fatalf("device+manual err = %v", err)
+		}
+	})
+}
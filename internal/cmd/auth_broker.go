@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/authbroker"
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	brokerSocketName   = "broker.sock"
+	brokerAuditLogName = "broker-audit.jsonl"
+)
+
+type AuthBrokerCmd struct {
+	Serve AuthBrokerServeCmd `cmd:"" name:"serve" help:"Serve short-lived access tokens to gog processes on a unix socket"`
+}
+
+type AuthBrokerServeCmd struct {
+	Socket     string `name:"socket" help:"Unix socket path (default: broker.sock in the state directory)" env:"GOG_BROKER_SOCKET"`
+	SocketMode string `name:"socket-mode" help:"Socket file permissions, octal; widen to 0660 to serve a group" default:"0600"`
+	AuditLog   string `name:"audit-log" help:"Append one JSON line per token request here (default: broker-audit.jsonl in the state directory; - for stderr)"`
+}
+
+func (c *AuthBrokerServeCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	mode, err := strconv.ParseUint(strings.TrimSpace(c.SocketMode), 8, 32)
+	if err != nil || mode > 0o777 {
+		return usagef("invalid --socket-mode %q (want octal like 0600)", c.SocketMode)
+	}
+
+	policy, err := commandBrokerPolicy(ctx)
+	if err != nil {
+		return err
+	}
+
+	socket, auditPath, err := c.paths(ctx)
+	if err != nil {
+		return err
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "auth.broker.serve", map[string]any{
+		"socket":      socket,
+		"socket_mode": fmt.Sprintf("%04o", mode),
+		"audit_log":   auditPath,
+		"callers":     len(policy.Callers),
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	var audit io.Writer = os.Stderr
+	if auditPath != "-" {
+		f, openErr := os.OpenFile(auditPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // user-selected audit log path
+		if openErr != nil {
+			return fmt.Errorf("open broker audit log: %w", openErr)
+		}
+		defer f.Close()
+		audit = f
+	}
+
+	serveCtx, stop := pollSignalContext(ctx)
+	defer stop()
+
+	ln, err := authbroker.Listen(serveCtx, socket, os.FileMode(mode))
+	if err != nil {
+		return err
+	}
+	defer os.Remove(socket)
+
+	// Minting reads the keyring through the command context; request
+	// contexts only carry the caller's connection.
+	server := &authbroker.Server{
+		Mint: func(_ context.Context, account, service string, scopes []string) (*oauth2.Token, error) {
+			return googleapi.MintAccessToken(serveCtx, service, account, scopes)
+		},
+		Policy: policy,
+		Audit:  audit,
+	}
+
+	u.Err().Linef("auth broker: listening on %s (audit log: %s)", socket, auditPath)
+
+	return server.Serve(serveCtx, ln)
+}
+
+func (c *AuthBrokerServeCmd) paths(ctx context.Context) (string, string, error) {
+	socket := strings.TrimSpace(c.Socket)
+	auditPath := strings.TrimSpace(c.AuditLog)
+	if socket != "" && auditPath != "" {
+		return socket, auditPath, nil
+	}
+
+	layout, err := commandLayout(ctx, config.PathKindState)
+	if err != nil {
+		return "", "", err
+	}
+	if socket == "" {
+		socket = filepath.Join(layout.StateDir, brokerSocketName)
+	}
+	if auditPath == "" {
+		auditPath = filepath.Join(layout.StateDir, brokerAuditLogName)
+	}
+	return socket, auditPath, nil
+}
+
+// commandBrokerPolicy reads the broker section of config.json.
+func commandBrokerPolicy(ctx context.Context) (authbroker.Policy, error) {
+	store, err := commandConfigStore(ctx)
+	if err != nil {
+		return authbroker.Policy{}, err
+	}
+	cfg, err := store.Read()
+	if err != nil {
+		return authbroker.Policy{}, err
+	}
+	if cfg.Broker == nil {
+		return authbroker.Policy{}, nil
+	}
+
+	ttl, err := parseBrokerTTL("broker.ttl", cfg.Broker.TTL)
+	if err != nil {
+		return authbroker.Policy{}, err
+	}
+	policy := authbroker.Policy{DefaultTTL: ttl}
+	for i, caller := range cfg.Broker.Callers {
+		callerTTL, err := parseBrokerTTL(fmt.Sprintf("broker.callers[%d].ttl", i), caller.TTL)
+		if err != nil {
+			return authbroker.Policy{}, err
+		}
+		name := strings.TrimSpace(caller.Name)
+		if name == "" {
+			name = fmt.Sprintf("caller-%d", i+1)
+		}
+		policy.Callers = append(policy.Callers, authbroker.CallerPolicy{
+			Name:     name,
+			UID:      caller.UID,
+			Accounts: caller.Accounts,
+			Services: caller.Services,
+			Scopes:   caller.Scopes,
+			TTL:      callerTTL,
+		})
+	}
+	return policy, nil
+}
+
+func parseBrokerTTL(field, value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, usagef("config %s: invalid duration %q", field, value)
+	}
+	return ttl, nil
+}
+
+// brokerTokenSource connects API clients to GOG_BROKER_SOCKET.
+func brokerTokenSource(socket string) googleapi.BrokerTokenSourceFunc {
+	client := authbroker.NewClient(socket)
+	return func(ctx context.Context, email, serviceLabel string, scopes []string) (oauth2.TokenSource, error) {
+		return client.TokenSource(ctx, email, serviceLabel, scopes)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steipete/gogcli/internal/app"
+	"github.com/steipete/gogcli/internal/config"
+)
+
+func TestCommandBrokerPolicy_ReadsCallersFromConfig(t *testing.T) {
+	t.Parallel()
+
+	uid := 1001
+	store := config.NewConfigStore(config.Layout{ConfigDir: t.TempDir()})
+	if err := store.Write(config.File{Broker: &config.BrokerConfig{
+		TTL: "15m",
+		Callers: []config.BrokerCaller{
+			{Name: "agent", UID: &uid, Accounts: []string{"a@example.com"}, Services: []string{"drive"}, TTL: "2m"},
+			{Services: []string{"calendar"}},
+		},
+	}}); err != nil {
+		t.Fatalf("WriteConfig: %v", err)
+	}
+	ctx := app.WithRuntime(context.Background(), &app.Runtime{Config: store})
+
+	policy, err := commandBrokerPolicy(ctx)
+	if err != nil {
+		t.Fatalf("commandBrokerPolicy: %v", err)
+	}
+	if policy.DefaultTTL != 15*time.Minute || len(policy.Callers) != 2 {
+		t.Fatalf("policy = %+v", policy)
+	}
+	agent := policy.Callers[0]
+	if agent.Name != "agent" || *agent.UID != 1001 || agent.TTL != 2*time.Minute || agent.Services[0] != "drive" {
+		t.Fatalf("agent = %+v", agent)
+	}
+	if policy.Callers[1].Name != "caller-2" || policy.Callers[1].UID != nil {
+		t.Fatalf("second caller = %+v", policy.Callers[1])
+	}
+
+	if err := store.Write(config.File{Broker: &config.BrokerConfig{Callers: []config.BrokerCaller{{TTL: "soon"}}}}); err != nil {
+		t.Fatalf("WriteConfig: %v", err)
+	}
+	if _, err := commandBrokerPolicy(ctx); err == nil || !strings.Contains(err.Error(), "broker.callers[0].ttl") {
+		t.Fatalf("bad ttl err = %v", err)
+	}
+}
+
+func TestRequireAccount_BrokerNeedsExplicitAccount(t *testing.T) {
+	t.Setenv("GOG_ACCOUNT", "")
+
+	_, err := requireAccount(&RootFlags{brokerSocket: "/run/gog/broker.sock"})
+	if err == nil || !strings.Contains(err.Error(), "GOG_BROKER_SOCKET") {
+		t.Fatalf("err = %v", err)
+	}
+
+	t.Setenv("GOG_ACCOUNT", "a@example.com")
+	got, err := requireAccount(&RootFlags{brokerSocket: "/run/gog/broker.sock"})
+	if err != nil || got != "a@example.com" {
+		t.Fatalf("account = %q, err = %v", got, err)
+	}
+}
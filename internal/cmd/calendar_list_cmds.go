@@ -44,7 +44,7 @@ func (c *CalendarCalendarsCmd) Run(ctx context.Context, flags *RootFlags) error
 		return r.Items, r.NextPageToken, nil
 	}
 
-	items, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	items, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
@@ -181,7 +181,7 @@ func (c *CalendarAclCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return r.Items, r.NextPageToken, nil
 	}
 
-	items, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	items, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
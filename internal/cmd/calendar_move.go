@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	"strings"
+
+	"github.com/steipete/gogcli/internal/undo"
 )
 
 type CalendarMoveCmd struct {
@@ -62,6 +65,12 @@ func (c *CalendarMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	recordUndo(ctx, mutation.account, fmt.Sprintf("moved event %s from %s to %s", eventID, mutation.calendarID, destinationCalendarID), undo.Op{
+		Kind:        undo.KindCalendarMove,
+		CalendarID:  destinationCalendarID,
+		EventID:     eventID,
+		Destination: mutation.calendarID,
+	})
 	mutation.calendarID = destinationCalendarID
 	return mutation.writeEvent(ctx, moved)
 }
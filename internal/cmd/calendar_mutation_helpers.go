@@ -11,6 +11,7 @@ import (
 
 type calendarMutationContext struct {
 	u          *ui.UI
+	account    string
 	svc        *calendar.Service
 	calendarID string
 }
@@ -22,7 +23,7 @@ type calendarInsertOptions struct {
 }
 
 func newCalendarMutationContext(ctx context.Context, flags *RootFlags, calendarID string) (*calendarMutationContext, error) {
-	_, svc, err := requireCalendarService(ctx, flags)
+	account, svc, err := requireCalendarService(ctx, flags)
 	if err != nil {
 		return nil, err
 	}
@@ -32,6 +33,7 @@ func newCalendarMutationContext(ctx context.Context, flags *RootFlags, calendarI
 	}
 	return &calendarMutationContext{
 		u:          ui.FromContext(ctx),
+		account:    account,
 		svc:        svc,
 		calendarID: resolvedCalendarID,
 	}, nil
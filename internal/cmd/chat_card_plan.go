@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+	"google.golang.org/api/chat/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+// loadChatCardFile reads cardsV2 from a JSON or YAML file. The file may hold
+// {"cardsV2": [...]}, a list of {cardId, card} entries, a single entry, or a
+// bare card. Unknown keys are rejected so typos fail before the API call.
+func loadChatCardFile(path string) ([]*chat.CardWithId, error) {
+	resolved, err := config.ExpandPath(strings.TrimSpace(path))
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(resolved) //nolint:gosec // path is user-supplied by design (local CLI)
+	if err != nil {
+		return nil, fmt.Errorf("read card file: %w", err)
+	}
+	cards, err := parseChatCards(data, filepath.Ext(resolved))
+	if err != nil {
+		return nil, usagef("invalid card file %s: %v", path, err)
+	}
+	return cards, nil
+}
+
+func parseChatCards(data []byte, ext string) ([]*chat.CardWithId, error) {
+	raw, err := chatCardJSON(data, ext)
+	if err != nil {
+		return nil, err
+	}
+
+	var cards []*chat.CardWithId
+	trimmed := bytes.TrimSpace(raw)
+	switch {
+	case len(trimmed) == 0:
+		return nil, fmt.Errorf("empty card file")
+	case trimmed[0] == '[':
+		if err := decodeChatCardStrict(trimmed, &cards); err != nil {
+			return nil, err
+		}
+	default:
+		var probe map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &probe); err != nil {
+			return nil, err
+		}
+		switch {
+		case probe["cardsV2"] != nil:
+			if len(probe) != 1 {
+				return nil, fmt.Errorf("top-level object with cardsV2 must not contain other keys")
+			}
+			if err := decodeChatCardStrict(probe["cardsV2"], &cards); err != nil {
+				return nil, err
+			}
+		case probe["card"] != nil:
+			var entry chat.CardWithId
+			if err := decodeChatCardStrict(trimmed, &entry); err != nil {
+				return nil, err
+			}
+			cards = []*chat.CardWithId{&entry}
+		default:
+			var card chat.GoogleAppsCardV1Card
+			if err := decodeChatCardStrict(trimmed, &card); err != nil {
+				return nil, err
+			}
+			cards = []*chat.CardWithId{{Card: &card}}
+		}
+	}
+
+	if err := validateChatCards(cards); err != nil {
+		return nil, err
+	}
+	return cards, nil
+}
+
+func chatCardJSON(data []byte, ext string) ([]byte, error) {
+	if strings.EqualFold(ext, ".json") {
+		return data, nil
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse yaml: %w", err)
+	}
+	return json.Marshal(doc)
+}
+
+func decodeChatCardStrict(data []byte, target any) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(target)
+}
+
+func validateChatCards(cards []*chat.CardWithId) error {
+	if len(cards) == 0 {
+		return fmt.Errorf("no cards")
+	}
+	seen := map[string]bool{}
+	for i, entry := range cards {
+		where := fmt.Sprintf("card %d", i+1)
+		if entry == nil || entry.Card == nil {
+			return fmt.Errorf("%s: missing card", where)
+		}
+		if entry.CardId == "" {
+			entry.CardId = fmt.Sprintf("card-%d", i+1)
+		}
+		if seen[entry.CardId] {
+			return fmt.Errorf("%s: duplicate cardId %q", where, entry.CardId)
+		}
+		seen[entry.CardId] = true
+		if err := validateChatCard(where, entry.Card); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateChatCard(where string, card *chat.GoogleAppsCardV1Card) error {
+	if card.Header == nil && len(card.Sections) == 0 {
+		return fmt.Errorf("%s: needs a header or at least one section", where)
+	}
+	for i, section := range card.Sections {
+		sectionWhere := fmt.Sprintf("%s section %d", where, i+1)
+		if section == nil || len(section.Widgets) == 0 {
+			return fmt.Errorf("%s: needs at least one widget", sectionWhere)
+		}
+		for j, widget := range section.Widgets {
+			if err := validateChatCardWidget(fmt.Sprintf("%s widget %d", sectionWhere, j+1), widget); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func validateChatCardWidget(where string, widget *chat.GoogleAppsCardV1Widget) error {
+	if widget == nil {
+		return fmt.Errorf("%s: empty widget", where)
+	}
+	kinds := chatCardWidgetKinds(widget)
+	if len(kinds) != 1 {
+		return fmt.Errorf("%s: needs exactly one widget type, got %d", where, len(kinds))
+	}
+	switch {
+	case widget.ButtonList != nil:
+		if len(widget.ButtonList.Buttons) == 0 {
+			return fmt.Errorf("%s: buttonList needs at least one button", where)
+		}
+		for k, button := range widget.ButtonList.Buttons {
+			if err := validateChatCardButton(fmt.Sprintf("%s button %d", where, k+1), button); err != nil {
+				return err
+			}
+		}
+	case widget.DecoratedText != nil:
+		if strings.TrimSpace(widget.DecoratedText.Text) == "" {
+			return fmt.Errorf("%s: decoratedText needs text", where)
+		}
+		if widget.DecoratedText.Button != nil {
+			return validateChatCardButton(where+" button", widget.DecoratedText.Button)
+		}
+	case widget.TextParagraph != nil:
+		if strings.TrimSpace(widget.TextParagraph.Text) == "" {
+			return fmt.Errorf("%s: textParagraph needs text", where)
+		}
+	case widget.Image != nil:
+		if strings.TrimSpace(widget.Image.ImageUrl) == "" {
+			return fmt.Errorf("%s: image needs imageUrl", where)
+		}
+	}
+	return nil
+}
+
+func chatCardWidgetKinds(widget *chat.GoogleAppsCardV1Widget) []string {
+	candidates := []struct {
+		name string
+		set  bool
+	}{
+		{"buttonList", widget.ButtonList != nil},
+		{"carousel", widget.Carousel != nil},
+		{"chipList", widget.ChipList != nil},
+		{"columns", widget.Columns != nil},
+		{"dateTimePicker", widget.DateTimePicker != nil},
+		{"decoratedText", widget.DecoratedText != nil},
+		{"divider", widget.Divider != nil},
+		{"grid", widget.Grid != nil},
+		{"image", widget.Image != nil},
+		{"selectionInput", widget.SelectionInput != nil},
+		{"textInput", widget.TextInput != nil},
+		{"textParagraph", widget.TextParagraph != nil},
+	}
+	var kinds []string
+	for _, candidate := range candidates {
+		if candidate.set {
+			kinds = append(kinds, candidate.name)
+		}
+	}
+	return kinds
+}
+
+func validateChatCardButton(where string, button *chat.GoogleAppsCardV1Button) error {
+	if button == nil {
+		return fmt.Errorf("%s: empty button", where)
+	}
+	if strings.TrimSpace(button.Text) == "" && button.Icon == nil {
+		return fmt.Errorf("%s: needs text or icon", where)
+	}
+	click := button.OnClick
+	if click == nil {
+		return fmt.Errorf("%s: needs onClick", where)
+	}
+	switch {
+	case click.OpenLink != nil:
+		if strings.TrimSpace(click.OpenLink.Url) == "" {
+			return fmt.Errorf("%s: openLink needs url", where)
+		}
+	case click.Action != nil:
+		if strings.TrimSpace(click.Action.Function) == "" {
+			return fmt.Errorf("%s: action needs function", where)
+		}
+	case click.Card == nil && click.OpenDynamicLinkAction == nil && click.OverflowMenu == nil:
+		return fmt.Errorf("%s: onClick needs openLink, action, card, or overflowMenu", where)
+	}
+	return nil
+}
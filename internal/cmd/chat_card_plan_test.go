@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseChatCardsShapes(t *testing.T) {
+	tests := []struct {
+		name string
+		ext  string
+		data string
+		ids  []string
+	}{
+		{
+			name: "envelope",
+			ext:  ".json",
+			data: `{"cardsV2":[{"cardId":"status","card":{"header":{"title":"Deploy"}}}]}`,
+			ids:  []string{"status"},
+		},
+		{
+			name: "list",
+			ext:  ".json",
+			data: `[{"card":{"header":{"title":"A"}}},{"card":{"header":{"title":"B"}}}]`,
+			ids:  []string{"card-1", "card-2"},
+		},
+		{
+			name: "bare card yaml",
+			ext:  ".yaml",
+			data: "header:\n  title: On-call\nsections:\n  - widgets:\n      - textParagraph:\n          text: All green\n",
+			ids:  []string{"card-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cards, err := parseChatCards([]byte(tt.data), tt.ext)
+			if err != nil {
+				t.Fatalf("parseChatCards: %v", err)
+			}
+			if len(cards) != len(tt.ids) {
+				t.Fatalf("got %d cards, want %d", len(cards), len(tt.ids))
+			}
+			for i, id := range tt.ids {
+				if cards[i].CardId != id {
+					t.Fatalf("card %d id = %q, want %q", i, cards[i].CardId, id)
+				}
+			}
+		})
+	}
+}
+
+func TestParseChatCardsValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{name: "unknown key", data: `{"header":{"titel":"x"}}`, want: `unknown field "titel"`},
+		{name: "empty card", data: `{}`, want: "card 1: needs a header or at least one section"},
+		{name: "empty section", data: `{"sections":[{"header":"x"}]}`, want: "card 1 section 1: needs at least one widget"},
+		{name: "two widget kinds", data: `{"sections":[{"widgets":[{"divider":{},"textParagraph":{"text":"x"}}]}]}`, want: "needs exactly one widget type, got 2"},
+		{name: "button without click", data: `{"sections":[{"widgets":[{"buttonList":{"buttons":[{"text":"Ack"}]}}]}]}`, want: "card 1 section 1 widget 1 button 1: needs onClick"},
+		{name: "link without url", data: `{"sections":[{"widgets":[{"buttonList":{"buttons":[{"text":"Open","onClick":{"openLink":{}}}]}}]}]}`, want: "openLink needs url"},
+		{name: "duplicate ids", data: `[{"cardId":"a","card":{"header":{"title":"x"}}},{"cardId":"a","card":{"header":{"title":"y"}}}]`, want: `duplicate cardId "a"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseChatCards([]byte(tt.data), ".json")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadChatCardFileUsageError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "card.json")
+	if err := os.WriteFile(path, []byte(`{"sections":[]}`), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	_, err := loadChatCardFile(path)
+	if err == nil || !strings.Contains(err.Error(), "invalid card file") {
+		t.Fatalf("error = %v", err)
+	}
+}
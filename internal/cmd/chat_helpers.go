@@ -63,6 +63,16 @@ func normalizeChatMemberUser(member string) (string, error) {
 	return "users/" + user, nil
 }
 
+// requireChatAppAuthForCards rejects --card-file unless the caller brought its
+// own access token. Google Chat only accepts cardsV2 from Chat apps, and gog's
+// stored and keyless credentials authenticate as a user.
+func requireChatAppAuthForCards(flags *RootFlags, cardFile string) error {
+	if cardFile == "" || hasDirectAccessToken(flags) {
+		return nil
+	}
+	return usage("--card-file needs Chat app authentication: Google Chat rejects cards sent with user credentials; pass a Chat app token (chat.bot scope) with --access-token")
+}
+
 func requireWorkspaceAccount(account string) error {
 	if isConsumerAccount(account) {
 		return usage("chat requires a Google Workspace account (non-gmail.com)")
@@ -14,6 +14,7 @@ type chatMessageSendInput struct {
 	Text        string
 	Thread      string
 	Attachments []string
+	CardFile    string
 }
 
 type chatMessageSendPlan struct {
@@ -22,6 +23,8 @@ type chatMessageSendPlan struct {
 	ThreadRaw   string
 	ThreadName  string
 	Attachments []string
+	CardFile    string
+	Cards       []*chat.CardWithId
 }
 
 func newChatMessageSendPlan(input chatMessageSendInput) (chatMessageSendPlan, error) {
@@ -40,9 +43,16 @@ func newChatMessageSendPlan(input chatMessageSendInput) (chatMessageSendPlan, er
 		Text:        strings.TrimSpace(input.Text),
 		ThreadRaw:   strings.TrimSpace(input.Thread),
 		Attachments: attachments,
+		CardFile:    strings.TrimSpace(input.CardFile),
 	}
-	if plan.Text == "" && len(plan.Attachments) == 0 {
-		return chatMessageSendPlan{}, usage("required: --text or --attach")
+	if plan.CardFile != "" {
+		plan.Cards, err = loadChatCardFile(plan.CardFile)
+		if err != nil {
+			return chatMessageSendPlan{}, err
+		}
+	}
+	if plan.Text == "" && len(plan.Attachments) == 0 && len(plan.Cards) == 0 {
+		return chatMessageSendPlan{}, usage("required: --text, --attach, or --card-file")
 	}
 	if plan.ThreadRaw != "" {
 		plan.ThreadName, err = normalizeThread(plan.Space, plan.ThreadRaw)
@@ -57,6 +67,7 @@ func (p chatMessageSendPlan) message(attachments []*chat.Attachment) *chat.Messa
 	message := &chat.Message{
 		Text:       p.Text,
 		Attachment: attachments,
+		CardsV2:    p.Cards,
 	}
 	if p.ThreadName != "" {
 		message.Thread = &chat.Thread{Name: p.ThreadName}
@@ -79,5 +90,7 @@ func (p chatMessageSendPlan) dryRunPayload() map[string]any {
 		"thread_raw":                   p.ThreadRaw,
 		"reply_fallback_to_new_thread": p.ThreadName != "",
 		"attachments":                  p.Attachments,
+		"card_file":                    p.CardFile,
+		"cards":                        p.Cards,
 	}
 }
@@ -72,7 +72,7 @@ func TestNewChatMessageSendPlanValidation(t *testing.T) {
 		want  string
 	}{
 		{name: "space", input: chatMessageSendInput{Text: "hello"}, want: "required: space"},
-		{name: "content", input: chatMessageSendInput{Space: "AAA"}, want: "required: --text or --attach"},
+		{name: "content", input: chatMessageSendInput{Space: "AAA"}, want: "required: --text, --attach, or --card-file"},
 		{name: "attachment", input: chatMessageSendInput{Space: "AAA", Attachments: []string{""}}, want: "attachment path must not be empty"},
 		{name: "thread", input: chatMessageSendInput{Space: "AAA", Text: "hello", Thread: "spaces/AAA/threads/t1/extra"}, want: "invalid thread"},
 	}
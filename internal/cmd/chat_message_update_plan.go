@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"strings"
+
+	"google.golang.org/api/chat/v1"
+)
+
+type chatMessageUpdateInput struct {
+	Space       string
+	Message     string
+	Text        *string
+	Attachments []string
+	CardFile    string
+}
+
+type chatMessageUpdatePlan struct {
+	Message     string
+	Text        string
+	SetText     bool
+	Attachments []string
+	CardFile    string
+	Cards       []*chat.CardWithId
+}
+
+// newChatMessageUpdatePlan validates an in-place edit. Text is a pointer so an
+// explicit --text "" clears the body while an omitted flag leaves it alone.
+func newChatMessageUpdatePlan(input chatMessageUpdateInput) (chatMessageUpdatePlan, error) {
+	message, err := normalizeMessage(input.Space, input.Message)
+	if err != nil {
+		return chatMessageUpdatePlan{}, usage("required: message (full resource path, or bare ID with --space)")
+	}
+
+	attachments, err := expandChatAttachmentPaths(input.Attachments)
+	if err != nil {
+		return chatMessageUpdatePlan{}, err
+	}
+
+	plan := chatMessageUpdatePlan{
+		Message:     message,
+		Attachments: attachments,
+		CardFile:    strings.TrimSpace(input.CardFile),
+	}
+	if input.Text != nil {
+		plan.Text = strings.TrimSpace(*input.Text)
+		plan.SetText = true
+	}
+	if plan.CardFile != "" {
+		plan.Cards, err = loadChatCardFile(plan.CardFile)
+		if err != nil {
+			return chatMessageUpdatePlan{}, err
+		}
+	}
+	if len(plan.updateMask()) == 0 {
+		return chatMessageUpdatePlan{}, usage("required: --text, --attach, or --card-file")
+	}
+	return plan, nil
+}
+
+// space returns the space that owns the message; attachments must be
+// uploaded there before they can be referenced.
+func (p chatMessageUpdatePlan) space() string {
+	parts := strings.SplitN(p.Message, "/", 3)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0] + "/" + parts[1]
+}
+
+func (p chatMessageUpdatePlan) updateMask() []string {
+	var mask []string
+	if p.SetText {
+		mask = append(mask, "text")
+	}
+	if len(p.Cards) > 0 {
+		mask = append(mask, "cards_v2")
+	}
+	if len(p.Attachments) > 0 {
+		mask = append(mask, "attachment")
+	}
+	return mask
+}
+
+func (p chatMessageUpdatePlan) message(attachments []*chat.Attachment) *chat.Message {
+	message := &chat.Message{
+		Text:       p.Text,
+		Attachment: attachments,
+		CardsV2:    p.Cards,
+	}
+	if p.SetText && p.Text == "" {
+		message.ForceSendFields = []string{"Text"}
+	}
+	return message
+}
+
+func (p chatMessageUpdatePlan) dryRunPayload() map[string]any {
+	return map[string]any{
+		"message":     p.Message,
+		"update_mask": strings.Join(p.updateMask(), ","),
+		"text":        p.Text,
+		"attachments": p.Attachments,
+		"card_file":   p.CardFile,
+		"cards":       p.Cards,
+	}
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewChatMessageUpdatePlan(t *testing.T) {
+	cardPath := filepath.Join(t.TempDir(), "status.yaml")
+	if err := os.WriteFile(cardPath, []byte("header:\n  title: Incident\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	empty := ""
+	plan, err := newChatMessageUpdatePlan(chatMessageUpdateInput{
+		Space:    "AAA",
+		Message:  "m1",
+		Text:     &empty,
+		CardFile: cardPath,
+	})
+	if err != nil {
+		t.Fatalf("newChatMessageUpdatePlan: %v", err)
+	}
+	if plan.Message != "spaces/AAA/messages/m1" || plan.space() != "spaces/AAA" {
+		t.Fatalf("unexpected plan: %#v", plan)
+	}
+	if got := plan.updateMask(); !reflect.DeepEqual(got, []string{"text", "cards_v2"}) {
+		t.Fatalf("updateMask() = %#v", got)
+	}
+	message := plan.message(nil)
+	if len(message.CardsV2) != 1 || !reflect.DeepEqual(message.ForceSendFields, []string{"Text"}) {
+		t.Fatalf("unexpected message: %#v", message)
+	}
+	if payload := plan.dryRunPayload(); payload["update_mask"] != "text,cards_v2" {
+		t.Fatalf("unexpected dry-run payload: %#v", payload)
+	}
+}
+
+func TestNewChatMessageUpdatePlanValidation(t *testing.T) {
+	text := "hi"
+	tests := []struct {
+		name  string
+		input chatMessageUpdateInput
+		want  string
+	}{
+		{name: "message", input: chatMessageUpdateInput{Message: "m1", Text: &text}, want: "required: message"},
+		{name: "fields", input: chatMessageUpdateInput{Message: "spaces/AAA/messages/m1"}, want: "required: --text, --attach, or --card-file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newChatMessageUpdatePlan(tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"google.golang.org/api/chat/v1"
 
 	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/timeparse"
 	"github.com/steipete/gogcli/internal/ui"
 )
 
 type ChatMessagesCmd struct {
 	List      ChatMessagesListCmd      `cmd:"" name:"list" aliases:"ls" help:"List messages"`
+	Get       ChatMessagesGetCmd       `cmd:"" name:"get" aliases:"show,info" help:"Get a message"`
 	Send      ChatMessagesSendCmd      `cmd:"" name:"send" aliases:"create,post" help:"Send a message"`
+	Update    ChatMessagesUpdateCmd    `cmd:"" name:"update" aliases:"edit,patch" help:"Update a message in place"`
+	Delete    ChatMessagesDeleteCmd    `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a message"`
 	React     ChatMessagesReactCmd     `cmd:"" name:"react" help:"Add an emoji reaction to a message"`
 	Reactions ChatMessagesReactionsCmd `cmd:"" name:"reactions" aliases:"reaction" help:"Manage emoji reactions on a message"`
 }
@@ -27,6 +32,9 @@ type ChatMessagesListCmd struct {
 	Order     string `name:"order" help:"Order by (e.g. createTime desc)"`
 	Thread    string `name:"thread" help:"Filter by thread (spaces/.../threads/...)"`
 	Unread    bool   `name:"unread" help:"Only messages after last read time"`
+	After     string `name:"after" aliases:"since" help:"Only messages created after this time (RFC3339, YYYY-MM-DD, or duration like 24h)"`
+	Before    string `name:"before" aliases:"until" help:"Only messages created before this time (RFC3339, YYYY-MM-DD, or duration like 24h)"`
+	Sender    string `name:"sender" aliases:"from" help:"Only messages from this sender (users/... or display name substring; filtered client-side per page)"`
 }
 
 func (c *ChatMessagesListCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -51,25 +59,10 @@ func (c *ChatMessagesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	filters := make([]string, 0, 2)
-	thread := strings.TrimSpace(c.Thread)
-	if thread != "" {
-		threadName, threadErr := normalizeThread(space, thread)
-		if threadErr != nil {
-			return usage(fmt.Sprintf("invalid thread: %v", threadErr))
-		}
-		filters = append(filters, fmt.Sprintf("thread.name = \"%s\"", threadName))
-	}
-	if c.Unread {
-		readState, readErr := svc.Users.Spaces.GetSpaceReadState(fmt.Sprintf("users/me/spaces/%s/spaceReadState", spaceID(space))).Do()
-		if readErr != nil {
-			return readErr
-		}
-		if readState.LastReadTime != "" {
-			filters = append(filters, fmt.Sprintf("createTime > \"%s\"", readState.LastReadTime))
-		}
+	filter, err := c.filter(svc, space)
+	if err != nil {
+		return err
 	}
-	filter := strings.Join(filters, " AND ")
 
 	fetch := func(pageToken string) ([]*chat.Message, string, error) {
 		call := svc.Spaces.Messages.List(space).
@@ -95,6 +88,7 @@ func (c *ChatMessagesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	messages = filterChatMessagesBySender(messages, c.Sender)
 
 	if outfmt.IsJSON(ctx) {
 		type item struct {
@@ -146,11 +140,74 @@ func (c *ChatMessagesListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+// filter builds the server-side list filter. Chat only supports createTime
+// and thread.name here; sender matching happens client-side.
+func (c *ChatMessagesListCmd) filter(svc *chat.Service, space string) (string, error) {
+	filters := make([]string, 0, 4)
+	thread := strings.TrimSpace(c.Thread)
+	if thread != "" {
+		threadName, threadErr := normalizeThread(space, thread)
+		if threadErr != nil {
+			return "", usage(fmt.Sprintf("invalid thread: %v", threadErr))
+		}
+		filters = append(filters, fmt.Sprintf("thread.name = \"%s\"", threadName))
+	}
+	if c.Unread {
+		readState, readErr := svc.Users.Spaces.GetSpaceReadState(fmt.Sprintf("users/me/spaces/%s/spaceReadState", spaceID(space))).Do()
+		if readErr != nil {
+			return "", readErr
+		}
+		if readState.LastReadTime != "" {
+			filters = append(filters, fmt.Sprintf("createTime > \"%s\"", readState.LastReadTime))
+		}
+	}
+	now := time.Now()
+	for _, bound := range []struct {
+		flag  string
+		value string
+		op    string
+	}{
+		{"--after", c.After, ">"},
+		{"--before", c.Before, "<"},
+	} {
+		if strings.TrimSpace(bound.value) == "" {
+			continue
+		}
+		parsed, parseErr := timeparse.ParseSince(bound.value, now, time.Local)
+		if parseErr != nil {
+			return "", usagef("invalid %s value: %v", bound.flag, parseErr)
+		}
+		filters = append(filters, fmt.Sprintf("createTime %s \"%s\"", bound.op, parsed.Time.Format(time.RFC3339)))
+	}
+	return strings.Join(filters, " AND "), nil
+}
+
+// filterChatMessagesBySender keeps messages whose sender resource matches
+// exactly or whose display name contains the query (case-insensitive).
+func filterChatMessagesBySender(messages []*chat.Message, sender string) []*chat.Message {
+	query := strings.TrimSpace(sender)
+	if query == "" {
+		return messages
+	}
+	lowered := strings.ToLower(query)
+	out := make([]*chat.Message, 0, len(messages))
+	for _, msg := range messages {
+		if msg == nil || msg.Sender == nil {
+			continue
+		}
+		if strings.EqualFold(msg.Sender.Name, query) || strings.Contains(strings.ToLower(msg.Sender.DisplayName), lowered) {
+			out = append(out, msg)
+		}
+	}
+	return out
+}
+
 type ChatMessagesSendCmd struct {
-	Space  string   `arg:"" name:"space" help:"Space name (spaces/...)"`
-	Text   string   `name:"text" help:"Message text (required unless --attach is provided)"`
-	Thread string   `name:"thread" help:"Reply to thread (spaces/.../threads/...)"`
-	Attach []string `name:"attach" help:"Attachment file path, e.g. an image (repeatable)"`
+	Space    string   `arg:"" name:"space" help:"Space name (spaces/...)"`
+	Text     string   `name:"text" help:"Message text (required unless --attach or --card-file is provided)"`
+	Thread   string   `name:"thread" help:"Reply to thread (spaces/.../threads/...)"`
+	Attach   []string `name:"attach" help:"Attachment file path, e.g. an image (repeatable)"`
+	CardFile string   `name:"card-file" help:"cardsV2 JSON or YAML file (buttons, sections, widgets); needs a Chat app token via --access-token"`
 }
 
 func (c *ChatMessagesSendCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -160,6 +217,7 @@ func (c *ChatMessagesSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 		Text:        c.Text,
 		Thread:      c.Thread,
 		Attachments: c.Attach,
+		CardFile:    c.CardFile,
 	})
 	if err != nil {
 		return err
@@ -168,6 +226,9 @@ func (c *ChatMessagesSendCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if dryRunErr := dryRunExit(ctx, flags, "chat.messages.send", plan.dryRunPayload()); dryRunErr != nil {
 		return dryRunErr
 	}
+	if err = requireChatAppAuthForCards(flags, plan.CardFile); err != nil {
+		return err
+	}
 
 	account, err := requireAccount(flags)
 	if err != nil {
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type ChatMessagesGetCmd struct {
+	Message string `arg:"" name:"message" help:"Message resource (spaces/.../messages/...) or bare message ID"`
+	Space   string `name:"space" help:"Space name (required when message is a bare ID)"`
+}
+
+func (c *ChatMessagesGetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	message, err := normalizeMessage(c.Space, c.Message)
+	if err != nil {
+		return usage("required: message (full resource path, or bare ID with --space)")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if err = requireWorkspaceAccount(account); err != nil {
+		return err
+	}
+
+	svc, err := chatService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Spaces.Messages.Get(message).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"message": resp})
+	}
+
+	u.Out().Linef("resource\t%s", resp.Name)
+	if sender := chatMessageSender(resp); sender != "" {
+		u.Out().Linef("sender\t%s", sender)
+	}
+	if resp.CreateTime != "" {
+		u.Out().Linef("created\t%s", resp.CreateTime)
+	}
+	if resp.LastUpdateTime != "" {
+		u.Out().Linef("updated\t%s", resp.LastUpdateTime)
+	}
+	if thread := chatMessageThread(resp); thread != "" {
+		u.Out().Linef("thread\t%s", thread)
+	}
+	if len(resp.CardsV2) > 0 {
+		u.Out().Linef("cards\t%d", len(resp.CardsV2))
+	}
+	for _, attachment := range resp.Attachment {
+		if attachment != nil {
+			u.Out().Linef("attachment\t%s", firstNonEmpty(attachment.ContentName, attachment.Name))
+		}
+	}
+	if text := chatMessageText(resp); text != "" {
+		u.Out().Linef("text\t%s", text)
+	}
+	return nil
+}
+
+type ChatMessagesUpdateCmd struct {
+	Message  string   `arg:"" name:"message" help:"Message resource (spaces/.../messages/...) or bare message ID"`
+	Space    string   `name:"space" help:"Space name (required when message is a bare ID)"`
+	Text     *string  `name:"text" help:"Replace the message text (empty string clears it)"`
+	CardFile string   `name:"card-file" help:"Replace cards with cardsV2 from a JSON or YAML file; needs a Chat app token via --access-token"`
+	Attach   []string `name:"attach" help:"Replace attachments with these files (repeatable)"`
+}
+
+func (c *ChatMessagesUpdateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	plan, err := newChatMessageUpdatePlan(chatMessageUpdateInput{
+		Space:       c.Space,
+		Message:     c.Message,
+		Text:        c.Text,
+		Attachments: c.Attach,
+		CardFile:    c.CardFile,
+	})
+	if err != nil {
+		return err
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "chat.messages.update", plan.dryRunPayload()); dryRunErr != nil {
+		return dryRunErr
+	}
+	if err = requireChatAppAuthForCards(flags, plan.CardFile); err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if err = requireWorkspaceAccount(account); err != nil {
+		return err
+	}
+
+	svc, err := chatService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := uploadChatAttachments(ctx, svc, plan.space(), plan.Attachments)
+	if err != nil {
+		return err
+	}
+
+	resp, err := svc.Spaces.Messages.Patch(plan.Message, plan.message(attachments)).
+		UpdateMask(strings.Join(plan.updateMask(), ",")).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"message": resp})
+	}
+
+	u.Out().Linef("resource\t%s", firstNonEmpty(resp.Name, plan.Message))
+	if resp.LastUpdateTime != "" {
+		u.Out().Linef("updated\t%s", resp.LastUpdateTime)
+	}
+	return nil
+}
+
+type ChatMessagesDeleteCmd struct {
+	Message     string `arg:"" name:"message" help:"Message resource (spaces/.../messages/...) or bare message ID"`
+	Space       string `name:"space" help:"Space name (required when message is a bare ID)"`
+	WithReplies bool   `name:"with-replies" help:"Also delete threaded replies (otherwise deleting a message with replies fails)"`
+}
+
+func (c *ChatMessagesDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	message, err := normalizeMessage(c.Space, c.Message)
+	if err != nil {
+		return usage("required: message (full resource path, or bare ID with --space)")
+	}
+
+	if confirmErr := dryRunAndConfirmDestructive(ctx, flags, "chat.messages.delete", map[string]any{
+		"message":      message,
+		"with_replies": c.WithReplies,
+	}, "delete chat message "+message); confirmErr != nil {
+		return confirmErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if err = requireWorkspaceAccount(account); err != nil {
+		return err
+	}
+
+	svc, err := chatService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	call := svc.Spaces.Messages.Delete(message).Context(ctx)
+	if c.WithReplies {
+		call = call.Force(true)
+	}
+	if _, err := call.Do(); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"deleted": message})
+	}
+
+	ui.FromContext(ctx).Out().Linef("deleted\t%s", message)
+	return nil
+}
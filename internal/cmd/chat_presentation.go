@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"strings"
+
 	"google.golang.org/api/chat/v1"
 
 	"github.com/steipete/gogcli/internal/outfmt"
@@ -14,6 +16,35 @@ func chatSpaceColumns() []outfmt.Column[*chat.Space] {
 	}
 }
 
+func chatMembershipColumns() []outfmt.Column[*chat.Membership] {
+	return []outfmt.Column[*chat.Membership]{
+		{Header: "RESOURCE", Value: func(m *chat.Membership) string { return m.Name }},
+		{Header: "MEMBER", Value: chatMembershipMember},
+		{Header: "TYPE", Value: func(m *chat.Membership) string {
+			if m.GroupMember != nil {
+				return "GROUP"
+			}
+			if m.Member != nil {
+				return m.Member.Type
+			}
+			return ""
+		}},
+		{Header: "ROLE", Value: func(m *chat.Membership) string { return strings.TrimPrefix(m.Role, "ROLE_") }},
+		{Header: "STATE", Value: func(m *chat.Membership) string { return m.State }},
+	}
+}
+
+func chatMembershipMember(m *chat.Membership) string {
+	switch {
+	case m.Member != nil:
+		return sanitizeTab(firstNonEmpty(m.Member.DisplayName, m.Member.Name))
+	case m.GroupMember != nil:
+		return m.GroupMember.Name
+	default:
+		return ""
+	}
+}
+
 func chatMessageColumns() []outfmt.Column[*chat.Message] {
 	return []outfmt.Column[*chat.Message]{
 		{Header: "RESOURCE", Value: func(message *chat.Message) string { return message.Name }},
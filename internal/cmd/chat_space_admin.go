@@ -0,0 +1,568 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	"google.golang.org/api/chat/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type ChatSpacesUpdateCmd struct {
+	Space       string `arg:"" name:"space" help:"Space name (spaces/...) or ID"`
+	DisplayName string `name:"name" aliases:"display-name" help:"New display name"`
+	Description string `name:"description" help:"Space description (empty clears it)"`
+	Guidelines  string `name:"guidelines" help:"Space rules and guidelines (empty clears them)"`
+	History     string `name:"history" help:"Message history: on|off" enum:"on,off," default:""`
+}
+
+func (c *ChatSpacesUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	space, err := normalizeSpace(c.Space)
+	if err != nil {
+		return usage(err.Error())
+	}
+
+	patch := &chat.Space{}
+	var mask []string
+	if flagProvided(kctx, "name") {
+		name := strings.TrimSpace(c.DisplayName)
+		if name == "" {
+			return usage("--name must not be empty")
+		}
+		patch.DisplayName = name
+		mask = append(mask, "displayName")
+	}
+	if flagProvided(kctx, "description") || flagProvided(kctx, "guidelines") {
+		patch.SpaceDetails = &chat.SpaceDetails{}
+		if flagProvided(kctx, "description") {
+			patch.SpaceDetails.Description = c.Description
+			patch.SpaceDetails.ForceSendFields = append(patch.SpaceDetails.ForceSendFields, "Description")
+			mask = append(mask, "spaceDetails.description")
+		}
+		if flagProvided(kctx, "guidelines") {
+			patch.SpaceDetails.Guidelines = c.Guidelines
+			patch.SpaceDetails.ForceSendFields = append(patch.SpaceDetails.ForceSendFields, "Guidelines")
+			mask = append(mask, "spaceDetails.guidelines")
+		}
+	}
+	if c.History != "" {
+		patch.SpaceHistoryState = "HISTORY_" + strings.ToUpper(c.History)
+		mask = append(mask, "spaceHistoryState")
+	}
+	if len(mask) == 0 {
+		return usage("nothing to update (use --name, --description, --guidelines, or --history)")
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "chat.spaces.update", map[string]any{
+		"space":       space,
+		"update_mask": strings.Join(mask, ","),
+		"space_patch": patch,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	svc, err := requireChatService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	updated, err := svc.Spaces.Patch(space, patch).UpdateMask(strings.Join(mask, ",")).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"space": updated})
+	}
+	u.Out().Linef("resource\t%s", updated.Name)
+	u.Out().Linef("name\t%s", updated.DisplayName)
+	if updated.SpaceHistoryState != "" {
+		u.Out().Linef("history\t%s", updated.SpaceHistoryState)
+	}
+	return nil
+}
+
+type ChatSpacesDeleteCmd struct {
+	Space string `arg:"" name:"space" help:"Space name (spaces/...) or ID"`
+}
+
+func (c *ChatSpacesDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	space, err := normalizeSpace(c.Space)
+	if err != nil {
+		return usage(err.Error())
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "chat.spaces.delete", map[string]any{"space": space}); dryRunErr != nil {
+		return dryRunErr
+	}
+	if confirmErr := confirmDestructiveChecked(ctx, flags, fmt.Sprintf("delete Chat space %s with all of its messages and memberships", space)); confirmErr != nil {
+		return confirmErr
+	}
+	svc, err := requireChatService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if _, err := svc.Spaces.Delete(space).Context(ctx).Do(); err != nil {
+		return err
+	}
+	return writeResult(ctx, ui.FromContext(ctx), kv("deleted", true), kv("space", space))
+}
+
+type ChatSpacesMembersCmd struct {
+	List       ChatSpacesMembersListCmd       `cmd:"" name:"list" aliases:"ls" help:"List members of a space"`
+	Add        ChatSpacesMembersAddCmd        `cmd:"" name:"add" help:"Add users to a space"`
+	Remove     ChatSpacesMembersRemoveCmd     `cmd:"" name:"remove" aliases:"rm" help:"Remove members from a space"`
+	UpdateRole ChatSpacesMembersUpdateRoleCmd `cmd:"" name:"update-role" aliases:"role" help:"Make a member a manager or a regular member"`
+}
+
+type ChatSpacesMembersListCmd struct {
+	Space       string `arg:"" name:"space" help:"Space name (spaces/...) or ID"`
+	Max         int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page        string `name:"page" aliases:"cursor" help:"Page token"`
+	All         bool   `name:"all" aliases:"all-pages,allpages" help:"Fetch all pages"`
+	Role        string `name:"role" help:"Only list members with this role: manager|member" enum:"manager,member," default:""`
+	ShowInvited bool   `name:"invited" help:"Include users who were invited but have not joined"`
+	ShowGroups  bool   `name:"groups" help:"Include Google Group memberships"`
+}
+
+func (c *ChatSpacesMembersListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	space, err := normalizeSpace(c.Space)
+	if err != nil {
+		return usage(err.Error())
+	}
+	if c.Max <= 0 {
+		return usage("max must be > 0")
+	}
+	svc, err := requireChatService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	filter := ""
+	if c.Role != "" {
+		filter = fmt.Sprintf("role = %q", chatMembershipRole(c.Role))
+	}
+	fetch := func(pageToken string) ([]*chat.Membership, string, error) {
+		call := svc.Spaces.Members.List(space).PageSize(c.Max).ShowInvited(c.ShowInvited).ShowGroups(c.ShowGroups).Context(ctx)
+		if filter != "" {
+			call = call.Filter(filter)
+		}
+		if strings.TrimSpace(pageToken) != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, callErr := call.Do()
+		if callErr != nil {
+			return nil, "", callErr
+		}
+		return resp.Memberships, resp.NextPageToken, nil
+	}
+	memberships, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
+	if err != nil {
+		return err
+	}
+	memberships = compactChatRows(memberships)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"memberships":   memberships,
+			"nextPageToken": nextPageToken,
+		})
+	}
+	if len(memberships) == 0 {
+		u.Err().Println("No members")
+		return nil
+	}
+	if err := outfmt.WriteTable(ctx, stdoutWriter(ctx), memberships, chatMembershipColumns()); err != nil {
+		return err
+	}
+	printNextPageHintWithAll(u, nextPageToken, "--all/--all-pages")
+	return nil
+}
+
+type ChatSpacesMembersAddCmd struct {
+	Space   string   `arg:"" name:"space" help:"Space name (spaces/...) or ID"`
+	Members []string `arg:"" name:"member" help:"User emails or users/... (comma-separated or repeated)"`
+	Role    string   `name:"role" help:"Role for the new members: member|manager" enum:"member,manager" default:"member"`
+}
+
+func (c *ChatSpacesMembersAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	space, err := normalizeSpace(c.Space)
+	if err != nil {
+		return usage(err.Error())
+	}
+	users, err := normalizeChatMemberUsers(c.Members)
+	if err != nil {
+		return err
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "chat.spaces.members.add", map[string]any{
+		"space": space,
+		"users": users,
+		"role":  chatMembershipRole(c.Role),
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	svc, err := requireChatService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	results := make([]chatMemberChange, 0, len(users))
+	for _, user := range users {
+		change := chatMemberChange{Action: "add", User: user}
+		membership, addErr := addChatMember(ctx, svc, space, user, c.Role)
+		change.finish(membership, addErr)
+		results = append(results, change)
+	}
+	return writeChatMemberChanges(ctx, space, results, nil)
+}
+
+type ChatSpacesMembersRemoveCmd struct {
+	Space   string   `arg:"" name:"space" help:"Space name (spaces/...) or ID"`
+	Members []string `arg:"" name:"member" help:"User emails, users/..., or spaces/.../members/... (comma-separated or repeated)"`
+}
+
+func (c *ChatSpacesMembersRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	space, err := normalizeSpace(c.Space)
+	if err != nil {
+		return usage(err.Error())
+	}
+	names, err := normalizeChatMemberships(space, c.Members)
+	if err != nil {
+		return err
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "chat.spaces.members.remove", map[string]any{
+		"space":       space,
+		"memberships": names,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	if confirmErr := confirmDestructiveChecked(ctx, flags, fmt.Sprintf("remove %d member%s from %s", len(names), pluralS(len(names)), space)); confirmErr != nil {
+		return confirmErr
+	}
+	svc, err := requireChatService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	results := make([]chatMemberChange, 0, len(names))
+	for _, name := range names {
+		change := chatMemberChange{Action: "remove", Membership: name}
+		membership, removeErr := svc.Spaces.Members.Delete(name).Context(ctx).Do()
+		change.finish(membership, removeErr)
+		results = append(results, change)
+	}
+	return writeChatMemberChanges(ctx, space, results, nil)
+}
+
+type ChatSpacesMembersUpdateRoleCmd struct {
+	Space  string `arg:"" name:"space" help:"Space name (spaces/...) or ID"`
+	Member string `arg:"" name:"member" help:"User email, users/..., or spaces/.../members/..."`
+	Role   string `name:"role" required:"" help:"New role: manager|member" enum:"manager,member"`
+}
+
+func (c *ChatSpacesMembersUpdateRoleCmd) Run(ctx context.Context, flags *RootFlags) error {
+	space, err := normalizeSpace(c.Space)
+	if err != nil {
+		return usage(err.Error())
+	}
+	names, err := normalizeChatMemberships(space, []string{c.Member})
+	if err != nil {
+		return err
+	}
+	if len(names) != 1 {
+		return usage("exactly one member required")
+	}
+	role := chatMembershipRole(c.Role)
+	if dryRunErr := dryRunExit(ctx, flags, "chat.spaces.members.update_role", map[string]any{
+		"membership": names[0],
+		"role":       role,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	svc, err := requireChatService(ctx, flags)
+	if err != nil {
+		return err
+	}
+	updated, err := svc.Spaces.Members.Patch(names[0], &chat.Membership{Role: role}).UpdateMask("role").Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"membership": updated})
+	}
+	return outfmt.WriteTable(ctx, stdoutWriter(ctx), []*chat.Membership{updated}, chatMembershipColumns())
+}
+
+// chatMemberChange is the per-member outcome of add, remove, and sync.
+type chatMemberChange struct {
+	Action     string `json:"action"`
+	User       string `json:"user,omitempty"`
+	Membership string `json:"membership,omitempty"`
+	Status     string `json:"status"`
+	Role       string `json:"role,omitempty"`
+	State      string `json:"state,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+func (c *chatMemberChange) finish(membership *chat.Membership, err error) {
+	if err != nil {
+		c.Status, c.Error = "failed", err.Error()
+		return
+	}
+	c.Status = "ok"
+	if membership != nil {
+		c.Membership = firstNonEmpty(membership.Name, c.Membership)
+		c.Role = membership.Role
+		c.State = membership.State
+		if membership.Member != nil && c.User == "" {
+			c.User = membership.Member.Name
+		}
+	}
+}
+
+// addChatMember creates the membership, then promotes it when role is
+// manager: the API ignores role on create.
+func addChatMember(ctx context.Context, svc *chat.Service, space, user, role string) (*chat.Membership, error) {
+	membership, err := svc.Spaces.Members.Create(space, &chat.Membership{
+		Member: &chat.User{Name: user, Type: "HUMAN"},
+	}).Context(ctx).Do()
+	if err != nil || role != "manager" {
+		return membership, err
+	}
+	promoted, err := svc.Spaces.Members.Patch(membership.Name, &chat.Membership{Role: chatMembershipRole(role)}).UpdateMask("role").Context(ctx).Do()
+	if err != nil {
+		return membership, fmt.Errorf("added but not made manager: %w", err)
+	}
+	return promoted, nil
+}
+
+func writeChatMemberChanges(ctx context.Context, space string, changes []chatMemberChange, extra map[string]any) error {
+	failed := 0
+	for _, change := range changes {
+		if change.Status == "failed" {
+			failed++
+		}
+	}
+	if outfmt.IsJSON(ctx) {
+		out := map[string]any{
+			"space":   space,
+			"changes": changes,
+			"failed":  failed,
+		}
+		for k, v := range extra {
+			out[k] = v
+		}
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), out); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "ACTION\tUSER\tMEMBERSHIP\tSTATUS")
+		for _, change := range changes {
+			status := change.Status
+			if change.State == "INVITED" {
+				status += " (invited)"
+			}
+			if change.Error != "" {
+				status += ": " + change.Error
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", change.Action, change.User, change.Membership, sanitizeTab(status))
+		}
+		flush()
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d membership change%s failed", failed, len(changes), pluralS(len(changes)))
+	}
+	return nil
+}
+
+func requireChatService(ctx context.Context, flags *RootFlags) (*chat.Service, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return nil, err
+	}
+	if err := requireWorkspaceAccount(account); err != nil {
+		return nil, err
+	}
+	return chatService(ctx, account)
+}
+
+func chatMembershipRole(role string) string {
+	if role == "manager" {
+		return "ROLE_MANAGER"
+	}
+	return "ROLE_MEMBER"
+}
+
+func normalizeChatMemberUsers(values []string) ([]string, error) {
+	members := parseCommaArgs(values)
+	users := make([]string, 0, len(members))
+	seen := map[string]bool{}
+	for _, member := range members {
+		user, err := normalizeChatMemberUser(member)
+		if err != nil {
+			return nil, err
+		}
+		if user == "" || seen[strings.ToLower(user)] {
+			continue
+		}
+		seen[strings.ToLower(user)] = true
+		users = append(users, user)
+	}
+	if len(users) == 0 {
+		return nil, usage("no members")
+	}
+	return users, nil
+}
+
+// normalizeChatMemberships turns emails, users/... and bare member IDs into
+// spaces/{space}/members/{member} names. The API accepts an email in place
+// of the member ID.
+func normalizeChatMemberships(space string, values []string) ([]string, error) {
+	members := parseCommaArgs(values)
+	names := make([]string, 0, len(members))
+	for _, member := range members {
+		if strings.HasPrefix(member, "spaces/") {
+			parts := strings.Split(member, "/")
+			if len(parts) != 4 || parts[2] != "members" || parts[3] == "" {
+				return nil, usagef("invalid membership %q", member)
+			}
+			if "spaces/"+parts[1] != space {
+				return nil, usagef("membership %q is not in %s", member, space)
+			}
+			names = append(names, member)
+			continue
+		}
+		id := strings.TrimPrefix(strings.TrimPrefix(member, "users/"), "members/")
+		if id == "" || strings.ContainsAny(id, "/ \t\r\n<>") {
+			return nil, usagef("invalid member %q", member)
+		}
+		names = append(names, space+"/members/"+id)
+	}
+	if len(names) == 0 {
+		return nil, usage("no members")
+	}
+	return names, nil
+}
+
+type ChatSpacesSyncMembersCmd struct {
+	Space        string `arg:"" name:"space" help:"Space name (spaces/...) or ID"`
+	Group        string `name:"group" required:"" help:"Google Group email whose users (including nested groups) should be the space members"`
+	NoRemove     bool   `name:"no-remove" help:"Only add missing members; keep members who are not in the group"`
+	KeepManagers bool   `name:"keep-managers" help:"Never remove space managers"`
+}
+
+func (c *ChatSpacesSyncMembersCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	space, err := normalizeSpace(c.Space)
+	if err != nil {
+		return usage(err.Error())
+	}
+	group := strings.TrimSpace(c.Group)
+	if err := validatePlainEmail("--group", group); err != nil {
+		return err
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	if err := requireWorkspaceAccount(account); err != nil {
+		return err
+	}
+	ciSvc, err := cloudIdentityService(ctx, account)
+	if err != nil {
+		return wrapCloudIdentityError(err, account)
+	}
+	emails, err := collectGroupMemberEmails(ctx, ciSvc, group)
+	if err != nil {
+		return wrapCloudIdentityError(err, account)
+	}
+	svc, err := chatService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	current, err := collectAllPages("", func(pageToken string) ([]*chat.Membership, string, error) {
+		call := svc.Spaces.Members.List(space).PageSize(100).ShowInvited(true).Filter(`member.type = "HUMAN"`).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, callErr := call.Do()
+		if callErr != nil {
+			return nil, "", callErr
+		}
+		return resp.Memberships, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return fmt.Errorf("list members of %s: %w", space, err)
+	}
+	resolved := make(map[string]string, len(emails))
+	for _, email := range emails {
+		user, resolveErr := chatSpaceMemberUser(ctx, svc, space, email)
+		if resolveErr != nil {
+			return fmt.Errorf("look up %s in %s: %w", email, space, resolveErr)
+		}
+		resolved[email] = user
+	}
+	caller, err := chatSpaceMemberUser(ctx, svc, space, account)
+	if err != nil {
+		return fmt.Errorf("look up %s in %s: %w", account, space, err)
+	}
+
+	plan := newChatMemberSyncPlan(chatMemberSyncInput{
+		Space:        space,
+		Group:        group,
+		Current:      current,
+		Resolved:     resolved,
+		Caller:       caller,
+		KeepManagers: c.KeepManagers,
+		NoRemove:     c.NoRemove,
+	})
+	if dryRunErr := dryRunExit(ctx, flags, "chat.spaces.sync_members", plan); dryRunErr != nil {
+		return dryRunErr
+	}
+	if len(plan.Remove) > 0 {
+		action := fmt.Sprintf("add %d and remove %d member%s of %s to match %s", len(plan.Add), len(plan.Remove), pluralS(len(plan.Remove)), space, group)
+		if confirmErr := confirmDestructiveChecked(ctx, flags, action); confirmErr != nil {
+			return confirmErr
+		}
+	}
+
+	changes := make([]chatMemberChange, 0, len(plan.Add)+len(plan.Remove))
+	for _, user := range plan.Add {
+		change := chatMemberChange{Action: "add", User: user}
+		membership, addErr := addChatMember(ctx, svc, space, user, "member")
+		change.finish(membership, addErr)
+		changes = append(changes, change)
+	}
+	for _, member := range plan.Remove {
+		change := chatMemberChange{Action: "remove", User: member.User, Membership: member.Membership}
+		membership, removeErr := svc.Spaces.Members.Delete(member.Membership).Context(ctx).Do()
+		change.finish(membership, removeErr)
+		changes = append(changes, change)
+	}
+	if !outfmt.IsJSON(ctx) {
+		u.Err().Linef("%d added, %d removed, %d unchanged, %d kept", len(plan.Add), len(plan.Remove), plan.Unchanged, len(plan.Kept))
+	}
+	return writeChatMemberChanges(ctx, space, changes, map[string]any{
+		"group":     group,
+		"unchanged": plan.Unchanged,
+		"kept":      plan.Kept,
+	})
+}
+
+// chatSpaceMemberUser returns users/{id} for the user with this email if they
+// are a member of the space (joined or invited), or "" if they are not.
+func chatSpaceMemberUser(ctx context.Context, svc *chat.Service, space, email string) (string, error) {
+	membership, err := svc.Spaces.Members.Get(space + "/members/" + email).Context(ctx).Do()
+	if isGoogleNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if membership.Member == nil {
+		return "", nil
+	}
+	return membership.Member.Name, nil
+}
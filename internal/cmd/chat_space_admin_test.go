@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/chat/v1"
+
+	"github.com/steipete/gogcli/internal/app"
+)
+
+func TestNewChatMemberSyncPlan(t *testing.T) {
+	human := func(membership, user, role string) *chat.Membership {
+		return &chat.Membership{Name: membership, Role: role, Member: &chat.User{Name: user, Type: "HUMAN"}}
+	}
+	input := chatMemberSyncInput{
+		Space: "spaces/AAA",
+		Group: "oncall@example.com",
+		Current: []*chat.Membership{
+			human("spaces/AAA/members/1", "users/1", "ROLE_MEMBER"),
+			human("spaces/AAA/members/2", "users/2", "ROLE_MEMBER"),
+			human("spaces/AAA/members/3", "users/3", "ROLE_MANAGER"),
+			human("spaces/AAA/members/9", "users/9", "ROLE_MANAGER"),
+			{Name: "spaces/AAA/members/app", Member: &chat.User{Name: "users/app", Type: "BOT"}},
+		},
+		Resolved: map[string]string{"alice@example.com": "users/1", "Carol@example.com": ""},
+		Caller:   "users/9",
+	}
+
+	plan := newChatMemberSyncPlan(input)
+	if strings.Join(plan.Add, ",") != "users/carol@example.com" {
+		t.Fatalf("Add = %v", plan.Add)
+	}
+	if plan.Unchanged != 1 {
+		t.Fatalf("Unchanged = %d", plan.Unchanged)
+	}
+	if len(plan.Remove) != 2 || plan.Remove[0].User != "users/2" || plan.Remove[1].User != "users/3" {
+		t.Fatalf("Remove = %+v", plan.Remove)
+	}
+	if len(plan.Kept) != 1 || plan.Kept[0].Reason != "caller" {
+		t.Fatalf("Kept = %+v", plan.Kept)
+	}
+
+	input.KeepManagers = true
+	plan = newChatMemberSyncPlan(input)
+	if len(plan.Remove) != 1 || plan.Remove[0].User != "users/2" || len(plan.Kept) != 2 {
+		t.Fatalf("keep managers: remove=%+v kept=%+v", plan.Remove, plan.Kept)
+	}
+
+	input.NoRemove = true
+	plan = newChatMemberSyncPlan(input)
+	if len(plan.Remove) != 0 || len(plan.Kept) != 3 || len(plan.Add) != 1 {
+		t.Fatalf("no remove: %+v", plan)
+	}
+}
+
+func TestExecute_ChatSpacesMembersAdd_ManagerIsPromotedAfterCreate(t *testing.T) {
+	var calls []string
+	svc := newChatTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+r.URL.Query().Get("updateMask"))
+		var body chat.Membership
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPost:
+			if body.Member == nil || body.Member.Name != "users/alice@example.com" {
+				t.Errorf("create body = %+v", body)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name": "spaces/AAA/members/1", "role": "ROLE_MEMBER", "state": "JOINED",
+				"member": map[string]any{"name": "users/1", "type": "HUMAN"},
+			})
+		case http.MethodPatch:
+			if body.Role != "ROLE_MANAGER" {
+				t.Errorf("patch role = %q", body.Role)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name": "spaces/AAA/members/1", "role": "ROLE_MANAGER", "state": "JOINED",
+				"member": map[string]any{"name": "users/1", "type": "HUMAN"},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	result := executeWithChatTestService(t, []string{"--json", "--account", "ops@example.com", "chat", "spaces", "members", "add", "AAA", "alice@example.com", "--role", "manager"}, svc)
+	if result.err != nil {
+		t.Fatalf("members add: %v\nstderr=%s", result.err, result.stderr)
+	}
+	if len(calls) != 2 || !strings.HasPrefix(calls[0], "POST ") || !strings.HasSuffix(calls[1], "spaces/AAA/members/1 role") {
+		t.Fatalf("calls = %v", calls)
+	}
+	if !strings.Contains(result.stdout, `"failed": 0`) || !strings.Contains(result.stdout, "ROLE_MANAGER") {
+		t.Fatalf("stdout = %s", result.stdout)
+	}
+}
+
+func TestExecute_ChatSpacesSyncMembers(t *testing.T) {
+	ciSvc := newCloudIdentityTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.Contains(r.URL.Path, "groups:lookup"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "groups/oncall"})
+		case strings.Contains(r.URL.Path, "groups/oncall/memberships"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"memberships": []any{
+				map[string]any{"preferredMemberKey": map[string]any{"id": "alice@example.com"}, "type": "USER"},
+				map[string]any{"preferredMemberKey": map[string]any{"id": "carol@example.com"}, "type": "USER"},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	known := map[string]string{"alice@example.com": "users/1", "ops@example.com": "users/9"}
+	var mu sync.Mutex
+	var writes []string
+	chatSvc := newChatTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		path := strings.TrimPrefix(r.URL.Path, "/v1/")
+		switch {
+		case r.Method == http.MethodGet && path == "spaces/AAA/members":
+			if r.URL.Query().Get("showInvited") != "true" {
+				t.Errorf("list should include invited members: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"memberships": []any{
+				map[string]any{"name": "spaces/AAA/members/1", "role": "ROLE_MEMBER", "member": map[string]any{"name": "users/1", "type": "HUMAN"}},
+				map[string]any{"name": "spaces/AAA/members/2", "role": "ROLE_MEMBER", "member": map[string]any{"name": "users/2", "type": "HUMAN"}},
+				map[string]any{"name": "spaces/AAA/members/3", "role": "ROLE_MANAGER", "member": map[string]any{"name": "users/3", "type": "HUMAN"}},
+				map[string]any{"name": "spaces/AAA/members/9", "role": "ROLE_MANAGER", "member": map[string]any{"name": "users/9", "type": "HUMAN"}},
+			}})
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "spaces/AAA/members/"):
+			user, ok := known[strings.TrimPrefix(path, "spaces/AAA/members/")]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": map[string]any{"code": 404, "message": "not found"}})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "spaces/AAA/members/x", "member": map[string]any{"name": user, "type": "HUMAN"}})
+		case r.Method == http.MethodPost && path == "spaces/AAA/members":
+			var body chat.Membership
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			mu.Lock()
+			writes = append(writes, "add "+body.Member.Name)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": "spaces/AAA/members/4", "state": "INVITED", "member": map[string]any{"name": "users/4", "type": "HUMAN"}})
+		case r.Method == http.MethodDelete:
+			mu.Lock()
+			writes = append(writes, "remove "+path)
+			mu.Unlock()
+			_ = json.NewEncoder(w).Encode(map[string]any{"name": path})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+
+	result := executeWithTestRuntime(t, []string{
+		"--json", "--force", "--account", "ops@example.com",
+		"chat", "spaces", "sync-members", "AAA", "--group", "oncall@example.com", "--keep-managers",
+	}, &app.Runtime{Services: app.Services{
+		Chat:          chatTestServices.fixed(chatSvc),
+		CloudIdentity: fixedCloudIdentityTestService(ciSvc),
+	}})
+	if result.err != nil {
+		t.Fatalf("sync-members: %v\nstderr=%s", result.err, result.stderr)
+	}
+	sort.Strings(writes)
+	if strings.Join(writes, ",") != "add users/carol@example.com,remove spaces/AAA/members/2" {
+		t.Fatalf("writes = %v", writes)
+	}
+	var out struct {
+		Group     string `json:"group"`
+		Unchanged int    `json:"unchanged"`
+		Failed    int    `json:"failed"`
+		Kept      []struct {
+			User   string `json:"user"`
+			Reason string `json:"reason"`
+		} `json:"kept"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &out); err != nil {
+		t.Fatalf("json: %v\n%s", err, result.stdout)
+	}
+	if out.Group != "oncall@example.com" || out.Unchanged != 1 || out.Failed != 0 || len(out.Kept) != 2 {
+		t.Fatalf("out = %+v", out)
+	}
+}
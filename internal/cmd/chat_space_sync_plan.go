@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"sort"
+	"strings"
+
+	"google.golang.org/api/chat/v1"
+)
+
+// chatMemberSyncPlan is the difference between a space's human members and
+// the users of a Google Group. Chat lists members as users/{id}, so group
+// emails are resolved to IDs (through the membership email alias) before
+// they are compared.
+type chatMemberSyncPlan struct {
+	Space     string             `json:"space"`
+	Group     string             `json:"group"`
+	Add       []string           `json:"add"`
+	Remove    []chatSyncedMember `json:"remove"`
+	Kept      []chatSyncedMember `json:"kept,omitempty"`
+	Unchanged int                `json:"unchanged"`
+}
+
+type chatSyncedMember struct {
+	Membership string `json:"membership"`
+	User       string `json:"user"`
+	Role       string `json:"role,omitempty"`
+	Reason     string `json:"reason,omitempty"`
+}
+
+type chatMemberSyncInput struct {
+	Space   string
+	Group   string
+	Current []*chat.Membership
+	// Resolved maps each group member email to its users/{id} when that user
+	// is already in the space, or to "" when they are not.
+	Resolved     map[string]string
+	Caller       string
+	KeepManagers bool
+	NoRemove     bool
+}
+
+func newChatMemberSyncPlan(in chatMemberSyncInput) chatMemberSyncPlan {
+	plan := chatMemberSyncPlan{Space: in.Space, Group: in.Group, Add: []string{}, Remove: []chatSyncedMember{}}
+	wanted := map[string]bool{}
+	for email, user := range in.Resolved {
+		if user == "" {
+			plan.Add = append(plan.Add, "users/"+strings.ToLower(email))
+			continue
+		}
+		wanted[user] = true
+	}
+	sort.Strings(plan.Add)
+
+	for _, m := range in.Current {
+		if m == nil || m.Member == nil || m.Member.Type != "HUMAN" {
+			continue
+		}
+		member := chatSyncedMember{Membership: m.Name, User: m.Member.Name, Role: m.Role}
+		switch {
+		case wanted[m.Member.Name]:
+			plan.Unchanged++
+		case in.Caller != "" && m.Member.Name == in.Caller:
+			member.Reason = "caller"
+			plan.Kept = append(plan.Kept, member)
+		case in.KeepManagers && m.Role == "ROLE_MANAGER":
+			member.Reason = "manager"
+			plan.Kept = append(plan.Kept, member)
+		case in.NoRemove:
+			member.Reason = "not in group"
+			plan.Kept = append(plan.Kept, member)
+		default:
+			plan.Remove = append(plan.Remove, member)
+		}
+	}
+	return plan
+}
@@ -14,6 +14,11 @@ type ChatSpacesCmd struct {
 	List   ChatSpacesListCmd   `cmd:"" name:"list" aliases:"ls" help:"List spaces"`
 	Find   ChatSpacesFindCmd   `cmd:"" name:"find" aliases:"search,query" help:"Find spaces by display name"`
 	Create ChatSpacesCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a space"`
+	Update ChatSpacesUpdateCmd `cmd:"" name:"update" aliases:"edit" help:"Update a space's name, description, guidelines, or history"`
+	Delete ChatSpacesDeleteCmd `cmd:"" name:"delete" aliases:"rm" help:"Delete a space with its messages"`
+
+	Members     ChatSpacesMembersCmd     `cmd:"" name:"members" help:"List and manage space members"`
+	SyncMembers ChatSpacesSyncMembersCmd `cmd:"" name:"sync-members" help:"Make a space's members match a Google Group"`
 }
 
 type ChatSpacesListCmd struct {
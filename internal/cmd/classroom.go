@@ -8,6 +8,7 @@ type ClassroomCmd struct {
 	Coursework      ClassroomCourseworkCmd      `cmd:"" name:"coursework" aliases:"work" help:"Coursework"`
 	Materials       ClassroomMaterialsCmd       `cmd:"" name:"materials" aliases:"material" help:"Coursework materials"`
 	Submissions     ClassroomSubmissionsCmd     `cmd:"" aliases:"submission" help:"Student submissions"`
+	Gradebook       ClassroomGradebookCmd       `cmd:"" aliases:"grades" help:"Gradebook export and grade import"`
 	Announcements   ClassroomAnnouncementsCmd   `cmd:"" aliases:"announcement,ann" help:"Announcements"`
 	Topics          ClassroomTopicsCmd          `cmd:"" aliases:"topic" help:"Topics"`
 	Invitations     ClassroomInvitationsCmd     `cmd:"" aliases:"invitation,invites" help:"Invitations"`
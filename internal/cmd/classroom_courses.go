@@ -82,7 +82,7 @@ func (c *ClassroomCoursesListCmd) Run(ctx context.Context, flags *RootFlags) err
 		return resp.Courses, resp.NextPageToken, nil
 	}
 
-	courses, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	courses, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
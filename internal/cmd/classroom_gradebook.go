@@ -0,0 +1,308 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/classroom/v1"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/sheetsa1"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type ClassroomGradebookCmd struct {
+	Export ClassroomGradebookExportCmd `cmd:"" help:"Export a students x coursework grade matrix"`
+	Import ClassroomGradebookImportCmd `cmd:"" help:"Import grades from CSV (matched by student email)"`
+}
+
+// classroomNow is the clock used to decide which work is missing.
+var classroomNow = time.Now
+
+type ClassroomGradebookExportCmd struct {
+	CourseID string `arg:"" name:"courseId" help:"Course ID or alias"`
+	Format   string `name:"format" short:"f" help:"Export format: csv|json" enum:"csv,json" default:"csv"`
+	Out      string `name:"out" short:"o" help:"Output path, or - for stdout" default:"-"`
+	ToSheet  string `name:"to-sheet" help:"Write the matrix to this spreadsheet instead of a file (replaces the tab contents)"`
+	SheetTab string `name:"sheet-tab" help:"Tab for --to-sheet (created if missing)" default:"Gradebook"`
+}
+
+func (c *ClassroomGradebookExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	courseID := strings.TrimSpace(c.CourseID)
+	if courseID == "" {
+		return usage("empty courseId")
+	}
+	spreadsheetID := strings.TrimSpace(normalizeGoogleID(c.ToSheet))
+	tab := strings.TrimSpace(c.SheetTab)
+	if spreadsheetID != "" && tab == "" {
+		return usage("empty --sheet-tab")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := classroomService(ctx, account)
+	if err != nil {
+		return wrapClassroomError(err)
+	}
+	book, err := loadClassroomGradebook(ctx, svc, courseID)
+	if err != nil {
+		return wrapClassroomError(err)
+	}
+
+	if spreadsheetID != "" {
+		return exportGradebookToSheet(ctx, flags, account, book, spreadsheetID, tab)
+	}
+
+	var buf bytes.Buffer
+	if c.Format == "json" || outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, &buf, book); err != nil {
+			return err
+		}
+	} else {
+		w := csv.NewWriter(&buf)
+		if err := w.WriteAll(book.table()); err != nil {
+			return err
+		}
+	}
+
+	outPath := strings.TrimSpace(c.Out)
+	if isStdoutPath(outPath) {
+		_, err = stdoutWriter(ctx).Write(buf.Bytes())
+		return err
+	}
+	outPath, err = config.ExpandPath(outPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	ui.FromContext(ctx).Err().Linef("Exported %d student%s x %d coursework to %s", len(book.Students), pluralS(len(book.Students)), len(book.Coursework), outPath)
+	return nil
+}
+
+// loadClassroomGradebook fetches the roster, published coursework and every
+// submission in the course ("-" lists submissions across all coursework).
+func loadClassroomGradebook(ctx context.Context, svc *classroom.Service, courseID string) (*classroomGradebook, error) {
+	var students []*classroom.Student
+	err := svc.Courses.Students.List(courseID).PageSize(100).Pages(ctx, func(resp *classroom.ListStudentsResponse) error {
+		students = append(students, resp.Students...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list students: %w", err)
+	}
+	var work []*classroom.CourseWork
+	err = svc.Courses.CourseWork.List(courseID).CourseWorkStates("PUBLISHED").OrderBy("dueDate asc").PageSize(100).Pages(ctx, func(resp *classroom.ListCourseWorkResponse) error {
+		work = append(work, resp.CourseWork...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list coursework: %w", err)
+	}
+	var submissions []*classroom.StudentSubmission
+	err = svc.Courses.CourseWork.StudentSubmissions.List(courseID, "-").PageSize(100).Pages(ctx, func(resp *classroom.ListStudentSubmissionsResponse) error {
+		submissions = append(submissions, resp.StudentSubmissions...)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list submissions: %w", err)
+	}
+	return newClassroomGradebook(courseID, students, work, submissions, classroomNow()), nil
+}
+
+func exportGradebookToSheet(ctx context.Context, flags *RootFlags, account string, book *classroomGradebook, spreadsheetID, tab string) error {
+	table := book.table()
+	payload := map[string]any{
+		"course_id":      book.CourseID,
+		"spreadsheet_id": spreadsheetID,
+		"tab":            tab,
+		"students":       len(book.Students),
+		"coursework":     len(book.Coursework),
+	}
+	if err := dryRunExit(ctx, flags, "classroom.gradebook.export", payload); err != nil {
+		return err
+	}
+	sheetsSvc, err := sheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	exists, _, err := readFormsSheetState(ctx, sheetsSvc, spreadsheetID, tab)
+	if err != nil {
+		return err
+	}
+	tabRange := strings.TrimSuffix(sheetsa1.SheetPrefix(tab), "!")
+	if exists {
+		if _, err := sheetsSvc.Spreadsheets.Values.Clear(spreadsheetID, tabRange, &sheets.ClearValuesRequest{}).Context(ctx).Do(); err != nil {
+			return err
+		}
+	} else {
+		_, err := sheetsSvc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: tab}}}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("create tab %q: %w", tab, err)
+		}
+	}
+	rows := make([][]interface{}, 0, len(table))
+	for i, row := range table {
+		values := sheetRowValues(row)[0]
+		for j := 2; i > 0 && j < len(row); j += 2 {
+			if grade, parseErr := parseFloat(row[j]); parseErr == nil {
+				values[j] = grade
+			}
+		}
+		rows = append(rows, values)
+	}
+	// RAW keeps names and titles literal; grades are sent as numbers so the
+	// sheet can sum and average them.
+	_, err = sheetsSvc.Spreadsheets.Values.Update(spreadsheetID, sheetsa1.FormatCell(tab, 1, 1), &sheets.ValueRange{Values: rows}).
+		ValueInputOption("RAW").
+		Context(ctx).
+		Do()
+	if err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload)
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Linef("students\t%d", len(book.Students))
+	u.Out().Linef("coursework\t%d", len(book.Coursework))
+	u.Out().Linef("tab\t%s", tab)
+	return nil
+}
+
+type ClassroomGradebookImportCmd struct {
+	File     string `arg:"" name:"file" help:"CSV with an email column and one column per coursework (ID, title, or \"Title [id]\"); - for stdin"`
+	CourseID string `name:"course" required:"" help:"Course ID or alias"`
+	Grade    string `name:"grade" help:"Grade to set: draft|assigned|both" enum:"draft,assigned,both" default:"draft"`
+	Return   bool   `name:"return" help:"Return updated submissions to students after grading"`
+}
+
+func (c *ClassroomGradebookImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	courseID := strings.TrimSpace(c.CourseID)
+	if courseID == "" {
+		return usage("empty --course")
+	}
+	data, err := readTextInput(ctx, strings.TrimSpace(c.File))
+	if err != nil {
+		return err
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := classroomService(ctx, account)
+	if err != nil {
+		return wrapClassroomError(err)
+	}
+	book, err := loadClassroomGradebook(ctx, svc, courseID)
+	if err != nil {
+		return wrapClassroomError(err)
+	}
+	plan, err := newGradebookImportPlan(book, bytes.NewReader(data), c.Grade, c.Return)
+	if err != nil {
+		return err
+	}
+	if len(plan.Errors) > 0 {
+		for _, msg := range plan.Errors {
+			u.Err().Println(msg)
+		}
+		return usagef("%d invalid grade%s; nothing was changed", len(plan.Errors), pluralS(len(plan.Errors)))
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "classroom.gradebook.import", map[string]any{
+		"course_id": courseID,
+		"grade":     c.Grade,
+		"changes":   plan.Changes,
+		"unchanged": plan.Unchanged,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	if n := plan.overwrites(); n > 0 || (c.Return && len(plan.Changes) > 0) {
+		action := fmt.Sprintf("overwrite %d existing grade%s in course %s", n, pluralS(n), courseID)
+		if c.Return {
+			action = fmt.Sprintf("update %d grade%s (%d overwritten) and return those submissions in course %s", len(plan.Changes), pluralS(len(plan.Changes)), n, courseID)
+		}
+		if confirmErr := confirmDestructiveChecked(ctx, flags, action); confirmErr != nil {
+			return confirmErr
+		}
+	}
+
+	results := make([]map[string]any, 0, len(plan.Changes))
+	failed := 0
+	for _, change := range plan.Changes {
+		result := map[string]any{"email": change.Email, "coursework_id": change.CourseworkID, "fields": change.Fields, "grade": change.New, "status": "ok"}
+		if applyErr := applyGradebookChange(ctx, svc, courseID, change); applyErr != nil {
+			failed++
+			result["status"], result["error"] = "failed", wrapClassroomError(applyErr).Error()
+		}
+		results = append(results, result)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"results":   results,
+			"unchanged": plan.Unchanged,
+			"failed":    failed,
+		}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "EMAIL\tCOURSEWORK\tGRADE\tSTATUS")
+		for _, r := range results {
+			status := r["status"].(string)
+			if msg, ok := r["error"].(string); ok {
+				status += ": " + msg
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", r["email"], r["coursework_id"], r["grade"], status)
+		}
+		flush()
+		u.Err().Linef("%d updated, %d unchanged, %d failed", len(results)-failed, plan.Unchanged, failed)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d grade update%s failed", failed, len(results), pluralS(len(results)))
+	}
+	return nil
+}
+
+func applyGradebookChange(ctx context.Context, svc *classroom.Service, courseID string, change gradebookChange) error {
+	sub := &classroom.StudentSubmission{}
+	for _, field := range change.Fields {
+		// ForceSendFields keeps a grade of 0 on the wire.
+		switch field {
+		case "draftGrade":
+			sub.DraftGrade = change.grade
+			sub.ForceSendFields = append(sub.ForceSendFields, "DraftGrade")
+		case "assignedGrade":
+			sub.AssignedGrade = change.grade
+			sub.ForceSendFields = append(sub.ForceSendFields, "AssignedGrade")
+		}
+	}
+	submissions := svc.Courses.CourseWork.StudentSubmissions
+	if _, err := submissions.Patch(courseID, change.CourseworkID, change.SubmissionID, sub).UpdateMask(updateMask(change.Fields)).Context(ctx).Do(); err != nil {
+		return err
+	}
+	if !change.Return {
+		return nil
+	}
+	_, err := submissions.Return(courseID, change.CourseworkID, change.SubmissionID, &classroom.ReturnStudentSubmissionRequest{}).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("graded but not returned: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"google.golang.org/api/classroom/v1"
+)
+
+const (
+	gradebookGradeDraft    = "draft"
+	gradebookGradeAssigned = "assigned"
+)
+
+// gradebookColumn is one coursework item in the gradebook.
+type gradebookColumn struct {
+	ID        string  `json:"id"`
+	Title     string  `json:"title"`
+	MaxPoints float64 `json:"max_points,omitempty"`
+	Due       string  `json:"due,omitempty"`
+	Header    string  `json:"-"`
+	dueAt     time.Time
+}
+
+// gradebookCell is one student's submission for one coursework item. Grades
+// are pointers so an explicit 0 is kept apart from "not graded".
+type gradebookCell struct {
+	SubmissionID string   `json:"submission_id"`
+	State        string   `json:"state,omitempty"`
+	DraftGrade   *float64 `json:"draft_grade,omitempty"`
+	Assigned     *float64 `json:"assigned_grade,omitempty"`
+	Late         bool     `json:"late,omitempty"`
+	Missing      bool     `json:"missing,omitempty"`
+}
+
+type gradebookStudent struct {
+	UserID string                    `json:"user_id"`
+	Email  string                    `json:"email"`
+	Name   string                    `json:"name,omitempty"`
+	Grades map[string]*gradebookCell `json:"grades"`
+}
+
+type classroomGradebook struct {
+	CourseID   string              `json:"course_id"`
+	Coursework []*gradebookColumn  `json:"coursework"`
+	Students   []*gradebookStudent `json:"students"`
+}
+
+// newClassroomGradebook builds the students × coursework matrix. now decides
+// which unsubmitted work counts as missing.
+func newClassroomGradebook(courseID string, students []*classroom.Student, work []*classroom.CourseWork, submissions []*classroom.StudentSubmission, now time.Time) *classroomGradebook {
+	book := &classroomGradebook{CourseID: courseID}
+	byID := map[string]*gradebookColumn{}
+	titles := map[string]int{}
+	for _, w := range work {
+		if w != nil {
+			titles[strings.TrimSpace(w.Title)]++
+		}
+	}
+	for _, w := range work {
+		if w == nil {
+			continue
+		}
+		col := &gradebookColumn{
+			ID:        w.Id,
+			Title:     strings.TrimSpace(w.Title),
+			MaxPoints: w.MaxPoints,
+			Due:       formatClassroomDue(w.DueDate, w.DueTime),
+			dueAt:     classroomDueTime(w.DueDate, w.DueTime),
+		}
+		col.Header = col.Title
+		if col.Header == "" || titles[col.Title] > 1 {
+			col.Header = strings.TrimSpace(fmt.Sprintf("%s [%s]", col.Title, col.ID))
+		}
+		book.Coursework = append(book.Coursework, col)
+		byID[col.ID] = col
+	}
+
+	byUser := map[string]*gradebookStudent{}
+	for _, s := range students {
+		if s == nil {
+			continue
+		}
+		student := &gradebookStudent{
+			UserID: s.UserId,
+			Email:  strings.ToLower(profileEmail(s.Profile)),
+			Name:   profileName(s.Profile),
+			Grades: map[string]*gradebookCell{},
+		}
+		book.Students = append(book.Students, student)
+		byUser[s.UserId] = student
+	}
+
+	for _, sub := range submissions {
+		if sub == nil {
+			continue
+		}
+		student, col := byUser[sub.UserId], byID[sub.CourseWorkId]
+		if student == nil || col == nil {
+			continue
+		}
+		cell := &gradebookCell{SubmissionID: sub.Id, State: sub.State, Late: sub.Late}
+		if sub.DraftGrade != 0 || submissionGradeChanged(sub, "DRAFT_GRADE_POINTS_EARNED_CHANGE") {
+			cell.DraftGrade = &sub.DraftGrade
+		}
+		if sub.AssignedGrade != 0 || submissionGradeChanged(sub, "ASSIGNED_GRADE_POINTS_EARNED_CHANGE") {
+			cell.Assigned = &sub.AssignedGrade
+		}
+		submitted := sub.State == "TURNED_IN" || sub.State == "RETURNED"
+		cell.Missing = !submitted && cell.Assigned == nil && !col.dueAt.IsZero() && now.After(col.dueAt)
+		student.Grades[col.ID] = cell
+	}
+	return book
+}
+
+// submissionGradeChanged reports whether the history records a grade change
+// of the given type; the API omits grades of 0 otherwise.
+func submissionGradeChanged(sub *classroom.StudentSubmission, changeType string) bool {
+	for _, h := range sub.SubmissionHistory {
+		if h != nil && h.GradeHistory != nil && h.GradeHistory.GradeChangeType == changeType {
+			return true
+		}
+	}
+	return false
+}
+
+// classroomDueTime converts a Classroom due date/time (UTC) to a time. Work due
+// on a date without a time is due at the end of that day.
+func classroomDueTime(d *classroom.Date, t *classroom.TimeOfDay) time.Time {
+	if d == nil || d.Year == 0 || d.Month == 0 || d.Day == 0 {
+		return time.Time{}
+	}
+	if t == nil {
+		return time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 23, 59, 59, 0, time.UTC)
+	}
+	return time.Date(int(d.Year), time.Month(d.Month), int(d.Day), int(t.Hours), int(t.Minutes), int(t.Seconds), 0, time.UTC)
+}
+
+// table flattens the gradebook for CSV and Sheets: one grade column and one
+// status column per coursework item. The grade column shows the assigned
+// grade, or the draft grade when nothing has been returned yet.
+func (b *classroomGradebook) table() [][]string {
+	header := []string{"email", "name"}
+	for _, col := range b.Coursework {
+		header = append(header, col.Header, col.Header+" status")
+	}
+	rows := [][]string{header}
+	for _, student := range b.Students {
+		row := []string{student.Email, student.Name}
+		for _, col := range b.Coursework {
+			cell := student.Grades[col.ID]
+			row = append(row, cell.grade(), cell.status())
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func (c *gradebookCell) grade() string {
+	switch {
+	case c == nil:
+		return ""
+	case c.Assigned != nil:
+		return formatFloatValue(*c.Assigned)
+	case c.DraftGrade != nil:
+		return formatFloatValue(*c.DraftGrade)
+	default:
+		return ""
+	}
+}
+
+func (c *gradebookCell) status() string {
+	if c == nil {
+		return ""
+	}
+	var parts []string
+	switch {
+	case c.Missing:
+		parts = append(parts, "missing")
+	case c.State == "TURNED_IN":
+		parts = append(parts, "turned_in")
+	case c.State == "RETURNED":
+		parts = append(parts, "returned")
+	}
+	if c.Late {
+		parts = append(parts, "late")
+	}
+	if c.Assigned == nil && c.DraftGrade != nil {
+		parts = append(parts, "draft")
+	}
+	return strings.Join(parts, ";")
+}
+
+// gradebookChange is one planned grade update.
+type gradebookChange struct {
+	Line         int      `json:"line"`
+	Email        string   `json:"email"`
+	CourseworkID string   `json:"coursework_id"`
+	Coursework   string   `json:"coursework"`
+	SubmissionID string   `json:"submission_id"`
+	Fields       []string `json:"fields"`
+	Current      string   `json:"current,omitempty"`
+	New          string   `json:"new"`
+	Return       bool     `json:"return,omitempty"`
+	grade        float64
+}
+
+// overwrites reports whether the change replaces a grade that is already set.
+func (c gradebookChange) overwrites() bool {
+	return c.Current != ""
+}
+
+type gradebookImportPlan struct {
+	Changes   []gradebookChange `json:"changes"`
+	Unchanged int               `json:"unchanged"`
+	Errors    []string          `json:"errors,omitempty"`
+}
+
+var gradebookHeaderID = regexp.MustCompile(`\[([^\[\]]+)\]\s*$`)
+
+// newGradebookImportPlan reads grades.csv (an email column plus one column per
+// coursework, matched by ID, title or "Title [id]") and compares every
+// non-empty cell with the current gradebook. Status and name columns from
+// `gradebook export` are ignored so an export can be edited and re-imported.
+func newGradebookImportPlan(book *classroomGradebook, reader io.Reader, mode string, returnSubmissions bool) (gradebookImportPlan, error) {
+	var plan gradebookImportPlan
+	cr := csv.NewReader(reader)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return plan, usage("csv is empty")
+	}
+	if err != nil {
+		return plan, fmt.Errorf("read csv header: %w", err)
+	}
+
+	emailCol := -1
+	columns := make([]*gradebookColumn, len(header))
+	for i, raw := range header {
+		name := strings.TrimSpace(strings.TrimPrefix(raw, "\ufeff"))
+		switch lower := strings.ToLower(name); {
+		case lower == "email" || lower == "email_address" || lower == "student":
+			emailCol = i
+		case lower == "name" || lower == "":
+		default:
+			col, resolveErr := book.resolveColumn(name)
+			if resolveErr != nil && strings.HasSuffix(lower, " status") {
+				continue
+			}
+			if resolveErr != nil {
+				return plan, resolveErr
+			}
+			columns[i] = col
+		}
+	}
+	if emailCol < 0 {
+		return plan, usage("csv needs an email column")
+	}
+
+	byEmail := map[string]*gradebookStudent{}
+	for _, s := range book.Students {
+		byEmail[s.Email] = s
+	}
+	for line := 2; ; line++ {
+		record, readErr := cr.Read()
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return plan, fmt.Errorf("read csv line %d: %w", line, readErr)
+		}
+		if emailCol >= len(record) || strings.TrimSpace(record[emailCol]) == "" {
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(record[emailCol]))
+		student := byEmail[email]
+		if student == nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("line %d: %s is not a student in this course", line, email))
+			continue
+		}
+		for i, col := range columns {
+			if col == nil || i >= len(record) || strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			label := strings.TrimSpace(header[i])
+			grade, parseErr := parseFloat(record[i])
+			switch {
+			case parseErr != nil:
+				plan.Errors = append(plan.Errors, fmt.Sprintf("line %d: %s: %v", line, label, parseErr))
+				continue
+			case grade < 0 || (col.MaxPoints > 0 && grade > col.MaxPoints):
+				plan.Errors = append(plan.Errors, fmt.Sprintf("line %d: %s: grade %s outside 0-%s", line, label, formatFloatValue(grade), formatFloatValue(col.MaxPoints)))
+				continue
+			case col.MaxPoints == 0:
+				plan.Errors = append(plan.Errors, fmt.Sprintf("line %d: %s is ungraded", line, label))
+				continue
+			}
+			cell := student.Grades[col.ID]
+			if cell == nil {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("line %d: %s has no submission for %s", line, email, label))
+				continue
+			}
+			change, changed := planGradebookCell(cell, mode, grade)
+			if !changed {
+				plan.Unchanged++
+				continue
+			}
+			change.Line, change.Email = line, email
+			change.CourseworkID, change.Coursework = col.ID, col.Title
+			change.Return = returnSubmissions
+			plan.Changes = append(plan.Changes, change)
+		}
+	}
+	return plan, nil
+}
+
+func planGradebookCell(cell *gradebookCell, mode string, grade float64) (gradebookChange, bool) {
+	change := gradebookChange{SubmissionID: cell.SubmissionID, New: formatFloatValue(grade), grade: grade}
+	var current []string
+	if mode != gradebookGradeAssigned && (cell.DraftGrade == nil || *cell.DraftGrade != grade) {
+		change.Fields = append(change.Fields, "draftGrade")
+		if cell.DraftGrade != nil {
+			current = append(current, "draft "+formatFloatValue(*cell.DraftGrade))
+		}
+	}
+	if mode != gradebookGradeDraft && (cell.Assigned == nil || *cell.Assigned != grade) {
+		change.Fields = append(change.Fields, "assignedGrade")
+		if cell.Assigned != nil {
+			current = append(current, "assigned "+formatFloatValue(*cell.Assigned))
+		}
+	}
+	change.Current = strings.Join(current, ", ")
+	return change, len(change.Fields) > 0
+}
+
+// resolveColumn matches a CSV header to coursework by ID, "Title [id]", or a
+// unique title.
+func (b *classroomGradebook) resolveColumn(header string) (*gradebookColumn, error) {
+	id := header
+	if m := gradebookHeaderID.FindStringSubmatch(header); m != nil {
+		id = strings.TrimSpace(m[1])
+	}
+	var byTitle []*gradebookColumn
+	for _, col := range b.Coursework {
+		if col.ID == id {
+			return col, nil
+		}
+		if strings.EqualFold(col.Title, header) {
+			byTitle = append(byTitle, col)
+		}
+	}
+	switch len(byTitle) {
+	case 1:
+		return byTitle[0], nil
+	case 0:
+		return nil, usagef("column %q does not match any coursework in course %s", header, b.CourseID)
+	default:
+		return nil, usagef("column %q matches %d coursework items; use \"Title [id]\" or the coursework ID", header, len(byTitle))
+	}
+}
+
+func (p gradebookImportPlan) overwrites() int {
+	n := 0
+	for _, c := range p.Changes {
+		if c.overwrites() {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/classroom/v1"
+)
+
+func TestNewClassroomGradebook(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	students := []*classroom.Student{
+		{UserId: "s1", Profile: &classroom.UserProfile{EmailAddress: "Ada@school.org", Name: &classroom.Name{FullName: "Ada Lovelace"}}},
+		{UserId: "s2", Profile: &classroom.UserProfile{EmailAddress: "bob@school.org"}},
+	}
+	work := []*classroom.CourseWork{
+		{Id: "w1", Title: "Essay", MaxPoints: 100, DueDate: &classroom.Date{Year: 2026, Month: 3, Day: 1}},
+		{Id: "w2", Title: "Quiz", MaxPoints: 10},
+		{Id: "w3", Title: "Quiz", MaxPoints: 10},
+	}
+	submissions := []*classroom.StudentSubmission{
+		{Id: "a1", UserId: "s1", CourseWorkId: "w1", State: "RETURNED", AssignedGrade: 91, DraftGrade: 91, Late: true},
+		{Id: "b1", UserId: "s2", CourseWorkId: "w1", State: "CREATED"},
+		{Id: "a2", UserId: "s1", CourseWorkId: "w2", State: "TURNED_IN", SubmissionHistory: []*classroom.SubmissionHistory{
+			{GradeHistory: &classroom.GradeHistory{GradeChangeType: "DRAFT_GRADE_POINTS_EARNED_CHANGE"}},
+		}},
+		{Id: "x", UserId: "nobody", CourseWorkId: "w2"},
+	}
+
+	book := newClassroomGradebook("c1", students, work, submissions, now)
+	table := book.table()
+	want := [][]string{
+		{"email", "name", "Essay", "Essay status", "Quiz [w2]", "Quiz [w2] status", "Quiz [w3]", "Quiz [w3] status"},
+		{"ada@school.org", "Ada Lovelace", "91", "returned;late", "0", "turned_in;draft", "", ""},
+		{"bob@school.org", "", "", "missing", "", "", "", ""},
+	}
+	if len(table) != len(want) {
+		t.Fatalf("table = %q", table)
+	}
+	for i := range want {
+		if strings.Join(table[i], ",") != strings.Join(want[i], ",") {
+			t.Fatalf("row %d = %q, want %q", i, table[i], want[i])
+		}
+	}
+}
+
+func TestNewGradebookImportPlan(t *testing.T) {
+	draft, assigned := 7.0, 80.0
+	book := &classroomGradebook{
+		CourseID: "c1",
+		Coursework: []*gradebookColumn{
+			{ID: "w1", Title: "Essay", MaxPoints: 100},
+			{ID: "w2", Title: "Quiz", MaxPoints: 10},
+			{ID: "w3", Title: "Reading"},
+		},
+		Students: []*gradebookStudent{
+			{UserID: "s1", Email: "ada@school.org", Grades: map[string]*gradebookCell{
+				"w1": {SubmissionID: "a1", Assigned: &assigned},
+				"w2": {SubmissionID: "a2", DraftGrade: &draft},
+			}},
+			{UserID: "s2", Email: "bob@school.org", Grades: map[string]*gradebookCell{
+				"w1": {SubmissionID: "b1"},
+			}},
+		},
+	}
+
+	csvText := "email,name,Essay,Essay status,w2\n" +
+		"ADA@school.org,Ada,85,returned,7\n" +
+		"bob@school.org,Bob,0,,\n" +
+		"eve@school.org,Eve,50,,\n" +
+		"bob@school.org,Bob,abc,,11\n" +
+		"bob@school.org,Bob,,,5\n"
+	plan, err := newGradebookImportPlan(book, strings.NewReader(csvText), gradebookGradeAssigned, true)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if len(plan.Errors) != 4 || !strings.Contains(plan.Errors[0], "eve@school.org is not a student") ||
+		!strings.Contains(plan.Errors[1], `invalid number "abc"`) || !strings.Contains(plan.Errors[2], "outside 0-10") ||
+		!strings.Contains(plan.Errors[3], "no submission") {
+		t.Fatalf("errors = %q", plan.Errors)
+	}
+	if len(plan.Changes) != 3 || plan.Unchanged != 0 {
+		t.Fatalf("changes = %#v unchanged=%d", plan.Changes, plan.Unchanged)
+	}
+	essay := plan.Changes[0]
+	if essay.SubmissionID != "a1" || essay.Current != "assigned 80" || essay.New != "85" || !essay.Return || strings.Join(essay.Fields, ",") != "assignedGrade" {
+		t.Fatalf("essay change = %#v", essay)
+	}
+	if plan.Changes[2].SubmissionID != "b1" || plan.Changes[2].New != "0" || plan.Changes[2].overwrites() {
+		t.Fatalf("bob change = %#v", plan.Changes[2])
+	}
+	if plan.overwrites() != 1 {
+		t.Fatalf("overwrites = %d", plan.overwrites())
+	}
+
+	draftPlan, err := newGradebookImportPlan(book, strings.NewReader("email,Quiz\nada@school.org,7\n"), gradebookGradeDraft, false)
+	if err != nil || len(draftPlan.Changes) != 0 || draftPlan.Unchanged != 1 {
+		t.Fatalf("draft plan = %#v (%v)", draftPlan, err)
+	}
+	if _, err := newGradebookImportPlan(book, strings.NewReader("email,Homework\n"), gradebookGradeDraft, false); err == nil {
+		t.Fatal("expected unknown column error")
+	}
+}
+
+func TestClassroomGradebookImport_DryRunThenApply(t *testing.T) {
+	var mu sync.Mutex
+	var patches []string
+	var returned []string
+	svc, closeService := newClassroomTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/students"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"students": []map[string]any{
+				{"userId": "s1", "profile": map[string]any{"emailAddress": "ada@school.org"}},
+			}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/courseWork"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"courseWork": []map[string]any{
+				{"id": "w1", "title": "Essay", "maxPoints": 100},
+			}})
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/courseWork/-/studentSubmissions"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"studentSubmissions": []map[string]any{
+				{"id": "a1", "userId": "s1", "courseWorkId": "w1", "state": "TURNED_IN", "draftGrade": 60},
+			}})
+		case r.Method == http.MethodPatch:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			patches = append(patches, r.URL.Query().Get("updateMask"))
+			if body["draftGrade"] != 0.0 || body["assignedGrade"] != 0.0 {
+				t.Errorf("patch body = %#v", body)
+			}
+			_ = json.NewEncoder(w).Encode(body)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":return"):
+			returned = append(returned, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeService()
+
+	csvPath := filepath.Join(t.TempDir(), "grades.csv")
+	if err := os.WriteFile(csvPath, []byte("email,Essay\nada@school.org,0\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	args := []string{"--account", "teacher@school.org", "--json", "classroom", "gradebook", "import", csvPath, "--course", "c1", "--grade", "both", "--return"}
+
+	dry := executeWithClassroomTestService(t, append(args, "--dry-run"), svc)
+	if dry.err != nil && ExitCode(dry.err) != 0 {
+		t.Fatalf("dry run: %v", dry.err)
+	}
+	if len(patches) != 0 || !strings.Contains(dry.stdout, `"current": "draft 60"`) {
+		t.Fatalf("dry run patches=%v stdout=%s", patches, dry.stdout)
+	}
+
+	noForce := executeWithClassroomTestService(t, append(args, "--no-input"), svc)
+	if noForce.err == nil || len(patches) != 0 {
+		t.Fatalf("overwrite without --force should not run: %v %v", noForce.err, patches)
+	}
+
+	result := executeWithClassroomTestService(t, append(args, "--force"), svc)
+	if result.err != nil {
+		t.Fatalf("import: %v\nstderr=%s", result.err, result.stderr)
+	}
+	if len(patches) != 1 || patches[0] != "draftGrade,assignedGrade" {
+		t.Fatalf("patches = %v", patches)
+	}
+	if len(returned) != 1 || !strings.HasSuffix(returned[0], "/courseWork/w1/studentSubmissions/a1:return") {
+		t.Fatalf("returned = %v", returned)
+	}
+}
@@ -37,9 +37,9 @@ func runClassroomPagedList[T any](ctx context.Context, flags *RootFlags, options
 	if err != nil {
 		return wrapClassroomError(err)
 	}
-	items, nextPageToken, err := loadPagedItems(options.page, options.all, func(pageToken string) ([]*T, string, error) {
+	items, nextPageToken, err := loadPagedItems(options.page, options.all, streamPageItems(ctx, func(pageToken string) ([]*T, string, error) {
 		return options.fetch(ctx, svc, parentID, options.max, pageToken)
-	})
+	}))
 	if err != nil {
 		return wrapClassroomError(err)
 	}
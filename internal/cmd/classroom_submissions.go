@@ -87,7 +87,7 @@ func (c *ClassroomSubmissionsListCmd) Run(ctx context.Context, flags *RootFlags)
 		return resp.StudentSubmissions, resp.NextPageToken, nil
 	}
 
-	submissions, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	submissions, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
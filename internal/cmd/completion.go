@@ -3,6 +3,8 @@ package cmd
 import (
 	"context"
 	"fmt"
+
+	"github.com/alecthomas/kong"
 )
 
 type CompletionCmd struct {
@@ -23,11 +25,8 @@ type CompletionInternalCmd struct {
 	Words []string `arg:"" optional:"" name:"words" help:"Words to complete"`
 }
 
-func (c *CompletionInternalCmd) Run(ctx context.Context) error {
-	items, err := completeWords(c.Cword, c.Words)
-	if err != nil {
-		return err
-	}
+func (c *CompletionInternalCmd) Run(ctx context.Context, kctx *kong.Context) error {
+	items := completeWordsFrom(buildCompletionNode(kctx.Model.Node), c.Cword, c.Words)
 	for _, item := range items {
 		if _, err := fmt.Fprintln(stdoutWriter(ctx), item); err != nil {
 			return err
@@ -33,25 +33,34 @@ func completeWords(cword int, words []string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
+	return completeWordsFrom(root, cword, words), nil
+}
+
+// completeWordsFrom completes against root, which callers build from the
+// running parser so plugins are included.
+func completeWordsFrom(root *completionNode, cword int, words []string) []string {
+	if len(words) == 0 {
+		return nil
+	}
 
 	cword = normalizeCword(cword, len(words))
 	if cword < 0 {
-		return nil, nil
+		return nil
 	}
 
 	start := completionStartIndex(words)
 
 	node, terminatorIndex, needsValue := advanceCompletionNode(root, words, start, cword)
 	if needsValue {
-		return nil, nil
+		return nil
 	}
 
 	if shouldStopAfterTerminator(terminatorIndex, cword, words) {
-		return nil, nil
+		return nil
 	}
 
 	if expectsFlagValue(node, cword, words, start) {
-		return nil, nil
+		return nil
 	}
 
 	current := ""
@@ -67,7 +76,7 @@ func completeWords(cword int, words []string) ([]string, error) {
 		suggestions = append(suggestions, matchingFlags(node, current)...)
 	}
 	sort.Strings(suggestions)
-	return suggestions, nil
+	return suggestions
 }
 
 func completionRootNode() (*completionNode, error) {
@@ -178,6 +187,10 @@ func buildCompletionNode(node *kong.Node) *completionNode {
 		children: make(map[string]*completionNode),
 		flags:    make(map[string]completionFlag),
 	}
+	// Everything after a passthrough command (a plugin) belongs to it.
+	if node.Passthrough {
+		return current
+	}
 
 	for _, group := range node.AllFlags(true) {
 		for _, flag := range group {
@@ -16,6 +16,8 @@ type ContactsCmd struct {
 	List      ContactsListCmd      `cmd:"" name:"list" aliases:"ls" help:"List contacts"`
 	Get       ContactsGetCmd       `cmd:"" name:"get" aliases:"info,show" help:"Get a contact"`
 	Export    ContactsExportCmd    `cmd:"" name:"export" help:"Export contacts as vCard (.vcf)"`
+	Groups    ContactsGroupsCmd    `cmd:"" name:"groups" aliases:"labels" help:"Manage contact groups (labels) and their members"`
+	Import    ContactsImportCmd    `cmd:"" name:"import" help:"Import contacts from vCard (.vcf) or Google/Outlook CSV, skipping or updating existing matches"`
 	Dedupe    ContactsDedupeCmd    `cmd:"" name:"dedupe" help:"Find likely duplicate contacts and optionally merge them"`
 	Create    ContactsCreateCmd    `cmd:"" name:"create" aliases:"add,new" help:"Create a contact"`
 	Update    ContactsUpdateCmd    `cmd:"" name:"update" aliases:"edit,set" help:"Update a contact"`
@@ -28,6 +30,7 @@ type ContactsCmd struct {
 type ContactsSearchCmd struct {
 	Query []string `arg:"" name:"query" help:"Search query"`
 	Max   int64    `name:"max" aliases:"limit" help:"Max results" default:"50"`
+	Group string   `name:"group" help:"Only return members of this contact group (contactGroups/... or name)"`
 }
 
 func (c *ContactsSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -55,6 +58,11 @@ func (c *ContactsSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if strings.TrimSpace(c.Group) != "" {
+		if resp.Results, err = filterContactSearchByGroup(ctx, svc, c.Group, resp.Results); err != nil {
+			return err
+		}
+	}
 	if outfmt.IsJSON(ctx) {
 		type item struct {
 			Resource string `json:"resource"`
@@ -87,6 +95,24 @@ func (c *ContactsSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return outfmt.WriteTable(ctx, stdoutWriter(ctx), contactSearchRows(resp.Results), contactColumns())
 }
 
+func filterContactSearchByGroup(ctx context.Context, svc *people.Service, selector string, results []*people.SearchResult) ([]*people.SearchResult, error) {
+	group, err := resolveContactGroup(ctx, svc, selector)
+	if err != nil {
+		return nil, err
+	}
+	members, err := contactGroupMemberSet(ctx, svc, group)
+	if err != nil {
+		return nil, err
+	}
+	filtered := results[:0]
+	for _, r := range results {
+		if r != nil && r.Person != nil && members[r.Person.ResourceName] {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered, nil
+}
+
 func primaryName(p *people.Person) string {
 	if p == nil || len(p.Names) == 0 || p.Names[0] == nil {
 		return ""
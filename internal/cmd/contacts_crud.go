@@ -21,8 +21,9 @@ const (
 )
 
 type ContactsListCmd struct {
-	Max  int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
-	Page string `name:"page" help:"Page token"`
+	Max   int64  `name:"max" aliases:"limit" help:"Max results" default:"100"`
+	Page  string `name:"page" help:"Page token"`
+	Group string `name:"group" help:"Only list members of this contact group (contactGroups/... or name)"`
 }
 
 func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -40,11 +41,7 @@ func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
-	resp, err := svc.People.Connections.List(peopleMeResource).
-		PersonFields(contactsReadMask).
-		PageSize(c.Max).
-		PageToken(c.Page).
-		Do()
+	connections, nextPageToken, err := c.load(ctx, svc)
 	if err != nil {
 		return err
 	}
@@ -56,8 +53,8 @@ func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 			Phone    string `json:"phone,omitempty"`
 			Birthday string `json:"birthday,omitempty"`
 		}
-		items := make([]item, 0, len(resp.Connections))
-		for _, p := range resp.Connections {
+		items := make([]item, 0, len(connections))
+		for _, p := range connections {
 			if p == nil {
 				continue
 			}
@@ -71,10 +68,10 @@ func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 		}
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
 			"contacts":      items,
-			"nextPageToken": resp.NextPageToken,
+			"nextPageToken": nextPageToken,
 		})
 	}
-	if len(resp.Connections) == 0 {
+	if len(connections) == 0 {
 		u.Err().Println("No contacts")
 		return nil
 	}
@@ -82,16 +79,43 @@ func (c *ContactsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err := outfmt.WriteTable(
 		ctx,
 		stdoutWriter(ctx),
-		compactPeopleRows(resp.Connections),
+		compactPeopleRows(connections),
 		contactColumns(),
 	); err != nil {
 		return err
 	}
 
-	printNextPageHint(u, resp.NextPageToken)
+	printNextPageHint(u, nextPageToken)
 	return nil
 }
 
+func (c *ContactsListCmd) load(ctx context.Context, svc *people.Service) ([]*people.Person, string, error) {
+	if strings.TrimSpace(c.Group) == "" {
+		resp, err := svc.People.Connections.List(peopleMeResource).
+			PersonFields(contactsReadMask).
+			PageSize(c.Max).
+			PageToken(c.Page).
+			Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Connections, resp.NextPageToken, nil
+	}
+	if c.Page != "" {
+		return nil, "", usage("--page cannot be combined with --group")
+	}
+	group, err := resolveContactGroup(ctx, svc, c.Group)
+	if err != nil {
+		return nil, "", err
+	}
+	resources, err := contactGroupMemberResources(ctx, svc, group, c.Max)
+	if err != nil {
+		return nil, "", err
+	}
+	contacts, err := getContactsBatch(ctx, svc, resources, contactsReadMask)
+	return contacts, "", err
+}
+
 type ContactsGetCmd struct {
 	Identifier string `arg:"" name:"resourceName" help:"Resource name (people/...) or email"`
 }
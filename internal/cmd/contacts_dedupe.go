@@ -7,6 +7,7 @@ import (
 
 	"google.golang.org/api/people/v1"
 
+	"github.com/steipete/gogcli/internal/googleauth"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -66,7 +67,11 @@ func (c *ContactsDedupeCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return writeContactsDedupe(ctx, u, groups, len(contacts))
 	}
 
-	plans, err := prepareContactsDedupeApply(ctx, svc, groups, match)
+	batch, err := googleBatch(ctx, account, googleauth.ServiceContacts, svc.BasePath)
+	if err != nil {
+		return err
+	}
+	plans, err := prepareContactsDedupeApply(ctx, batch, groups, match)
 	if err != nil {
 		return err
 	}
@@ -79,11 +84,14 @@ func (c *ContactsDedupeCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return confirmErr
 	}
 
-	result, err := applyContactsDedupePlans(ctx, svc, len(contacts), plans)
+	result, err := applyContactsDedupePlans(ctx, batch, len(contacts), plans)
 	if err != nil {
 		return err
 	}
-	return writeContactsDedupeApplyResult(ctx, u, result)
+	if err := writeContactsDedupeApplyResult(ctx, u, result); err != nil {
+		return err
+	}
+	return result.err()
 }
 
 type contactsDedupeMatch struct {
@@ -148,6 +156,10 @@ func contactsDedupeGetResources(ctx context.Context, svc *people.Service, resour
 }
 
 func contactsDedupeList(ctx context.Context, svc *people.Service, maxResults int64) ([]*people.Person, error) {
+	return listContactSourceConnections(ctx, svc, maxResults, contactsReadMask)
+}
+
+func listContactSourceConnections(ctx context.Context, svc *people.Service, maxResults int64, personFields string) ([]*people.Person, error) {
 	var out []*people.Person
 	pageToken := ""
 	for {
@@ -156,11 +168,11 @@ func contactsDedupeList(ctx context.Context, svc *people.Service, maxResults int
 			pageSize = maxResults - int64(len(out))
 		}
 		resp, err := svc.People.Connections.List(peopleMeResource).
-			PersonFields(contactsReadMask).
+			PersonFields(personFields).
 			PageSize(pageSize).
 			PageToken(pageToken).
 			RequestSyncToken(false).
-			Sources("READ_SOURCE_TYPE_CONTACT").
+			Sources(contactsDedupeContactSource).
 			Context(ctx).
 			Do()
 		if err != nil {
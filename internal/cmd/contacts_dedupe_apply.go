@@ -4,12 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
 	"reflect"
 	"sort"
 	"strings"
 
 	"google.golang.org/api/people/v1"
 
+	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -55,6 +58,7 @@ type contactsDedupeApplyResult struct {
 	Plans           []contactsDedupeApplyPlan
 	GroupsMerged    int
 	ContactsDeleted int
+	Failures        []string
 }
 
 func contactsDedupeApplyReadMask() string {
@@ -63,17 +67,17 @@ func contactsDedupeApplyReadMask() string {
 
 func prepareContactsDedupeApply(
 	ctx context.Context,
-	svc *people.Service,
+	batch *googleapi.Batch,
 	groups []contactsDedupeGroup,
 	match contactsDedupeMatch,
 ) ([]contactsDedupeApplyPlan, error) {
-	plans := make([]contactsDedupeApplyPlan, 0, len(groups))
-	for i, group := range groups {
-		fresh, err := refreshContactsDedupeGroup(ctx, svc, group, match)
-		if err != nil {
-			return nil, fmt.Errorf("prepare duplicate group %d: %w", i+1, err)
-		}
-		plan, err := buildContactsDedupeApplyPlan(fresh)
+	fresh, err := refreshContactsDedupeGroups(ctx, batch, groups, match)
+	if err != nil {
+		return nil, err
+	}
+	plans := make([]contactsDedupeApplyPlan, 0, len(fresh))
+	for i, group := range fresh {
+		plan, err := buildContactsDedupeApplyPlan(group)
 		if err != nil {
 			return nil, fmt.Errorf("prepare duplicate group %d: %w", i+1, err)
 		}
@@ -82,34 +86,48 @@ func prepareContactsDedupeApply(
 	return plans, nil
 }
 
-func refreshContactsDedupeGroup(
+// refreshContactsDedupeGroups re-reads every member of every group in one
+// batch and checks that each group still forms the same duplicate set.
+func refreshContactsDedupeGroups(
 	ctx context.Context,
-	svc *people.Service,
-	group contactsDedupeGroup,
+	batch *googleapi.Batch,
+	groups []contactsDedupeGroup,
 	match contactsDedupeMatch,
-) (contactsDedupeGroup, error) {
-	members := make([]*people.Person, 0, len(group.Members))
-	for _, member := range group.Members {
-		resource := contactsDedupeResource(member)
-		if resource == "" {
-			return contactsDedupeGroup{}, fmt.Errorf("member is missing a resource name")
-		}
-		fresh, err := svc.People.Get(resource).
-			PersonFields(contactsDedupeApplyReadMask()).
-			Sources(contactsDedupeContactSource).
-			Context(ctx).
-			Do()
-		if err != nil {
-			return contactsDedupeGroup{}, wrapPeopleAPIError(err)
+) ([]contactsDedupeGroup, error) {
+	calls := make([]googleapi.BatchCall, 0, len(groups)*2)
+	for i, group := range groups {
+		for _, member := range group.Members {
+			resource := contactsDedupeResource(member)
+			if resource == "" {
+				return nil, fmt.Errorf("prepare duplicate group %d: member is missing a resource name", i+1)
+			}
+			calls = append(calls, contactsDedupeGetCall(resource, contactsDedupeApplyReadMask()))
 		}
-		members = append(members, fresh)
+	}
+	results, err := batch.Do(ctx, calls)
+	if err != nil {
+		return nil, err
 	}
 
-	freshGroups := buildContactsDedupeGroups(members, match)
-	if len(freshGroups) != 1 || len(freshGroups[0].Members) != len(members) {
-		return contactsDedupeGroup{}, fmt.Errorf("contacts changed and no longer form the same duplicate group; rerun without --apply to review")
+	fresh := make([]contactsDedupeGroup, 0, len(groups))
+	next := 0
+	for i, group := range groups {
+		members := make([]*people.Person, 0, len(group.Members))
+		for range group.Members {
+			var person people.Person
+			if err := results[next].Decode(&person); err != nil {
+				return nil, fmt.Errorf("prepare duplicate group %d: %w", i+1, wrapPeopleAPIError(err))
+			}
+			next++
+			members = append(members, &person)
+		}
+		freshGroups := buildContactsDedupeGroups(members, match)
+		if len(freshGroups) != 1 || len(freshGroups[0].Members) != len(members) {
+			return nil, fmt.Errorf("prepare duplicate group %d: contacts changed and no longer form the same duplicate group; rerun without --apply to review", i+1)
+		}
+		fresh = append(fresh, freshGroups[0])
 	}
-	return freshGroups[0], nil
+	return fresh, nil
 }
 
 func buildContactsDedupeApplyPlan(group contactsDedupeGroup) (contactsDedupeApplyPlan, error) {
@@ -373,59 +391,118 @@ func hasContactPhoto(person *people.Person) bool {
 	return false
 }
 
+// applyContactsDedupePlans runs in three batched phases: update every
+// primary, recheck the etags of the redundant contacts whose primary was
+// updated, then delete the ones that did not change since the preview. A
+// failed group is reported and skipped; the other groups still proceed.
 func applyContactsDedupePlans(
 	ctx context.Context,
-	svc *people.Service,
+	batch *googleapi.Batch,
 	scanned int,
 	plans []contactsDedupeApplyPlan,
 ) (contactsDedupeApplyResult, error) {
 	result := contactsDedupeApplyResult{Scanned: scanned, Plans: plans}
+
+	updates := make([]googleapi.BatchCall, 0, len(plans))
+	for _, plan := range plans {
+		updates = append(updates, googleapi.BatchCall{
+			Method: http.MethodPatch,
+			Path:   "v1/" + contactsDedupeResource(plan.Merged) + ":updateContact",
+			Query: url.Values{
+				"updatePersonFields": {strings.Join(plan.UpdateFields, ",")},
+				"personFields":       {"metadata"},
+				"sources":            {contactsDedupeContactSource},
+			},
+			Body: plan.Merged,
+		})
+	}
+	updated, err := batch.Do(ctx, updates)
+	if err != nil {
+		return result, err
+	}
+
+	type pendingDelete struct {
+		group     int
+		redundant *people.Person
+	}
+	var pending []pendingDelete
+	var rechecks []googleapi.BatchCall
+	merged := make([]bool, len(plans))
 	for index, plan := range plans {
-		resource := contactsDedupeResource(plan.Merged)
-		if _, err := svc.People.UpdateContact(resource, plan.Merged).
-			UpdatePersonFields(strings.Join(plan.UpdateFields, ",")).
-			PersonFields("metadata").
-			Sources(contactsDedupeContactSource).
-			Context(ctx).
-			Do(); err != nil {
-			return result, fmt.Errorf(
-				"contacts dedupe apply stopped after %d/%d groups and %d deletions: update primary %s: %w",
-				result.GroupsMerged, len(plans), result.ContactsDeleted, resource, wrapPeopleAPIError(err),
-			)
+		if updated[index].Err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("group %d: update primary %s: %v",
+				index+1, contactsDedupeResource(plan.Merged), wrapPeopleAPIError(updated[index].Err)))
+			continue
 		}
-
+		merged[index] = true
 		for _, redundant := range plan.Delete {
-			redundantResource := contactsDedupeResource(redundant)
-			latest, err := svc.People.Get(redundantResource).
-				PersonFields("metadata").
-				Sources(contactsDedupeContactSource).
-				Context(ctx).
-				Do()
-			if err != nil {
-				return result, fmt.Errorf(
-					"contacts dedupe apply stopped in group %d/%d after %d deletions: recheck %s: %w",
-					index+1, len(plans), result.ContactsDeleted, redundantResource, wrapPeopleAPIError(err),
-				)
-			}
-			if contactSourceETag(latest) != contactSourceETag(redundant) {
-				return result, fmt.Errorf(
-					"contacts dedupe apply stopped in group %d/%d after %d deletions: contact %s changed after preview and was not deleted; rerun the command",
-					index+1, len(plans), result.ContactsDeleted, redundantResource,
-				)
-			}
-			if _, err := svc.People.DeleteContact(redundantResource).Context(ctx).Do(); err != nil {
-				return result, fmt.Errorf(
-					"contacts dedupe apply stopped in group %d/%d after %d deletions: delete %s: %w",
-					index+1, len(plans), result.ContactsDeleted, redundantResource, wrapPeopleAPIError(err),
-				)
-			}
-			result.ContactsDeleted++
+			pending = append(pending, pendingDelete{group: index, redundant: redundant})
+			rechecks = append(rechecks, contactsDedupeGetCall(contactsDedupeResource(redundant), "metadata"))
+		}
+	}
+	latest, err := batch.Do(ctx, rechecks)
+	if err != nil {
+		return result, err
+	}
+
+	var deletes []pendingDelete
+	var deleteCalls []googleapi.BatchCall
+	for n, item := range pending {
+		resource := contactsDedupeResource(item.redundant)
+		var person people.Person
+		if err := latest[n].Decode(&person); err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("group %d: recheck %s: %v", item.group+1, resource, wrapPeopleAPIError(err)))
+			merged[item.group] = false
+			continue
+		}
+		if contactSourceETag(&person) != contactSourceETag(item.redundant) {
+			result.Failures = append(result.Failures, fmt.Sprintf("group %d: contact %s changed after preview and was not deleted; rerun the command", item.group+1, resource))
+			merged[item.group] = false
+			continue
+		}
+		deletes = append(deletes, item)
+		deleteCalls = append(deleteCalls, googleapi.BatchCall{Method: http.MethodDelete, Path: "v1/" + resource + ":deleteContact"})
+	}
+	deleted, err := batch.Do(ctx, deleteCalls)
+	if err != nil {
+		return result, err
+	}
+	for n, item := range deletes {
+		if deleted[n].Err != nil {
+			result.Failures = append(result.Failures, fmt.Sprintf("group %d: delete %s: %v",
+				item.group+1, contactsDedupeResource(item.redundant), wrapPeopleAPIError(deleted[n].Err)))
+			merged[item.group] = false
+			continue
+		}
+		result.ContactsDeleted++
+	}
+	for _, ok := range merged {
+		if ok {
+			result.GroupsMerged++
 		}
-		result.GroupsMerged++
 	}
 	return result, nil
 }
 
+func contactsDedupeGetCall(resource, personFields string) googleapi.BatchCall {
+	return googleapi.BatchCall{
+		Method: http.MethodGet,
+		Path:   "v1/" + resource,
+		Query:  url.Values{"personFields": {personFields}, "sources": {contactsDedupeContactSource}},
+	}
+}
+
+// err summarizes the groups that were not fully merged.
+func (r contactsDedupeApplyResult) err() error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	return fmt.Errorf(
+		"contacts dedupe apply merged %d/%d groups and deleted %d contacts; %d problem(s): %s",
+		r.GroupsMerged, len(r.Plans), r.ContactsDeleted, len(r.Failures), strings.Join(r.Failures, "; "),
+	)
+}
+
 func contactsDedupeDeleteCount(plans []contactsDedupeApplyPlan) int {
 	total := 0
 	for _, plan := range plans {
@@ -466,6 +543,9 @@ func writeContactsDedupeApplyResult(ctx context.Context, u *ui.UI, result contac
 	payload["applied"] = true
 	payload["groups_merged"] = result.GroupsMerged
 	payload["contacts_deleted"] = result.ContactsDeleted
+	if len(result.Failures) > 0 {
+		payload["failures"] = result.Failures
+	}
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload)
 	}
@@ -86,7 +86,7 @@ func TestContactsDedupeApplyExecuteJSON(t *testing.T) {
 	var updateMask string
 	var deleted []string
 
-	svc, closeSrv := newPeopleService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svc, closeSrv := newPeopleService(t, serveGoogleBatch(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodGet && r.URL.Path == "/v1/people/me/connections":
 			if got := r.URL.Query()["sources"]; !reflect.DeepEqual(got, []string{contactsDedupeContactSource}) {
@@ -113,13 +113,13 @@ func TestContactsDedupeApplyExecuteJSON(t *testing.T) {
 		default:
 			http.NotFound(w, r)
 		}
-	}))
+	})))
 	defer closeSrv()
 
 	result := executeWithPeopleTestServices(
 		t,
 		[]string{"--json", "--account", "a@example.com", "--force", "contacts", "dedupe", "--apply"},
-		peopleTestServices{Contacts: fixedPeopleTestService(svc)},
+		peopleTestServices{Contacts: fixedPeopleTestService(svc), Batch: googleBatchTestClient},
 	)
 	if result.err != nil {
 		t.Fatalf("Execute: %v\nstdout=%s\nstderr=%s", result.err, result.stdout, result.stderr)
@@ -153,7 +153,7 @@ func TestContactsDedupeApplyExecuteJSON(t *testing.T) {
 
 func TestContactsDedupeApplyDryRunSkipsMutations(t *testing.T) {
 	mutated := false
-	svc, closeSrv := newPeopleService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svc, closeSrv := newPeopleService(t, serveGoogleBatch(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodGet && r.URL.Path == "/v1/people/me/connections":
 			writeDedupeConnections(t, w)
@@ -165,13 +165,13 @@ func TestContactsDedupeApplyDryRunSkipsMutations(t *testing.T) {
 			mutated = true
 			http.NotFound(w, r)
 		}
-	}))
+	})))
 	defer closeSrv()
 
 	result := executeWithPeopleTestServices(
 		t,
 		[]string{"--json", "--account", "a@example.com", "--dry-run", "contacts", "dedupe", "--apply"},
-		peopleTestServices{Contacts: fixedPeopleTestService(svc)},
+		peopleTestServices{Contacts: fixedPeopleTestService(svc), Batch: googleBatchTestClient},
 	)
 	if ExitCode(result.err) != 0 {
 		t.Fatalf("Execute: %v\nstdout=%s\nstderr=%s", result.err, result.stdout, result.stderr)
@@ -198,7 +198,7 @@ func TestContactsDedupeApplyDryRunSkipsMutations(t *testing.T) {
 
 func TestContactsDedupeApplyRetainsChangedContact(t *testing.T) {
 	deleted := false
-	svc, closeSrv := newPeopleService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	svc, closeSrv := newPeopleService(t, serveGoogleBatch(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch {
 		case r.Method == http.MethodGet && r.URL.Path == "/v1/people/me/connections":
 			writeDedupeConnections(t, w)
@@ -216,13 +216,13 @@ func TestContactsDedupeApplyRetainsChangedContact(t *testing.T) {
 		default:
 			http.NotFound(w, r)
 		}
-	}))
+	})))
 	defer closeSrv()
 
 	result := executeWithPeopleTestServices(
 		t,
 		[]string{"--account", "a@example.com", "--force", "contacts", "dedupe", "--apply"},
-		peopleTestServices{Contacts: fixedPeopleTestService(svc)},
+		peopleTestServices{Contacts: fixedPeopleTestService(svc), Batch: googleBatchTestClient},
 	)
 	if result.err == nil || !strings.Contains(result.err.Error(), "changed after preview and was not deleted") {
 		t.Fatalf("error = %v", result.err)
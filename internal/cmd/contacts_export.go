@@ -22,6 +22,7 @@ type ContactsExportCmd struct {
 	Max      int64  `name:"max" aliases:"limit" help:"Max results for --query (1-30)" default:"30"`
 	PageSize int64  `name:"page-size" help:"Page size for --all (1-1000)" default:"1000"`
 	Page     string `name:"page" help:"Start page token for --all"`
+	Group    string `name:"group" help:"Export all members of this contact group (contactGroups/... or name); combine with --query to filter, not with --all"`
 }
 
 func (c *ContactsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -42,6 +43,7 @@ func (c *ContactsExportCmd) Run(ctx context.Context, flags *RootFlags) error {
 		"max":       c.Max,
 		"page_size": c.PageSize,
 		"page":      strings.TrimSpace(c.Page),
+		"group":     strings.TrimSpace(c.Group),
 	}); dryRunErr != nil {
 		return dryRunErr
 	}
@@ -96,8 +98,18 @@ func (c *ContactsExportCmd) validate() error {
 	if c.All {
 		selectors++
 	}
-	if selectors != 1 {
-		return usage("provide exactly one of selector, --query, or --all")
+	group := strings.TrimSpace(c.Group) != ""
+	if group && strings.TrimSpace(c.Selector) != "" {
+		return usage("--group cannot be combined with a selector")
+	}
+	if group && c.All {
+		return usage("--all cannot be combined with --group; --group already exports every member")
+	}
+	if group && strings.TrimSpace(c.Page) != "" {
+		return usage("--page cannot be combined with --group")
+	}
+	if selectors > 1 || selectors == 0 && !group {
+		return usage("provide exactly one of selector, --query, --all, or --group")
 	}
 	if c.Max < 1 || c.Max > 30 {
 		return usage("--max must be between 1 and 30")
@@ -112,13 +124,16 @@ func (c *ContactsExportCmd) validate() error {
 }
 
 func (c *ContactsExportCmd) loadContacts(ctx context.Context, svc *people.Service) ([]*people.Person, error) {
+	if strings.TrimSpace(c.Group) != "" {
+		return c.loadGroupContacts(ctx, svc)
+	}
 	switch {
 	case c.All:
 		return c.loadAllContacts(ctx, svc)
 	case strings.TrimSpace(c.Query) != "":
-		return c.searchContacts(ctx, svc, strings.TrimSpace(c.Query), c.Max)
+		return searchExportContacts(ctx, svc, strings.TrimSpace(c.Query), c.Max)
 	default:
-		return c.loadSelectedContact(ctx, svc, strings.TrimSpace(c.Selector))
+		return loadSelectedExportContact(ctx, svc, strings.TrimSpace(c.Selector))
 	}
 }
 
@@ -140,7 +155,30 @@ func (c *ContactsExportCmd) loadAllContacts(ctx context.Context, svc *people.Ser
 	return contacts, err
 }
 
-func (c *ContactsExportCmd) searchContacts(ctx context.Context, svc *people.Service, query string, limit int64) ([]*people.Person, error) {
+func (c *ContactsExportCmd) loadGroupContacts(ctx context.Context, svc *people.Service) ([]*people.Person, error) {
+	group, err := resolveContactGroup(ctx, svc, c.Group)
+	if err != nil {
+		return nil, err
+	}
+	if query := strings.TrimSpace(c.Query); query != "" {
+		contacts, searchErr := searchExportContacts(ctx, svc, query, c.Max)
+		if searchErr != nil {
+			return nil, searchErr
+		}
+		members, memberErr := contactGroupMemberSet(ctx, svc, group)
+		if memberErr != nil {
+			return nil, memberErr
+		}
+		return filterContactsByMembers(contacts, members), nil
+	}
+	resources, err := contactGroupMemberResources(ctx, svc, group, 0)
+	if err != nil {
+		return nil, err
+	}
+	return getContactsBatch(ctx, svc, resources, contactsExportReadMask)
+}
+
+func searchExportContacts(ctx context.Context, svc *people.Service, query string, limit int64) ([]*people.Person, error) {
 	warmSearchContactsCache(ctx, svc)
 	resp, err := svc.People.SearchContacts().
 		Query(query).
@@ -160,7 +198,7 @@ func (c *ContactsExportCmd) searchContacts(ctx context.Context, svc *people.Serv
 	return contacts, nil
 }
 
-func (c *ContactsExportCmd) loadSelectedContact(ctx context.Context, svc *people.Service, selector string) ([]*people.Person, error) {
+func loadSelectedExportContact(ctx context.Context, svc *people.Service, selector string) ([]*people.Person, error) {
 	if strings.HasPrefix(selector, "people/") {
 		p, err := svc.People.Get(selector).
 			PersonFields(contactsExportReadMask).
@@ -172,7 +210,7 @@ func (c *ContactsExportCmd) loadSelectedContact(ctx context.Context, svc *people
 		return []*people.Person{p}, nil
 	}
 
-	contacts, err := c.searchContacts(ctx, svc, selector, 30)
+	contacts, err := searchExportContacts(ctx, svc, selector, 30)
 	if err != nil {
 		return nil, err
 	}
@@ -231,32 +269,18 @@ func contactsHaveGroupMemberships(contacts []*people.Person) bool {
 }
 
 func fetchExportContactGroups(ctx context.Context, svc *people.Service) (map[string]string, error) {
+	groups, err := listContactGroups(ctx, svc)
+	if err != nil {
+		return nil, err
+	}
 	out := map[string]string{}
-	pageToken := ""
-	for {
-		call := svc.ContactGroups.List().
-			PageSize(1000).
-			GroupFields("groupType,metadata,name").
-			Context(ctx)
-		if pageToken != "" {
-			call = call.PageToken(pageToken)
-		}
-		resp, err := call.Do()
-		if err != nil {
-			return nil, err
-		}
-		for _, group := range resp.ContactGroups {
-			if group == nil || group.GroupType != "USER_CONTACT_GROUP" || group.Name == "" {
-				continue
-			}
-			if group.ResourceName != "" {
-				out[group.ResourceName] = group.Name
-			}
+	for _, group := range groups {
+		if group.GroupType != contactGroupTypeUser || group.Name == "" {
+			continue
 		}
-		if resp.NextPageToken == "" {
-			break
+		if group.ResourceName != "" {
+			out[group.ResourceName] = group.Name
 		}
-		pageToken = resp.NextPageToken
 	}
 	return out, nil
 }
@@ -150,6 +150,13 @@ func TestContactsExport_AmbiguousSelectorErrors(t *testing.T) {
 	}
 }
 
+func TestContactsExport_RejectsAllWithGroup(t *testing.T) {
+	err := runKong(t, &ContactsExportCmd{}, []string{"--group", "Vendors", "--all"}, context.Background(), &RootFlags{Account: "a@b.com"})
+	if err == nil || !strings.Contains(err.Error(), "--all cannot be combined with --group") {
+		t.Fatalf("expected usage error, got %v", err)
+	}
+}
+
 func TestWriteContactVCard_EscapesStructuredFieldsAndFolds(t *testing.T) {
 	var b strings.Builder
 	p := &people.Person{
@@ -0,0 +1,465 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	contactGroupTypeUser      = "USER_CONTACT_GROUP"
+	contactGroupFields        = "groupType,memberCount,metadata,name"
+	contactGroupResourcePfx   = "contactGroups/"
+	contactGroupModifyLimit   = 1000 // People API cap for contactGroups.members.modify
+	contactsBatchGetLimit     = 200  // People API cap for people.getBatchGet
+	contactGroupMembersAllMax = 100000
+)
+
+type ContactsGroupsCmd struct {
+	List    ContactsGroupsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List contact groups (labels)"`
+	Create  ContactsGroupsCreateCmd `cmd:"" name:"create" aliases:"add,new" help:"Create a contact group"`
+	Rename  ContactsGroupsRenameCmd `cmd:"" name:"rename" help:"Rename a contact group"`
+	Delete  ContactsGroupsDeleteCmd `cmd:"" name:"delete" aliases:"rm,del" help:"Delete a contact group"`
+	Members ContactsGroupMembersCmd `cmd:"" name:"members" help:"Add or remove contact group members"`
+}
+
+type ContactsGroupMembersCmd struct {
+	Add    ContactsGroupMembersAddCmd    `cmd:"" name:"add" help:"Add contacts to a group"`
+	Remove ContactsGroupMembersRemoveCmd `cmd:"" name:"remove" aliases:"rm" help:"Remove contacts from a group"`
+}
+
+type ContactsGroupsListCmd struct {
+	UserOnly bool `name:"user-only" help:"Hide system groups (myContacts, starred, ...)"`
+}
+
+func (c *ContactsGroupsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := peopleContactsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	groups, err := listContactGroups(ctx, svc)
+	if err != nil {
+		return wrapPeopleAPIError(err)
+	}
+	if c.UserOnly {
+		filtered := groups[:0]
+		for _, group := range groups {
+			if group.GroupType == contactGroupTypeUser {
+				filtered = append(filtered, group)
+			}
+		}
+		groups = filtered
+	}
+
+	if outfmt.IsJSON(ctx) {
+		items := make([]map[string]any, 0, len(groups))
+		for _, group := range groups {
+			items = append(items, contactGroupJSON(group))
+		}
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"groups": items})
+	}
+	if len(groups) == 0 {
+		u.Err().Println("No contact groups")
+		return nil
+	}
+	return outfmt.WriteTable(ctx, stdoutWriter(ctx), groups, []outfmt.Column[*people.ContactGroup]{
+		{Header: "RESOURCE", Value: func(g *people.ContactGroup) string { return g.ResourceName }},
+		{Header: "NAME", Value: func(g *people.ContactGroup) string { return sanitizeTab(contactGroupDisplayName(g)) }},
+		{Header: "TYPE", Value: func(g *people.ContactGroup) string { return contactGroupTypeLabel(g) }},
+		{Header: "MEMBERS", Value: func(g *people.ContactGroup) string { return fmt.Sprintf("%d", g.MemberCount) }},
+	})
+}
+
+type ContactsGroupsCreateCmd struct {
+	Name string `arg:"" name:"name" help:"Group name"`
+}
+
+func (c *ContactsGroupsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return usage("empty group name")
+	}
+	if err := dryRunExit(ctx, flags, "contacts.groups.create", map[string]any{"name": name}); err != nil {
+		return err
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := peopleContactsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	group, err := svc.ContactGroups.Create(&people.CreateContactGroupRequest{
+		ContactGroup:    &people.ContactGroup{Name: name},
+		ReadGroupFields: contactGroupFields,
+	}).Context(ctx).Do()
+	if err != nil {
+		return wrapPeopleAPIError(err)
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"group": contactGroupJSON(group)})
+	}
+	return writeResult(ctx, u, kv("resource", group.ResourceName), kv("name", group.Name))
+}
+
+type ContactsGroupsRenameCmd struct {
+	Group string `arg:"" name:"group" help:"Group resource name (contactGroups/...) or name"`
+	Name  string `arg:"" name:"newName" help:"New group name"`
+}
+
+func (c *ContactsGroupsRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	name := strings.TrimSpace(c.Name)
+	if name == "" {
+		return usage("empty group name")
+	}
+	if err := dryRunExit(ctx, flags, "contacts.groups.rename", map[string]any{
+		"group": strings.TrimSpace(c.Group),
+		"name":  name,
+	}); err != nil {
+		return err
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := peopleContactsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	group, err := resolveContactGroup(ctx, svc, c.Group)
+	if err != nil {
+		return err
+	}
+	if group.GroupType != contactGroupTypeUser {
+		return usagef("cannot rename system group %s", group.ResourceName)
+	}
+	updated, err := svc.ContactGroups.Update(group.ResourceName, &people.UpdateContactGroupRequest{
+		ContactGroup:      &people.ContactGroup{Name: name, Etag: group.Etag},
+		UpdateGroupFields: "name",
+		ReadGroupFields:   contactGroupFields,
+	}).Context(ctx).Do()
+	if err != nil {
+		return wrapPeopleAPIError(err)
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"group": contactGroupJSON(updated)})
+	}
+	return writeResult(ctx, u, kv("resource", updated.ResourceName), kv("name", updated.Name))
+}
+
+type ContactsGroupsDeleteCmd struct {
+	Group          string `arg:"" name:"group" help:"Group resource name (contactGroups/...) or name"`
+	DeleteContacts bool   `name:"delete-contacts" help:"Also delete every contact in the group"`
+}
+
+func (c *ContactsGroupsDeleteCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	selector := strings.TrimSpace(c.Group)
+	action := fmt.Sprintf("delete contact group %s", selector)
+	if c.DeleteContacts {
+		action += " and all of its contacts"
+	}
+	if err := dryRunAndConfirmDestructive(ctx, flags, "contacts.groups.delete", map[string]any{
+		"group":           selector,
+		"delete_contacts": c.DeleteContacts,
+	}, action); err != nil {
+		return err
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := peopleContactsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	group, err := resolveContactGroup(ctx, svc, selector)
+	if err != nil {
+		return err
+	}
+	if group.GroupType != contactGroupTypeUser {
+		return usagef("cannot delete system group %s", group.ResourceName)
+	}
+	if _, err := svc.ContactGroups.Delete(group.ResourceName).DeleteContacts(c.DeleteContacts).Context(ctx).Do(); err != nil {
+		return wrapPeopleAPIError(err)
+	}
+	return writeDeleteResult(ctx, u, group.ResourceName)
+}
+
+type ContactsGroupMembersAddCmd struct {
+	Group    string   `arg:"" name:"group" help:"Group resource name (contactGroups/...) or name"`
+	Contacts []string `arg:"" name:"contact" help:"Contact resource names (people/...) or emails"`
+}
+
+func (c *ContactsGroupMembersAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return runContactGroupMembersModify(ctx, flags, c.Group, c.Contacts, true)
+}
+
+type ContactsGroupMembersRemoveCmd struct {
+	Group    string   `arg:"" name:"group" help:"Group resource name (contactGroups/...) or name"`
+	Contacts []string `arg:"" name:"contact" help:"Contact resource names (people/...) or emails"`
+}
+
+func (c *ContactsGroupMembersRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	return runContactGroupMembersModify(ctx, flags, c.Group, c.Contacts, false)
+}
+
+func runContactGroupMembersModify(ctx context.Context, flags *RootFlags, groupSelector string, selectors []string, add bool) error {
+	u := ui.FromContext(ctx)
+	op, key := "contacts.groups.members.remove", "removed"
+	if add {
+		op, key = "contacts.groups.members.add", "added"
+	}
+	if len(selectors) == 0 {
+		return usage("no contacts given")
+	}
+	if err := dryRunExit(ctx, flags, op, map[string]any{
+		"group":    strings.TrimSpace(groupSelector),
+		"contacts": selectors,
+	}); err != nil {
+		return err
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := peopleContactsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	group, err := resolveContactGroup(ctx, svc, groupSelector)
+	if err != nil {
+		return err
+	}
+	resources, err := resolveContactResources(ctx, svc, selectors)
+	if err != nil {
+		return err
+	}
+
+	var notFound, lastGroup []string
+	for start := 0; start < len(resources); start += contactGroupModifyLimit {
+		chunk := resources[start:min(start+contactGroupModifyLimit, len(resources))]
+		req := &people.ModifyContactGroupMembersRequest{}
+		if add {
+			req.ResourceNamesToAdd = chunk
+		} else {
+			req.ResourceNamesToRemove = chunk
+		}
+		resp, modifyErr := svc.ContactGroups.Members.Modify(group.ResourceName, req).Context(ctx).Do()
+		if modifyErr != nil {
+			return wrapPeopleAPIError(modifyErr)
+		}
+		notFound = append(notFound, resp.NotFoundResourceNames...)
+		lastGroup = append(lastGroup, resp.CanNotRemoveLastContactGroupResourceNames...)
+	}
+
+	changed := contactResourcesWithout(resources, append(append([]string{}, notFound...), lastGroup...))
+	if outfmt.IsJSON(ctx) {
+		payload := map[string]any{"group": group.ResourceName, key: changed}
+		if len(notFound) > 0 {
+			payload["notFound"] = notFound
+		}
+		if len(lastGroup) > 0 {
+			payload["lastGroup"] = lastGroup
+		}
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload)
+	}
+	kvs := []resultKV{kv("group", group.ResourceName), kv(key, len(changed))}
+	if len(notFound) > 0 {
+		kvs = append(kvs, kv("not_found", strings.Join(notFound, ",")))
+	}
+	if len(lastGroup) > 0 {
+		kvs = append(kvs, kv("last_group", strings.Join(lastGroup, ",")))
+	}
+	return writeResult(ctx, u, kvs...)
+}
+
+func listContactGroups(ctx context.Context, svc *people.Service) ([]*people.ContactGroup, error) {
+	var out []*people.ContactGroup
+	pageToken := ""
+	for {
+		call := svc.ContactGroups.List().
+			PageSize(1000).
+			GroupFields(contactGroupFields).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range resp.ContactGroups {
+			if group != nil {
+				out = append(out, group)
+			}
+		}
+		if resp.NextPageToken == "" {
+			return out, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// resolveContactGroup accepts a contactGroups/... resource name, a group name,
+// or a system group's formatted name ("My Contacts"), case-insensitively.
+func resolveContactGroup(ctx context.Context, svc *people.Service, selector string) (*people.ContactGroup, error) {
+	selector = strings.TrimSpace(selector)
+	if selector == "" {
+		return nil, usage("empty contact group")
+	}
+	if strings.HasPrefix(selector, contactGroupResourcePfx) {
+		group, err := svc.ContactGroups.Get(selector).GroupFields(contactGroupFields).Context(ctx).Do()
+		if err != nil {
+			return nil, wrapPeopleAPIError(err)
+		}
+		return group, nil
+	}
+	groups, err := listContactGroups(ctx, svc)
+	if err != nil {
+		return nil, wrapPeopleAPIError(err)
+	}
+	var matches []*people.ContactGroup
+	for _, group := range groups {
+		if strings.EqualFold(group.Name, selector) || strings.EqualFold(group.FormattedName, selector) {
+			matches = append(matches, group)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, usagef("contact group %q not found", selector)
+	case 1:
+		return matches[0], nil
+	default:
+		return nil, usagef("contact group %q is ambiguous (%d matches); use contactGroups/...", selector, len(matches))
+	}
+}
+
+func contactGroupMemberResources(ctx context.Context, svc *people.Service, group *people.ContactGroup, limit int64) ([]string, error) {
+	if limit <= 0 {
+		limit = max(group.MemberCount, 1)
+		limit = min(limit, contactGroupMembersAllMax)
+	}
+	full, err := svc.ContactGroups.Get(group.ResourceName).MaxMembers(limit).Context(ctx).Do()
+	if err != nil {
+		return nil, wrapPeopleAPIError(err)
+	}
+	return full.MemberResourceNames, nil
+}
+
+func contactGroupMemberSet(ctx context.Context, svc *people.Service, group *people.ContactGroup) (map[string]bool, error) {
+	resources, err := contactGroupMemberResources(ctx, svc, group, 0)
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		set[resource] = true
+	}
+	return set, nil
+}
+
+func getContactsBatch(ctx context.Context, svc *people.Service, resources []string, personFields string) ([]*people.Person, error) {
+	out := make([]*people.Person, 0, len(resources))
+	for start := 0; start < len(resources); start += contactsBatchGetLimit {
+		chunk := resources[start:min(start+contactsBatchGetLimit, len(resources))]
+		resp, err := svc.People.GetBatchGet().
+			ResourceNames(chunk...).
+			PersonFields(personFields).
+			Context(ctx).
+			Do()
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Responses {
+			if r != nil && r.Person != nil {
+				out = append(out, r.Person)
+			}
+		}
+	}
+	return out, nil
+}
+
+func filterContactsByMembers(contacts []*people.Person, members map[string]bool) []*people.Person {
+	out := contacts[:0]
+	for _, p := range contacts {
+		if p != nil && members[p.ResourceName] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func resolveContactResources(ctx context.Context, svc *people.Service, selectors []string) ([]string, error) {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(selectors))
+	for _, selector := range selectors {
+		selector = strings.TrimSpace(selector)
+		resource := selector
+		if !strings.HasPrefix(selector, "people/") {
+			if !strings.Contains(selector, "@") {
+				return nil, usagef("invalid contact %q (expected people/... or an email)", selector)
+			}
+			contacts, err := loadSelectedExportContact(ctx, svc, selector)
+			if err != nil {
+				return nil, wrapPeopleAPIError(err)
+			}
+			if len(contacts) == 0 {
+				return nil, usagef("no contact found for %q", selector)
+			}
+			resource = contacts[0].ResourceName
+		}
+		if !seen[resource] {
+			seen[resource] = true
+			out = append(out, resource)
+		}
+	}
+	return out, nil
+}
+
+func contactResourcesWithout(resources, drop []string) []string {
+	skip := map[string]bool{}
+	for _, resource := range drop {
+		skip[resource] = true
+	}
+	out := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if !skip[resource] {
+			out = append(out, resource)
+		}
+	}
+	return out
+}
+
+func contactGroupJSON(group *people.ContactGroup) map[string]any {
+	return map[string]any{
+		"resource":    group.ResourceName,
+		"name":        contactGroupDisplayName(group),
+		"type":        contactGroupTypeLabel(group),
+		"memberCount": group.MemberCount,
+	}
+}
+
+func contactGroupDisplayName(group *people.ContactGroup) string {
+	return firstNonEmpty(group.FormattedName, group.Name)
+}
+
+func contactGroupTypeLabel(group *people.ContactGroup) string {
+	if group.GroupType == contactGroupTypeUser {
+		return "user"
+	}
+	return "system"
+}
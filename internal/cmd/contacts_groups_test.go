@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/people/v1"
+)
+
+type contactGroupsTestServer struct {
+	mu       sync.Mutex
+	created  people.CreateContactGroupRequest
+	updated  people.UpdateContactGroupRequest
+	modified people.ModifyContactGroupMembersRequest
+	deleted  []string
+	batchGet []string
+}
+
+func (s *contactGroupsTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/contactGroups":
+			_ = json.NewEncoder(w).Encode(map[string]any{"contactGroups": []map[string]any{
+				{"resourceName": "contactGroups/myContacts", "name": "myContacts", "formattedName": "My Contacts", "groupType": "SYSTEM_CONTACT_GROUP", "memberCount": 3},
+				{"resourceName": "contactGroups/vendors", "name": "Vendors", "formattedName": "Vendors", "groupType": "USER_CONTACT_GROUP", "memberCount": 2, "etag": "g-etag"},
+			}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/contactGroups/vendors":
+			if r.URL.Query().Get("maxMembers") == "" {
+				_ = json.NewEncoder(w).Encode(map[string]any{
+					"resourceName": "contactGroups/vendors", "name": "Vendors", "groupType": "USER_CONTACT_GROUP", "memberCount": 2,
+				})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"resourceName":        "contactGroups/vendors",
+				"memberResourceNames": []string{"people/1", "people/2"},
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/contactGroups":
+			_ = json.NewDecoder(r.Body).Decode(&s.created)
+			_ = json.NewEncoder(w).Encode(map[string]any{"resourceName": "contactGroups/board", "name": s.created.ContactGroup.Name, "groupType": "USER_CONTACT_GROUP"})
+		case r.Method == http.MethodPut && r.URL.Path == "/v1/contactGroups/vendors":
+			_ = json.NewDecoder(r.Body).Decode(&s.updated)
+			_ = json.NewEncoder(w).Encode(map[string]any{"resourceName": "contactGroups/vendors", "name": s.updated.ContactGroup.Name, "groupType": "USER_CONTACT_GROUP"})
+		case r.Method == http.MethodDelete && r.URL.Path == "/v1/contactGroups/vendors":
+			s.deleted = append(s.deleted, r.URL.Query().Get("deleteContacts"))
+			_, _ = w.Write([]byte(`{}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/contactGroups/vendors/members:modify":
+			_ = json.NewDecoder(r.Body).Decode(&s.modified)
+			_ = json.NewEncoder(w).Encode(map[string]any{"notFoundResourceNames": []string{"people/missing"}})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/people:batchGet":
+			s.batchGet = r.URL.Query()["resourceNames"]
+			var responses []map[string]any
+			for _, resource := range s.batchGet {
+				responses = append(responses, map[string]any{"person": map[string]any{
+					"resourceName":   resource,
+					"names":          []map[string]any{{"displayName": "Vendor " + strings.TrimPrefix(resource, "people/")}},
+					"emailAddresses": []map[string]any{{"value": strings.TrimPrefix(resource, "people/") + "@vendor.example"}},
+				}})
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"responses": responses})
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/people:searchContacts":
+			_ = json.NewEncoder(w).Encode(map[string]any{"results": []map[string]any{
+				{"person": map[string]any{"resourceName": "people/1", "names": []map[string]any{{"displayName": "Vendor 1"}}}},
+				{"person": map[string]any{"resourceName": "people/9", "names": []map[string]any{{"displayName": "Vendor 9"}}}},
+			}})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func runContactGroupsTest(t *testing.T, srv *contactGroupsTestServer, args ...string) executeTestResult {
+	t.Helper()
+	svc, closeSrv := newPeopleService(t, srv.handler(t))
+	t.Cleanup(closeSrv)
+	result := executeWithPeopleTestServices(t, append([]string{"--json", "--account", "a@example.com"}, args...),
+		peopleTestServices{Contacts: fixedPeopleTestService(svc)})
+	if result.err != nil {
+		t.Fatalf("Execute %v: %v\nstdout=%s\nstderr=%s", args, result.err, result.stdout, result.stderr)
+	}
+	return result
+}
+
+func TestContactsGroupsListAndCreate(t *testing.T) {
+	srv := &contactGroupsTestServer{}
+	result := runContactGroupsTest(t, srv, "contacts", "groups", "list", "--user-only")
+	var listed struct {
+		Groups []map[string]any `json:"groups"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &listed); err != nil {
+		t.Fatalf("decode: %v\n%s", err, result.stdout)
+	}
+	if len(listed.Groups) != 1 || listed.Groups[0]["name"] != "Vendors" || listed.Groups[0]["type"] != "user" {
+		t.Fatalf("groups = %#v", listed.Groups)
+	}
+
+	runContactGroupsTest(t, srv, "contacts", "groups", "create", "Board")
+	if srv.created.ContactGroup == nil || srv.created.ContactGroup.Name != "Board" {
+		t.Fatalf("create request = %#v", srv.created)
+	}
+}
+
+func TestContactsGroupsRenameAndDelete(t *testing.T) {
+	srv := &contactGroupsTestServer{}
+	runContactGroupsTest(t, srv, "contacts", "groups", "rename", "vendors", "Suppliers")
+	if srv.updated.UpdateGroupFields != "name" || srv.updated.ContactGroup.Name != "Suppliers" || srv.updated.ContactGroup.Etag != "g-etag" {
+		t.Fatalf("update request = %#v", srv.updated)
+	}
+
+	runContactGroupsTest(t, srv, "--force", "contacts", "groups", "delete", "Vendors")
+	if !reflect.DeepEqual(srv.deleted, []string{"false"}) {
+		t.Fatalf("deleted = %#v", srv.deleted)
+	}
+
+	svc, closeSrv := newPeopleService(t, srv.handler(t))
+	defer closeSrv()
+	result := executeWithPeopleTestServices(t, []string{"--account", "a@example.com", "--force", "contacts", "groups", "delete", "My Contacts"},
+		peopleTestServices{Contacts: fixedPeopleTestService(svc)})
+	if result.err == nil || !strings.Contains(result.err.Error(), "system group") {
+		t.Fatalf("error = %v, want system group refusal", result.err)
+	}
+}
+
+func TestContactsGroupMembersAdd(t *testing.T) {
+	srv := &contactGroupsTestServer{}
+	result := runContactGroupsTest(t, srv, "contacts", "groups", "members", "add", "Vendors", "people/3", "people/missing", "people/3")
+	if !reflect.DeepEqual(srv.modified.ResourceNamesToAdd, []string{"people/3", "people/missing"}) {
+		t.Fatalf("modify request = %#v", srv.modified)
+	}
+	var payload struct {
+		Added    []string `json:"added"`
+		NotFound []string `json:"notFound"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, result.stdout)
+	}
+	if !reflect.DeepEqual(payload.Added, []string{"people/3"}) || !reflect.DeepEqual(payload.NotFound, []string{"people/missing"}) {
+		t.Fatalf("payload = %#v", payload)
+	}
+}
+
+func TestContactsGroupFilters(t *testing.T) {
+	srv := &contactGroupsTestServer{}
+	result := runContactGroupsTest(t, srv, "contacts", "list", "--group", "Vendors")
+	if !reflect.DeepEqual(srv.batchGet, []string{"people/1", "people/2"}) {
+		t.Fatalf("batchGet = %#v", srv.batchGet)
+	}
+	if !strings.Contains(result.stdout, "1@vendor.example") || !strings.Contains(result.stdout, "2@vendor.example") {
+		t.Fatalf("list output = %s", result.stdout)
+	}
+
+	result = runContactGroupsTest(t, srv, "contacts", "search", "vendor", "--group", "contactGroups/vendors")
+	if !strings.Contains(result.stdout, "people/1") || strings.Contains(result.stdout, "people/9") {
+		t.Fatalf("search output = %s", result.stdout)
+	}
+
+	out := filepath.Join(t.TempDir(), "vendors.vcf")
+	runContactGroupsTest(t, srv, "contacts", "export", "--group", "Vendors", "--out", out)
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("read export: %v", err)
+	}
+	if got := strings.Count(string(data), "BEGIN:VCARD"); got != 2 {
+		t.Fatalf("exported %d cards:\n%s", got, data)
+	}
+}
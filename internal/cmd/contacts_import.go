@@ -0,0 +1,501 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	contactsImportReadMask  = "names,nicknames,emailAddresses,phoneNumbers,addresses,birthdays,organizations,urls,biographies,metadata"
+	contactsImportBatchSize = 200 // People API cap for batchCreateContacts/batchUpdateContacts
+
+	contactsImportCreate    = "create"
+	contactsImportUpdate    = "update"
+	contactsImportSkip      = "skip"
+	contactsImportUnchanged = "unchanged"
+	contactsImportMerged    = "merged"
+	contactsImportInvalid   = "invalid"
+)
+
+type ContactsImportCmd struct {
+	File           string `arg:"" name:"file" help:"vCard (.vcf) or Google/Outlook CSV file, or - for stdin"`
+	Format         string `name:"format" help:"Input format: auto|vcf|csv" default:"auto" enum:"auto,vcf,vcard,csv"`
+	Match          string `name:"match" help:"Match existing contacts on: email,phone,name" default:"email,phone"`
+	UpdateExisting bool   `name:"update-existing" help:"Merge imported fields into matching contacts instead of skipping them"`
+}
+
+func (c *ContactsImportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	match, err := parseContactsDedupeMatch(c.Match)
+	if err != nil {
+		return err
+	}
+	format, records, err := readContactsImportFile(ctx, c.File, c.Format)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return usage("no contacts found in import file")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := peopleContactsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	existing, err := listContactSourceConnections(ctx, svc, 0, contactsImportReadMask)
+	if err != nil {
+		return wrapPeopleAPIError(err)
+	}
+
+	plan, err := buildContactsImportPlan(records, existing, match, c.UpdateExisting)
+	if err != nil {
+		return err
+	}
+	payload := contactsImportPayload(c.File, format, len(existing), plan)
+	if dryRunErr := dryRunExit(ctx, flags, "contacts.import", payload); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	if err := applyContactsImportPlan(ctx, svc, plan); err != nil {
+		return err
+	}
+	payload = contactsImportPayload(c.File, format, len(existing), plan)
+	payload["applied"] = true
+	return writeContactsImportResult(ctx, u, payload, plan)
+}
+
+func readContactsImportFile(ctx context.Context, path, format string) (string, []*people.Person, error) {
+	reader, closeFn, err := openFileOrStdin(ctx, path)
+	if err != nil {
+		return "", nil, err
+	}
+	if closeFn != nil {
+		defer closeFn()
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	format = detectContactsImportFormat(path, format, string(data))
+	var records []*people.Person
+	if format == "vcf" {
+		records, err = parseContactsVCards(string(data))
+	} else {
+		records, err = parseContactsCSV(strings.NewReader(string(data)))
+	}
+	if err != nil {
+		return "", nil, usagef("parse %s: %v", path, err)
+	}
+	return format, records, nil
+}
+
+func detectContactsImportFormat(path, format, data string) string {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case "vcf", "vcard":
+		return "vcf"
+	case "csv":
+		return "csv"
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".vcf", ".vcard":
+		return "vcf"
+	case ".csv":
+		return "csv"
+	}
+	head := strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(data, "\ufeff")))
+	if strings.HasPrefix(head, "BEGIN:VCARD") {
+		return "vcf"
+	}
+	return "csv"
+}
+
+type contactsImportEntry struct {
+	Index        int
+	Action       string
+	Person       *people.Person
+	Resource     string
+	MatchedOn    []string
+	UpdateFields []string
+	MergedInto   int
+	Reason       string
+}
+
+type contactsImportPlan struct {
+	Entries []*contactsImportEntry
+}
+
+func (p contactsImportPlan) count(action string) int {
+	n := 0
+	for _, entry := range p.Entries {
+		if entry.Action == action {
+			n++
+		}
+	}
+	return n
+}
+
+// buildContactsImportPlan matches each record against existing contacts and
+// earlier records in the same file using the dedupe normalization, so a
+// re-import never creates a second copy of a known email or phone.
+func buildContactsImportPlan(
+	records []*people.Person,
+	existing []*people.Person,
+	match contactsDedupeMatch,
+	updateExisting bool,
+) (contactsImportPlan, error) {
+	existingByKey := map[string]*people.Person{}
+	for _, person := range existing {
+		for _, key := range contactsDedupeKeys(person, match) {
+			if _, ok := existingByKey[key]; !ok {
+				existingByKey[key] = person
+			}
+		}
+	}
+
+	plan := contactsImportPlan{}
+	createByKey := map[string]*contactsImportEntry{}
+	updateByResource := map[string]*contactsImportEntry{}
+	for i, record := range records {
+		entry := &contactsImportEntry{Index: i + 1, Person: record}
+		plan.Entries = append(plan.Entries, entry)
+		if contactsImportRecordEmpty(record) {
+			entry.Action = contactsImportInvalid
+			entry.Reason = "no name, email, phone, or organization"
+			continue
+		}
+
+		keys := contactsDedupeKeys(record, match)
+		if target, matchedOn := contactsImportLookup(existingByKey, keys); target != nil {
+			entry.Resource = contactsDedupeResource(target)
+			entry.MatchedOn = matchedOn
+			if !updateExisting {
+				entry.Action = contactsImportSkip
+				entry.Reason = "matches existing contact"
+				continue
+			}
+			if prior := updateByResource[entry.Resource]; prior != nil {
+				prior.UpdateFields = mergeContactsImportFields(prior.UpdateFields, mergeContactsImportPerson(prior.Person, record))
+				entry.Action = contactsImportMerged
+				entry.MergedInto = prior.Index
+				continue
+			}
+			merged, err := cloneContactPerson(target)
+			if err != nil {
+				return contactsImportPlan{}, err
+			}
+			entry.Person = merged
+			entry.UpdateFields = mergeContactsImportPerson(merged, record)
+			entry.Action = contactsImportUpdate
+			if len(entry.UpdateFields) == 0 {
+				entry.Action = contactsImportUnchanged
+			}
+			updateByResource[entry.Resource] = entry
+			continue
+		}
+
+		if prior, matchedOn := contactsImportLookup(createByKey, keys); prior != nil {
+			mergeContactsImportPerson(prior.Person, record)
+			entry.Action = contactsImportMerged
+			entry.MergedInto = prior.Index
+			entry.MatchedOn = matchedOn
+			keys = contactsDedupeKeys(prior.Person, match)
+			for _, key := range keys {
+				createByKey[key] = prior
+			}
+			continue
+		}
+		entry.Action = contactsImportCreate
+		for _, key := range keys {
+			createByKey[key] = entry
+		}
+	}
+
+	// Later records folded into an unchanged contact may still add values.
+	for _, entry := range updateByResource {
+		if entry.Action == contactsImportUnchanged && len(entry.UpdateFields) > 0 {
+			entry.Action = contactsImportUpdate
+		}
+	}
+	return plan, nil
+}
+
+func contactsImportLookup[T any](index map[string]T, keys []string) (T, []string) {
+	var found T
+	var hit bool
+	var matchedOn []string
+	for _, key := range keys {
+		value, ok := index[key]
+		if !ok {
+			continue
+		}
+		if !hit {
+			found = value
+			hit = true
+		}
+		matchedOn = append(matchedOn, key)
+	}
+	return found, matchedOn
+}
+
+func contactsImportRecordEmpty(p *people.Person) bool {
+	if p == nil {
+		return true
+	}
+	return len(p.Names) == 0 && len(p.EmailAddresses) == 0 && len(p.PhoneNumbers) == 0 && len(p.Organizations) == 0
+}
+
+// mergeContactsImportPerson folds src into dst and returns the People API
+// field names it changed. Multi-value fields gain missing values; singleton
+// fields (name, organization, birthday, notes) take the imported value.
+func mergeContactsImportPerson(dst, src *people.Person) []string {
+	var changed []string
+	mark := func(field string, did bool) {
+		if did {
+			changed = append(changed, field)
+		}
+	}
+
+	var did bool
+	dst.EmailAddresses, did = appendContactsImportItems(dst.EmailAddresses, src.EmailAddresses,
+		func(e *people.EmailAddress) string { return normalizeContactEmail(e.Value) })
+	mark("emailAddresses", did)
+	dst.PhoneNumbers, did = appendContactsImportItems(dst.PhoneNumbers, src.PhoneNumbers,
+		func(p *people.PhoneNumber) string { return normalizeContactPhone(p.Value) })
+	mark("phoneNumbers", did)
+	dst.Addresses, did = appendContactsImportItems(dst.Addresses, src.Addresses, contactsImportAddressKey)
+	mark("addresses", did)
+	dst.Urls, did = appendContactsImportItems(dst.Urls, src.Urls,
+		func(u *people.Url) string { return strings.ToLower(strings.TrimSpace(u.Value)) })
+	mark("urls", did)
+	dst.Nicknames, did = appendContactsImportItems(dst.Nicknames, src.Nicknames,
+		func(n *people.Nickname) string { return normalizeContactName(n.Value) })
+	mark("nicknames", did)
+
+	if name := firstContactsImportItem(src.Names); name != nil && contactsImportNameKey(name) != contactsImportNameKey(firstContactsImportItem(dst.Names)) {
+		dst.Names = []*people.Name{name}
+		mark("names", true)
+	}
+	if org := firstContactsImportItem(src.Organizations); org != nil && contactsImportOrgKey(org) != contactsImportOrgKey(firstContactsImportItem(dst.Organizations)) {
+		dst.Organizations = []*people.Organization{org}
+		mark("organizations", true)
+	}
+	if bday := firstContactsImportItem(src.Birthdays); bday != nil && contactsImportBirthdayKey(bday) != contactsImportBirthdayKey(firstContactsImportItem(dst.Birthdays)) {
+		dst.Birthdays = []*people.Birthday{bday}
+		mark("birthdays", true)
+	}
+	if bio := firstContactsImportItem(src.Biographies); bio != nil && strings.TrimSpace(bio.Value) != primaryBio(dst) {
+		dst.Biographies = []*people.Biography{bio}
+		mark("biographies", true)
+	}
+	return changed
+}
+
+func appendContactsImportItems[T any](dst, src []*T, keyFn func(*T) string) ([]*T, bool) {
+	seen := map[string]bool{}
+	for _, item := range dst {
+		if item != nil {
+			seen[keyFn(item)] = true
+		}
+	}
+	added := false
+	for _, item := range src {
+		if item == nil {
+			continue
+		}
+		key := keyFn(item)
+		if key == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		dst = append(dst, item)
+		added = true
+	}
+	return dst, added
+}
+
+func firstContactsImportItem[T any](items []*T) *T {
+	for _, item := range items {
+		if item != nil {
+			return item
+		}
+	}
+	return nil
+}
+
+func contactsImportAddressKey(a *people.Address) string {
+	return normalizeContactName(strings.Join([]string{
+		firstNonEmpty(a.StreetAddress, a.FormattedValue), a.City, a.PostalCode, firstNonEmpty(a.Country, a.CountryCode),
+	}, " "))
+}
+
+func contactsImportNameKey(n *people.Name) string {
+	if n == nil {
+		return ""
+	}
+	if n.GivenName == "" && n.FamilyName == "" && n.MiddleName == "" {
+		return normalizeContactName(firstNonEmpty(n.UnstructuredName, n.DisplayName))
+	}
+	return normalizeContactName(strings.Join([]string{n.HonorificPrefix, n.GivenName, n.MiddleName, n.FamilyName, n.HonorificSuffix}, " "))
+}
+
+func contactsImportOrgKey(o *people.Organization) string {
+	if o == nil {
+		return ""
+	}
+	return normalizeContactName(o.Name) + "|" + normalizeContactName(o.Title) + "|" + normalizeContactName(o.Department)
+}
+
+func contactsImportBirthdayKey(b *people.Birthday) string {
+	if b == nil {
+		return ""
+	}
+	if b.Date != nil {
+		return fmt.Sprintf("%04d-%02d-%02d", b.Date.Year, b.Date.Month, b.Date.Day)
+	}
+	return strings.TrimSpace(b.Text)
+}
+
+func mergeContactsImportFields(a, b []string) []string {
+	set := map[string]bool{}
+	for _, field := range append(append([]string{}, a...), b...) {
+		set[field] = true
+	}
+	return sortedContactsDedupeKeys(set)
+}
+
+func applyContactsImportPlan(ctx context.Context, svc *people.Service, plan contactsImportPlan) error {
+	var creates, updates []*contactsImportEntry
+	for _, entry := range plan.Entries {
+		switch entry.Action {
+		case contactsImportCreate:
+			creates = append(creates, entry)
+		case contactsImportUpdate:
+			updates = append(updates, entry)
+		}
+	}
+
+	created, updated := 0, 0
+	for start := 0; start < len(creates); start += contactsImportBatchSize {
+		chunk := creates[start:min(start+contactsImportBatchSize, len(creates))]
+		req := &people.BatchCreateContactsRequest{ReadMask: "metadata", Sources: []string{contactsDedupeContactSource}}
+		for _, entry := range chunk {
+			req.Contacts = append(req.Contacts, &people.ContactToCreate{ContactPerson: entry.Person})
+		}
+		resp, err := svc.People.BatchCreateContacts(req).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("contacts import stopped after %d created and %d updated: %w", created, updated, wrapPeopleAPIError(err))
+		}
+		for i, result := range resp.CreatedPeople {
+			if i < len(chunk) && result != nil && result.Person != nil {
+				chunk[i].Resource = result.Person.ResourceName
+			}
+		}
+		created += len(chunk)
+	}
+
+	for start := 0; start < len(updates); start += contactsImportBatchSize {
+		chunk := updates[start:min(start+contactsImportBatchSize, len(updates))]
+		req := &people.BatchUpdateContactsRequest{
+			Contacts: map[string]people.Person{},
+			ReadMask: "metadata",
+			Sources:  []string{contactsDedupeContactSource},
+		}
+		var fields []string
+		for _, entry := range chunk {
+			req.Contacts[entry.Resource] = *entry.Person
+			fields = mergeContactsImportFields(fields, entry.UpdateFields)
+		}
+		// Every person carries its full current value for each field, so the
+		// union mask is safe across the chunk.
+		req.UpdateMask = strings.Join(fields, ",")
+		if _, err := svc.People.BatchUpdateContacts(req).Context(ctx).Do(); err != nil {
+			return fmt.Errorf("contacts import stopped after %d created and %d updated: %w", created, updated, wrapPeopleAPIError(err))
+		}
+		updated += len(chunk)
+	}
+	return nil
+}
+
+func contactsImportLabel(p *people.Person) string {
+	if name := firstContactsImportItem(p.Names); name != nil {
+		if label := firstNonEmpty(name.DisplayName, name.UnstructuredName, strings.TrimSpace(name.GivenName+" "+name.FamilyName)); label != "" {
+			return label
+		}
+	}
+	return firstNonEmpty(primaryOrganizationName(p), primaryEmail(p), primaryPhone(p))
+}
+
+func contactsImportPayload(file, format string, scanned int, plan contactsImportPlan) map[string]any {
+	contacts := make([]map[string]any, 0, len(plan.Entries))
+	for _, entry := range plan.Entries {
+		item := map[string]any{
+			"index":  entry.Index,
+			"action": entry.Action,
+			"name":   contactsImportLabel(entry.Person),
+		}
+		if entry.Resource != "" {
+			item["resource"] = entry.Resource
+		}
+		if len(entry.MatchedOn) > 0 {
+			item["matched_on"] = entry.MatchedOn
+		}
+		if len(entry.UpdateFields) > 0 {
+			sort.Strings(entry.UpdateFields)
+			item["update_fields"] = entry.UpdateFields
+		}
+		if entry.MergedInto > 0 {
+			item["merged_into"] = entry.MergedInto
+		}
+		if entry.Reason != "" {
+			item["reason"] = entry.Reason
+		}
+		contacts = append(contacts, item)
+	}
+	return map[string]any{
+		"file":      file,
+		"format":    format,
+		"scanned":   scanned,
+		"records":   len(plan.Entries),
+		"created":   plan.count(contactsImportCreate),
+		"updated":   plan.count(contactsImportUpdate),
+		"unchanged": plan.count(contactsImportUnchanged),
+		"skipped":   plan.count(contactsImportSkip),
+		"merged":    plan.count(contactsImportMerged),
+		"invalid":   plan.count(contactsImportInvalid),
+		"contacts":  contacts,
+	}
+}
+
+func writeContactsImportResult(ctx context.Context, u *ui.UI, payload map[string]any, plan contactsImportPlan) error {
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload)
+	}
+	out := stdoutWriter(ctx)
+	for _, entry := range plan.Entries {
+		detail := firstNonEmpty(entry.Resource, entry.Reason)
+		if entry.MergedInto > 0 {
+			detail = fmt.Sprintf("record %d", entry.MergedInto)
+		}
+		fmt.Fprintf(out, "%d\t%s\t%s\t%s\n", entry.Index, entry.Action, contactsImportLabel(entry.Person), detail)
+	}
+	if outfmt.IsPlain(ctx) || u == nil {
+		return nil
+	}
+	u.Out().Successf("Created %d, updated %d, skipped %d existing, %d unchanged, %d merged, %d invalid",
+		payload["created"], payload["updated"], payload["skipped"], payload["unchanged"], payload["merged"], payload["invalid"])
+	return nil
+}
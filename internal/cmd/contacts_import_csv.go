@@ -0,0 +1,271 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// Google Contacts exports repeat value groups as "<Kind> N - <Attr>" columns
+// and pack multiple values into one cell separated by " ::: ".
+var contactsCSVNumberedColumn = regexp.MustCompile(`^(e-mail|phone|address|website|organization) (\d+) - (.+)$`)
+
+const contactsCSVMultiValueSep = ":::"
+
+// Outlook exports use fixed columns per kind; these map them to People API types.
+var contactsOutlookEmailColumns = []string{"e-mail address", "e-mail 2 address", "e-mail 3 address"}
+
+var contactsOutlookPhoneColumns = []struct {
+	Column string
+	Type   string
+}{
+	{"mobile phone", "mobile"},
+	{"primary phone", "main"},
+	{"company main phone", "main"},
+	{"business phone", "work"},
+	{"business phone 2", "work"},
+	{"home phone", "home"},
+	{"home phone 2", "home"},
+	{"other phone", "other"},
+	{"business fax", "workFax"},
+	{"home fax", "homeFax"},
+	{"other fax", "otherFax"},
+	{"pager", "pager"},
+}
+
+var contactsOutlookAddressPrefixes = []struct {
+	Prefix string
+	Type   string
+}{
+	{"home", "home"},
+	{"business", "work"},
+	{"other", "other"},
+}
+
+type contactsCSVRow map[string]string
+
+func (r contactsCSVRow) get(columns ...string) string {
+	for _, column := range columns {
+		if value := strings.TrimSpace(r[column]); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func parseContactsCSV(reader io.Reader) ([]*people.Person, error) {
+	cr := csv.NewReader(reader)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read csv header: %w", err)
+	}
+	columns := make([]string, len(header))
+	for i, name := range header {
+		columns[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+	}
+
+	var out []*people.Person
+	for line := 2; ; line++ {
+		record, readErr := cr.Read()
+		if readErr == io.EOF {
+			return out, nil
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("read csv row %d: %w", line, readErr)
+		}
+		row := contactsCSVRow{}
+		for i, value := range record {
+			if i < len(columns) && columns[i] != "" {
+				row[columns[i]] = value
+			}
+		}
+		out = append(out, contactsCSVRowToPerson(row))
+	}
+}
+
+func contactsCSVRowToPerson(row contactsCSVRow) *people.Person {
+	p := &people.Person{}
+	name := &people.Name{
+		GivenName:       row.get("first name", "given name"),
+		MiddleName:      row.get("middle name", "additional name"),
+		FamilyName:      row.get("last name", "family name"),
+		HonorificPrefix: row.get("name prefix", "title"),
+		HonorificSuffix: row.get("name suffix", "suffix"),
+	}
+	if name.GivenName == "" && name.MiddleName == "" && name.FamilyName == "" {
+		if full := row.get("name", "display name"); full != "" {
+			p.Names = []*people.Name{{UnstructuredName: full}}
+		}
+	} else {
+		p.Names = []*people.Name{name}
+	}
+	for _, nick := range splitContactsCSVValues(row.get("nickname")) {
+		p.Nicknames = append(p.Nicknames, &people.Nickname{Value: nick})
+	}
+	if bday := parseContactsImportBirthday(row.get("birthday")); bday != nil {
+		p.Birthdays = []*people.Birthday{bday}
+	}
+	if notes := row.get("notes"); notes != "" {
+		p.Biographies = []*people.Biography{{Value: notes, ContentType: "TEXT_PLAIN"}}
+	}
+	org := &people.Organization{
+		Name:       row.get("organization name", "company"),
+		Title:      row.get("organization title", "job title"),
+		Department: row.get("organization department", "department"),
+	}
+	if !contactsImportOrganizationEmpty(org) {
+		p.Organizations = []*people.Organization{org}
+	}
+
+	applyContactsCSVNumberedColumns(p, row)
+	applyContactsOutlookColumns(p, row)
+	return p
+}
+
+func applyContactsCSVNumberedColumns(p *people.Person, row contactsCSVRow) {
+	groups := map[string]map[int]map[string]string{}
+	maxIndex := 0
+	for column, value := range row {
+		m := contactsCSVNumberedColumn.FindStringSubmatch(column)
+		if m == nil {
+			continue
+		}
+		index, err := strconv.Atoi(m[2])
+		if err != nil {
+			continue
+		}
+		if groups[m[1]] == nil {
+			groups[m[1]] = map[int]map[string]string{}
+		}
+		if groups[m[1]][index] == nil {
+			groups[m[1]][index] = map[string]string{}
+		}
+		groups[m[1]][index][m[3]] = strings.TrimSpace(value)
+		maxIndex = max(maxIndex, index)
+	}
+
+	for i := 1; i <= maxIndex; i++ {
+		if attrs := groups["e-mail"][i]; attrs != nil {
+			for _, value := range splitContactsCSVValues(attrs["value"]) {
+				p.EmailAddresses = append(p.EmailAddresses, &people.EmailAddress{Value: value, Type: contactsCSVLabel(attrs)})
+			}
+		}
+		if attrs := groups["phone"][i]; attrs != nil {
+			for _, value := range splitContactsCSVValues(attrs["value"]) {
+				p.PhoneNumbers = append(p.PhoneNumbers, &people.PhoneNumber{Value: value, Type: contactsCSVLabel(attrs)})
+			}
+		}
+		if attrs := groups["website"][i]; attrs != nil {
+			for _, value := range splitContactsCSVValues(attrs["value"]) {
+				p.Urls = append(p.Urls, &people.Url{Value: value, Type: contactsCSVLabel(attrs)})
+			}
+		}
+		if attrs := groups["address"][i]; attrs != nil {
+			addr := &people.Address{
+				FormattedValue:  attrs["formatted"],
+				StreetAddress:   attrs["street"],
+				City:            attrs["city"],
+				PoBox:           attrs["po box"],
+				Region:          attrs["region"],
+				PostalCode:      attrs["postal code"],
+				Country:         attrs["country"],
+				ExtendedAddress: attrs["extended address"],
+				Type:            contactsCSVLabel(attrs),
+			}
+			if !vcardAddressEmpty(addr) || addr.FormattedValue != "" {
+				p.Addresses = append(p.Addresses, addr)
+			}
+		}
+		if attrs := groups["organization"][i]; attrs != nil && len(p.Organizations) == 0 {
+			org := &people.Organization{Name: attrs["name"], Title: attrs["title"], Department: attrs["department"]}
+			if !contactsImportOrganizationEmpty(org) {
+				p.Organizations = []*people.Organization{org}
+			}
+		}
+	}
+}
+
+func applyContactsOutlookColumns(p *people.Person, row contactsCSVRow) {
+	for _, column := range contactsOutlookEmailColumns {
+		if value := row.get(column); value != "" {
+			p.EmailAddresses = append(p.EmailAddresses, &people.EmailAddress{Value: value})
+		}
+	}
+	for _, phone := range contactsOutlookPhoneColumns {
+		if value := row.get(phone.Column); value != "" {
+			p.PhoneNumbers = append(p.PhoneNumbers, &people.PhoneNumber{Value: value, Type: phone.Type})
+		}
+	}
+	for _, prefix := range contactsOutlookAddressPrefixes {
+		street := strings.TrimSpace(strings.Join(nonEmptyStrings(
+			row.get(prefix.Prefix+" street"),
+			row.get(prefix.Prefix+" street 2"),
+			row.get(prefix.Prefix+" street 3"),
+		), "\n"))
+		addr := &people.Address{
+			StreetAddress: street,
+			City:          row.get(prefix.Prefix + " city"),
+			Region:        row.get(prefix.Prefix + " state"),
+			PostalCode:    row.get(prefix.Prefix + " postal code"),
+			Country:       row.get(prefix.Prefix + " country/region"),
+			PoBox:         row.get(prefix.Prefix + " po box"),
+			Type:          prefix.Type,
+		}
+		if !vcardAddressEmpty(addr) {
+			p.Addresses = append(p.Addresses, addr)
+		}
+	}
+	if value := row.get("web page"); value != "" {
+		p.Urls = append(p.Urls, &people.Url{Value: value})
+	}
+}
+
+// contactsCSVLabel turns Google's "* Home" / "Work" labels into People types.
+func contactsCSVLabel(attrs map[string]string) string {
+	label := strings.TrimSpace(strings.TrimPrefix(firstNonEmpty(attrs["label"], attrs["type"]), "*"))
+	if label == "" {
+		return ""
+	}
+	switch normalized := strings.ToLower(strings.TrimSpace(label)); normalized {
+	case "home", "work", "other", "mobile", "main", "pager":
+		return normalized
+	case "home fax":
+		return "homeFax"
+	case "work fax":
+		return "workFax"
+	case "google voice":
+		return "googleVoice"
+	default:
+		return strings.TrimSpace(label)
+	}
+}
+
+func splitContactsCSVValues(value string) []string {
+	var out []string
+	for _, part := range strings.Split(value, contactsCSVMultiValueSep) {
+		if part = strings.TrimSpace(part); part != "" {
+			out = append(out, part)
+		}
+	}
+	return out
+}
+
+func nonEmptyStrings(values ...string) []string {
+	out := make([]string, 0, len(values))
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			out = append(out, value)
+		}
+	}
+	return out
+}
@@ -0,0 +1,300 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/people/v1"
+)
+
+func TestParseContactsVCards(t *testing.T) {
+	data := strings.Join([]string{
+		"BEGIN:VCARD",
+		"VERSION:4.0",
+		"FN:Ada Lovelace",
+		"N:Lovelace;Ada;;Countess;",
+		"NICKNAME:Ada,Enchantress",
+		"EMAIL;TYPE=work:ada@example.com",
+		"item1.TEL;TYPE=work,cell:tel:+1 555 0100",
+		"ADR;TYPE=home:;;12 St James\\, Sq;London;;SW1;UK",
+		"ORG:Analytical Engines;Research",
+		"TITLE:Programmer",
+		"NOTE:First line\\nsecond line with a long tail that ",
+		" continues on a folded line",
+		"BDAY:--1210",
+		"END:VCARD",
+		"BEGIN:VCARD",
+		"VERSION:3.0",
+		"FN:Charles Babbage",
+		"TEL;HOME;FAX:+44 20 0000",
+		"BDAY:1791-12-26",
+		"END:VCARD",
+	}, "\r\n")
+
+	got, err := parseContactsVCards(data)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("cards = %d, want 2", len(got))
+	}
+	ada := got[0]
+	if n := ada.Names[0]; n.GivenName != "Ada" || n.FamilyName != "Lovelace" || n.HonorificPrefix != "Countess" {
+		t.Fatalf("name = %#v", n)
+	}
+	if len(ada.Nicknames) != 2 || ada.Nicknames[1].Value != "Enchantress" {
+		t.Fatalf("nicknames = %#v", ada.Nicknames)
+	}
+	if ada.EmailAddresses[0].Type != "work" || ada.PhoneNumbers[0].Value != "+1 555 0100" || ada.PhoneNumbers[0].Type != "workMobile" {
+		t.Fatalf("email/phone = %#v %#v", ada.EmailAddresses[0], ada.PhoneNumbers[0])
+	}
+	if a := ada.Addresses[0]; a.StreetAddress != "12 St James, Sq" || a.City != "London" || a.Country != "UK" || a.Type != "home" {
+		t.Fatalf("address = %#v", a)
+	}
+	if o := ada.Organizations[0]; o.Name != "Analytical Engines" || o.Department != "Research" || o.Title != "Programmer" {
+		t.Fatalf("org = %#v", o)
+	}
+	if got := ada.Biographies[0].Value; got != "First line\nsecond line with a long tail that continues on a folded line" {
+		t.Fatalf("note = %q", got)
+	}
+	if d := ada.Birthdays[0].Date; d.Year != 0 || d.Month != 12 || d.Day != 10 {
+		t.Fatalf("birthday = %#v", d)
+	}
+
+	charles := got[1]
+	if charles.Names[0].UnstructuredName != "Charles Babbage" {
+		t.Fatalf("FN fallback = %#v", charles.Names[0])
+	}
+	if charles.PhoneNumbers[0].Type != "homeFax" {
+		t.Fatalf("v3 bare params type = %q", charles.PhoneNumbers[0].Type)
+	}
+	if d := charles.Birthdays[0].Date; d.Year != 1791 || d.Month != 12 || d.Day != 26 {
+		t.Fatalf("birthday = %#v", d)
+	}
+}
+
+func TestParseContactsVCardsRejectsUnterminatedCard(t *testing.T) {
+	if _, err := parseContactsVCards("BEGIN:VCARD\nFN:Ada\n"); err == nil || !strings.Contains(err.Error(), "END:VCARD") {
+		t.Fatalf("error = %v", err)
+	}
+}
+
+func TestParseContactsCSVGoogleAndOutlook(t *testing.T) {
+	google := "First Name,Last Name,Organization Name,Birthday,E-mail 1 - Label,E-mail 1 - Value,Phone 1 - Label,Phone 1 - Value,Address 1 - Label,Address 1 - City\n" +
+		"Ada,Lovelace,Engines,1815-12-10,* Home,ada@example.com ::: ada@work.example,Mobile,+1 555 0100,Home,London\n"
+	got, err := parseContactsCSV(strings.NewReader(google))
+	if err != nil {
+		t.Fatalf("parse google: %v", err)
+	}
+	ada := got[0]
+	if ada.Names[0].GivenName != "Ada" || ada.Organizations[0].Name != "Engines" || ada.Birthdays[0].Date.Year != 1815 {
+		t.Fatalf("google person = %#v", ada)
+	}
+	if len(ada.EmailAddresses) != 2 || ada.EmailAddresses[0].Type != "home" || ada.EmailAddresses[1].Value != "ada@work.example" {
+		t.Fatalf("google emails = %#v", ada.EmailAddresses)
+	}
+	if ada.PhoneNumbers[0].Type != "mobile" || ada.Addresses[0].City != "London" {
+		t.Fatalf("google phone/address = %#v %#v", ada.PhoneNumbers[0], ada.Addresses[0])
+	}
+
+	outlook := "First Name,Last Name,Company,Job Title,E-mail Address,Mobile Phone,Business Fax,Business Street,Business City,Birthday,Notes\n" +
+		"Grace,Hopper,Navy,Rear Admiral,grace@example.com,555-0199,555-0111,1 Main St,Arlington,12/9/1906,Bugs\n"
+	got, err = parseContactsCSV(strings.NewReader(outlook))
+	if err != nil {
+		t.Fatalf("parse outlook: %v", err)
+	}
+	grace := got[0]
+	if grace.Names[0].FamilyName != "Hopper" || grace.Organizations[0].Title != "Rear Admiral" || grace.Biographies[0].Value != "Bugs" {
+		t.Fatalf("outlook person = %#v", grace)
+	}
+	if phoneValues(grace.PhoneNumbers)[1] != "555-0111" || grace.PhoneNumbers[1].Type != "workFax" {
+		t.Fatalf("outlook phones = %#v", grace.PhoneNumbers)
+	}
+	if a := grace.Addresses[0]; a.StreetAddress != "1 Main St" || a.Type != "work" {
+		t.Fatalf("outlook address = %#v", a)
+	}
+	if d := grace.Birthdays[0].Date; d.Year != 1906 || d.Month != 12 || d.Day != 9 {
+		t.Fatalf("outlook birthday = %#v", d)
+	}
+}
+
+func TestBuildContactsImportPlan(t *testing.T) {
+	existing := []*people.Person{
+		testDedupeApplyPerson("people/1", "etag-1", "Ada", "ada@example.com", "+1 555 0100"),
+	}
+	records := []*people.Person{
+		{EmailAddresses: []*people.EmailAddress{{Value: " ADA@example.com "}}, PhoneNumbers: []*people.PhoneNumber{{Value: "+1 555 0300"}}},
+		{Names: []*people.Name{{GivenName: "Grace"}}, PhoneNumbers: []*people.PhoneNumber{{Value: "(555) 0199"}}},
+		{PhoneNumbers: []*people.PhoneNumber{{Value: "555-0199"}}, EmailAddresses: []*people.EmailAddress{{Value: "grace@example.com"}}},
+		{Urls: []*people.Url{{Value: "https://example.com"}}},
+	}
+	match := contactsDedupeMatch{Email: true, Phone: true}
+
+	plan, err := buildContactsImportPlan(records, existing, match, false)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if got := contactsImportActions(plan); !reflect.DeepEqual(got, []string{"skip", "create", "merged", "invalid"}) {
+		t.Fatalf("actions = %#v", got)
+	}
+	if got := plan.Entries[1].Person.EmailAddresses; len(got) != 1 || got[0].Value != "grace@example.com" {
+		t.Fatalf("in-file duplicate not merged into create: %#v", got)
+	}
+
+	plan, err = buildContactsImportPlan(records[:1], existing, match, true)
+	if err != nil {
+		t.Fatalf("plan update: %v", err)
+	}
+	entry := plan.Entries[0]
+	if entry.Action != "update" || entry.Resource != "people/1" || !reflect.DeepEqual(entry.UpdateFields, []string{"phoneNumbers"}) {
+		t.Fatalf("update entry = %#v", entry)
+	}
+	if got := phoneValues(entry.Person.PhoneNumbers); !reflect.DeepEqual(got, []string{"+1 555 0100", "+1 555 0300"}) {
+		t.Fatalf("merged phones = %#v", got)
+	}
+	if contactSourceETag(entry.Person) != "etag-1" {
+		t.Fatalf("update lost etag: %#v", entry.Person.Metadata)
+	}
+
+	plan, err = buildContactsImportPlan([]*people.Person{{EmailAddresses: []*people.EmailAddress{{Value: "ada@example.com"}}}}, existing, match, true)
+	if err != nil {
+		t.Fatalf("plan unchanged: %v", err)
+	}
+	if plan.Entries[0].Action != "unchanged" {
+		t.Fatalf("action = %q, want unchanged", plan.Entries[0].Action)
+	}
+}
+
+func TestContactsImportExecuteJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "contacts.vcf")
+	vcf := "BEGIN:VCARD\nVERSION:4.0\nFN:Ada\nEMAIL:ada@example.com\nTEL:+1 555 0300\nEND:VCARD\n" +
+		"BEGIN:VCARD\nVERSION:4.0\nFN:Grace Hopper\nEMAIL:grace@example.com\nEND:VCARD\n"
+	if err := os.WriteFile(path, []byte(vcf), 0o600); err != nil {
+		t.Fatalf("write vcf: %v", err)
+	}
+
+	var createReq people.BatchCreateContactsRequest
+	var updateReq people.BatchUpdateContactsRequest
+	svc, closeSrv := newPeopleService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/people/me/connections":
+			if got := r.URL.Query().Get("personFields"); !strings.Contains(got, "metadata") {
+				t.Fatalf("personFields = %q", got)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"connections": []*people.Person{
+				testDedupeApplyPerson("people/1", "etag-1", "Ada", "ada@example.com", "+1 555 0100"),
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/people:batchCreateContacts":
+			if err := json.NewDecoder(r.Body).Decode(&createReq); err != nil {
+				t.Fatalf("decode create: %v", err)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"createdPeople": []map[string]any{
+				{"person": map[string]any{"resourceName": "people/new1"}},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/people:batchUpdateContacts":
+			if err := json.NewDecoder(r.Body).Decode(&updateReq); err != nil {
+				t.Fatalf("decode update: %v", err)
+			}
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	result := executeWithPeopleTestServices(
+		t,
+		[]string{"--json", "--account", "a@example.com", "contacts", "import", path, "--update-existing"},
+		peopleTestServices{Contacts: fixedPeopleTestService(svc)},
+	)
+	if result.err != nil {
+		t.Fatalf("Execute: %v\nstdout=%s\nstderr=%s", result.err, result.stdout, result.stderr)
+	}
+	var payload struct {
+		Applied  bool   `json:"applied"`
+		Format   string `json:"format"`
+		Created  int    `json:"created"`
+		Updated  int    `json:"updated"`
+		Contacts []struct {
+			Action   string `json:"action"`
+			Resource string `json:"resource"`
+		} `json:"contacts"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &payload); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, result.stdout)
+	}
+	if !payload.Applied || payload.Format != "vcf" || payload.Created != 1 || payload.Updated != 1 {
+		t.Fatalf("output = %#v", payload)
+	}
+	if payload.Contacts[1].Resource != "people/new1" {
+		t.Fatalf("created resource = %#v", payload.Contacts[1])
+	}
+	if len(createReq.Contacts) != 1 || primaryEmail(createReq.Contacts[0].ContactPerson) != "grace@example.com" {
+		t.Fatalf("create request = %#v", createReq.Contacts)
+	}
+	updated, ok := updateReq.Contacts["people/1"]
+	if !ok || updateReq.UpdateMask != "phoneNumbers" {
+		t.Fatalf("update request = %#v", updateReq)
+	}
+	if got := phoneValues(updated.PhoneNumbers); !reflect.DeepEqual(got, []string{"+1 555 0100", "+1 555 0300"}) {
+		t.Fatalf("updated phones = %#v", got)
+	}
+}
+
+func TestContactsImportDryRunSkipsMutations(t *testing.T) {
+	mutated := false
+	svc, closeSrv := newPeopleService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet && r.URL.Path == "/v1/people/me/connections" {
+			writeDedupeConnections(t, w)
+			return
+		}
+		mutated = true
+		http.NotFound(w, r)
+	}))
+	defer closeSrv()
+
+	path := filepath.Join(t.TempDir(), "contacts.csv")
+	csv := "Name,E-mail 1 - Value\nAda,ada@example.com\nLinus,linus@example.com\n"
+	if err := os.WriteFile(path, []byte(csv), 0o600); err != nil {
+		t.Fatalf("write csv: %v", err)
+	}
+	result := executeWithPeopleTestServices(
+		t,
+		[]string{"--json", "--account", "a@example.com", "--dry-run", "contacts", "import", path},
+		peopleTestServices{Contacts: fixedPeopleTestService(svc)},
+	)
+	if ExitCode(result.err) != 0 {
+		t.Fatalf("Execute: %v\nstdout=%s\nstderr=%s", result.err, result.stdout, result.stderr)
+	}
+	var payload struct {
+		DryRun  bool   `json:"dry_run"`
+		Op      string `json:"op"`
+		Request struct {
+			Created int `json:"created"`
+			Skipped int `json:"skipped"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &payload); err != nil {
+		t.Fatalf("decode output: %v\n%s", err, result.stdout)
+	}
+	if !payload.DryRun || payload.Op != "contacts.import" || payload.Request.Created != 1 || payload.Request.Skipped != 1 {
+		t.Fatalf("output = %#v", payload)
+	}
+	if mutated {
+		t.Fatal("dry-run sent a mutation request")
+	}
+}
+
+func contactsImportActions(plan contactsImportPlan) []string {
+	out := make([]string, 0, len(plan.Entries))
+	for _, entry := range plan.Entries {
+		out = append(out, entry.Action)
+	}
+	return out
+}
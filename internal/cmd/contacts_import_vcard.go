@@ -0,0 +1,340 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// vcardProperty is one unfolded content line: GROUP.NAME;PARAMS:VALUE.
+type vcardProperty struct {
+	Name  string
+	Types []string
+	Value string
+}
+
+func parseContactsVCards(data string) ([]*people.Person, error) {
+	var out []*people.Person
+	var card []vcardProperty
+	inCard := false
+	for i, line := range unfoldVCardLines(data) {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		prop, ok := parseVCardLine(line)
+		if !ok {
+			return nil, fmt.Errorf("vcard line %d: malformed property %q", i+1, line)
+		}
+		switch {
+		case prop.Name == "BEGIN" && strings.EqualFold(prop.Value, "VCARD"):
+			if inCard {
+				return nil, fmt.Errorf("vcard line %d: nested BEGIN:VCARD", i+1)
+			}
+			inCard = true
+			card = card[:0]
+		case prop.Name == "END" && strings.EqualFold(prop.Value, "VCARD"):
+			if !inCard {
+				return nil, fmt.Errorf("vcard line %d: END:VCARD without BEGIN", i+1)
+			}
+			inCard = false
+			out = append(out, vcardPropertiesToPerson(card))
+		case inCard:
+			card = append(card, prop)
+		}
+	}
+	if inCard {
+		return nil, fmt.Errorf("vcard: missing END:VCARD")
+	}
+	return out, nil
+}
+
+func unfoldVCardLines(data string) []string {
+	data = strings.ReplaceAll(data, "\r\n", "\n")
+	data = strings.ReplaceAll(data, "\r", "\n")
+	data = strings.TrimPrefix(data, "\ufeff")
+	var lines []string
+	for _, line := range strings.Split(data, "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func parseVCardLine(line string) (vcardProperty, bool) {
+	colon := vcardPropertyColon(line)
+	if colon <= 0 {
+		return vcardProperty{}, false
+	}
+	head := strings.Split(line[:colon], ";")
+	name := strings.ToUpper(strings.TrimSpace(head[0]))
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		name = name[dot+1:]
+	}
+	prop := vcardProperty{Name: name, Value: line[colon+1:]}
+	for _, param := range head[1:] {
+		key, value, hasValue := strings.Cut(param, "=")
+		if !hasValue {
+			// vCard 2.1/3.0 bare parameters: TEL;CELL;HOME:...
+			prop.Types = append(prop.Types, strings.ToLower(strings.TrimSpace(key)))
+			continue
+		}
+		if !strings.EqualFold(strings.TrimSpace(key), "TYPE") {
+			continue
+		}
+		for _, t := range strings.Split(strings.Trim(value, `"`), ",") {
+			if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+				prop.Types = append(prop.Types, t)
+			}
+		}
+	}
+	return prop, true
+}
+
+// vcardPropertyColon finds the name/value separator, skipping colons inside
+// quoted parameter values.
+func vcardPropertyColon(line string) int {
+	quoted := false
+	for i, r := range line {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func vcardPropertiesToPerson(props []vcardProperty) *people.Person {
+	p := &people.Person{}
+	fullName := ""
+	for _, prop := range props {
+		switch prop.Name {
+		case "FN":
+			fullName = vcardUnescapeText(prop.Value)
+		case "N":
+			parts := vcardSplitValue(prop.Value, ';')
+			name := &people.Name{
+				FamilyName:      vcardPart(parts, 0),
+				GivenName:       vcardPart(parts, 1),
+				MiddleName:      vcardPart(parts, 2),
+				HonorificPrefix: vcardPart(parts, 3),
+				HonorificSuffix: vcardPart(parts, 4),
+			}
+			if name.FamilyName != "" || name.GivenName != "" || name.MiddleName != "" {
+				p.Names = []*people.Name{name}
+			}
+		case "NICKNAME":
+			for _, nick := range vcardSplitValue(prop.Value, ',') {
+				if nick != "" {
+					p.Nicknames = append(p.Nicknames, &people.Nickname{Value: nick})
+				}
+			}
+		case "EMAIL":
+			if value := strings.TrimPrefix(vcardUnescapeText(prop.Value), "mailto:"); strings.TrimSpace(value) != "" {
+				p.EmailAddresses = append(p.EmailAddresses, &people.EmailAddress{Value: strings.TrimSpace(value), Type: vcardImportType(prop.Types)})
+			}
+		case "TEL":
+			if value := strings.TrimPrefix(vcardUnescapeText(prop.Value), "tel:"); strings.TrimSpace(value) != "" {
+				p.PhoneNumbers = append(p.PhoneNumbers, &people.PhoneNumber{Value: strings.TrimSpace(value), Type: vcardImportPhoneType(prop.Types)})
+			}
+		case "ADR":
+			if addr := vcardImportAddress(prop); addr != nil {
+				p.Addresses = append(p.Addresses, addr)
+			}
+		case "ORG":
+			parts := vcardSplitValue(prop.Value, ';')
+			org := contactsImportOrganization(p)
+			org.Name = vcardPart(parts, 0)
+			org.Department = vcardPart(parts, 1)
+		case "TITLE":
+			contactsImportOrganization(p).Title = vcardUnescapeText(prop.Value)
+		case "URL":
+			if value := strings.TrimSpace(vcardUnescapeText(prop.Value)); value != "" {
+				p.Urls = append(p.Urls, &people.Url{Value: value, Type: vcardImportType(prop.Types)})
+			}
+		case "NOTE":
+			if value := strings.TrimSpace(vcardUnescapeText(prop.Value)); value != "" {
+				p.Biographies = []*people.Biography{{Value: value, ContentType: "TEXT_PLAIN"}}
+			}
+		case "BDAY":
+			if bday := parseContactsImportBirthday(vcardUnescapeText(prop.Value)); bday != nil {
+				p.Birthdays = []*people.Birthday{bday}
+			}
+		}
+	}
+	if len(p.Names) == 0 && strings.TrimSpace(fullName) != "" {
+		p.Names = []*people.Name{{UnstructuredName: strings.TrimSpace(fullName)}}
+	}
+	if len(p.Organizations) > 0 && contactsImportOrganizationEmpty(p.Organizations[0]) {
+		p.Organizations = nil
+	}
+	return p
+}
+
+func vcardImportAddress(prop vcardProperty) *people.Address {
+	parts := vcardSplitValue(prop.Value, ';')
+	addr := &people.Address{
+		PoBox:           vcardPart(parts, 0),
+		ExtendedAddress: vcardPart(parts, 1),
+		StreetAddress:   vcardPart(parts, 2),
+		City:            vcardPart(parts, 3),
+		Region:          vcardPart(parts, 4),
+		PostalCode:      vcardPart(parts, 5),
+		Country:         vcardPart(parts, 6),
+		Type:            vcardImportType(prop.Types),
+	}
+	if vcardAddressEmpty(addr) {
+		return nil
+	}
+	return addr
+}
+
+// vcardImportType maps vCard TYPE parameters to People API's free-form type,
+// dropping transport markers (pref, internet, voice) that People has no use for.
+func vcardImportType(types []string) string {
+	for _, t := range types {
+		switch t {
+		case "home", "work", "other":
+			return t
+		case "pref", "internet", "voice", "x400", "text":
+			continue
+		default:
+			if strings.HasPrefix(t, "x-") {
+				continue
+			}
+			return t
+		}
+	}
+	return ""
+}
+
+func vcardImportPhoneType(types []string) string {
+	has := map[string]bool{}
+	for _, t := range types {
+		has[t] = true
+	}
+	switch {
+	case has["cell"] && has["work"]:
+		return "workMobile"
+	case has["cell"]:
+		return "mobile"
+	case has["fax"] && has["home"]:
+		return "homeFax"
+	case has["fax"] && has["work"]:
+		return "workFax"
+	case has["fax"]:
+		return "otherFax"
+	case has["pager"] && has["work"]:
+		return "workPager"
+	case has["pager"]:
+		return "pager"
+	case has["main"]:
+		return "main"
+	default:
+		return vcardImportType(types)
+	}
+}
+
+// vcardSplitValue splits a structured or list value on unescaped sep and
+// unescapes each component.
+func vcardSplitValue(value string, sep byte) []string {
+	var parts []string
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+		if c == '\\' && i+1 < len(value) {
+			b.WriteByte(c)
+			b.WriteByte(value[i+1])
+			i++
+			continue
+		}
+		if c == sep {
+			parts = append(parts, strings.TrimSpace(vcardUnescapeText(b.String())))
+			b.Reset()
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return append(parts, strings.TrimSpace(vcardUnescapeText(b.String())))
+}
+
+func vcardUnescapeText(s string) string {
+	if !strings.Contains(s, "\\") {
+		return s
+	}
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 >= len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n', 'N':
+			b.WriteByte('\n')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+func vcardPart(parts []string, i int) string {
+	if i < len(parts) {
+		return parts[i]
+	}
+	return ""
+}
+
+// parseContactsImportBirthday accepts the date forms vCard and contact CSV
+// exports produce: YYYY-MM-DD, YYYYMMDD, --MM-DD, --MMDD, and M/D/YYYY.
+// Anything else is kept as free text.
+func parseContactsImportBirthday(value string) *people.Birthday {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0/0/00" {
+		return nil
+	}
+	if t, _, ok := strings.Cut(value, "T"); ok && len(t) >= 8 {
+		value = t
+	}
+	digits := strings.ReplaceAll(value, "-", "")
+	if len(digits) == 8 && isDigits(digits) && !strings.HasPrefix(value, "--") {
+		return contactsImportDateBirthday(digits[0:4], digits[4:6], digits[6:8])
+	}
+	if strings.HasPrefix(value, "--") && len(digits) == 4 && isDigits(digits) {
+		return contactsImportDateBirthday("", digits[0:2], digits[2:4])
+	}
+	if parts := strings.Split(value, "/"); len(parts) == 3 && isDigits(parts[0]+parts[1]+parts[2]) && len(parts[2]) == 4 {
+		return contactsImportDateBirthday(parts[2], parts[0], parts[1])
+	}
+	return &people.Birthday{Text: value}
+}
+
+func contactsImportDateBirthday(year, month, day string) *people.Birthday {
+	y, _ := strconv.ParseInt(year, 10, 64)
+	m, mErr := strconv.ParseInt(month, 10, 64)
+	d, dErr := strconv.ParseInt(day, 10, 64)
+	if mErr != nil || dErr != nil || m < 1 || m > 12 || d < 1 || d > 31 {
+		return nil
+	}
+	return &people.Birthday{Date: &people.Date{Year: y, Month: m, Day: d}}
+}
+
+func contactsImportOrganization(p *people.Person) *people.Organization {
+	if len(p.Organizations) == 0 {
+		p.Organizations = []*people.Organization{{}}
+	}
+	return p.Organizations[0]
+}
+
+func contactsImportOrganizationEmpty(org *people.Organization) bool {
+	return org == nil || org.Name == "" && org.Department == "" && org.Title == ""
+}
@@ -3,7 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"regexp"
+	"slices"
 	"strings"
 
 	"google.golang.org/api/drive/v3"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/undo"
 )
 
 var (
@@ -344,6 +347,11 @@ func (c *DriveMoveCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	inverse := undo.Op{Kind: undo.KindDriveUpdate, FileID: fileID, AddParents: meta.Parents}
+	if !slices.Contains(meta.Parents, parent) {
+		inverse.RemoveParents = []string{parent}
+	}
+	recordUndo(ctx, account, fmt.Sprintf("moved %q to %s", meta.Name, parent), inverse)
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{strFile: updated})
@@ -386,6 +394,14 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	// The old name is only needed for the undo journal; a failed lookup
+	// must not block the rename.
+	var before *drive.File
+	var getErr error
+	if undoEnabled(ctx) {
+		before, getErr = svc.Files.Get(fileID).SupportsAllDrives(true).Fields("id, name").Context(ctx).Do()
+	}
+
 	updated, err := svc.Files.Update(fileID, &drive.File{Name: newName}).
 		SupportsAllDrives(true).
 		Fields("id, name").
@@ -394,6 +410,11 @@ func (c *DriveRenameCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	if getErr == nil && before != nil && before.Name != "" && before.Name != newName {
+		recordUndo(ctx, account, fmt.Sprintf("renamed %q to %q", before.Name, newName), undo.Op{Kind: undo.KindDriveUpdate, FileID: fileID, Name: before.Name})
+	} else if getErr != nil {
+		slog.Warn("undo journal skipped: could not read the previous name", "fileId", fileID, "err", getErr)
+	}
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{strFile: updated})
@@ -54,7 +54,7 @@ func (c *DriveActivityQueryCmd) Run(ctx context.Context, flags *RootFlags) error
 		}
 		return resp.Activities, resp.NextPageToken, nil
 	}
-	activities, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	activities, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
@@ -3,10 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"google.golang.org/api/drive/v3"
 
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -47,6 +51,7 @@ type driveBulkPermissionPlan struct {
 	Target       string `json:"target,omitempty"`
 	Inherited    bool   `json:"inherited,omitempty"`
 	Action       string `json:"action"`
+	Error        string `json:"error,omitempty"`
 }
 
 func (c *DriveBulkRemovePublicCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -59,7 +64,7 @@ func (c *DriveBulkRemovePublicCmd) Run(ctx context.Context, flags *RootFlags) er
 		return err
 	}
 
-	_, svc, err := requireDriveService(ctx, flags)
+	account, svc, err := requireDriveService(ctx, flags)
 	if err != nil {
 		return err
 	}
@@ -79,15 +84,17 @@ func (c *DriveBulkRemovePublicCmd) Run(ctx context.Context, flags *RootFlags) er
 	if err := driveBulkConfirm(ctx, flags, plans, "remove public Drive permissions"); err != nil {
 		return err
 	}
-	for _, plan := range plans {
-		if plan.Inherited {
-			continue
-		}
-		if err := svc.Permissions.Delete(plan.FileID, plan.PermissionID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
-			return fmt.Errorf("remove permission %s from %s: %w", plan.PermissionID, plan.FileID, err)
+	failed, err := applyDriveBulkPlans(ctx, account, svc, plans, func(plan driveBulkPermissionPlan) googleapi.BatchCall {
+		return googleapi.BatchCall{
+			Method: http.MethodDelete,
+			Path:   drivePermissionBatchPath(plan),
+			Query:  url.Values{"supportsAllDrives": {"true"}},
 		}
+	})
+	if err != nil {
+		return err
 	}
-	return writeDriveBulkResult(ctx, u, plans, truncated)
+	return writeDriveBulkResult(ctx, u, plans, truncated, failed)
 }
 
 func (c *DriveBulkUpdateRoleCmd) Run(ctx context.Context, flags *RootFlags) error {
@@ -117,7 +124,7 @@ func (c *DriveBulkUpdateRoleCmd) Run(ctx context.Context, flags *RootFlags) erro
 		return dryRunErr
 	}
 
-	_, svc, err := requireDriveService(ctx, flags)
+	account, svc, err := requireDriveService(ctx, flags)
 	if err != nil {
 		return err
 	}
@@ -147,19 +154,55 @@ func (c *DriveBulkUpdateRoleCmd) Run(ctx context.Context, flags *RootFlags) erro
 	if err := driveBulkConfirm(ctx, flags, plans, fmt.Sprintf("update %d Drive permission roles from %s to %s", len(plans), from, to)); err != nil {
 		return err
 	}
-	for _, plan := range plans {
+	failed, err := applyDriveBulkPlans(ctx, account, svc, plans, func(plan driveBulkPermissionPlan) googleapi.BatchCall {
+		return googleapi.BatchCall{
+			Method: http.MethodPatch,
+			Path:   drivePermissionBatchPath(plan),
+			Query:  url.Values{"supportsAllDrives": {"true"}, "fields": {"id,role"}},
+			Body:   &drive.Permission{Role: plan.NewRole},
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return writeDriveBulkResult(ctx, u, plans, truncated, failed)
+}
+
+// applyDriveBulkPlans sends every direct (non-inherited) permission change
+// through one Drive batch client and records per-item failures on the plans.
+func applyDriveBulkPlans(ctx context.Context, account string, svc *drive.Service, plans []driveBulkPermissionPlan, call func(driveBulkPermissionPlan) googleapi.BatchCall) (int, error) {
+	indexes := make([]int, 0, len(plans))
+	calls := make([]googleapi.BatchCall, 0, len(plans))
+	for i, plan := range plans {
 		if plan.Inherited {
 			continue
 		}
-		if _, err := svc.Permissions.Update(plan.FileID, plan.PermissionID, &drive.Permission{Role: plan.NewRole}).
-			SupportsAllDrives(true).
-			Fields("id,role").
-			Context(ctx).
-			Do(); err != nil {
-			return fmt.Errorf("update permission %s on %s: %w", plan.PermissionID, plan.FileID, err)
+		indexes = append(indexes, i)
+		calls = append(calls, call(plan))
+	}
+	if len(calls) == 0 {
+		return 0, nil
+	}
+	batch, err := googleBatch(ctx, account, googleauth.ServiceDrive, svc.BasePath)
+	if err != nil {
+		return 0, err
+	}
+	results, err := batch.Do(ctx, calls)
+	if err != nil {
+		return 0, err
+	}
+	failed := 0
+	for n, result := range results {
+		if result.Err != nil {
+			plans[indexes[n]].Error = result.Err.Error()
+			failed++
 		}
 	}
-	return writeDriveBulkResult(ctx, u, plans, truncated)
+	return failed, nil
+}
+
+func drivePermissionBatchPath(plan driveBulkPermissionPlan) string {
+	return "files/" + url.PathEscape(plan.FileID) + "/permissions/" + url.PathEscape(plan.PermissionID)
 }
 
 func collectDriveBulkPlans(ctx context.Context, svc *drive.Service, items []driveTreeItem, include func(*drive.Permission) (string, bool)) ([]driveBulkPermissionPlan, error) {
@@ -211,20 +254,23 @@ func driveBulkScanRequest(fileID, parent string, depth, maxItems int, allDrives
 	}
 }
 
-func writeDriveBulkResult(ctx context.Context, u *ui.UI, plans []driveBulkPermissionPlan, truncated bool) error {
+func writeDriveBulkResult(ctx context.Context, u *ui.UI, plans []driveBulkPermissionPlan, truncated bool, failed int) error {
 	if outfmt.IsJSON(ctx) {
-		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
 			"items":     plans,
 			"count":     len(plans),
+			"failed":    failed,
 			"truncated": truncated,
-		})
+		}); err != nil {
+			return err
+		}
+		return driveBulkFailure(plans, failed)
 	}
 	if len(plans) == 0 {
 		u.Err().Println("No matching permissions")
 		return nil
 	}
 	w, flush := tableWriter(ctx)
-	defer flush()
 	fmt.Fprintln(w, "PATH\tACTION\tTYPE\tROLE\tNEW_ROLE\tTARGET\tPERMISSION_ID")
 	for _, p := range plans {
 		path := p.Path
@@ -233,10 +279,29 @@ func writeDriveBulkResult(ctx context.Context, u *ui.UI, plans []driveBulkPermis
 		}
 		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", sanitizeTab(path), p.Action, p.Type, p.Role, p.NewRole, p.Target, p.PermissionID)
 	}
+	flush()
+	for _, p := range plans {
+		if p.Error != "" {
+			u.Err().Errorf("%s %s: %s", p.FileID, p.PermissionID, p.Error)
+		}
+	}
 	if truncated {
 		u.Err().Println("Results truncated; increase --max to scan more.")
 	}
-	return nil
+	return driveBulkFailure(plans, failed)
+}
+
+func driveBulkFailure(plans []driveBulkPermissionPlan, failed int) error {
+	if failed == 0 {
+		return nil
+	}
+	attempted := 0
+	for _, p := range plans {
+		if !p.Inherited {
+			attempted++
+		}
+	}
+	return fmt.Errorf("%d of %d permission changes failed", failed, attempted)
 }
 
 func normalizeDriveBulkRole(raw string, flag string) (string, error) {
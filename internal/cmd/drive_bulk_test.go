@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/app"
+)
+
+func TestExecute_DriveBulkRemovePublic_BatchesDeletesAndReportsFailures(t *testing.T) {
+	var mu sync.Mutex
+	var deleted []string
+	batches := 0
+	batched := serveGoogleBatch(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/files/F1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "F1", "name": "Budget"})
+		case r.Method == http.MethodGet && r.URL.Path == "/files/F1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"permissions": []any{
+				map[string]any{"id": "anyoneWithLink", "type": "anyone", "role": "reader"},
+				map[string]any{"id": "anyone2", "type": "anyone", "role": "writer"},
+				map[string]any{"id": "inheritedAnyone", "type": "anyone", "role": "reader",
+					"permissionDetails": []any{map[string]any{"inherited": true}}},
+				map[string]any{"id": "u1", "type": "user", "role": "writer", "emailAddress": "a@example.com"},
+			}})
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/files/F1/permissions/"):
+			if r.URL.Query().Get("supportsAllDrives") != "true" {
+				t.Errorf("delete query = %s", r.URL.RawQuery)
+			}
+			id := strings.TrimPrefix(r.URL.Path, "/files/F1/permissions/")
+			if id == "anyone2" {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Permission not found"}}`))
+				return
+			}
+			mu.Lock()
+			deleted = append(deleted, id)
+			mu.Unlock()
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/batch") {
+			mu.Lock()
+			batches++
+			mu.Unlock()
+		}
+		batched(w, r)
+	}))
+	defer closeSrv()
+
+	result := executeWithTestRuntime(t, []string{
+		"--json", "--force", "--account", "a@example.com",
+		"drive", "bulk", "remove-public", "--file", "F1",
+	}, &app.Runtime{Services: app.Services{Drive: stubDriveService(svc), Batch: googleBatchTestClient}})
+	if result.err == nil || !strings.Contains(result.err.Error(), "1 of 2 permission changes failed") {
+		t.Fatalf("err = %v\nstderr=%s", result.err, result.stderr)
+	}
+	sort.Strings(deleted)
+	if batches != 1 || strings.Join(deleted, ",") != "anyoneWithLink" {
+		t.Fatalf("batches=%d deleted=%v", batches, deleted)
+	}
+
+	var out struct {
+		Count  int `json:"count"`
+		Failed int `json:"failed"`
+		Items  []struct {
+			PermissionID string `json:"permissionId"`
+			Inherited    bool   `json:"inherited"`
+			Error        string `json:"error"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal([]byte(result.stdout), &out); err != nil {
+		t.Fatalf("json: %v\n%s", err, result.stdout)
+	}
+	if out.Count != 3 || out.Failed != 1 || len(out.Items) != 3 {
+		t.Fatalf("out = %+v", out)
+	}
+	if out.Items[1].PermissionID != "anyone2" || !strings.Contains(out.Items[1].Error, "Permission not found") {
+		t.Fatalf("failed item = %+v", out.Items[1])
+	}
+	if !out.Items[2].Inherited || out.Items[2].Error != "" {
+		t.Fatalf("inherited item = %+v", out.Items[2])
+	}
+}
@@ -49,7 +49,7 @@ func (c *DriveDrivesCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return resp.Drives, resp.NextPageToken, nil
 	}
 
-	drives, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	drives, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
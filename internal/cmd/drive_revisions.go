@@ -61,7 +61,7 @@ func (c *DriveRevisionsListCmd) Run(ctx context.Context, flags *RootFlags) error
 		return resp.Revisions, resp.NextPageToken, nil
 	}
 
-	revisions, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	revisions, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 
 	"google.golang.org/api/drive/v3"
@@ -57,6 +58,55 @@ func TestDriveRevisionsListCmd_AllPagesJSON(t *testing.T) {
 	}
 }
 
+func TestDriveRevisionsListCmd_NDJSONStreamsPages(t *testing.T) {
+	var stdout lockedBuffer
+	var calls int
+	svc, closeSvc := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			_, _ = io.WriteString(w, `{"revisions":[{"id":"r1"},{"id":"r2"}],"nextPageToken":"p2"}`)
+			return
+		}
+		if got := stdout.String(); got != "{\"id\":\"r1\"}\n{\"id\":\"r2\"}\n" {
+			t.Errorf("first page not streamed before second fetch: %q", got)
+		}
+		_, _ = io.WriteString(w, `{"revisions":[{"id":"r3"}]}`)
+	}))
+	t.Cleanup(closeSvc)
+
+	mode, err := outfmt.ParseOutput("ndjson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := outfmt.WithMode(newDriveRevisionsTestContext(t, false, &stdout, io.Discard, svc), mode)
+	ctx = outfmt.WithJSONTransform(ctx, outfmt.JSONTransform{Select: []string{"id"}})
+	if err := runKong(t, &DriveRevisionsListCmd{}, []string{"file1", "--all"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if got := stdout.String(); got != "{\"id\":\"r1\"}\n{\"id\":\"r2\"}\n{\"id\":\"r3\"}\n" {
+		t.Fatalf("stdout = %q", got)
+	}
+}
+
+func TestDriveRevisionsListCmd_CSV(t *testing.T) {
+	svc, closeSvc := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, `{"revisions":[{"id":"r1","modifiedTime":"2026-06-10T12:34:56Z","lastModifyingUser":{"displayName":"Doe, Jane"}}]}`)
+	}))
+	t.Cleanup(closeSvc)
+
+	var stdout bytes.Buffer
+	ctx := outfmt.WithMode(newDriveRevisionsTestContext(t, false, &stdout, io.Discard, svc), outfmt.Mode{Plain: true, Format: outfmt.FormatCSV})
+	if err := runKong(t, &DriveRevisionsListCmd{}, []string{"file1"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 || lines[0] != "ID,MODIFIED,MIME,USER,SIZE,KEEP,PUBLISHED,EXPORTS" || !strings.Contains(lines[1], `,"Doe, Jane",`) {
+		t.Fatalf("csv = %q", stdout.String())
+	}
+}
+
 func TestDriveRevisionsListCmd_Text(t *testing.T) {
 	svc, closeSvc := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -192,3 +242,21 @@ func newDriveRevisionsTestContext(t *testing.T, jsonMode bool, stdout, stderr io
 	}
 	return ctx
 }
+
+// lockedBuffer lets a test server handler read output the command is writing.
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
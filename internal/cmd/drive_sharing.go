@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"net/mail"
 	"strings"
 
@@ -10,6 +11,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/undo"
 )
 
 const (
@@ -97,6 +99,8 @@ func (c *DriveShareCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	recordUndo(ctx, account, fmt.Sprintf("shared %s with %s as %s", fileID, target.describe(), created.Role),
+		undo.Op{Kind: undo.KindDrivePermissionDelete, FileID: fileID, PermissionID: created.Id})
 
 	link, err := driveWebLink(ctx, svc, fileID)
 	if err != nil {
@@ -207,6 +211,17 @@ func isValidDriveShareDomain(domain string) bool {
 	return true
 }
 
+func (target driveShareTarget) describe() string {
+	switch target.to {
+	case driveShareToUser:
+		return target.email
+	case driveShareToDomain:
+		return target.domain
+	default:
+		return driveShareToAnyone
+	}
+}
+
 func (target driveShareTarget) permission(role string, discoverable bool) *drive.Permission {
 	perm := &drive.Permission{Role: role}
 	switch target.to {
@@ -270,9 +285,26 @@ func (c *DriveUnshareCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	// The permission is read first so the undo journal can re-create it; a
+	// failed lookup must not block the removal.
+	var before *drive.Permission
+	var getErr error
+	if undoEnabled(ctx) {
+		before, getErr = svc.Permissions.Get(fileID, permissionID).
+			SupportsAllDrives(true).
+			Fields("id, type, role, emailAddress, domain, allowFileDiscovery").
+			Context(ctx).
+			Do()
+	}
+
 	if err := svc.Permissions.Delete(fileID, permissionID).SupportsAllDrives(true).Context(ctx).Do(); err != nil {
 		return err
 	}
+	if getErr == nil && before != nil && before.Type != "" && before.Role != "" {
+		recordUndo(ctx, account, fmt.Sprintf("removed permission %s (%s %s) from %s", permissionID, before.Type, before.Role, fileID), drivePermissionInverse(fileID, before))
+	} else if getErr != nil {
+		slog.Warn("undo journal skipped: could not read the permission", "fileId", fileID, "permissionId", permissionID, "err", getErr)
+	}
 
 	return writeResult(ctx, u,
 		kv("removed", true),
@@ -23,21 +23,41 @@ type driveUploadProgressReader struct {
 }
 
 func driveUploadReader(ctx context.Context, reader io.Reader, opts driveUploadOptions) io.Reader {
-	if outfmt.IsJSON(ctx) || opts.size < driveUploadProgressMinBytes {
+	progress := newUploadProgress(ctx, opts.size)
+	if progress == nil {
 		return reader
 	}
+	progress.reader = reader
+	return progress
+}
+
+// newUploadProgress returns a progress reporter for uploads that do not go
+// through a single reader (chunked uploads call advanceTo), or nil when no
+// progress should be shown.
+func newUploadProgress(ctx context.Context, size int64) *driveUploadProgressReader {
+	if outfmt.IsJSON(ctx) || size < driveUploadProgressMinBytes {
+		return nil
+	}
 	u := ui.FromContext(ctx)
 	if u == nil || u.Err() == nil {
-		return reader
+		return nil
 	}
 	return &driveUploadProgressReader{
-		reader:  reader,
-		size:    opts.size,
+		size:    size,
 		nextPct: driveUploadProgressStep,
 		logf:    u.Err().Linef,
 	}
 }
 
+// advanceTo records that the first read bytes are stored server-side.
+func (r *driveUploadProgressReader) advanceTo(read int64) {
+	if r == nil {
+		return
+	}
+	r.read = read
+	r.report(false)
+}
+
 func (r *driveUploadProgressReader) Read(p []byte) (int, error) {
 	n, err := r.reader.Read(p)
 	if n > 0 {
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExecute_ChatMessagesUpdate_CardFile(t *testing.T) {
+	cardPath := filepath.Join(t.TempDir(), "status.json")
+	card := `{"cardsV2":[{"cardId":"status","card":{"header":{"title":"Deploy"},"sections":[{"widgets":[` +
+		`{"buttonList":{"buttons":[{"text":"Runbook","onClick":{"openLink":{"url":"https://example.com/runbook"}}}]}}]}]}}]}`
+	if err := os.WriteFile(cardPath, []byte(card), 0o600); err != nil {
+		t.Fatalf("write card: %v", err)
+	}
+
+	var gotPath, gotMask string
+	var body map[string]any
+	svc := useFakeChatService(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPatch {
+			http.NotFound(w, r)
+			return
+		}
+		gotPath = r.URL.Path
+		gotMask = r.URL.Query().Get("updateMask")
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"name": "spaces/aaa/messages/m1", "lastUpdateTime": "2026-01-01T00:00:00Z"})
+	})
+
+	result := executeWithChatTestService(t, []string{"--account", "a@b.com", "chat", "messages", "update", "m1", "--space", "aaa", "--card-file", cardPath}, svc)
+	if result.err == nil || !strings.Contains(result.err.Error(), "Chat app authentication") || gotPath != "" {
+		t.Fatalf("user credentials: err=%v path=%q", result.err, gotPath)
+	}
+
+	result = executeWithChatTestService(t, []string{"--access-token", "app-token", "chat", "messages", "update", "m1", "--space", "aaa", "--text", "Deploy done", "--card-file", cardPath}, svc)
+	if result.err != nil {
+		t.Fatalf("Execute: %v", result.err)
+	}
+	if gotPath != "/v1/spaces/aaa/messages/m1" || gotMask != "text,cards_v2" {
+		t.Fatalf("path=%q mask=%q", gotPath, gotMask)
+	}
+	cards, _ := body["cardsV2"].([]any)
+	if body["text"] != "Deploy done" || len(cards) != 1 {
+		t.Fatalf("unexpected body: %#v", body)
+	}
+	if !strings.Contains(result.stdout, "updated\t2026-01-01T00:00:00Z") {
+		t.Fatalf("unexpected out=%q", result.stdout)
+	}
+}
+
+func TestExecute_ChatMessagesGetAndDelete(t *testing.T) {
+	var deleted, force string
+	svc := useFakeChatService(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"name":   "spaces/aaa/messages/m1",
+				"text":   "status: ok",
+				"sender": map[string]any{"displayName": "Bot"},
+			})
+		case http.MethodDelete:
+			deleted = r.URL.Path
+			force = r.URL.Query().Get("force")
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	result := executeWithChatTestService(t, []string{"--account", "a@b.com", "chat", "messages", "get", "spaces/aaa/messages/m1"}, svc)
+	if result.err != nil {
+		t.Fatalf("get: %v", result.err)
+	}
+	if !strings.Contains(result.stdout, "sender\tBot") || !strings.Contains(result.stdout, "text\tstatus: ok") {
+		t.Fatalf("unexpected get out=%q", result.stdout)
+	}
+
+	result = executeWithChatTestService(t, []string{"--json", "--force", "--account", "a@b.com", "chat", "messages", "delete", "spaces/aaa/messages/m1", "--with-replies"}, svc)
+	if result.err != nil {
+		t.Fatalf("delete: %v", result.err)
+	}
+	if deleted != "/v1/spaces/aaa/messages/m1" || force != "true" {
+		t.Fatalf("deleted=%q force=%q", deleted, force)
+	}
+	if !strings.Contains(result.stdout, `"deleted": "spaces/aaa/messages/m1"`) {
+		t.Fatalf("unexpected delete out=%q", result.stdout)
+	}
+}
+
+func TestExecute_ChatMessagesList_TimeRangeAndSender(t *testing.T) {
+	var gotFilter string
+	svc := useFakeChatService(t, func(w http.ResponseWriter, r *http.Request) {
+		gotFilter = r.URL.Query().Get("filter")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"messages": []map[string]any{
+			{"name": "spaces/aaa/messages/m1", "text": "from ada", "sender": map[string]any{"name": "users/1", "displayName": "Ada Lovelace"}},
+			{"name": "spaces/aaa/messages/m2", "text": "from bot", "sender": map[string]any{"name": "users/2", "displayName": "Pager Bot"}},
+		}})
+	})
+
+	result := executeWithChatTestService(t, []string{"--json", "--account", "a@b.com", "chat", "messages", "list", "aaa",
+		"--after", "2026-01-01T00:00:00Z", "--before", "2026-01-02", "--sender", "ada"}, svc)
+	if result.err != nil {
+		t.Fatalf("Execute: %v", result.err)
+	}
+	want := `createTime > "2026-01-01T00:00:00Z" AND createTime < "2026-01-02T00:00:00Z"`
+	if gotFilter != want {
+		t.Fatalf("filter = %q, want %q", gotFilter, want)
+	}
+	if !strings.Contains(result.stdout, "messages/m1") || strings.Contains(result.stdout, "messages/m2") {
+		t.Fatalf("unexpected out=%q", result.stdout)
+	}
+}
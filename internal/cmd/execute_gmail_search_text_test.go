@@ -96,3 +96,22 @@ func TestExecute_GmailSearch_AppliesSystemLabelFilters(t *testing.T) {
 	}
 	assertSameStrings(t, gotLabels, []string{"SPAM", "UNREAD"})
 }
+
+func TestExecute_GmailSearch_NDJSONStreamsThreadDetails(t *testing.T) {
+	srv := httptest.NewServer(gmailSearchTestHandler())
+	defer srv.Close()
+
+	result := executeWithGmailTestService(
+		t,
+		[]string{"--output", "ndjson", "--account", "a@b.com", "gmail", "search", "newer_than:7d", "--max", "1"},
+		newGmailServiceFromServer(t, srv),
+	)
+	if result.err != nil {
+		t.Fatalf("Execute: %v\nstderr=%q", result.err, result.stderr)
+	}
+	lines := strings.Split(strings.TrimSpace(result.stdout), "\n")
+	var first map[string]any
+	if len(lines) == 0 || json.Unmarshal([]byte(lines[0]), &first) != nil || first["id"] == nil || strings.Contains(result.stdout, "nextPageToken") {
+		t.Fatalf("unexpected ndjson: %q", result.stdout)
+	}
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/config"
 	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ratelimit"
 )
 
 const (
@@ -100,6 +101,12 @@ func stableExitCode(err error) error {
 		}
 	}
 
+	var dailyErr *ratelimit.DailyBudgetError
+	var callsErr *ratelimit.CallBudgetError
+	if errors.As(err, &dailyErr) || errors.As(err, &callsErr) {
+		return &ExitError{Code: exitCodeRateLimited, Err: err}
+	}
+
 	var cbErr *gogapi.CircuitBreakerError
 	if errors.As(err, &cbErr) {
 		return &ExitError{Code: exitCodeRetryable, Err: err}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/99designs/keyring"
@@ -10,6 +11,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/config"
 	gogapi "github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/ratelimit"
 )
 
 func TestStableExitCode_PreservesExistingExitError(t *testing.T) {
@@ -103,6 +105,17 @@ func TestStableExitCode_GoogleAPIRateLimited(t *testing.T) {
 	}
 }
 
+func TestStableExitCode_LocalBudgets(t *testing.T) {
+	for _, in := range []error{
+		fmt.Errorf("round trip: %w", &ratelimit.CallBudgetError{Max: 10}),
+		fmt.Errorf("round trip: %w", &ratelimit.DailyBudgetError{Account: "a@b.com", Service: "drive", Daily: 100}),
+	} {
+		if got := ExitCode(stableExitCode(in)); got != exitCodeRateLimited {
+			t.Fatalf("%v: expected exit code %d, got %d", in, exitCodeRateLimited, got)
+		}
+	}
+}
+
 func TestStableExitCode_GoogleAPIQuotaExceeded(t *testing.T) {
 	in := &ggoogleapi.Error{
 		Code:    403,
@@ -1,11 +1,11 @@
 package cmd
 
 type OutputPathFlag struct {
-	Path string `name:"out" aliases:"output" help:"Output file path (default: gogcli config dir)"`
+	Path string `name:"out" help:"Output file path (default: gogcli config dir)"`
 }
 
 type OutputPathRequiredFlag struct {
-	Path string `name:"out" aliases:"output" help:"Output file path (required)"`
+	Path string `name:"out" help:"Output file path (required)"`
 }
 
 type OutputDirFlag struct {
@@ -1,6 +1,9 @@
 package cmd
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 type outputPathCmd struct {
 	Output OutputPathFlag `embed:""`
@@ -14,12 +17,30 @@ type outputDirCmd struct {
 	OutputDir OutputDirFlag `embed:""`
 }
 
-func TestOutputPathFlag_AcceptsOutputAlias(t *testing.T) {
-	cmd := &outputPathCmd{}
-	parseKongContext(t, cmd, []string{"--output", "file.txt"})
-
-	if cmd.Output.Path != "file.txt" {
-		t.Fatalf("expected output path, got %q", cmd.Output.Path)
+func TestOutputPathFlag_RejectsOutputAfterCommand(t *testing.T) {
+	model := desirePathModel(t)
+	for _, args := range []string{
+		"drive download id1 --output csv",
+		"docs export id1 --output=file.txt",
+		"auth tokens export a@b.com --output x",
+	} {
+		err := checkOutputAfterOutFlag(model, strings.Fields(args))
+		if ExitCode(err) != 2 || !strings.Contains(err.Error(), "--out PATH") {
+			t.Errorf("%s: want usage error pointing to --out, got %v", args, err)
+		}
+		if got := rewriteDesirePathArgs(model, strings.Fields(args)); strings.Join(got, " ") != args {
+			t.Errorf("%s: rewritten to %v", args, got)
+		}
+	}
+	for _, args := range []string{
+		"--output csv drive download id1",
+		"drive ls --output yaml",
+		"drive download id1 --out file.txt",
+		"drive download id1 -- --output",
+	} {
+		if err := checkOutputAfterOutFlag(model, strings.Fields(args)); err != nil {
+			t.Errorf("%s: unexpected error %v", args, err)
+		}
 	}
 }
 
@@ -32,12 +53,12 @@ func TestOutputPathFlag_AcceptsOut(t *testing.T) {
 	}
 }
 
-func TestOutputPathRequiredFlag_AcceptsOutputAlias(t *testing.T) {
+func TestOutputPathRequiredFlag_AcceptsOut(t *testing.T) {
 	cmd := &outputPathRequiredCmd{}
-	parseKongContext(t, cmd, []string{"--output", "file.txt"})
+	parseKongContext(t, cmd, []string{"--out", "file.txt"})
 
 	if cmd.Output.Path != "file.txt" {
-		t.Fatalf("expected output path, got %q", cmd.Output.Path)
+		t.Fatalf("expected out path, got %q", cmd.Output.Path)
 	}
 }
 
@@ -21,6 +21,8 @@ type FormsCmd struct {
 	DeleteQuestion FormsDeleteQuestionCmd `cmd:"" name:"delete-question" aliases:"delete-q,dq,rm-q" help:"Delete a question by index"`
 	MoveQuestion   FormsMoveQuestionCmd   `cmd:"" name:"move-question" aliases:"move-q,mq" help:"Move a question to a new position"`
 	Responses      FormsResponsesCmd      `cmd:"" name:"responses" help:"Form responses"`
+	Apply          FormsApplyCmd          `cmd:"" name:"apply" help:"Create or update a form from a YAML/JSON spec (diffs items by ID or title)"`
+	ExportSpec     FormsExportSpecCmd     `cmd:"" name:"export-spec" aliases:"spec" help:"Write a form as a YAML/JSON spec for forms apply"`
 	Watch          FormsWatchCmd          `cmd:"" name:"watch" aliases:"watches" help:"Response watches (push notifications)"`
 	Raw            FormsRawCmd            `cmd:"" name:"raw" help:"Dump raw Google Forms API response as JSON (Forms.Get; lossless; for scripting and LLM consumption)"`
 }
@@ -32,8 +34,10 @@ type FormsQuestionsCmd struct {
 }
 
 type FormsResponsesCmd struct {
-	List FormsResponsesListCmd `cmd:"" name:"list" aliases:"ls" help:"List form responses"`
-	Get  FormsResponseGetCmd   `cmd:"" name:"get" aliases:"info,show" help:"Get a form response"`
+	List    FormsResponsesListCmd    `cmd:"" name:"list" aliases:"ls" help:"List form responses"`
+	Get     FormsResponseGetCmd      `cmd:"" name:"get" aliases:"info,show" help:"Get a form response"`
+	Export  FormsResponsesExportCmd  `cmd:"" name:"export" help:"Export responses as CSV/JSONL columns titled by question, or append new ones to a sheet"`
+	Summary FormsResponsesSummaryCmd `cmd:"" name:"summary" aliases:"stats" help:"Per-question counts, percentages, and numeric stats"`
 }
 
 type FormsGetCmd struct {
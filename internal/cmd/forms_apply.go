@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+	formsapi "google.golang.org/api/forms/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// FormsApplyCmd makes a form match a declarative YAML/JSON spec.
+type FormsApplyCmd struct {
+	File   string `arg:"" name:"spec" help:"Form spec file (YAML or JSON)"`
+	FormID string `name:"form-id" help:"Form to update (overrides form_id in the spec; omit both to create a new form)"`
+}
+
+func (c *FormsApplyCmd) Run(ctx context.Context, flags *RootFlags) error {
+	spec, err := loadFormSpecFile(c.File)
+	if err != nil {
+		return err
+	}
+	formID := firstNonEmpty(strings.TrimSpace(normalizeGoogleID(c.FormID)), spec.FormID)
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := formsService(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	current := &formsapi.Form{Info: &formsapi.Info{Title: spec.Title}}
+	if formID != "" {
+		current, err = svc.Forms.Get(formID).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+	plan, err := newFormsApplyPlan(formID, spec, current)
+	if err != nil {
+		return err
+	}
+	plan.CreateForm = formID == ""
+
+	if dryRunErr := dryRunExit(ctx, flags, "forms.apply", plan.dryRunPayload()); dryRunErr != nil {
+		return dryRunErr
+	}
+	if n := plan.deletes(); n > 0 {
+		action := fmt.Sprintf("delete %d item%s from form %s (their answers are no longer shown)", n, pluralS(n), formID)
+		if confirmErr := confirmDestructiveChecked(ctx, flags, action); confirmErr != nil {
+			return confirmErr
+		}
+	}
+
+	if plan.CreateForm {
+		created, createErr := svc.Forms.Create(&formsapi.Form{Info: &formsapi.Info{Title: spec.Title}}).Context(ctx).Do()
+		if createErr != nil {
+			return createErr
+		}
+		formID = strings.TrimSpace(created.FormId)
+		plan.FormID = formID
+	}
+	if len(plan.Request.Requests) > 0 {
+		if _, err := svc.Forms.BatchUpdate(formID, plan.Request).Context(ctx).Do(); err != nil {
+			return err
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		payload := plan.dryRunPayload()
+		payload["applied"] = true
+		payload["edit_url"] = formEditURL(formID)
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload)
+	}
+
+	u := ui.FromContext(ctx)
+	if plan.CreateForm {
+		u.Out().Linef("created\ttrue")
+	}
+	for _, change := range plan.Changes {
+		u.Out().Linef("%s\t%s", change.Action, formsApplyChangeLabel(change))
+	}
+	if len(plan.Changes) == 0 {
+		u.Err().Println("Already up to date")
+	}
+	u.Out().Linef("form_id\t%s", formID)
+	u.Out().Linef("edit_url\t%s", formEditURL(formID))
+	return nil
+}
+
+func formsApplyChangeLabel(c formsApplyChange) string {
+	switch c.Action {
+	case formsApplyInfo, formsApplyQuiz:
+		return strings.Join(c.Fields, ",")
+	case formsApplyMove:
+		return fmt.Sprintf("%d -> %d\t%s", c.From, c.Index, c.Title)
+	case formsApplyUpdate:
+		return fmt.Sprintf("%d\t%s\t%s", c.Index, c.Title, strings.Join(c.Fields, ","))
+	default:
+		return fmt.Sprintf("%d\t%s", c.Index, c.Title)
+	}
+}
+
+// FormsExportSpecCmd writes a form as a spec that `forms apply` reads back.
+type FormsExportSpecCmd struct {
+	FormID string `arg:"" name:"formId" help:"Form ID"`
+	Out    string `name:"out" short:"o" help:"Output path, or - for stdout" default:"-"`
+	Format string `name:"format" short:"f" help:"Spec format: yaml|json (default: from --out extension, else yaml)" enum:",yaml,json" default:""`
+}
+
+func (c *FormsExportSpecCmd) Run(ctx context.Context, flags *RootFlags) error {
+	formID := strings.TrimSpace(normalizeGoogleID(c.FormID))
+	if formID == "" {
+		return usage("empty formId")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := formsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	form, err := svc.Forms.Get(formID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	spec := formSpecFromForm(form)
+
+	outPath := strings.TrimSpace(c.Out)
+	format := c.Format
+	if format == "" {
+		format = "yaml"
+		if strings.EqualFold(filepath.Ext(outPath), ".json") || (isStdoutPath(outPath) && outfmt.IsJSON(ctx)) {
+			format = "json"
+		}
+	}
+	var buf bytes.Buffer
+	if format == "json" {
+		enc := json.NewEncoder(&buf)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(spec)
+	} else {
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		err = enc.Encode(spec)
+		if closeErr := enc.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if isStdoutPath(outPath) {
+		_, err = stdoutWriter(ctx).Write(buf.Bytes())
+		return err
+	}
+	outPath, err = config.ExpandPath(outPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	ui.FromContext(ctx).Err().Linef("Exported %d item%s to %s", len(spec.Items), pluralS(len(spec.Items)), outPath)
+	return nil
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	formsapi "google.golang.org/api/forms/v1"
+)
+
+const (
+	formsApplyCreate = "create"
+	formsApplyUpdate = "update"
+	formsApplyMove   = "move"
+	formsApplyDelete = "delete"
+	formsApplyInfo   = "update_info"
+	formsApplyQuiz   = "update_settings"
+)
+
+// formsApplyChange describes one request in the apply batch. Index is the
+// item position when the request runs; From is the position a move starts at.
+type formsApplyChange struct {
+	Action string   `json:"action"`
+	Index  int      `json:"index"`
+	From   int      `json:"from,omitempty"`
+	ItemID string   `json:"item_id,omitempty"`
+	Title  string   `json:"title,omitempty"`
+	Type   string   `json:"type,omitempty"`
+	Fields []string `json:"fields,omitempty"`
+}
+
+type formsApplyPlan struct {
+	FormID     string
+	CreateForm bool
+	Changes    []formsApplyChange
+	Request    *formsapi.BatchUpdateFormRequest
+}
+
+// newFormsApplyPlan diffs a spec against the current form and builds one
+// batchUpdate. Items are matched by id, then by title among items of the same
+// kind. Deletes run first (highest index first), then each spec item is moved,
+// updated, or created in order, so every index refers to the form as it is
+// when that request runs.
+func newFormsApplyPlan(formID string, spec *formSpec, current *formsapi.Form) (formsApplyPlan, error) {
+	plan := formsApplyPlan{FormID: formID}
+	if current == nil {
+		current = &formsapi.Form{}
+	}
+	var requests []*formsapi.Request
+
+	if spec.Quiz != nil && *spec.Quiz != formIsQuiz(current) {
+		// Settings go first so graded questions can be created in the same batch.
+		requests = append(requests, &formsapi.Request{UpdateSettings: &formsapi.UpdateSettingsRequest{
+			Settings: &formsapi.FormSettings{QuizSettings: &formsapi.QuizSettings{
+				IsQuiz: *spec.Quiz, ForceSendFields: []string{"IsQuiz"},
+			}},
+			UpdateMask: "quizSettings.isQuiz",
+		}})
+		plan.Changes = append(plan.Changes, formsApplyChange{Action: formsApplyQuiz, Index: -1, Fields: []string{"quiz"}})
+	}
+	if info, masks := formsApplyInfoUpdate(spec, current); len(masks) > 0 {
+		requests = append(requests, &formsapi.Request{UpdateFormInfo: &formsapi.UpdateFormInfoRequest{
+			Info: info, UpdateMask: strings.Join(masks, ","),
+		}})
+		plan.Changes = append(plan.Changes, formsApplyChange{Action: formsApplyInfo, Index: -1, Title: spec.Title, Fields: masks})
+	}
+
+	matches, err := matchFormSpecItems(spec.Items, current.Items)
+	if err != nil {
+		return formsApplyPlan{}, err
+	}
+	matched := map[string]bool{}
+	for _, item := range matches {
+		matched[item.ItemId] = true
+	}
+
+	order := make([]string, 0, len(current.Items))
+	byID := map[string]*formsapi.Item{}
+	for _, item := range current.Items {
+		if item == nil {
+			continue
+		}
+		order = append(order, item.ItemId)
+		byID[item.ItemId] = item
+	}
+	for i := len(order) - 1; i >= 0; i-- {
+		id := order[i]
+		if matched[id] {
+			continue
+		}
+		requests = append(requests, &formsapi.Request{DeleteItem: &formsapi.DeleteItemRequest{Location: formLocationIndex(i)}})
+		existing := formSpecItemFromItem(byID[id])
+		plan.Changes = append(plan.Changes, formsApplyChange{Action: formsApplyDelete, Index: i, ItemID: id, Title: existing.Title, Type: existing.Type})
+		order = slices.Delete(order, i, i+1)
+	}
+
+	for i, it := range spec.Items {
+		existing := matches[it]
+		if existing == nil {
+			if it.readOnly() {
+				return formsApplyPlan{}, usagef("item %d (%s): %s items cannot be created through the Forms API", i+1, it.Title, it.Type)
+			}
+			requests = append(requests, &formsapi.Request{CreateItem: &formsapi.CreateItemRequest{
+				Item: it.apiItem(nil), Location: formLocationIndex(i),
+			}})
+			plan.Changes = append(plan.Changes, formsApplyChange{Action: formsApplyCreate, Index: i, Title: it.Title, Type: it.Type})
+			order = slices.Insert(order, i, fmt.Sprintf("new:%d", i))
+			continue
+		}
+
+		if at := slices.Index(order, existing.ItemId); at != i {
+			requests = append(requests, &formsapi.Request{MoveItem: &formsapi.MoveItemRequest{
+				OriginalLocation: formLocationIndex(at), NewLocation: formLocationIndex(i),
+			}})
+			plan.Changes = append(plan.Changes, formsApplyChange{Action: formsApplyMove, Index: i, From: at, ItemID: existing.ItemId, Title: it.Title, Type: it.Type})
+			order = slices.Delete(order, at, at+1)
+			order = slices.Insert(order, i, existing.ItemId)
+		}
+
+		fields, masks := formSpecItemDiff(it, existing)
+		if len(masks) == 0 {
+			continue
+		}
+		requests = append(requests, &formsapi.Request{UpdateItem: &formsapi.UpdateItemRequest{
+			Item: it.apiItem(existing), Location: formLocationIndex(i), UpdateMask: strings.Join(masks, ","),
+		}})
+		plan.Changes = append(plan.Changes, formsApplyChange{Action: formsApplyUpdate, Index: i, ItemID: existing.ItemId, Title: it.Title, Type: it.Type, Fields: fields})
+	}
+
+	if plan.Changes == nil {
+		plan.Changes = []formsApplyChange{}
+	}
+	plan.Request = &formsapi.BatchUpdateFormRequest{Requests: requests, IncludeFormInResponse: true}
+	return plan, nil
+}
+
+func formIsQuiz(form *formsapi.Form) bool {
+	return form.Settings != nil && form.Settings.QuizSettings != nil && form.Settings.QuizSettings.IsQuiz
+}
+
+func formsApplyInfoUpdate(spec *formSpec, current *formsapi.Form) (*formsapi.Info, []string) {
+	var title, description string
+	if current.Info != nil {
+		title, description = strings.TrimSpace(current.Info.Title), strings.TrimSpace(current.Info.Description)
+	}
+	info := &formsapi.Info{}
+	var masks []string
+	if spec.Title != title {
+		info.Title = spec.Title
+		masks = append(masks, "title")
+	}
+	if spec.Description != description {
+		info.Description = spec.Description
+		info.ForceSendFields = []string{"Description"}
+		masks = append(masks, "description")
+	}
+	return info, masks
+}
+
+// matchFormSpecItems pairs spec items with existing items: first by explicit
+// id, then by title among unmatched items of the same kind. An item whose
+// kind changes cannot be updated in place, so a title match across kinds is
+// treated as delete + create; an explicit id across kinds is an error.
+func matchFormSpecItems(items []*formSpecItem, current []*formsapi.Item) (map[*formSpecItem]*formsapi.Item, error) {
+	matches := map[*formSpecItem]*formsapi.Item{}
+	used := map[string]bool{}
+	byID := map[string]*formsapi.Item{}
+	for _, item := range current {
+		if item != nil {
+			byID[item.ItemId] = item
+		}
+	}
+	for i, it := range items {
+		if it.ID == "" {
+			continue
+		}
+		existing := byID[it.ID]
+		if existing == nil {
+			return nil, usagef("item %d (%s): id %q is not in the form", i+1, it.Title, it.ID)
+		}
+		if kind := formSpecItemFromItem(existing); kind.apiKind() != it.apiKind() {
+			return nil, usagef("item %d (%s): cannot change type from %s to %s in place; remove the id to recreate it", i+1, it.Title, kind.Type, it.Type)
+		}
+		matches[it] = existing
+		used[it.ID] = true
+	}
+	for _, it := range items {
+		if it.ID != "" {
+			continue
+		}
+		for _, item := range current {
+			if item == nil || used[item.ItemId] {
+				continue
+			}
+			existing := formSpecItemFromItem(item)
+			if existing.Title == it.Title && existing.apiKind() == it.apiKind() {
+				matches[it] = item
+				used[item.ItemId] = true
+				break
+			}
+		}
+	}
+	return matches, nil
+}
+
+// formSpecItemDiff returns the changed spec fields and the matching update
+// mask. Question details are replaced as a whole.
+func formSpecItemDiff(want *formSpecItem, existing *formsapi.Item) ([]string, []string) {
+	have := formSpecItemFromItem(existing)
+	// Only the trimming matters here; an existing item that breaks a spec rule
+	// still diffs field by field.
+	_ = have.normalize()
+	var fields, masks []string
+	if want.Title != have.Title {
+		fields = append(fields, "title")
+		masks = append(masks, "title")
+	}
+	if want.Description != have.Description {
+		fields = append(fields, "description")
+		masks = append(masks, "description")
+	}
+	if want.readOnly() {
+		return fields, masks
+	}
+
+	a, b := *want, *have
+	var detail []string
+	for _, f := range []struct {
+		name string
+		same bool
+	}{
+		{"type", a.Type == b.Type},
+		{"required", a.Required == b.Required},
+		{"choices", slices.Equal(a.Choices, b.Choices)},
+		{"other", a.Other == b.Other},
+		{"shuffle", a.Shuffle == b.Shuffle},
+		{"rows", slices.Equal(a.Rows, b.Rows)},
+		{"columns", slices.Equal(a.Columns, b.Columns)},
+		{"low", reflect.DeepEqual(a.Low, b.Low)},
+		{"high", reflect.DeepEqual(a.High, b.High)},
+		{"low_label", a.LowLabel == b.LowLabel},
+		{"high_label", a.HighLabel == b.HighLabel},
+		{"include_time", a.IncludeTime == b.IncludeTime},
+		{"include_year", a.IncludeYear == b.IncludeYear},
+		{"duration", a.Duration == b.Duration},
+		{"points", a.Points == b.Points},
+		{"correct", slices.Equal(a.Correct, b.Correct)},
+	} {
+		if !f.same {
+			detail = append(detail, f.name)
+		}
+	}
+	if len(detail) == 0 {
+		return fields, masks
+	}
+	fields = append(fields, detail...)
+	switch {
+	case want.isGrid():
+		masks = append(masks, "questionGroupItem")
+	case want.isQuestion():
+		masks = append(masks, "questionItem.question")
+	}
+	return fields, masks
+}
+
+func (p formsApplyPlan) counts() map[string]int {
+	counts := map[string]int{}
+	for _, c := range p.Changes {
+		counts[c.Action]++
+	}
+	return counts
+}
+
+func (p formsApplyPlan) deletes() int {
+	return p.counts()[formsApplyDelete]
+}
+
+func (p formsApplyPlan) dryRunPayload() map[string]any {
+	return map[string]any{
+		"form_id":     p.FormID,
+		"create_form": p.CreateForm,
+		"changes":     p.Changes,
+		"counts":      p.counts(),
+		"requests":    len(p.Request.Requests),
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	formsapi "google.golang.org/api/forms/v1"
+)
+
+func formsApplyTestForm(t *testing.T) *formsapi.Form {
+	t.Helper()
+	var form formsapi.Form
+	err := json.Unmarshal([]byte(`{
+		"formId": "form1",
+		"info": {"title": "Onboarding", "description": "Old"},
+		"items": [
+			{"itemId": "i1", "title": "Name", "questionItem": {"question": {"questionId": "q1", "required": true, "textQuestion": {}}}},
+			{"itemId": "i2", "title": "Team", "questionItem": {"question": {"questionId": "q2", "choiceQuestion": {"type": "RADIO", "options": [{"value": "Eng"}, {"value": "Ops"}]}}}},
+			{"itemId": "i3", "title": "Legacy", "questionItem": {"question": {"questionId": "q3", "textQuestion": {"paragraph": true}}}},
+			{"itemId": "i4", "title": "Rate", "questionGroupItem": {
+				"grid": {"columns": {"type": "RADIO", "options": [{"value": "Bad"}, {"value": "Good"}]}},
+				"questions": [{"questionId": "g1", "rowQuestion": {"title": "Docs"}}]
+			}}
+		]
+	}`), &form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &form
+}
+
+func TestParseFormSpecValidation(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		spec string
+		want string
+	}{
+		{"items: []", "title is required"},
+		{"title: x\nitems:\n  - type: radio\n    title: Q", "radio needs choices"},
+		{"title: x\nitems:\n  - type: radio\n    title: Q\n    choices: [a]\n    points: 1\n    correct: [b]", `correct answer "b" is not one of the choices`},
+		{"title: x\nitems:\n  - type: scale\n    title: Q\n    high: 11", "high must be between 2 and 10"},
+		{"title: x\nitems:\n  - type: text\n    title: Q\n    validation: email", "field validation not found"},
+		{"title: x\nitems:\n  - type: grid\n    title: Q\n    rows: [a]", "grid needs rows and columns"},
+	} {
+		if _, err := parseFormSpec([]byte(tc.spec)); err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Errorf("spec %q: err = %v, want %q", tc.spec, err, tc.want)
+		}
+	}
+}
+
+func TestFormsApplyPlanRoundTripIsEmpty(t *testing.T) {
+	t.Parallel()
+
+	form := formsApplyTestForm(t)
+	data, err := json.Marshal(formSpecFromForm(form))
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec, err := parseFormSpec(data)
+	if err != nil {
+		t.Fatalf("parseFormSpec: %v", err)
+	}
+	plan, err := newFormsApplyPlan("form1", spec, form)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plan.Changes) != 0 || len(plan.Request.Requests) != 0 {
+		t.Fatalf("round trip changes = %#v", plan.Changes)
+	}
+}
+
+func TestNewFormsApplyPlan(t *testing.T) {
+	t.Parallel()
+
+	spec, err := parseFormSpec([]byte(`
+title: Onboarding
+description: New hires
+quiz: true
+items:
+  - type: radio
+    title: Team
+    choices: [Eng, Ops, Sales]
+    points: 2
+    correct: [Eng]
+  - type: section
+    title: About you
+  - id: i1
+    type: paragraph
+    title: Full name
+    required: true
+  - type: grid
+    title: Rate
+    rows: [Docs, Speed]
+    columns: [Bad, Good]
+`))
+	if err != nil {
+		t.Fatalf("parseFormSpec: %v", err)
+	}
+	plan, err := newFormsApplyPlan("form1", spec, formsApplyTestForm(t))
+	if err != nil {
+		t.Fatalf("newFormsApplyPlan: %v", err)
+	}
+
+	var got []string
+	for _, c := range plan.Changes {
+		got = append(got, strings.TrimSpace(c.Action+" "+formsApplyChangeLabel(c)))
+	}
+	want := []string{
+		"update_settings quiz",
+		"update_info description",
+		"delete 2\tLegacy",
+		"move 1 -> 0\tTeam",
+		"update 0\tTeam\tchoices,points,correct",
+		"create 1\tAbout you",
+		"update 2\tFull name\ttitle,type",
+		"update 3\tRate\trows",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("changes:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	reqs := plan.Request.Requests
+	if len(reqs) != len(want) || reqs[0].UpdateSettings == nil || reqs[2].DeleteItem.Location.Index != 2 {
+		t.Fatalf("requests = %#v", reqs)
+	}
+	if move := reqs[3].MoveItem; move.OriginalLocation.Index != 1 || move.NewLocation.Index != 0 {
+		t.Fatalf("move = %#v", move)
+	}
+	if q := reqs[4].UpdateItem.Item.QuestionItem.Question; q.QuestionId != "q2" || q.Grading.PointValue != 2 {
+		t.Fatalf("team question = %#v", q)
+	}
+	grid := reqs[7].UpdateItem
+	if grid.UpdateMask != "questionGroupItem" || grid.Item.QuestionGroupItem.Questions[0].QuestionId != "g1" || grid.Item.QuestionGroupItem.Questions[1].QuestionId != "" {
+		t.Fatalf("grid update = %#v", grid)
+	}
+}
+
+func TestExecute_FormsApplyAndExportSpec(t *testing.T) {
+	form := formsApplyTestForm(t)
+	var batches []formsapi.BatchUpdateFormRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/forms/form1"):
+			_ = json.NewEncoder(w).Encode(form)
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/forms/form1:batchUpdate"):
+			var req formsapi.BatchUpdateFormRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			batches = append(batches, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{"form": form})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	svc := newFormsTestService(t, t.Context(), srv)
+
+	result := executeWithFormsTestService(t, []string{"--account", "a@b.com", "forms", "export-spec", "form1"}, svc)
+	if result.err != nil {
+		t.Fatalf("export-spec: %v", result.err)
+	}
+	for _, line := range []string{"form_id: form1", "  - id: i2\n    type: radio\n    title: Team\n    choices:\n      - Eng\n      - Ops", "    rows:\n      - Docs"} {
+		if !strings.Contains(result.stdout, line) {
+			t.Fatalf("spec missing %q:\n%s", line, result.stdout)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "form.yaml")
+	edited := strings.Replace(result.stdout, "      - Ops\n", "      - Ops\n      - Sales\n", 1)
+	if err := os.WriteFile(path, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	result = executeWithFormsTestService(t, []string{"--account", "a@b.com", "--dry-run", "--json", "forms", "apply", path}, svc)
+	if result.err != nil || len(batches) != 0 || !strings.Contains(result.stdout, `"fields": [`) {
+		t.Fatalf("dry-run err=%v batches=%d out=%s", result.err, len(batches), result.stdout)
+	}
+
+	result = executeWithFormsTestService(t, []string{"--account", "a@b.com", "forms", "apply", path}, svc)
+	if result.err != nil {
+		t.Fatalf("apply: %v", result.err)
+	}
+	if len(batches) != 1 || len(batches[0].Requests) != 1 {
+		t.Fatalf("batches = %#v", batches)
+	}
+	update := batches[0].Requests[0].UpdateItem
+	if update == nil || update.UpdateMask != "questionItem.question" || update.Location.Index != 1 ||
+		update.Item.QuestionItem.Question.QuestionId != "q2" || len(update.Item.QuestionItem.Question.ChoiceQuestion.Options) != 3 {
+		t.Fatalf("update = %#v", update)
+	}
+	if !strings.Contains(result.stdout, "update\t1\tTeam\tchoices") {
+		t.Fatalf("stdout = %q", result.stdout)
+	}
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type FormsResponsesExportCmd struct {
+	FormID   string `arg:"" name:"formId" help:"Form ID"`
+	Format   string `name:"format" short:"f" help:"Export format: csv|jsonl" enum:"csv,jsonl" default:"csv"`
+	Out      string `name:"out" short:"o" help:"Output path, or - for stdout" default:"-"`
+	Filter   string `name:"filter" help:"Filter expression (e.g. timestamp > 2026-01-01T00:00:00Z)"`
+	ToSheet  string `name:"to-sheet" help:"Append new responses to this spreadsheet instead of writing a file"`
+	SheetTab string `name:"sheet-tab" help:"Tab for --to-sheet (created if missing)" default:"Responses"`
+}
+
+func (c *FormsResponsesExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	formID := strings.TrimSpace(normalizeGoogleID(c.FormID))
+	if formID == "" {
+		return usage("empty formId")
+	}
+	spreadsheetID := strings.TrimSpace(normalizeGoogleID(c.ToSheet))
+	if spreadsheetID != "" && strings.TrimSpace(c.Filter) != "" {
+		return usage("--filter cannot be combined with --to-sheet (the sheet tracks the last submitted time)")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := formsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	form, err := svc.Forms.Get(formID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	if spreadsheetID != "" {
+		return exportFormResponsesToSheet(ctx, flags, account, svc, form, formID, spreadsheetID, strings.TrimSpace(c.SheetTab))
+	}
+
+	responses, err := listAllFormResponses(ctx, svc, formID, strings.TrimSpace(c.Filter))
+	if err != nil {
+		return err
+	}
+	columns := formResponseColumns(form, responses)
+
+	var buf bytes.Buffer
+	if c.Format == "jsonl" {
+		enc := json.NewEncoder(&buf)
+		for _, resp := range responses {
+			if err := enc.Encode(formResponseRecord(resp, columns)); err != nil {
+				return err
+			}
+		}
+	} else {
+		table := buildFormResponseTable(columns, responses)
+		w := csv.NewWriter(&buf)
+		if err := w.Write(table.headers()); err != nil {
+			return err
+		}
+		if err := w.WriteAll(table.Rows); err != nil {
+			return err
+		}
+	}
+
+	outPath := strings.TrimSpace(c.Out)
+	if isStdoutPath(outPath) {
+		_, err = stdoutWriter(ctx).Write(buf.Bytes())
+		return err
+	}
+	outPath, err = config.ExpandPath(outPath)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	ui.FromContext(ctx).Err().Linef("Exported %d response%s to %s", len(responses), pluralS(len(responses)), outPath)
+	return nil
+}
@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	formsapi "google.golang.org/api/forms/v1"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/app"
+)
+
+func formsResponsesTestForm() map[string]any {
+	return map[string]any{
+		"formId": "form1",
+		"info":   map[string]any{"title": "Survey"},
+		"items": []map[string]any{
+			{"title": "Name", "questionItem": map[string]any{"question": map[string]any{"questionId": "q1", "textQuestion": map[string]any{}}}},
+			{"title": "Tools", "questionItem": map[string]any{"question": map[string]any{"questionId": "q2", "choiceQuestion": map[string]any{
+				"type": "CHECKBOX", "options": []map[string]any{{"value": "Go"}, {"value": "Rust"}, {"isOther": true}},
+			}}}},
+			{"title": "Rate", "questionGroupItem": map[string]any{
+				"grid": map[string]any{"columns": map[string]any{"type": "RADIO", "options": []map[string]any{{"value": "Bad"}, {"value": "Good"}}}},
+				"questions": []map[string]any{
+					{"questionId": "g1", "rowQuestion": map[string]any{"title": "Docs"}},
+					{"questionId": "g2", "rowQuestion": map[string]any{"title": "Speed"}},
+				},
+			}},
+			{"title": "Score", "questionItem": map[string]any{"question": map[string]any{"questionId": "q3", "scaleQuestion": map[string]any{"low": 1, "high": 5}}}},
+			{"title": "Upload", "questionItem": map[string]any{"question": map[string]any{"questionId": "q4", "fileUploadQuestion": map[string]any{}}}},
+		},
+	}
+}
+
+func formsTextAnswer(values ...string) map[string]any {
+	answers := make([]map[string]any, 0, len(values))
+	for _, v := range values {
+		answers = append(answers, map[string]any{"value": v})
+	}
+	return map[string]any{"textAnswers": map[string]any{"answers": answers}}
+}
+
+func formsResponsesTestResponses() []map[string]any {
+	return []map[string]any{
+		{"responseId": "r2", "lastSubmittedTime": "2026-10-02T09:00:00Z", "answers": map[string]any{
+			"q1": formsTextAnswer("Bo"), "q2": formsTextAnswer("Rust", "Zig"), "g1": formsTextAnswer("Bad"), "q3": formsTextAnswer("2"),
+		}},
+		{"responseId": "r1", "lastSubmittedTime": "2026-10-01T09:00:00Z", "respondentEmail": "a@example.com", "answers": map[string]any{
+			"q1": formsTextAnswer("Ann, Jr."), "q2": formsTextAnswer("Go", "Rust"), "g1": formsTextAnswer("Good"), "g2": formsTextAnswer("Good"),
+			"q3":  formsTextAnswer("5"),
+			"q4":  map[string]any{"fileUploadAnswers": map[string]any{"answers": []map[string]any{{"fileId": "f1", "fileName": "cv.pdf"}}}},
+			"old": formsTextAnswer("legacy"),
+		}},
+	}
+}
+
+func newFormsResponsesTestService(t *testing.T, filters *[]string) *formsapi.Service {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/forms/form1"):
+			_ = json.NewEncoder(w).Encode(formsResponsesTestForm())
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/forms/form1/responses"):
+			if filters != nil {
+				*filters = append(*filters, r.URL.Query().Get("filter"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"responses": formsResponsesTestResponses()})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return newFormsTestService(t, t.Context(), srv)
+}
+
+func TestExecute_FormsResponsesExportCSV(t *testing.T) {
+	result := executeWithFormsTestService(t, []string{"--account", "a@b.com", "forms", "responses", "export", "form1"},
+		newFormsResponsesTestService(t, nil))
+	if result.err != nil {
+		t.Fatalf("Execute: %v", result.err)
+	}
+	want := "Response ID,Submitted,Email,Name,Tools,Rate [Docs],Rate [Speed],Score,Upload,old\n" +
+		"r1,2026-10-01T09:00:00Z,a@example.com,\"Ann, Jr.\",\"Go, Rust\",Good,Good,5,https://drive.google.com/open?id=f1,legacy\n" +
+		"r2,2026-10-02T09:00:00Z,,Bo,\"Rust, Zig\",Bad,,2,,\n"
+	if result.stdout != want {
+		t.Fatalf("csv:\n%s\nwant:\n%s", result.stdout, want)
+	}
+}
+
+func TestExecute_FormsResponsesExportJSONL(t *testing.T) {
+	result := executeWithFormsTestService(t, []string{"--account", "a@b.com", "forms", "responses", "export", "form1", "--format", "jsonl"},
+		newFormsResponsesTestService(t, nil))
+	if result.err != nil {
+		t.Fatalf("Execute: %v", result.err)
+	}
+	lines := strings.Split(strings.TrimSpace(result.stdout), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("lines = %q", lines)
+	}
+	var first struct {
+		ResponseID string         `json:"response_id"`
+		Answers    map[string]any `json:"answers"`
+	}
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatal(err)
+	}
+	if first.ResponseID != "r1" || first.Answers["Name"] != "Ann, Jr." ||
+		!reflect.DeepEqual(first.Answers["Tools"], []any{"Go", "Rust"}) ||
+		!reflect.DeepEqual(first.Answers["Upload"], []any{"https://drive.google.com/open?id=f1"}) {
+		t.Fatalf("first = %#v", first)
+	}
+}
+
+func TestSummarizeFormResponses(t *testing.T) {
+	var form formsapi.Form
+	data, _ := json.Marshal(formsResponsesTestForm())
+	if err := json.Unmarshal(data, &form); err != nil {
+		t.Fatal(err)
+	}
+	var responses []*formsapi.FormResponse
+	data, _ = json.Marshal(formsResponsesTestResponses())
+	if err := json.Unmarshal(data, &responses); err != nil {
+		t.Fatal(err)
+	}
+
+	summaries := summarizeFormResponses(&form, responses, 5)
+	byTitle := map[string]*formQuestionSummary{}
+	for _, s := range summaries {
+		byTitle[s.Title] = s
+	}
+	tools := byTitle["Tools"]
+	if tools.Kind != "checkbox" || tools.Answered != 2 || !reflect.DeepEqual(tools.Options, []formOptionCount{
+		{Value: "Go", Count: 1, Percent: 50}, {Value: "Rust", Count: 2, Percent: 100}, {Value: "Other", Count: 1, Percent: 50},
+	}) {
+		t.Fatalf("tools = %#v", tools)
+	}
+	if speed := byTitle["Rate [Speed]"]; speed.Kind != "grid" || speed.Answered != 1 || speed.AnsweredPercent != 50 {
+		t.Fatalf("speed = %#v", speed)
+	}
+	score := byTitle["Score"]
+	if len(score.Options) != 5 || score.Stats == nil || score.Stats.Mean != 3.5 || score.Stats.Median != 3.5 || score.Stats.Min != 2 {
+		t.Fatalf("score = %#v stats=%#v", score, score.Stats)
+	}
+	if name := byTitle["Name"]; len(name.Top) != 2 || name.Stats != nil {
+		t.Fatalf("name = %#v", name)
+	}
+	if upload := byTitle["Upload"]; upload.Files != 1 || upload.Answered != 1 {
+		t.Fatalf("upload = %#v", upload)
+	}
+}
+
+func TestExecute_FormsResponsesExportToSheet(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		updates []*sheets.ValueRange
+		appends [][]interface{}
+	)
+	sheetsSrv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/spreadsheets/sheet1"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"sheets": []map[string]any{{"properties": map[string]any{"title": "Responses"}}}})
+		case r.Method == http.MethodGet && strings.Contains(r.URL.Path, "/values/"):
+			_ = json.NewEncoder(w).Encode(map[string]any{"values": [][]string{
+				{"Response ID", "Submitted", "Email", "Name"},
+				{"r1", "2026-10-01T09:00:00Z", "a@example.com", "Ann"},
+			}})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/values:batchUpdate"):
+			var req sheets.BatchUpdateValuesRequest
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.ValueInputOption != "RAW" {
+				t.Errorf("valueInputOption = %q", req.ValueInputOption)
+			}
+			updates = append(updates, req.Data...)
+			_ = json.NewEncoder(w).Encode(map[string]any{})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, ":append"):
+			var vr sheets.ValueRange
+			_ = json.NewDecoder(r.Body).Decode(&vr)
+			appends = append(appends, vr.Values...)
+			_ = json.NewEncoder(w).Encode(map[string]any{"updates": map[string]any{}})
+		default:
+			t.Errorf("unexpected sheets request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer sheetsSrv.Close()
+
+	var filters []string
+	formsSvc := newFormsResponsesTestService(t, &filters)
+	sheetsSvc := newSheetsServiceFromServer(t, sheetsSrv)
+	result := executeWithTestRuntime(t, []string{"--json", "--account", "a@b.com", "forms", "responses", "export", "form1", "--to-sheet", "sheet1"},
+		&app.Runtime{Services: app.Services{
+			Forms:  fixedFormsTestService(formsSvc),
+			Sheets: func(context.Context, string) (*sheets.Service, error) { return sheetsSvc, nil },
+		}})
+	if result.err != nil {
+		t.Fatalf("Execute: %v\nstderr=%s", result.err, result.stderr)
+	}
+	if !reflect.DeepEqual(filters, []string{"timestamp >= 2026-10-01T09:00:00Z"}) {
+		t.Fatalf("filters = %v", filters)
+	}
+	if len(updates) != 2 || updates[0].Range != "Responses!A1" || updates[1].Range != "Responses!A2" {
+		t.Fatalf("updates = %#v", updates)
+	}
+	if got := updates[0].Values[0]; len(got) != 10 || got[4] != "Tools" || got[9] != "old" {
+		t.Fatalf("header = %#v", got)
+	}
+	if got := updates[1].Values[0]; got[3] != "Ann, Jr." {
+		t.Fatalf("updated row = %#v", got)
+	}
+	if len(appends) != 1 || appends[0][0] != "r2" || appends[0][3] != "Bo" {
+		t.Fatalf("appends = %#v", appends)
+	}
+	var out map[string]any
+	if err := json.Unmarshal([]byte(result.stdout), &out); err != nil || out["append"] != float64(1) || out["update"] != float64(1) || out["last_submitted"] != "2026-10-02T09:00:00Z" {
+		t.Fatalf("out = %s (%v)", result.stdout, err)
+	}
+}
@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	formsapi "google.golang.org/api/forms/v1"
+	"google.golang.org/api/sheets/v4"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/sheetsa1"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// formsSheetPlan is the work for one incremental --to-sheet run.
+type formsSheetPlan struct {
+	CreateTab  bool
+	Header     []string
+	NewColumns []string
+	Since      string
+	Append     [][]string
+	Update     map[int][]string // 1-based sheet row -> values
+	Latest     string
+}
+
+// readFormsSheetState returns the tab's existing values, or nil when the tab
+// does not exist yet.
+func readFormsSheetState(ctx context.Context, svc *sheets.Service, spreadsheetID, tab string) (exists bool, values [][]string, err error) {
+	meta, err := svc.Spreadsheets.Get(spreadsheetID).Fields("sheets.properties.title").Context(ctx).Do()
+	if err != nil {
+		return false, nil, err
+	}
+	for _, sheet := range meta.Sheets {
+		if sheet != nil && sheet.Properties != nil && sheet.Properties.Title == tab {
+			exists = true
+			break
+		}
+	}
+	if !exists {
+		return false, nil, nil
+	}
+	resp, err := svc.Spreadsheets.Values.Get(spreadsheetID, strings.TrimSuffix(sheetsa1.SheetPrefix(tab), "!")).Context(ctx).Do()
+	if err != nil {
+		return true, nil, err
+	}
+	for _, row := range resp.Values {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = fmt.Sprint(cell)
+		}
+		values = append(values, cells)
+	}
+	return true, values, nil
+}
+
+// formsSheetWatermark returns the newest Submitted value already in the tab
+// and the sheet row of each response ID.
+func formsSheetWatermark(values [][]string) (string, map[string]int, error) {
+	rows := map[string]int{}
+	if len(values) == 0 {
+		return "", rows, nil
+	}
+	header := values[0]
+	idCol := slices.Index(header, formsColumnResponseID)
+	submittedCol := slices.Index(header, formsColumnSubmitted)
+	if idCol < 0 || submittedCol < 0 {
+		return "", nil, usagef("tab header has no %q and %q columns; pick an empty or new --sheet-tab", formsColumnResponseID, formsColumnSubmitted)
+	}
+	var latest time.Time
+	watermark := ""
+	for i, row := range values[1:] {
+		if idCol < len(row) && strings.TrimSpace(row[idCol]) != "" {
+			rows[strings.TrimSpace(row[idCol])] = i + 2
+		}
+		if submittedCol >= len(row) {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339Nano, strings.TrimSpace(row[submittedCol]))
+		if err == nil && ts.After(latest) {
+			latest, watermark = ts, ts.UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return watermark, rows, nil
+}
+
+// planFormsSheetSync lays new responses out under the tab's existing header,
+// adding columns for questions the header lacks. Responses already in the tab
+// (edited after submission) are rewritten in place when their values changed.
+func planFormsSheetSync(values [][]string, columns []formResponseColumn, responses []*formsapi.FormResponse, rows map[string]int) formsSheetPlan {
+	plan := formsSheetPlan{Update: map[int][]string{}}
+	if len(values) > 0 {
+		plan.Header = slices.Clone(values[0])
+	}
+	index := map[string]int{}
+	for i, h := range plan.Header {
+		index[h] = i
+	}
+	for _, col := range columns {
+		if _, ok := index[col.Header]; ok {
+			continue
+		}
+		index[col.Header] = len(plan.Header)
+		plan.Header = append(plan.Header, col.Header)
+		if len(values) > 0 {
+			plan.NewColumns = append(plan.NewColumns, col.Header)
+		}
+	}
+
+	table := buildFormResponseTable(columns, responses)
+	for r, cells := range table.Rows {
+		row := make([]string, len(plan.Header))
+		for i, col := range columns {
+			row[index[col.Header]] = cells[i]
+		}
+		if submitted := firstFormTime(responses[r].LastSubmittedTime, responses[r].CreateTime); submitted > plan.Latest {
+			plan.Latest = submitted
+		}
+		sheetRow, ok := rows[responses[r].ResponseId]
+		if !ok {
+			plan.Append = append(plan.Append, row)
+			continue
+		}
+		if existing := values[sheetRow-1]; !slices.Equal(padCells(existing, len(row)), row) {
+			plan.Update[sheetRow] = row
+		}
+	}
+	return plan
+}
+
+func padCells(cells []string, n int) []string {
+	if len(cells) >= n {
+		return cells[:n]
+	}
+	return append(slices.Clone(cells), make([]string, n-len(cells))...)
+}
+
+func exportFormResponsesToSheet(ctx context.Context, flags *RootFlags, account string, formsSvc *formsapi.Service, form *formsapi.Form, formID, spreadsheetID, tab string) error {
+	if tab == "" {
+		return usage("empty --sheet-tab")
+	}
+	sheetsSvc, err := sheetsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	exists, values, err := readFormsSheetState(ctx, sheetsSvc, spreadsheetID, tab)
+	if err != nil {
+		return err
+	}
+	since, rows, err := formsSheetWatermark(values)
+	if err != nil {
+		return err
+	}
+	filter := ""
+	if since != "" {
+		// >= keeps responses submitted in the same instant as the last row;
+		// they are matched by response ID and not appended twice.
+		filter = "timestamp >= " + since
+	}
+	responses, err := listAllFormResponses(ctx, formsSvc, formID, filter)
+	if err != nil {
+		return err
+	}
+	plan := planFormsSheetSync(values, formResponseColumns(form, responses), responses, rows)
+	plan.CreateTab = !exists
+	plan.Since = since
+
+	payload := map[string]any{
+		"form_id":        formID,
+		"spreadsheet_id": spreadsheetID,
+		"tab":            tab,
+		"since":          since,
+		"create_tab":     plan.CreateTab,
+		"append":         len(plan.Append),
+		"update":         len(plan.Update),
+		"new_columns":    plan.NewColumns,
+	}
+	if err := dryRunExit(ctx, flags, "forms.responses.export", payload); err != nil {
+		return err
+	}
+	if err := applyFormsSheetPlan(ctx, sheetsSvc, spreadsheetID, tab, values, plan); err != nil {
+		return err
+	}
+
+	payload["last_submitted"] = firstNonEmpty(plan.Latest, since)
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload)
+	}
+	u := ui.FromContext(ctx)
+	u.Out().Linef("appended\t%d", len(plan.Append))
+	u.Out().Linef("updated\t%d", len(plan.Update))
+	u.Out().Linef("tab\t%s", tab)
+	if last := firstNonEmpty(plan.Latest, since); last != "" {
+		u.Out().Linef("last_submitted\t%s", last)
+	}
+	return nil
+}
+
+func applyFormsSheetPlan(ctx context.Context, svc *sheets.Service, spreadsheetID, tab string, values [][]string, plan formsSheetPlan) error {
+	if plan.CreateTab {
+		_, err := svc.Spreadsheets.BatchUpdate(spreadsheetID, &sheets.BatchUpdateSpreadsheetRequest{
+			Requests: []*sheets.Request{{AddSheet: &sheets.AddSheetRequest{Properties: &sheets.SheetProperties{Title: tab}}}},
+		}).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("create tab %q: %w", tab, err)
+		}
+	}
+
+	var data []*sheets.ValueRange
+	if len(values) == 0 || len(plan.NewColumns) > 0 {
+		data = append(data, &sheets.ValueRange{Range: sheetsa1.FormatCell(tab, 1, 1), Values: sheetRowValues(plan.Header)})
+	}
+	updated := make([]int, 0, len(plan.Update))
+	for row := range plan.Update {
+		updated = append(updated, row)
+	}
+	slices.Sort(updated)
+	for _, row := range updated {
+		data = append(data, &sheets.ValueRange{Range: sheetsa1.FormatCell(tab, row, 1), Values: sheetRowValues(plan.Update[row])})
+	}
+	if len(data) > 0 {
+		// RAW keeps respondent text such as "=SUM(...)" from becoming formulas.
+		_, err := svc.Spreadsheets.Values.BatchUpdate(spreadsheetID, &sheets.BatchUpdateValuesRequest{
+			ValueInputOption: "RAW",
+			Data:             data,
+		}).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(plan.Append) == 0 {
+		return nil
+	}
+	rows := make([][]interface{}, 0, len(plan.Append))
+	for _, row := range plan.Append {
+		rows = append(rows, sheetRowValues(row)[0])
+	}
+	_, err := svc.Spreadsheets.Values.Append(spreadsheetID, sheetsa1.FormatCell(tab, 1, 1), &sheets.ValueRange{Values: rows}).
+		ValueInputOption("RAW").
+		InsertDataOption("INSERT_ROWS").
+		Context(ctx).
+		Do()
+	return err
+}
+
+func sheetRowValues(cells []string) [][]interface{} {
+	row := make([]interface{}, len(cells))
+	for i, cell := range cells {
+		row[i] = cell
+	}
+	return [][]interface{}{row}
+}
@@ -0,0 +1,321 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	formsapi "google.golang.org/api/forms/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const formsOtherOption = "Other"
+
+type FormsResponsesSummaryCmd struct {
+	FormID string `arg:"" name:"formId" help:"Form ID"`
+	Filter string `name:"filter" help:"Filter expression (e.g. timestamp > 2026-01-01T00:00:00Z)"`
+	Top    int    `name:"top" help:"Most common answers to show for free-text questions" default:"5"`
+}
+
+type formQuestionSummary struct {
+	QuestionID      string            `json:"question_id"`
+	Title           string            `json:"title"`
+	Kind            string            `json:"kind"`
+	Answered        int               `json:"answered"`
+	AnsweredPercent float64           `json:"answered_percent"`
+	Options         []formOptionCount `json:"options,omitempty"`
+	Top             []formOptionCount `json:"top,omitempty"`
+	Stats           *formNumericStats `json:"stats,omitempty"`
+	Files           int               `json:"files,omitempty"`
+	options         map[string]int    `json:"-"`
+	optionOrder     []string          `json:"-"`
+	numbers         []float64         `json:"-"`
+	texts           map[string]int    `json:"-"`
+}
+
+type formOptionCount struct {
+	Value   string  `json:"value"`
+	Count   int     `json:"count"`
+	Percent float64 `json:"percent"`
+}
+
+type formNumericStats struct {
+	Count  int     `json:"count"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+}
+
+func (c *FormsResponsesSummaryCmd) Run(ctx context.Context, flags *RootFlags) error {
+	formID := strings.TrimSpace(normalizeGoogleID(c.FormID))
+	if formID == "" {
+		return usage("empty formId")
+	}
+	if c.Top < 0 {
+		return usage("--top must be >= 0")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := formsService(ctx, account)
+	if err != nil {
+		return err
+	}
+	form, err := svc.Forms.Get(formID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	responses, err := listAllFormResponses(ctx, svc, formID, strings.TrimSpace(c.Filter))
+	if err != nil {
+		return err
+	}
+	summaries := summarizeFormResponses(form, responses, c.Top)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"form_id":   formID,
+			"responses": len(responses),
+			"questions": summaries,
+		})
+	}
+
+	u := ui.FromContext(ctx)
+	u.Out().Linef("responses\t%d", len(responses))
+	for _, s := range summaries {
+		u.Out().Println("")
+		u.Out().Linef("%s\t%s\t%d/%d answered (%s%%)", s.Title, s.Kind, s.Answered, len(responses), formatPercent(s.AnsweredPercent))
+		for _, o := range s.Options {
+			u.Out().Linef("  %s\t%d\t%s%%", o.Value, o.Count, formatPercent(o.Percent))
+		}
+		for _, o := range s.Top {
+			u.Out().Linef("  %q\t%d\t%s%%", o.Value, o.Count, formatPercent(o.Percent))
+		}
+		if s.Stats != nil {
+			u.Out().Linef("  min %s  max %s  mean %s  median %s", formatStat(s.Stats.Min), formatStat(s.Stats.Max), formatStat(s.Stats.Mean), formatStat(s.Stats.Median))
+		}
+		if s.Files > 0 {
+			u.Out().Linef("  files\t%d", s.Files)
+		}
+	}
+	return nil
+}
+
+// summarizeFormResponses computes per-question counts. Choice, scale, and
+// rating questions list every option, including ones nobody picked; option
+// percentages are of the respondents who answered that question, so checkbox
+// percentages can add up to more than 100. Scale, rating, and all-numeric
+// text answers also get numeric stats.
+func summarizeFormResponses(form *formsapi.Form, responses []*formsapi.FormResponse, top int) []*formQuestionSummary {
+	var summaries []*formQuestionSummary
+	byID := map[string]*formQuestionSummary{}
+	add := func(title string, q *formsapi.Question, choice *formsapi.ChoiceQuestion) {
+		if q == nil || q.QuestionId == "" {
+			return
+		}
+		s := &formQuestionSummary{QuestionID: q.QuestionId, Title: strings.TrimSpace(title),
+			options: map[string]int{}, texts: map[string]int{}}
+		s.Kind, s.optionOrder = formQuestionKind(q, choice)
+		summaries = append(summaries, s)
+		byID[q.QuestionId] = s
+	}
+	if form != nil {
+		for _, item := range form.Items {
+			switch {
+			case item == nil:
+			case item.QuestionItem != nil && item.QuestionItem.Question != nil:
+				add(item.Title, item.QuestionItem.Question, item.QuestionItem.Question.ChoiceQuestion)
+			case item.QuestionGroupItem != nil:
+				var columns *formsapi.ChoiceQuestion
+				if item.QuestionGroupItem.Grid != nil {
+					columns = item.QuestionGroupItem.Grid.Columns
+				}
+				for _, q := range item.QuestionGroupItem.Questions {
+					if q != nil && q.RowQuestion != nil {
+						add(fmt.Sprintf("%s [%s]", strings.TrimSpace(item.Title), strings.TrimSpace(q.RowQuestion.Title)), q, columns)
+					}
+				}
+			}
+		}
+	}
+
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		for id, answer := range resp.Answers {
+			s := byID[id]
+			if s == nil {
+				continue
+			}
+			s.record(answer)
+		}
+	}
+	for _, s := range summaries {
+		s.finish(len(responses), top)
+	}
+	if summaries == nil {
+		summaries = []*formQuestionSummary{}
+	}
+	return summaries
+}
+
+func formQuestionKind(q *formsapi.Question, choice *formsapi.ChoiceQuestion) (string, []string) {
+	switch {
+	case q.RowQuestion != nil:
+		kind := "grid"
+		if choice != nil && choice.Type == "CHECKBOX" {
+			kind = "checkbox_grid"
+		}
+		return kind, formChoiceOptions(choice)
+	case q.ChoiceQuestion != nil:
+		return strings.ToLower(firstNonEmpty(q.ChoiceQuestion.Type, "choice")), formChoiceOptions(q.ChoiceQuestion)
+	case q.ScaleQuestion != nil:
+		var opts []string
+		for v := q.ScaleQuestion.Low; v <= q.ScaleQuestion.High; v++ {
+			opts = append(opts, strconv.FormatInt(v, 10))
+		}
+		return "scale", opts
+	case q.RatingQuestion != nil:
+		var opts []string
+		for v := int64(1); v <= q.RatingQuestion.RatingScaleLevel; v++ {
+			opts = append(opts, strconv.FormatInt(v, 10))
+		}
+		return "rating", opts
+	case q.FileUploadQuestion != nil:
+		return "file_upload", nil
+	case q.DateQuestion != nil:
+		return "date", nil
+	case q.TimeQuestion != nil:
+		return "time", nil
+	default:
+		return "text", nil
+	}
+}
+
+func formChoiceOptions(choice *formsapi.ChoiceQuestion) []string {
+	if choice == nil {
+		return nil
+	}
+	var opts []string
+	for _, o := range choice.Options {
+		if o == nil {
+			continue
+		}
+		if o.IsOther {
+			opts = append(opts, formsOtherOption)
+			continue
+		}
+		opts = append(opts, o.Value)
+	}
+	return opts
+}
+
+func (s *formQuestionSummary) record(answer formsapi.Answer) {
+	values := formAnswerValues(answer)
+	if len(values) == 0 {
+		return
+	}
+	s.Answered++
+	if answer.FileUploadAnswers != nil {
+		s.Files += len(answer.FileUploadAnswers.Answers)
+		return
+	}
+	for _, v := range values {
+		if len(s.optionOrder) > 0 {
+			// Free text typed into an "Other" option is counted as Other.
+			if !slices.Contains(s.optionOrder, v) {
+				v = formsOtherOption
+			}
+			s.options[v]++
+		} else {
+			s.texts[v]++
+		}
+		if f, err := strconv.ParseFloat(strings.TrimSpace(v), 64); err == nil {
+			s.numbers = append(s.numbers, f)
+		}
+	}
+}
+
+func (s *formQuestionSummary) finish(total, top int) {
+	s.AnsweredPercent = percentOf(s.Answered, total)
+	if s.options[formsOtherOption] > 0 && !slices.Contains(s.optionOrder, formsOtherOption) {
+		s.optionOrder = append(s.optionOrder, formsOtherOption)
+	}
+	for _, v := range s.optionOrder {
+		s.Options = append(s.Options, formOptionCount{Value: v, Count: s.options[v], Percent: percentOf(s.options[v], s.Answered)})
+	}
+
+	numericKind := s.Kind == "scale" || s.Kind == "rating"
+	textCount := 0
+	for _, n := range s.texts {
+		textCount += n
+	}
+	if s.Kind == "text" && textCount > 0 && len(s.numbers) == textCount {
+		numericKind = true
+	}
+	if numericKind && len(s.numbers) > 0 {
+		s.Stats = numericStats(s.numbers)
+	}
+
+	if len(s.texts) > 0 && top > 0 && s.Stats == nil {
+		counts := make([]formOptionCount, 0, len(s.texts))
+		for v, n := range s.texts {
+			counts = append(counts, formOptionCount{Value: v, Count: n, Percent: percentOf(n, s.Answered)})
+		}
+		sort.Slice(counts, func(i, j int) bool {
+			if counts[i].Count != counts[j].Count {
+				return counts[i].Count > counts[j].Count
+			}
+			return counts[i].Value < counts[j].Value
+		})
+		s.Top = counts[:min(top, len(counts))]
+	}
+}
+
+func numericStats(values []float64) *formNumericStats {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	sum := 0.0
+	for _, v := range sorted {
+		sum += v
+	}
+	median := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		median = (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+	}
+	return &formNumericStats{
+		Count:  len(sorted),
+		Min:    sorted[0],
+		Max:    sorted[len(sorted)-1],
+		Mean:   roundTo(sum/float64(len(sorted)), 2),
+		Median: median,
+	}
+}
+
+func percentOf(n, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundTo(float64(n)*100/float64(total), 1)
+}
+
+func roundTo(v float64, places int) float64 {
+	scale := math.Pow(10, float64(places))
+	return math.Round(v*scale) / scale
+}
+
+func formatPercent(v float64) string {
+	return strconv.FormatFloat(v, 'f', 1, 64)
+}
+
+func formatStat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	formsapi "google.golang.org/api/forms/v1"
+)
+
+const (
+	formsColumnResponseID = "Response ID"
+	formsColumnSubmitted  = "Submitted"
+	formsColumnEmail      = "Email"
+	formsColumnScore      = "Score"
+
+	formsDriveFileURL = "https://drive.google.com/open?id="
+)
+
+// formResponseColumn is one export column. Fixed columns have an empty
+// QuestionID; question columns are titled by question text, and grid rows by
+// "Grid title [Row title]" like the Sheets that Forms links itself.
+type formResponseColumn struct {
+	Header     string
+	QuestionID string
+	Multi      bool
+}
+
+type formResponseTable struct {
+	Columns []formResponseColumn
+	Rows    [][]string
+}
+
+// formResponseColumns lists the export columns for a form. Answers to
+// questions that no longer exist in the form get trailing columns titled by
+// question ID, so no answer is dropped.
+func formResponseColumns(form *formsapi.Form, responses []*formsapi.FormResponse) []formResponseColumn {
+	columns := []formResponseColumn{
+		{Header: formsColumnResponseID},
+		{Header: formsColumnSubmitted},
+		{Header: formsColumnEmail},
+	}
+	if form != nil && form.Settings != nil && form.Settings.QuizSettings != nil && form.Settings.QuizSettings.IsQuiz {
+		columns = append(columns, formResponseColumn{Header: formsColumnScore})
+	}
+
+	known := map[string]bool{}
+	used := map[string]int{}
+	add := func(header, questionID string, multi bool) {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			header = questionID
+		}
+		used[header]++
+		if n := used[header]; n > 1 {
+			header = fmt.Sprintf("%s (%d)", header, n)
+		}
+		known[questionID] = true
+		columns = append(columns, formResponseColumn{Header: header, QuestionID: questionID, Multi: multi})
+	}
+
+	if form != nil {
+		for _, item := range form.Items {
+			if item == nil {
+				continue
+			}
+			switch {
+			case item.QuestionItem != nil && item.QuestionItem.Question != nil:
+				q := item.QuestionItem.Question
+				add(item.Title, q.QuestionId, formQuestionMulti(q))
+			case item.QuestionGroupItem != nil:
+				multi := item.QuestionGroupItem.Grid != nil && item.QuestionGroupItem.Grid.Columns != nil &&
+					item.QuestionGroupItem.Grid.Columns.Type == "CHECKBOX"
+				for _, q := range item.QuestionGroupItem.Questions {
+					if q == nil {
+						continue
+					}
+					row := ""
+					if q.RowQuestion != nil {
+						row = q.RowQuestion.Title
+					}
+					add(fmt.Sprintf("%s [%s]", strings.TrimSpace(item.Title), strings.TrimSpace(row)), q.QuestionId, multi)
+				}
+			}
+		}
+	}
+
+	var orphans []string
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		for id := range resp.Answers {
+			if !known[id] {
+				known[id] = true
+				orphans = append(orphans, id)
+			}
+		}
+	}
+	sort.Strings(orphans)
+	for _, id := range orphans {
+		add(id, id, false)
+	}
+	return columns
+}
+
+func formQuestionMulti(q *formsapi.Question) bool {
+	if q == nil {
+		return false
+	}
+	if q.FileUploadQuestion != nil {
+		return true
+	}
+	return q.ChoiceQuestion != nil && q.ChoiceQuestion.Type == "CHECKBOX"
+}
+
+func buildFormResponseTable(columns []formResponseColumn, responses []*formsapi.FormResponse) formResponseTable {
+	table := formResponseTable{Columns: columns, Rows: make([][]string, 0, len(responses))}
+	for _, resp := range responses {
+		if resp == nil {
+			continue
+		}
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = formResponseCell(resp, col)
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	return table
+}
+
+func (t formResponseTable) headers() []string {
+	headers := make([]string, len(t.Columns))
+	for i, col := range t.Columns {
+		headers[i] = col.Header
+	}
+	return headers
+}
+
+func formResponseCell(resp *formsapi.FormResponse, col formResponseColumn) string {
+	if col.QuestionID == "" {
+		switch col.Header {
+		case formsColumnResponseID:
+			return resp.ResponseId
+		case formsColumnSubmitted:
+			return firstFormTime(resp.LastSubmittedTime, resp.CreateTime)
+		case formsColumnEmail:
+			return resp.RespondentEmail
+		case formsColumnScore:
+			return strconv.FormatFloat(resp.TotalScore, 'f', -1, 64)
+		}
+		return ""
+	}
+	answer, ok := resp.Answers[col.QuestionID]
+	if !ok {
+		return ""
+	}
+	return strings.Join(formAnswerValues(answer), ", ")
+}
+
+// formAnswerValues returns the text values of an answer, or Drive links for
+// uploaded files.
+func formAnswerValues(answer formsapi.Answer) []string {
+	var values []string
+	if answer.TextAnswers != nil {
+		for _, a := range answer.TextAnswers.Answers {
+			if a != nil {
+				values = append(values, a.Value)
+			}
+		}
+	}
+	if answer.FileUploadAnswers != nil {
+		for _, f := range answer.FileUploadAnswers.Answers {
+			if f != nil && f.FileId != "" {
+				values = append(values, formsDriveFileURL+f.FileId)
+			}
+		}
+	}
+	return values
+}
+
+// formResponseRecord is one JSONL line. Checkbox, checkbox-grid, and
+// file-upload answers are arrays; other answers are strings.
+func formResponseRecord(resp *formsapi.FormResponse, columns []formResponseColumn) map[string]any {
+	record := map[string]any{
+		"response_id": resp.ResponseId,
+		"submitted":   firstFormTime(resp.LastSubmittedTime, resp.CreateTime),
+	}
+	if resp.RespondentEmail != "" {
+		record["email"] = resp.RespondentEmail
+	}
+	answers := map[string]any{}
+	for _, col := range columns {
+		if col.QuestionID == "" {
+			if col.Header == formsColumnScore {
+				record["score"] = resp.TotalScore
+			}
+			continue
+		}
+		answer, ok := resp.Answers[col.QuestionID]
+		if !ok {
+			continue
+		}
+		values := formAnswerValues(answer)
+		if col.Multi || len(values) > 1 {
+			if values == nil {
+				values = []string{}
+			}
+			answers[col.Header] = values
+		} else if len(values) == 1 {
+			answers[col.Header] = values[0]
+		}
+	}
+	record["answers"] = answers
+	return record
+}
+
+// listAllFormResponses loads every response page. filter is passed through
+// to the API (for example "timestamp > 2026-01-01T00:00:00Z").
+func listAllFormResponses(ctx context.Context, svc *formsapi.Service, formID, filter string) ([]*formsapi.FormResponse, error) {
+	items, _, err := loadPagedItems("", true, func(pageToken string) ([]*formsapi.FormResponse, string, error) {
+		call := svc.Forms.Responses.List(formID).PageSize(5000).Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		if filter != "" {
+			call = call.Filter(filter)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Responses, resp.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		return firstFormTime(items[i].LastSubmittedTime, items[i].CreateTime) <
+			firstFormTime(items[j].LastSubmittedTime, items[j].CreateTime)
+	})
+	return items, nil
+}
@@ -0,0 +1,505 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+	formsapi "google.golang.org/api/forms/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+// Item types in a form spec. Questions map to questionItem, grids to
+// questionGroupItem, "section" to a page break, and "heading" to a text item.
+const (
+	formSpecText         = "text"
+	formSpecParagraph    = "paragraph"
+	formSpecRadio        = "radio"
+	formSpecCheckbox     = "checkbox"
+	formSpecDropdown     = "dropdown"
+	formSpecScale        = "scale"
+	formSpecDate         = strDate
+	formSpecTime         = "time"
+	formSpecGrid         = "grid"
+	formSpecCheckboxGrid = "checkbox_grid"
+	formSpecSection      = "section"
+	formSpecHeading      = "heading"
+
+	// The Forms API can read but not create these; apply keeps matching
+	// items and only changes their title and description.
+	formSpecFileUpload = "file_upload"
+	formSpecRating     = "rating"
+	formSpecImage      = "image"
+	formSpecVideo      = "video"
+)
+
+// formSpec is the declarative form definition read by `forms apply` and
+// written by `forms export-spec`.
+type formSpec struct {
+	FormID      string          `yaml:"form_id,omitempty" json:"form_id,omitempty"`
+	Title       string          `yaml:"title" json:"title"`
+	Description string          `yaml:"description,omitempty" json:"description,omitempty"`
+	Quiz        *bool           `yaml:"quiz,omitempty" json:"quiz,omitempty"`
+	Items       []*formSpecItem `yaml:"items" json:"items"`
+}
+
+type formSpecItem struct {
+	ID          string   `yaml:"id,omitempty" json:"id,omitempty"`
+	Type        string   `yaml:"type" json:"type"`
+	Title       string   `yaml:"title,omitempty" json:"title,omitempty"`
+	Description string   `yaml:"description,omitempty" json:"description,omitempty"`
+	Required    bool     `yaml:"required,omitempty" json:"required,omitempty"`
+	Choices     []string `yaml:"choices,omitempty" json:"choices,omitempty"`
+	Other       bool     `yaml:"other,omitempty" json:"other,omitempty"`
+	Shuffle     bool     `yaml:"shuffle,omitempty" json:"shuffle,omitempty"`
+	Rows        []string `yaml:"rows,omitempty" json:"rows,omitempty"`
+	Columns     []string `yaml:"columns,omitempty" json:"columns,omitempty"`
+	Low         *int64   `yaml:"low,omitempty" json:"low,omitempty"`
+	High        *int64   `yaml:"high,omitempty" json:"high,omitempty"`
+	LowLabel    string   `yaml:"low_label,omitempty" json:"low_label,omitempty"`
+	HighLabel   string   `yaml:"high_label,omitempty" json:"high_label,omitempty"`
+	IncludeTime bool     `yaml:"include_time,omitempty" json:"include_time,omitempty"`
+	IncludeYear bool     `yaml:"include_year,omitempty" json:"include_year,omitempty"`
+	Duration    bool     `yaml:"duration,omitempty" json:"duration,omitempty"`
+	Points      int64    `yaml:"points,omitempty" json:"points,omitempty"`
+	Correct     []string `yaml:"correct,omitempty" json:"correct,omitempty"`
+}
+
+func loadFormSpecFile(path string) (*formSpec, error) {
+	resolved, err := config.ExpandPath(strings.TrimSpace(path))
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(resolved) //nolint:gosec // path is user-supplied by design (local CLI)
+	if err != nil {
+		return nil, fmt.Errorf("read form spec: %w", err)
+	}
+	spec, err := parseFormSpec(data)
+	if err != nil {
+		return nil, usagef("invalid form spec %s: %v", path, err)
+	}
+	return spec, nil
+}
+
+// parseFormSpec reads YAML or JSON (JSON is valid YAML). Unknown keys are
+// rejected so a typo fails before any API call.
+func parseFormSpec(data []byte) (*formSpec, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var spec formSpec
+	if err := dec.Decode(&spec); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("empty spec")
+		}
+		return nil, err
+	}
+	if err := spec.normalize(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+func (s *formSpec) normalize() error {
+	s.FormID = strings.TrimSpace(normalizeGoogleID(s.FormID))
+	s.Title = strings.TrimSpace(s.Title)
+	s.Description = strings.TrimSpace(s.Description)
+	if s.Title == "" {
+		return fmt.Errorf("title is required")
+	}
+	ids := map[string]bool{}
+	for i, item := range s.Items {
+		if item == nil {
+			return fmt.Errorf("item %d: empty", i+1)
+		}
+		where := fmt.Sprintf("item %d", i+1)
+		if item.Title != "" {
+			where = fmt.Sprintf("item %d (%s)", i+1, strings.TrimSpace(item.Title))
+		}
+		if err := item.normalize(); err != nil {
+			return fmt.Errorf("%s: %w", where, err)
+		}
+		if item.ID != "" {
+			if ids[item.ID] {
+				return fmt.Errorf("%s: duplicate id %q", where, item.ID)
+			}
+			ids[item.ID] = true
+		}
+		if item.Points > 0 && s.Quiz != nil && !*s.Quiz {
+			return fmt.Errorf("%s: points need quiz: true", where)
+		}
+	}
+	return nil
+}
+
+func (it *formSpecItem) normalize() error {
+	it.ID = strings.TrimSpace(it.ID)
+	it.Type = strings.ToLower(strings.TrimSpace(it.Type))
+	it.Title = strings.TrimSpace(it.Title)
+	it.Description = strings.TrimSpace(it.Description)
+	it.Choices = cleanedStrings(it.Choices)
+	it.Rows = cleanedStrings(it.Rows)
+	it.Columns = cleanedStrings(it.Columns)
+	it.Correct = cleanedStrings(it.Correct)
+
+	switch it.Type {
+	case "":
+		return fmt.Errorf("type is required")
+	case formSpecText, formSpecParagraph, formSpecRadio, formSpecCheckbox, formSpecDropdown,
+		formSpecScale, formSpecDate, formSpecTime, formSpecGrid, formSpecCheckboxGrid,
+		formSpecSection, formSpecHeading, formSpecFileUpload, formSpecRating, formSpecImage, formSpecVideo:
+	default:
+		return fmt.Errorf("unknown type %q (use text|paragraph|radio|checkbox|dropdown|scale|date|time|grid|checkbox_grid|section|heading)", it.Type)
+	}
+	if it.isQuestion() && it.Title == "" {
+		return fmt.Errorf("questions need a title")
+	}
+
+	choice := it.isChoice()
+	if choice {
+		if len(it.Choices) == 0 && !it.Other {
+			return fmt.Errorf("%s needs choices", it.Type)
+		}
+		if dup := firstDuplicate(it.Choices); dup != "" {
+			return fmt.Errorf("duplicate choice %q", dup)
+		}
+		if it.Other && it.Type == formSpecDropdown {
+			return fmt.Errorf("dropdown questions cannot have an Other option")
+		}
+	} else if len(it.Choices) > 0 || it.Other || it.Shuffle && !it.isGrid() {
+		return fmt.Errorf("choices, other, and shuffle only apply to radio, checkbox, and dropdown")
+	}
+
+	if it.isGrid() {
+		if len(it.Rows) == 0 || len(it.Columns) == 0 {
+			return fmt.Errorf("%s needs rows and columns", it.Type)
+		}
+		if dup := firstDuplicate(it.Rows); dup != "" {
+			return fmt.Errorf("duplicate row %q", dup)
+		}
+	} else if len(it.Rows) > 0 || len(it.Columns) > 0 {
+		return fmt.Errorf("rows and columns only apply to grid and checkbox_grid")
+	}
+
+	if it.Type == formSpecScale {
+		if it.Low == nil {
+			it.Low = int64Ptr(1)
+		}
+		if it.High == nil {
+			it.High = int64Ptr(5)
+		}
+		if *it.Low != 0 && *it.Low != 1 {
+			return fmt.Errorf("low must be 0 or 1")
+		}
+		if *it.High < 2 || *it.High > 10 {
+			return fmt.Errorf("high must be between 2 and 10")
+		}
+	} else if it.Low != nil || it.High != nil || it.LowLabel != "" || it.HighLabel != "" {
+		return fmt.Errorf("low, high, and labels only apply to scale")
+	}
+	if (it.IncludeTime || it.IncludeYear) && it.Type != formSpecDate {
+		return fmt.Errorf("include_time and include_year only apply to date")
+	}
+	if it.Duration && it.Type != formSpecTime {
+		return fmt.Errorf("duration only applies to time")
+	}
+	if it.Required && !it.isQuestion() {
+		return fmt.Errorf("required only applies to questions")
+	}
+	return it.normalizeGrading()
+}
+
+func (it *formSpecItem) normalizeGrading() error {
+	if len(it.Correct) == 0 && it.Points == 0 {
+		return nil
+	}
+	switch it.Type {
+	case formSpecText, formSpecRadio, formSpecCheckbox, formSpecDropdown:
+	default:
+		return fmt.Errorf("correct and points are supported only for text, radio, checkbox, and dropdown")
+	}
+	if it.Points <= 0 {
+		return fmt.Errorf("correct needs points > 0")
+	}
+	if len(it.Correct) == 0 {
+		return fmt.Errorf("points need at least one correct answer")
+	}
+	if it.isChoice() && !it.Other {
+		for _, c := range it.Correct {
+			if !slices.Contains(it.Choices, c) {
+				return fmt.Errorf("correct answer %q is not one of the choices", c)
+			}
+		}
+	}
+	return nil
+}
+
+func (it *formSpecItem) isChoice() bool {
+	return it.Type == formSpecRadio || it.Type == formSpecCheckbox || it.Type == formSpecDropdown
+}
+
+func (it *formSpecItem) isGrid() bool {
+	return it.Type == formSpecGrid || it.Type == formSpecCheckboxGrid
+}
+
+func (it *formSpecItem) isQuestion() bool {
+	switch it.Type {
+	case formSpecSection, formSpecHeading, formSpecImage, formSpecVideo:
+		return false
+	}
+	return true
+}
+
+// readOnly reports item types the API can read but not create or reshape.
+func (it *formSpecItem) readOnly() bool {
+	switch it.Type {
+	case formSpecFileUpload, formSpecRating, formSpecImage, formSpecVideo:
+		return true
+	}
+	return false
+}
+
+// apiKind groups spec types that share an API representation. An existing
+// item can only be updated in place into a type of the same kind.
+func (it *formSpecItem) apiKind() string {
+	switch it.Type {
+	case formSpecText, formSpecParagraph:
+		return "text"
+	case formSpecRadio, formSpecCheckbox, formSpecDropdown:
+		return "choice"
+	case formSpecGrid, formSpecCheckboxGrid:
+		return "grid"
+	}
+	return it.Type
+}
+
+func firstDuplicate(values []string) string {
+	seen := map[string]bool{}
+	for _, v := range values {
+		if seen[v] {
+			return v
+		}
+		seen[v] = true
+	}
+	return ""
+}
+
+// formSpecFromForm converts a live form into a spec, keeping item IDs so a
+// later apply matches items even after they are renamed.
+func formSpecFromForm(form *formsapi.Form) *formSpec {
+	spec := &formSpec{FormID: form.FormId, Items: []*formSpecItem{}}
+	if form.Info != nil {
+		spec.Title = form.Info.Title
+		spec.Description = form.Info.Description
+	}
+	if form.Settings != nil && form.Settings.QuizSettings != nil && form.Settings.QuizSettings.IsQuiz {
+		quiz := true
+		spec.Quiz = &quiz
+	}
+	for _, item := range form.Items {
+		if item != nil {
+			spec.Items = append(spec.Items, formSpecItemFromItem(item))
+		}
+	}
+	return spec
+}
+
+func formSpecItemFromItem(item *formsapi.Item) *formSpecItem {
+	it := &formSpecItem{ID: item.ItemId, Title: strings.TrimSpace(item.Title), Description: strings.TrimSpace(item.Description)}
+	switch {
+	case item.QuestionItem != nil && item.QuestionItem.Question != nil:
+		fillFormSpecQuestion(it, item.QuestionItem.Question)
+	case item.QuestionGroupItem != nil:
+		group := item.QuestionGroupItem
+		it.Type = formSpecGrid
+		if group.Grid != nil && group.Grid.Columns != nil {
+			if group.Grid.Columns.Type == "CHECKBOX" {
+				it.Type = formSpecCheckboxGrid
+			}
+			for _, o := range group.Grid.Columns.Options {
+				if o != nil {
+					it.Columns = append(it.Columns, o.Value)
+				}
+			}
+			it.Shuffle = group.Grid.ShuffleQuestions
+		}
+		for _, q := range group.Questions {
+			if q == nil || q.RowQuestion == nil {
+				continue
+			}
+			it.Rows = append(it.Rows, q.RowQuestion.Title)
+			it.Required = it.Required || q.Required
+		}
+	case item.PageBreakItem != nil:
+		it.Type = formSpecSection
+	case item.ImageItem != nil:
+		it.Type = formSpecImage
+	case item.VideoItem != nil:
+		it.Type = formSpecVideo
+	default:
+		it.Type = formSpecHeading
+	}
+	return it
+}
+
+func fillFormSpecQuestion(it *formSpecItem, q *formsapi.Question) {
+	it.Required = q.Required
+	switch {
+	case q.TextQuestion != nil:
+		it.Type = formSpecText
+		if q.TextQuestion.Paragraph {
+			it.Type = formSpecParagraph
+		}
+	case q.ChoiceQuestion != nil:
+		it.Type = map[string]string{"CHECKBOX": formSpecCheckbox, "DROP_DOWN": formSpecDropdown}[q.ChoiceQuestion.Type]
+		if it.Type == "" {
+			it.Type = formSpecRadio
+		}
+		for _, o := range q.ChoiceQuestion.Options {
+			switch {
+			case o == nil:
+			case o.IsOther:
+				it.Other = true
+			default:
+				it.Choices = append(it.Choices, o.Value)
+			}
+		}
+		it.Shuffle = q.ChoiceQuestion.Shuffle
+	case q.ScaleQuestion != nil:
+		it.Type = formSpecScale
+		it.Low = int64Ptr(q.ScaleQuestion.Low)
+		it.High = int64Ptr(q.ScaleQuestion.High)
+		it.LowLabel = q.ScaleQuestion.LowLabel
+		it.HighLabel = q.ScaleQuestion.HighLabel
+	case q.DateQuestion != nil:
+		it.Type = formSpecDate
+		it.IncludeTime = q.DateQuestion.IncludeTime
+		it.IncludeYear = q.DateQuestion.IncludeYear
+	case q.TimeQuestion != nil:
+		it.Type = formSpecTime
+		it.Duration = q.TimeQuestion.Duration
+	case q.FileUploadQuestion != nil:
+		it.Type = formSpecFileUpload
+		it.Required = false
+	case q.RatingQuestion != nil:
+		it.Type = formSpecRating
+		it.Required = false
+	default:
+		it.Type = formSpecText
+	}
+	if q.Grading != nil && !it.readOnly() {
+		it.Points = q.Grading.PointValue
+		if q.Grading.CorrectAnswers != nil {
+			for _, a := range q.Grading.CorrectAnswers.Answers {
+				if a != nil {
+					it.Correct = append(it.Correct, a.Value)
+				}
+			}
+		}
+	}
+}
+
+// apiItem builds the Forms API item for a spec entry. existing, when set, is
+// the item being updated; its question IDs are kept so responses stay linked.
+func (it *formSpecItem) apiItem(existing *formsapi.Item) *formsapi.Item {
+	item := &formsapi.Item{Title: it.Title, Description: it.Description}
+	if existing != nil {
+		item.ItemId = existing.ItemId
+	}
+	switch {
+	case it.Type == formSpecSection:
+		item.PageBreakItem = &formsapi.PageBreakItem{}
+	case it.Type == formSpecHeading:
+		item.TextItem = &formsapi.TextItem{}
+	case it.isGrid():
+		item.QuestionGroupItem = it.apiGrid(existing)
+	case it.isQuestion():
+		q := it.apiQuestion()
+		if existing != nil && existing.QuestionItem != nil && existing.QuestionItem.Question != nil {
+			q.QuestionId = existing.QuestionItem.Question.QuestionId
+		}
+		item.QuestionItem = &formsapi.QuestionItem{Question: q}
+	}
+	return item
+}
+
+func (it *formSpecItem) apiQuestion() *formsapi.Question {
+	q := &formsapi.Question{Required: it.Required, ForceSendFields: []string{"Required"}}
+	switch it.Type {
+	case formSpecText, formSpecParagraph:
+		q.TextQuestion = &formsapi.TextQuestion{Paragraph: it.Type == formSpecParagraph}
+	case formSpecRadio, formSpecCheckbox, formSpecDropdown:
+		q.ChoiceQuestion = &formsapi.ChoiceQuestion{
+			Type:    map[string]string{formSpecRadio: "RADIO", formSpecCheckbox: "CHECKBOX", formSpecDropdown: "DROP_DOWN"}[it.Type],
+			Options: formSpecOptions(it.Choices, it.Other),
+			Shuffle: it.Shuffle,
+		}
+	case formSpecScale:
+		q.ScaleQuestion = &formsapi.ScaleQuestion{
+			Low: *it.Low, High: *it.High, LowLabel: it.LowLabel, HighLabel: it.HighLabel,
+			ForceSendFields: []string{"Low"},
+		}
+	case formSpecDate:
+		q.DateQuestion = &formsapi.DateQuestion{IncludeTime: it.IncludeTime, IncludeYear: it.IncludeYear}
+	case formSpecTime:
+		q.TimeQuestion = &formsapi.TimeQuestion{Duration: it.Duration}
+	}
+	if it.Points > 0 {
+		answers := make([]*formsapi.CorrectAnswer, len(it.Correct))
+		for i, value := range it.Correct {
+			answers[i] = &formsapi.CorrectAnswer{Value: value}
+		}
+		q.Grading = &formsapi.Grading{
+			PointValue:      it.Points,
+			CorrectAnswers:  &formsapi.CorrectAnswers{Answers: answers},
+			ForceSendFields: []string{"PointValue"},
+		}
+	}
+	return q
+}
+
+func (it *formSpecItem) apiGrid(existing *formsapi.Item) *formsapi.QuestionGroupItem {
+	rowIDs := map[string]string{}
+	if existing != nil && existing.QuestionGroupItem != nil {
+		for _, q := range existing.QuestionGroupItem.Questions {
+			if q != nil && q.RowQuestion != nil {
+				rowIDs[q.RowQuestion.Title] = q.QuestionId
+			}
+		}
+	}
+	columnType := "RADIO"
+	if it.Type == formSpecCheckboxGrid {
+		columnType = "CHECKBOX"
+	}
+	group := &formsapi.QuestionGroupItem{
+		Grid: &formsapi.Grid{
+			Columns:          &formsapi.ChoiceQuestion{Type: columnType, Options: formSpecOptions(it.Columns, false)},
+			ShuffleQuestions: it.Shuffle,
+		},
+	}
+	for _, row := range it.Rows {
+		group.Questions = append(group.Questions, &formsapi.Question{
+			QuestionId:      rowIDs[row],
+			Required:        it.Required,
+			RowQuestion:     &formsapi.RowQuestion{Title: row},
+			ForceSendFields: []string{"Required"},
+		})
+	}
+	return group
+}
+
+func formSpecOptions(values []string, other bool) []*formsapi.Option {
+	opts := make([]*formsapi.Option, 0, len(values)+1)
+	for _, v := range values {
+		opts = append(opts, &formsapi.Option{Value: v})
+	}
+	if other {
+		opts = append(opts, &formsapi.Option{IsOther: true})
+	}
+	return opts
+}
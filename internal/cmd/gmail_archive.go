@@ -3,10 +3,14 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"strings"
 
 	"google.golang.org/api/gmail/v1"
 
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
 	"github.com/steipete/gogcli/internal/outfmt"
 	"github.com/steipete/gogcli/internal/ui"
 )
@@ -65,14 +69,30 @@ func gmailArchiveThreads(ctx context.Context, flags *RootFlags, rawIDs []string,
 		Success  bool   `json:"success"`
 		Error    string `json:"error,omitempty"`
 	}
+	batch, err := googleBatch(ctx, account, googleauth.ServiceGmail, svc.BasePath)
+	if err != nil {
+		return err
+	}
+	snapshot, snapshotErr := gmailLabelSnapshot(ctx, account, svc, threadIDs, true)
+	calls := make([]googleapi.BatchCall, 0, len(threadIDs))
+	for _, threadID := range threadIDs {
+		calls = append(calls, googleapi.BatchCall{
+			Method: http.MethodPost,
+			Path:   "gmail/v1/users/me/threads/" + url.PathEscape(threadID) + "/modify",
+			Body:   &gmail.ModifyThreadRequest{RemoveLabelIds: []string{"INBOX"}},
+		})
+	}
+	modified, err := batch.Do(ctx, calls)
+	if err != nil {
+		return err
+	}
+
 	results := make([]archiveResult, 0, len(threadIDs))
+	archived := make([]string, 0, len(threadIDs))
 	succeeded := 0
 	failed := 0
-	for _, threadID := range threadIDs {
-		_, err := svc.Users.Threads.Modify("me", threadID, &gmail.ModifyThreadRequest{
-			RemoveLabelIds: []string{"INBOX"},
-		}).Context(ctx).Do()
-		if err != nil {
+	for i, threadID := range threadIDs {
+		if err := modified[i].Err; err != nil {
 			results = append(results, archiveResult{ThreadID: threadID, Error: err.Error()})
 			failed++
 			if !outfmt.IsJSON(ctx) {
@@ -81,8 +101,12 @@ func gmailArchiveThreads(ctx context.Context, flags *RootFlags, rawIDs []string,
 			continue
 		}
 		results = append(results, archiveResult{ThreadID: threadID, Success: true})
+		archived = append(archived, threadID)
 		succeeded++
 	}
+	if len(archived) > 0 {
+		recordGmailLabelUndo(ctx, account, fmt.Sprintf("archived %d thread%s", succeeded, pluralS(succeeded)), snapshot, snapshotErr, archived, nil, []string{"INBOX"})
+	}
 
 	switch {
 	case outfmt.IsJSON(ctx):
@@ -213,6 +237,8 @@ func gmailBulkLabelOp(ctx context.Context, flags *RootFlags, messageIDs []string
 	addIDs := resolveLabelIDs(addLabels, idMap)
 	removeIDs := resolveLabelIDs(removeLabels, idMap)
 
+	snapshot, snapshotErr := gmailLabelSnapshot(ctx, account, svc, ids, false)
+
 	// Batch modify in chunks of 1000 (API limit)
 	total := 0
 	for i := 0; i < len(ids); i += 1000 {
@@ -237,6 +263,7 @@ func gmailBulkLabelOp(ctx context.Context, flags *RootFlags, messageIDs []string
 		}
 		total += len(chunk)
 	}
+	recordGmailLabelUndo(ctx, account, fmt.Sprintf("%s %d message%s", verb, total, pluralS(total)), snapshot, snapshotErr, ids, addIDs, removeIDs)
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
@@ -192,7 +192,7 @@ func TestGmailArchiveCmd_ThreadModeRejectsQuery(t *testing.T) {
 
 func TestGmailArchiveCmd_ArchivesWholeThreads(t *testing.T) {
 	var modified []string
-	svc, cleanup := newGmailServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+	svc, cleanup := newGmailServiceForTest(t, serveGoogleBatch(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1")
 		if r.Method != http.MethodPost || !strings.HasSuffix(path, "/modify") {
 			http.NotFound(w, r)
@@ -210,11 +210,11 @@ func TestGmailArchiveCmd_ArchivesWholeThreads(t *testing.T) {
 		modified = append(modified, parts[len(parts)-2])
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = w.Write([]byte(`{"id":"ok"}`))
-	})
+	})))
 	defer cleanup()
 
 	var out bytes.Buffer
-	ctx := withGmailTestService(newCmdRuntimeJSONOutputContext(t, &out, io.Discard), svc)
+	ctx := withGoogleBatchTestClient(withGmailTestService(newCmdRuntimeJSONOutputContext(t, &out, io.Discard), svc))
 	if err := runKong(t, &GmailArchiveCmd{}, []string{"--thread", "thread1", "thread2"}, ctx, &RootFlags{Account: "a@b.com"}); err != nil {
 		t.Fatalf("archive threads: %v", err)
 	}
@@ -240,7 +240,7 @@ func TestGmailArchiveCmd_ArchivesWholeThreads(t *testing.T) {
 
 func TestGmailArchiveCmd_ReportsPartialThreadFailures(t *testing.T) {
 	var modified []string
-	svc, cleanup := newGmailServiceForTest(t, func(w http.ResponseWriter, r *http.Request) {
+	svc, cleanup := newGmailServiceForTest(t, serveGoogleBatch(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		path := strings.TrimPrefix(r.URL.Path, "/gmail/v1")
 		parts := strings.Split(path, "/")
 		threadID := parts[len(parts)-2]
@@ -251,11 +251,11 @@ func TestGmailArchiveCmd_ReportsPartialThreadFailures(t *testing.T) {
 		}
 		w.Header().Set("Content-Type", "application/json")
 		_, _ = w.Write([]byte(`{"id":"ok"}`))
-	})
+	})))
 	defer cleanup()
 
 	var out bytes.Buffer
-	ctx := withGmailTestService(newCmdRuntimeJSONOutputContext(t, &out, io.Discard), svc)
+	ctx := withGoogleBatchTestClient(withGmailTestService(newCmdRuntimeJSONOutputContext(t, &out, io.Discard), svc))
 	runErr := runKong(t, &GmailArchiveCmd{}, []string{"--thread", "thread1", "thread2", "thread3"}, ctx, &RootFlags{Account: "a@b.com"})
 	if runErr == nil || !strings.Contains(runErr.Error(), "archived 2 of 3 threads; 1 failed") {
 		t.Fatalf("unexpected error: %v", runErr)
@@ -106,34 +106,20 @@ func (c *GmailAutoForwardUpdateCmd) Run(ctx context.Context, kctx *kong.Context,
 		return err
 	}
 
-	// Get current settings first
-	current, err := svc.Users.Settings.GetAutoForwarding("me").Do()
-	if err != nil {
-		return err
-	}
-
-	// Build update request, preserving existing values if not specified
-	autoForward := &gmail.AutoForwarding{
-		Enabled:      current.Enabled,
-		EmailAddress: current.EmailAddress,
-		Disposition:  current.Disposition,
-	}
-
-	// Apply flags
-	if c.Enable {
-		autoForward.Enabled = true
-	}
-	if c.Disable {
-		autoForward.Enabled = false
-	}
-	if flagProvided(kctx, "email") {
-		autoForward.EmailAddress = strings.TrimSpace(c.Email)
-	}
-	if flagProvided(kctx, "disposition") {
-		autoForward.Disposition = c.Disposition
-	}
-
-	updated, err := svc.Users.Settings.UpdateAutoForwarding("me", autoForward).Do()
+	updated, err := updateGmailAutoForwarding(ctx, svc, func(autoForward *gmail.AutoForwarding) {
+		if c.Enable {
+			autoForward.Enabled = true
+		}
+		if c.Disable {
+			autoForward.Enabled = false
+		}
+		if flagProvided(kctx, "email") {
+			autoForward.EmailAddress = strings.TrimSpace(c.Email)
+		}
+		if flagProvided(kctx, "disposition") {
+			autoForward.Disposition = c.Disposition
+		}
+	})
 	if err != nil {
 		return err
 	}
@@ -152,3 +138,19 @@ func (c *GmailAutoForwardUpdateCmd) Run(ctx context.Context, kctx *kong.Context,
 	}
 	return nil
 }
+
+// updateGmailAutoForwarding reads the current auto-forwarding settings, applies
+// edit, and writes them back.
+func updateGmailAutoForwarding(ctx context.Context, svc *gmail.Service, edit func(*gmail.AutoForwarding)) (*gmail.AutoForwarding, error) {
+	current, err := svc.Users.Settings.GetAutoForwarding("me").Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	autoForward := &gmail.AutoForwarding{
+		Enabled:      current.Enabled,
+		EmailAddress: current.EmailAddress,
+		Disposition:  current.Disposition,
+	}
+	edit(autoForward)
+	return svc.Users.Settings.UpdateAutoForwarding("me", autoForward).Context(ctx).Do()
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	"google.golang.org/api/gmail/v1"
 
@@ -115,6 +116,7 @@ func (c *GmailBatchModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	snapshot, snapshotErr := gmailLabelSnapshot(ctx, account, svc, ids, false)
 	err = svc.Users.Messages.BatchModify("me", &gmail.BatchModifyMessagesRequest{
 		Ids:            ids,
 		AddLabelIds:    addIDs,
@@ -123,6 +125,7 @@ func (c *GmailBatchModifyCmd) Run(ctx context.Context, flags *RootFlags) error {
 	if err != nil {
 		return err
 	}
+	recordGmailLabelUndo(ctx, account, fmt.Sprintf("modified labels on %d message%s", len(ids), pluralS(len(ids))), snapshot, snapshotErr, ids, addIDs, removeIDs)
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
@@ -53,7 +53,7 @@ func (c *GmailHistoryCmd) Run(ctx context.Context, flags *RootFlags) error {
 		historyIDs := collectHistoryMessageIDs(resp)
 		return historyIDs.FetchIDs, resp.NextPageToken, nil
 	}
-	ids, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	ids, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
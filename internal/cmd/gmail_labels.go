@@ -361,6 +361,8 @@ func (c *GmailLabelsModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 		Error    string `json:"error,omitempty"`
 	}
 	results := make([]result, 0, len(threadIDs))
+	modified := make([]string, 0, len(threadIDs))
+	snapshot, snapshotErr := gmailLabelSnapshot(ctx, account, svc, threadIDs, true)
 
 	for _, tid := range threadIDs {
 		_, err := svc.Users.Threads.Modify("me", tid, &gmail.ModifyThreadRequest{
@@ -375,10 +377,14 @@ func (c *GmailLabelsModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 			continue
 		}
 		results = append(results, result{ThreadID: tid, Success: true})
+		modified = append(modified, tid)
 		if !outfmt.IsJSON(ctx) {
 			u.Out().Linef("%s\tok", tid)
 		}
 	}
+	if len(modified) > 0 {
+		recordGmailLabelUndo(ctx, account, fmt.Sprintf("modified labels on %d thread%s", len(modified), pluralS(len(modified))), snapshot, snapshotErr, modified, addIDs, removeIDs)
+	}
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"results": results})
 	}
@@ -73,6 +73,10 @@ func (c *GmailMessagesSearchCmd) Run(ctx context.Context, flags *RootFlags) erro
 		return resp.Messages, resp.NextPageToken, nil
 	}
 
+	if outfmt.IsNDJSON(ctx) {
+		return c.streamNDJSON(ctx, svc, fetch)
+	}
+
 	messages, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
 	if err != nil {
 		return err
@@ -128,6 +132,35 @@ func (c *GmailMessagesSearchCmd) Run(ctx context.Context, flags *RootFlags) erro
 	return nil
 }
 
+// streamNDJSON prints each page of message details as it arrives.
+func (c *GmailMessagesSearchCmd) streamNDJSON(ctx context.Context, svc *gmail.Service, fetch pageFetchFunc[*gmail.Message]) error {
+	loc, err := resolveOutputLocation(ctx, c.Timezone, c.Local, stderrWriter(ctx))
+	if err != nil {
+		return err
+	}
+	var idToName map[string]string
+	count, err := streamConvertedPages(ctx, c.Page, c.All, fetch, func(messages []*gmail.Message) ([]messageItem, error) {
+		if len(messages) == 0 {
+			return nil, nil
+		}
+		if idToName == nil {
+			names, labelErr := fetchLabelIDToName(svc)
+			if labelErr != nil {
+				return nil, labelErr
+			}
+			idToName = names
+		}
+		return fetchMessageDetails(ctx, svc, messages, idToName, loc, c.IncludeBody, c.BodyFormat)
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return failEmptyExit(c.FailEmpty)
+	}
+	return nil
+}
+
 type GmailMessagesModifyCmd struct {
 	MessageID string `arg:"" name:"messageId" help:"Message ID"`
 	Add       string `name:"add" help:"Labels to add (comma-separated, name or ID)"`
@@ -170,6 +203,7 @@ func (c *GmailMessagesModifyCmd) Run(ctx context.Context, flags *RootFlags) erro
 		return err
 	}
 
+	snapshot, snapshotErr := gmailLabelSnapshot(ctx, account, svc, []string{messageID}, false)
 	_, err = svc.Users.Messages.Modify("me", messageID, &gmail.ModifyMessageRequest{
 		AddLabelIds:    addIDs,
 		RemoveLabelIds: removeIDs,
@@ -177,6 +211,7 @@ func (c *GmailMessagesModifyCmd) Run(ctx context.Context, flags *RootFlags) erro
 	if err != nil {
 		return err
 	}
+	recordGmailLabelUndo(ctx, account, "modified labels on message "+messageID, snapshot, snapshotErr, []string{messageID}, addIDs, removeIDs)
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
@@ -60,6 +60,10 @@ func (c *GmailSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return resp.Threads, resp.NextPageToken, nil
 	}
 
+	if outfmt.IsNDJSON(ctx) {
+		return c.streamNDJSON(ctx, svc, fetch)
+	}
+
 	threads, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
 	if err != nil {
 		return err
@@ -110,6 +114,35 @@ func (c *GmailSearchCmd) Run(ctx context.Context, flags *RootFlags) error {
 	return nil
 }
 
+// streamNDJSON prints each page of thread details as it arrives.
+func (c *GmailSearchCmd) streamNDJSON(ctx context.Context, svc *gmail.Service, fetch pageFetchFunc[*gmail.Thread]) error {
+	loc, err := resolveOutputLocation(ctx, c.Timezone, c.Local, stderrWriter(ctx))
+	if err != nil {
+		return err
+	}
+	var idToName map[string]string
+	count, err := streamConvertedPages(ctx, c.Page, c.All, fetch, func(threads []*gmail.Thread) ([]threadItem, error) {
+		if len(threads) == 0 {
+			return nil, nil
+		}
+		if idToName == nil {
+			names, labelErr := fetchLabelIDToName(svc)
+			if labelErr != nil {
+				return nil, labelErr
+			}
+			idToName = names
+		}
+		return fetchThreadDetails(ctx, svc, threads, idToName, c.Oldest, loc)
+	})
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return failEmptyExit(c.FailEmpty)
+	}
+	return nil
+}
+
 func gmailFromContactQuery(ctx context.Context, account, selector string) (string, error) {
 	selector = strings.TrimSpace(selector)
 	if selector == "" {
@@ -199,6 +199,7 @@ func (c *GmailThreadModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 		return err
 	}
 
+	snapshot, snapshotErr := gmailLabelSnapshot(ctx, account, svc, []string{threadID}, true)
 	// Use Gmail's Threads.Modify API
 	_, err = svc.Users.Threads.Modify("me", threadID, &gmail.ModifyThreadRequest{
 		AddLabelIds:    addIDs,
@@ -207,6 +208,7 @@ func (c *GmailThreadModifyCmd) Run(ctx context.Context, flags *RootFlags) error
 	if err != nil {
 		return err
 	}
+	recordGmailLabelUndo(ctx, account, "modified labels on thread "+threadID, snapshot, snapshotErr, []string{threadID}, addIDs, removeIDs)
 
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
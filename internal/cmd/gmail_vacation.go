@@ -125,62 +125,33 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 		return err
 	}
 
-	// Get current settings first
-	current, err := svc.Users.Settings.GetVacation("me").Do()
-	if err != nil {
-		return err
-	}
-
-	// Build update request, preserving existing values if not specified
-	vacation := &gmail.VacationSettings{
-		EnableAutoReply:       current.EnableAutoReply,
-		ResponseSubject:       current.ResponseSubject,
-		ResponseBodyHtml:      current.ResponseBodyHtml,
-		ResponseBodyPlainText: current.ResponseBodyPlainText,
-		StartTime:             current.StartTime,
-		EndTime:               current.EndTime,
-		RestrictToContacts:    current.RestrictToContacts,
-		RestrictToDomain:      current.RestrictToDomain,
-	}
-
-	// Apply flags
-	if c.Enable {
-		vacation.EnableAutoReply = true
-	}
-	if c.Disable {
-		vacation.EnableAutoReply = false
-	}
-	if flagProvided(kctx, "subject") {
-		vacation.ResponseSubject = c.Subject
-	}
-	if flagProvided(kctx, "body") {
-		vacation.ResponseBodyHtml = c.Body
-		vacation.ResponseBodyPlainText = stripHTML(c.Body)
-	}
-	if flagProvided(kctx, "start") {
-		var t int64
-		t, err = parseRFC3339ToMillis(c.Start)
-		if err != nil {
-			return usagef("invalid --start %q (expected RFC3339)", c.Start)
+	updated, err := updateGmailVacation(ctx, svc, func(vacation *gmail.VacationSettings) {
+		if c.Enable {
+			vacation.EnableAutoReply = true
 		}
-		vacation.StartTime = t
-	}
-	if flagProvided(kctx, "end") {
-		var t int64
-		t, err = parseRFC3339ToMillis(c.End)
-		if err != nil {
-			return usagef("invalid --end %q (expected RFC3339)", c.End)
+		if c.Disable {
+			vacation.EnableAutoReply = false
 		}
-		vacation.EndTime = t
-	}
-	if flagProvided(kctx, "contacts-only") {
-		vacation.RestrictToContacts = c.ContactsOnly
-	}
-	if flagProvided(kctx, "domain-only") {
-		vacation.RestrictToDomain = c.DomainOnly
-	}
-
-	updated, err := svc.Users.Settings.UpdateVacation("me", vacation).Do()
+		if flagProvided(kctx, "subject") {
+			vacation.ResponseSubject = c.Subject
+		}
+		if flagProvided(kctx, "body") {
+			vacation.ResponseBodyHtml = c.Body
+			vacation.ResponseBodyPlainText = stripHTML(c.Body)
+		}
+		if t, ok := updates["start_time"].(int64); ok {
+			vacation.StartTime = t
+		}
+		if t, ok := updates["end_time"].(int64); ok {
+			vacation.EndTime = t
+		}
+		if flagProvided(kctx, "contacts-only") {
+			vacation.RestrictToContacts = c.ContactsOnly
+		}
+		if flagProvided(kctx, "domain-only") {
+			vacation.RestrictToDomain = c.DomainOnly
+		}
+	})
 	if err != nil {
 		return err
 	}
@@ -203,6 +174,27 @@ func (c *GmailVacationUpdateCmd) Run(ctx context.Context, kctx *kong.Context, fl
 	return nil
 }
 
+// updateGmailVacation reads the current vacation responder, applies edit, and
+// writes it back, so fields the caller does not touch keep their values.
+func updateGmailVacation(ctx context.Context, svc *gmail.Service, edit func(*gmail.VacationSettings)) (*gmail.VacationSettings, error) {
+	current, err := svc.Users.Settings.GetVacation("me").Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+	vacation := &gmail.VacationSettings{
+		EnableAutoReply:       current.EnableAutoReply,
+		ResponseSubject:       current.ResponseSubject,
+		ResponseBodyHtml:      current.ResponseBodyHtml,
+		ResponseBodyPlainText: current.ResponseBodyPlainText,
+		StartTime:             current.StartTime,
+		EndTime:               current.EndTime,
+		RestrictToContacts:    current.RestrictToContacts,
+		RestrictToDomain:      current.RestrictToDomain,
+	}
+	edit(vacation)
+	return svc.Users.Settings.UpdateVacation("me", vacation).Context(ctx).Do()
+}
+
 func parseRFC3339ToMillis(rfc3339 string) (int64, error) {
 	if rfc3339 == "" {
 		return 0, nil
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/app"
+	"github.com/steipete/gogcli/internal/googleauth"
+)
+
+// serveGoogleBatch answers Google /batch requests by replaying every part
+// against next, in order, so tests keep their per-call handlers.
+func serveGoogleBatch(t *testing.T, next http.Handler) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || !strings.HasPrefix(r.URL.Path, "/batch") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("batch content type: %v", err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var buf bytes.Buffer
+		out := multipart.NewWriter(&buf)
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Errorf("batch part: %v", err)
+				return
+			}
+			sub, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				t.Errorf("batch sub-request: %v", err)
+				return
+			}
+			rec := httptest.NewRecorder()
+			next.ServeHTTP(rec, sub.WithContext(r.Context()))
+
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Type", "application/http")
+			header.Set("Content-ID", "<response-"+strings.Trim(part.Header.Get("Content-ID"), "<>")+">")
+			pw, _ := out.CreatePart(header)
+			fmt.Fprintf(pw, "HTTP/1.1 %d %s\r\n", rec.Code, http.StatusText(rec.Code))
+			_ = rec.Header().Write(pw)
+			fmt.Fprintf(pw, "\r\n%s", rec.Body.Bytes())
+		}
+		_ = out.Close()
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+out.Boundary())
+		_, _ = w.Write(buf.Bytes())
+	}
+}
+
+func googleBatchTestClient(context.Context, string, googleauth.Service) (*http.Client, error) {
+	return http.DefaultClient, nil
+}
+
+func withGoogleBatchTestClient(ctx context.Context) context.Context {
+	runtime := &app.Runtime{}
+	if existing, ok := app.FromContext(ctx); ok {
+		*runtime = *existing
+	}
+	runtime.Services.Batch = googleBatchTestClient
+	return app.WithRuntime(ctx, runtime)
+}
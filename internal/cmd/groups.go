@@ -131,7 +131,7 @@ func requireGroupsAccount(flags *RootFlags) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if account == accessTokenPlaceholderAccount || account == adcPlaceholderAccount || shouldAutoSelectAccount(account) {
+	if account == accessTokenPlaceholderAccount || account == adcPlaceholderAccount || account == externalAccountPlaceholder || shouldAutoSelectAccount(account) {
 		return "", usage(groupsExplicitAccountMessage)
 	}
 	if isConsumerAccount(account) {
@@ -145,8 +145,8 @@ func requireGroupsAuthAccount(flags *RootFlags) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if isADCAuthMode(flags) {
-		return adcPlaceholderAccount, nil
+	if isKeylessAuthMode(flags) {
+		return keylessPlaceholderAccount(flags), nil
 	}
 	if hasDirectAccessToken(flags) {
 		return accessTokenPlaceholderAccount, nil
@@ -182,6 +182,14 @@ func wrapCloudIdentityError(err error, account string) error {
 				),
 				err,
 			)
+		case externalAccountPlaceholder:
+			return errfmt.NewUserFacingError(
+				fmt.Sprintf(
+					"Insufficient permissions for Cloud Identity API; the external-account principal needs Workspace Cloud Identity access and scope %s. To use a stored delegated service account instead, unset GOG_AUTH_MODE and pass --account <workspace-email>.",
+					groupReadonlyScope,
+				),
+				err,
+			)
 		case adcPlaceholderAccount:
 			return errfmt.NewUserFacingError(
 				fmt.Sprintf(
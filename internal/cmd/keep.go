@@ -59,7 +59,7 @@ func (c *KeepListCmd) Run(ctx context.Context, flags *RootFlags, keep *KeepCmd)
 		return resp.Notes, resp.NextPageToken, nil
 	}
 
-	notes, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	notes, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
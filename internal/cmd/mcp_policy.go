@@ -37,7 +37,7 @@ func mcpEnabledToolsForRun(ctx context.Context, cmd McpCmd, flags *RootFlags) ([
 }
 
 func resolveMCPPolicyAccount(flags *RootFlags) (string, error) {
-	if hasDirectAccessToken(flags) || isADCAuthMode(flags) {
+	if hasDirectAccessToken(flags) || isKeylessAuthMode(flags) {
 		// Account values are only labels in these modes, so they must never select
 		// a per-account authorization policy. The global policy still applies.
 		return "", nil
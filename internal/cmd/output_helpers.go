@@ -20,6 +20,10 @@ func kv(key string, value any) resultKV {
 
 func tableWriter(ctx context.Context) (io.Writer, func()) {
 	stdout := stdoutWriter(ctx)
+	if outfmt.IsCSV(ctx) {
+		cw := outfmt.NewCSVLineWriter(stdout)
+		return cw, func() { _ = cw.Flush() }
+	}
 	if outfmt.IsPlain(ctx) {
 		return stdout, func() {}
 	}
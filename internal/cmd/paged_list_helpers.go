@@ -20,6 +20,42 @@ func loadPagedItems[T any](page string, all bool, fetch pageFetchFunc[T]) ([]T,
 	return fetch(page)
 }
 
+// streamPageItems prints each fetched page as NDJSON lines as soon as it
+// arrives under --output ndjson. Only wrap fetches whose items the command
+// prints unchanged.
+func streamPageItems[T any](ctx context.Context, fetch pageFetchFunc[T]) pageFetchFunc[T] {
+	if !outfmt.IsNDJSON(ctx) {
+		return fetch
+	}
+	return func(pageToken string) ([]T, string, error) {
+		items, next, err := fetch(pageToken)
+		if err != nil {
+			return items, next, err
+		}
+		return items, next, outfmt.StreamItems(ctx, stdoutWriter(ctx), items)
+	}
+}
+
+// streamConvertedPages is streamPageItems for commands that print a
+// per-page conversion of the fetched items, such as detail lookups. It
+// returns how many converted items were printed.
+func streamConvertedPages[T, U any](ctx context.Context, page string, all bool, fetch pageFetchFunc[T], convert func([]T) ([]U, error)) (int, error) {
+	count := 0
+	_, _, err := loadPagedItems(page, all, func(pageToken string) ([]T, string, error) {
+		items, next, err := fetch(pageToken)
+		if err != nil {
+			return items, next, err
+		}
+		converted, err := convert(items)
+		if err != nil {
+			return items, next, err
+		}
+		count += len(converted)
+		return items, next, outfmt.StreamItems(ctx, stdoutWriter(ctx), converted)
+	})
+	return count, err
+}
+
 func writePagedJSONResult(ctx context.Context, payload map[string]any, emptyCount int, failEmpty bool) error {
 	if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload); err != nil {
 		return err
@@ -3,6 +3,9 @@ package cmd
 import (
 	"fmt"
 	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/telemetry"
 )
 
 const emptyResultsExitCode = 3
@@ -16,21 +19,25 @@ func failEmptyExit(failEmpty bool) error {
 
 // collectAllPages keeps calling fetch until it returns an empty next page token.
 // It guards against pagination loops by tracking seen page tokens.
-func collectAllPages[T any](startPageToken string, fetch func(pageToken string) ([]T, string, error)) ([]T, error) {
+func collectAllPages[T any](startPageToken string, fetch func(pageToken string) ([]T, string, error)) (out []T, err error) {
+	span := telemetry.StartPagination()
+	defer func() { span.End(err) }()
+
 	pageToken := strings.TrimSpace(startPageToken)
 	seen := map[string]bool{}
 
-	var out []T
 	for i := 0; i < 10_000; i++ {
 		if seen[pageToken] {
 			return nil, fmt.Errorf("pagination loop: repeated page token %q", pageToken)
 		}
 		seen[pageToken] = true
 
+		start := time.Now()
 		items, next, err := fetch(pageToken)
 		if err != nil {
 			return nil, err
 		}
+		span.Page(len(items), time.Since(start))
 		out = append(out, items...)
 
 		next = strings.TrimSpace(next)
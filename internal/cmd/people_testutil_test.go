@@ -62,6 +62,7 @@ type peopleTestServices struct {
 	Contacts  app.PeopleServiceFactory
 	Directory app.PeopleServiceFactory
 	Other     app.PeopleServiceFactory
+	Batch     app.BatchHTTPClientFactory
 }
 
 func fixedPeopleTestService(svc *people.Service) app.PeopleServiceFactory {
@@ -96,6 +97,7 @@ func executeWithPeopleTestServices(t *testing.T, args []string, services peopleT
 		PeopleContacts:  services.Contacts,
 		PeopleDirectory: services.Directory,
 		PeopleOther:     services.Other,
+		Batch:           services.Batch,
 	}})
 }
 
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type PhotosAlbumsCmd struct {
+	List   PhotosAlbumsListCmd   `cmd:"" name:"list" aliases:"ls" help:"List app-created albums"`
+	Create PhotosAlbumsCreateCmd `cmd:"" name:"create" aliases:"new" help:"Create an album"`
+	Add    PhotosAlbumsAddCmd    `cmd:"" name:"add" help:"Add app-created media items to an app-created album"`
+	Remove PhotosAlbumsRemoveCmd `cmd:"" name:"remove" aliases:"rm" help:"Remove media items from an app-created album (the items are kept)"`
+}
+
+type PhotosAlbumsListCmd struct {
+	Max  int64  `name:"max" aliases:"limit" help:"Max results (max 50)" default:"25"`
+	Page string `name:"page" aliases:"cursor" help:"Page token"`
+}
+
+func (c *PhotosAlbumsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Max <= 0 || c.Max > 50 {
+		return usage("max must be between 1 and 50")
+	}
+	client, err := requirePhotosClient(ctx, flags)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListAlbums(ctx, googleapi.PhotosListOptions{PageSize: c.Max, PageToken: c.Page})
+	if err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"albums":        resp.Albums,
+			"albumCount":    len(resp.Albums),
+			"nextPageToken": resp.NextPageToken,
+		})
+	}
+	if len(resp.Albums) == 0 {
+		u.Err().Println("No albums")
+		return nil
+	}
+	w, flush := tableWriter(ctx)
+	defer flush()
+	fmt.Fprintln(w, "ID\tTITLE\tITEMS\tPRODUCT_URL")
+	for _, album := range resp.Albums {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", album.ID, sanitizeTab(album.Title), album.MediaItemsCount, album.ProductURL)
+	}
+	printNextPageHint(u, resp.NextPageToken)
+	return nil
+}
+
+type PhotosAlbumsCreateCmd struct {
+	Title string `arg:"" name:"title" help:"Album title"`
+}
+
+func (c *PhotosAlbumsCreateCmd) Run(ctx context.Context, flags *RootFlags) error {
+	title := strings.TrimSpace(c.Title)
+	if title == "" {
+		return usage("empty title")
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "photos.albums.create", map[string]any{"title": title}); dryRunErr != nil {
+		return dryRunErr
+	}
+	client, err := requirePhotosWriteClient(ctx, flags)
+	if err != nil {
+		return err
+	}
+	album, err := client.CreateAlbum(ctx, title)
+	if err != nil {
+		return err
+	}
+	return writeResult(ctx, ui.FromContext(ctx),
+		kv("id", album.ID),
+		kv("title", album.Title),
+		kv("productUrl", album.ProductURL),
+	)
+}
+
+type PhotosAlbumsAddCmd struct {
+	AlbumID      string   `arg:"" name:"albumId" help:"App-created album ID"`
+	MediaItemIDs []string `arg:"" name:"mediaItemId" help:"App-created media item IDs"`
+}
+
+func (c *PhotosAlbumsAddCmd) Run(ctx context.Context, flags *RootFlags) error {
+	albumID, ids, err := photosAlbumBatchArgs(c.AlbumID, c.MediaItemIDs)
+	if err != nil {
+		return err
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "photos.albums.add", map[string]any{
+		"album_id":       albumID,
+		"media_item_ids": ids,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	client, err := requirePhotosWriteClient(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if err := photosAlbumBatches(ids, func(chunk []string) error {
+		return client.AddMediaItemsToAlbum(ctx, albumID, chunk)
+	}); err != nil {
+		return err
+	}
+	return writeResult(ctx, ui.FromContext(ctx), kv("albumId", albumID), kv("added", len(ids)))
+}
+
+type PhotosAlbumsRemoveCmd struct {
+	AlbumID      string   `arg:"" name:"albumId" help:"App-created album ID"`
+	MediaItemIDs []string `arg:"" name:"mediaItemId" help:"Media item IDs to remove from the album"`
+}
+
+func (c *PhotosAlbumsRemoveCmd) Run(ctx context.Context, flags *RootFlags) error {
+	albumID, ids, err := photosAlbumBatchArgs(c.AlbumID, c.MediaItemIDs)
+	if err != nil {
+		return err
+	}
+	if dryRunErr := dryRunExit(ctx, flags, "photos.albums.remove", map[string]any{
+		"album_id":       albumID,
+		"media_item_ids": ids,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	if confirmErr := confirmDestructiveChecked(ctx, flags, fmt.Sprintf("remove %d media item%s from album %s", len(ids), pluralS(len(ids)), albumID)); confirmErr != nil {
+		return confirmErr
+	}
+	client, err := requirePhotosWriteClient(ctx, flags)
+	if err != nil {
+		return err
+	}
+	if err := photosAlbumBatches(ids, func(chunk []string) error {
+		return client.RemoveMediaItemsFromAlbum(ctx, albumID, chunk)
+	}); err != nil {
+		return err
+	}
+	return writeResult(ctx, ui.FromContext(ctx), kv("albumId", albumID), kv("removed", len(ids)))
+}
+
+func requirePhotosWriteClient(ctx context.Context, flags *RootFlags) (*googleapi.PhotosClient, error) {
+	account, err := requireAccount(flags)
+	if err != nil {
+		return nil, err
+	}
+	return photosWriteService(ctx, account)
+}
+
+func photosAlbumBatchArgs(rawAlbumID string, rawIDs []string) (string, []string, error) {
+	albumID := strings.TrimSpace(rawAlbumID)
+	if albumID == "" {
+		return "", nil, usage("empty albumId")
+	}
+	ids := make([]string, 0, len(rawIDs))
+	seen := map[string]bool{}
+	for _, raw := range rawIDs {
+		id := strings.TrimSpace(raw)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 {
+		return "", nil, usage("no media item IDs")
+	}
+	return albumID, ids, nil
+}
+
+// photosAlbumBatches calls fn with at most 50 IDs at a time, the API limit
+// for album batch changes.
+func photosAlbumBatches(ids []string, fn func([]string) error) error {
+	for start := 0; start < len(ids); start += googleapi.PhotosAlbumBatchMaxItems {
+		end := min(start+googleapi.PhotosAlbumBatchMaxItems, len(ids))
+		if err := fn(ids[start:end]); err != nil {
+			if start > 0 {
+				return fmt.Errorf("after %d of %d items: %w", start, len(ids), err)
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -12,6 +12,7 @@ import (
 
 type photosTestServices struct {
 	Photos       app.PhotosServiceFactory
+	PhotosWrite  app.PhotosServiceFactory
 	PhotosPicker app.PhotosPickerServiceFactory
 	OpenURL      app.OpenURLFunc
 }
@@ -67,6 +68,7 @@ func executeWithPhotosTestServices(t *testing.T, args []string, services photosT
 	t.Helper()
 	return executeWithTestRuntime(t, args, &app.Runtime{Services: app.Services{
 		Photos:       services.Photos,
+		PhotosWrite:  services.PhotosWrite,
 		PhotosPicker: services.PhotosPicker,
 		OpenURL:      services.OpenURL,
 	}})
@@ -75,6 +77,7 @@ func executeWithPhotosTestServices(t *testing.T, args []string, services photosT
 func withPhotosTestServices(ctx context.Context, services photosTestServices) context.Context {
 	return withTestRuntime(ctx, func(runtime *app.Runtime) {
 		runtime.Services.Photos = services.Photos
+		runtime.Services.PhotosWrite = services.PhotosWrite
 		runtime.Services.PhotosPicker = services.PhotosPicker
 		runtime.Services.OpenURL = services.OpenURL
 	})
@@ -0,0 +1,571 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// Upload tokens are valid for a day; reuse saved ones only well inside that.
+const photosUploadTokenReuse = 20 * time.Hour
+
+var sleepBeforePhotosRetry = func(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// photosMediaTypes lists the extensions picked up when walking a directory.
+var photosMediaTypes = map[string]string{
+	".jpg":  "image/jpeg",
+	".jpeg": "image/jpeg",
+	".png":  "image/png",
+	".gif":  "image/gif",
+	".webp": "image/webp",
+	".heic": "image/heic",
+	".heif": "image/heif",
+	".avif": "image/avif",
+	".bmp":  "image/bmp",
+	".tif":  "image/tiff",
+	".tiff": "image/tiff",
+	".dng":  "image/x-adobe-dng",
+	".mp4":  "video/mp4",
+	".m4v":  "video/x-m4v",
+	".mov":  "video/quicktime",
+	".avi":  "video/x-msvideo",
+	".mkv":  "video/x-matroska",
+	".webm": "video/webm",
+	".3gp":  "video/3gpp",
+	".mpg":  "video/mpeg",
+	".mpeg": "video/mpeg",
+	".wmv":  "video/x-ms-wmv",
+	".mts":  "video/mp2t",
+	".m2ts": "video/mp2t",
+}
+
+type PhotosUploadCmd struct {
+	Paths       []string `arg:"" name:"path" help:"Files or directories to upload; directories are walked for photos and videos" type:"path"`
+	Album       string   `name:"album" aliases:"album-id" help:"App-created album ID to add the new media items to"`
+	Description string   `name:"description" help:"Description for every new media item"`
+	State       string   `name:"state" help:"Dedupe state file (default: one per account under the state directory)"`
+	NoDedupe    bool     `name:"no-dedupe" help:"Upload every file, even content that was uploaded before"`
+	Retries     int      `name:"retries" help:"Retries per upload or batch after rate limits and server errors" default:"3"`
+}
+
+// photosUploadFile is one file in an upload run. Status is "pending" or
+// "skipped" in the plan and "created" or "failed" once the run finishes.
+type photosUploadFile struct {
+	Path        string `json:"path"`
+	Size        int64  `json:"size"`
+	SHA256      string `json:"sha256,omitempty"`
+	Status      string `json:"status"`
+	Reason      string `json:"reason,omitempty"`
+	MediaItemID string `json:"media_item_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+
+	mimeType string
+	token    string
+}
+
+// photosUploadState remembers which file contents already became media items
+// (keyed by SHA-256), so syncing the same folder again skips them. Hashes are
+// cached by path, size and mtime, and upload tokens are kept until
+// batchCreate uses them so an interrupted run does not resend bytes.
+type photosUploadState struct {
+	Account string                         `json:"account"`
+	Items   map[string]*photosUploadedItem `json:"items"`
+	Hashes  map[string]*photosFileHash     `json:"hashes"`
+	Pending map[string]*photosPendingToken `json:"pending,omitempty"`
+}
+
+type photosUploadedItem struct {
+	MediaItemID string    `json:"media_item_id"`
+	File        string    `json:"file"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type photosFileHash struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+	SHA256  string    `json:"sha256"`
+}
+
+type photosPendingToken struct {
+	Token      string    `json:"token"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+func (c *PhotosUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	if c.Retries < 0 {
+		return usage("--retries must be >= 0")
+	}
+	files, err := collectPhotosUploadFiles(c.Paths)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return usage("no photos or videos to upload")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+
+	statePath := ""
+	var state *photosUploadState
+	if !c.NoDedupe {
+		statePath, err = photosUploadStatePath(ctx, c.State, account)
+		if err != nil {
+			return err
+		}
+		state, err = loadPhotosUploadState(statePath, account)
+		if err != nil {
+			return err
+		}
+		if err := planPhotosUpload(files, state); err != nil {
+			return err
+		}
+	}
+	pending := 0
+	for _, f := range files {
+		if f.Status == "pending" {
+			pending++
+		}
+	}
+
+	albumID := strings.TrimSpace(c.Album)
+	if dryRunErr := dryRunExit(ctx, flags, "photos.upload", map[string]any{
+		"album_id": albumID,
+		"state":    statePath,
+		"upload":   pending,
+		"skipped":  len(files) - pending,
+		"files":    files,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	if pending > 0 {
+		client, clientErr := photosWriteService(ctx, account)
+		if clientErr != nil {
+			return clientErr
+		}
+		up := &photosUploader{
+			client:      client,
+			albumID:     albumID,
+			description: c.Description,
+			retries:     c.Retries,
+			state:       state,
+			statePath:   statePath,
+		}
+		if uploadErr := up.run(ctx, files, pending); uploadErr != nil {
+			return uploadErr
+		}
+	}
+
+	created, skipped, failed := 0, 0, 0
+	for _, f := range files {
+		switch f.Status {
+		case "created":
+			created++
+		case "skipped":
+			skipped++
+		default:
+			failed++
+		}
+	}
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"files":    files,
+			"created":  created,
+			"skipped":  skipped,
+			"failed":   failed,
+			"album_id": albumID,
+			"state":    statePath,
+		}); err != nil {
+			return err
+		}
+	} else {
+		w, flush := tableWriter(ctx)
+		fmt.Fprintln(w, "PATH\tSTATUS\tMEDIA_ITEM_ID\tNOTE")
+		for _, f := range files {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", sanitizeTab(f.Path), f.Status, f.MediaItemID, sanitizeTab(firstNonEmpty(f.Error, f.Reason)))
+		}
+		flush()
+		u.Err().Linef("%d created, %d skipped, %d failed", created, skipped, failed)
+	}
+	if failed > 0 {
+		hint := ""
+		if state != nil {
+			hint = " (run the same command again to retry; created items are skipped)"
+		}
+		return fmt.Errorf("%d of %d upload%s failed%s", failed, len(files), pluralS(len(files)), hint)
+	}
+	return nil
+}
+
+// collectPhotosUploadFiles expands the arguments into files. Directories are
+// walked recursively for known photo and video extensions, skipping hidden
+// entries; explicitly named files are always included.
+func collectPhotosUploadFiles(paths []string) ([]*photosUploadFile, error) {
+	var files []*photosUploadFile
+	seen := map[string]bool{}
+	add := func(path string, size int64) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		files = append(files, &photosUploadFile{
+			Path:     path,
+			Size:     size,
+			Status:   "pending",
+			mimeType: photosMediaTypes[strings.ToLower(filepath.Ext(path))],
+		})
+	}
+	for _, raw := range paths {
+		path, err := config.ExpandPath(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+		if path, err = filepath.Abs(path); err != nil {
+			return nil, err
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			if info.Size() == 0 {
+				return nil, usagef("%s is empty", raw)
+			}
+			add(path, info.Size())
+			continue
+		}
+		err = filepath.WalkDir(path, func(p string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if p != path && strings.HasPrefix(d.Name(), ".") {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || photosMediaTypes[strings.ToLower(filepath.Ext(p))] == "" {
+				return nil
+			}
+			fi, infoErr := d.Info()
+			if infoErr != nil {
+				return infoErr
+			}
+			if fi.Mode().IsRegular() && fi.Size() > 0 {
+				add(p, fi.Size())
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// planPhotosUpload hashes every file and skips content that is already in the
+// state or appears earlier in this run.
+func planPhotosUpload(files []*photosUploadFile, state *photosUploadState) error {
+	firstByHash := map[string]string{}
+	for _, f := range files {
+		sum, err := photosFileSHA256(f.Path, state)
+		if err != nil {
+			return err
+		}
+		f.SHA256 = sum
+		if item, ok := state.Items[sum]; ok {
+			f.Status, f.MediaItemID = "skipped", item.MediaItemID
+			f.Reason = "already uploaded"
+			if item.File != f.Path {
+				f.Reason += " from " + item.File
+			}
+			continue
+		}
+		if first, ok := firstByHash[sum]; ok {
+			f.Status, f.Reason = "skipped", "same content as "+first
+			continue
+		}
+		firstByHash[sum] = f.Path
+	}
+	return nil
+}
+
+func photosFileSHA256(path string, state *photosUploadState) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if cached, ok := state.Hashes[path]; ok && cached.Size == info.Size() && cached.ModTime.Equal(info.ModTime()) {
+		return cached.SHA256, nil
+	}
+	f, err := os.Open(path) //nolint:gosec // user-provided upload path
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("hash %s: %w", path, err)
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	state.Hashes[path] = &photosFileHash{Size: info.Size(), ModTime: info.ModTime(), SHA256: sum}
+	return sum, nil
+}
+
+type photosUploader struct {
+	client      *googleapi.PhotosClient
+	albumID     string
+	description string
+	retries     int
+	state       *photosUploadState
+	statePath   string
+}
+
+// run uploads pending files and creates their media items in batches of 50,
+// saving the dedupe state after every batch.
+func (up *photosUploader) run(ctx context.Context, files []*photosUploadFile, total int) error {
+	u := ui.FromContext(ctx)
+	var pending []*photosUploadFile
+	for _, f := range files {
+		if f.Status == "pending" {
+			pending = append(pending, f)
+		}
+	}
+	done := 0
+	for start := 0; start < len(pending); start += googleapi.PhotosBatchCreateMaxItems {
+		batch := pending[start:min(start+googleapi.PhotosBatchCreateMaxItems, len(pending))]
+		var ready []*photosUploadFile
+		for _, f := range batch {
+			if err := up.upload(ctx, f); err != nil {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				f.Status, f.Error = "failed", err.Error()
+				continue
+			}
+			ready = append(ready, f)
+		}
+		up.create(ctx, ready)
+		if err := up.save(); err != nil {
+			return err
+		}
+		done += len(batch)
+		if !outfmt.IsJSON(ctx) {
+			u.Err().Linef("Uploaded %d/%d", done, total)
+		}
+	}
+	return nil
+}
+
+// upload sends the file bytes, or reuses a recent token from an earlier run.
+func (up *photosUploader) upload(ctx context.Context, f *photosUploadFile) error {
+	if up.state != nil {
+		if saved, ok := up.state.Pending[f.SHA256]; ok && time.Since(saved.UploadedAt) < photosUploadTokenReuse {
+			f.token = saved.Token
+			return nil
+		}
+	}
+	file, err := os.Open(f.Path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	err = retryPhotosCall(ctx, up.retries, func() error {
+		token, uploadErr := up.client.UploadMedia(ctx, file, f.Size, filepath.Base(f.Path), f.mimeType)
+		f.token = token
+		return uploadErr
+	})
+	if err != nil {
+		return err
+	}
+	if up.state != nil {
+		up.state.Pending[f.SHA256] = &photosPendingToken{Token: f.token, UploadedAt: time.Now().UTC()}
+		return up.save()
+	}
+	return nil
+}
+
+// create turns upload tokens into media items. Items that fail with a
+// transient status are retried on their own; the rest of the batch is kept.
+func (up *photosUploader) create(ctx context.Context, files []*photosUploadFile) {
+	delay := 500 * time.Millisecond
+	for attempt := 0; len(files) > 0; attempt++ {
+		items := make([]googleapi.PhotosNewMediaItem, 0, len(files))
+		for _, f := range files {
+			items = append(items, googleapi.PhotosNewMediaItem{UploadToken: f.token, FileName: filepath.Base(f.Path), Description: up.description})
+		}
+		var resp *googleapi.PhotosBatchCreateResponse
+		err := retryPhotosCall(ctx, up.retries, func() error {
+			var createErr error
+			resp, createErr = up.client.BatchCreateMediaItems(ctx, up.albumID, items)
+			return createErr
+		})
+		if err != nil {
+			for _, f := range files {
+				f.Status, f.Error = "failed", err.Error()
+			}
+			return
+		}
+		byToken := make(map[string]*googleapi.PhotosNewMediaItemResult, len(resp.NewMediaItemResults))
+		for _, r := range resp.NewMediaItemResults {
+			if r != nil {
+				byToken[r.UploadToken] = r
+			}
+		}
+		var retry []*photosUploadFile
+		for _, f := range files {
+			r := byToken[f.token]
+			switch {
+			case r == nil:
+				f.Status, f.Error = "failed", "missing from batchCreate response"
+			case r.MediaItem != nil && (r.Status == nil || r.Status.Code == 0):
+				f.Status, f.MediaItemID = "created", r.MediaItem.ID
+				up.record(f)
+			case r.Status != nil && photosRetryableStatus(r.Status.Code) && attempt < up.retries:
+				retry = append(retry, f)
+			default:
+				f.Status = "failed"
+				f.Error = "batchCreate failed"
+				if r.Status != nil && r.Status.Message != "" {
+					f.Error = r.Status.Message
+				}
+				if up.state != nil {
+					// The token may be the problem (expired or rejected); upload again next time.
+					delete(up.state.Pending, f.SHA256)
+				}
+			}
+		}
+		files = retry
+		if len(files) > 0 {
+			if sleepErr := sleepBeforePhotosRetry(ctx, delay); sleepErr != nil {
+				for _, f := range files {
+					f.Status, f.Error = "failed", sleepErr.Error()
+				}
+				return
+			}
+			delay = min(delay*2, 8*time.Second)
+		}
+	}
+}
+
+func (up *photosUploader) record(f *photosUploadFile) {
+	if up.state == nil {
+		return
+	}
+	delete(up.state.Pending, f.SHA256)
+	up.state.Items[f.SHA256] = &photosUploadedItem{MediaItemID: f.MediaItemID, File: f.Path, CreatedAt: time.Now().UTC()}
+}
+
+func (up *photosUploader) save() error {
+	if up.state == nil {
+		return nil
+	}
+	return savePhotosUploadState(up.statePath, up.state)
+}
+
+// retryPhotosCall retries rate limits, server errors and network failures
+// with backoff.
+func retryPhotosCall(ctx context.Context, retries int, call func() error) error {
+	delay := 500 * time.Millisecond
+	for attempt := 0; ; attempt++ {
+		err := call()
+		if err == nil || attempt >= retries || ctx.Err() != nil {
+			return err
+		}
+		var statusErr *googleapi.HTTPStatusError
+		if errors.As(err, &statusErr) && statusErr.Code != http.StatusTooManyRequests && statusErr.Code < 500 {
+			return err
+		}
+		if sleepErr := sleepBeforePhotosRetry(ctx, delay); sleepErr != nil {
+			return sleepErr
+		}
+		delay = min(delay*2, 8*time.Second)
+	}
+}
+
+// photosRetryableStatus reports whether a batchCreate item status (a
+// google.rpc.Code) is worth retrying: DEADLINE_EXCEEDED, RESOURCE_EXHAUSTED,
+// ABORTED, INTERNAL, UNAVAILABLE.
+func photosRetryableStatus(code int) bool {
+	switch code {
+	case 4, 8, 10, 13, 14:
+		return true
+	default:
+		return false
+	}
+}
+
+func photosUploadStatePath(ctx context.Context, override, account string) (string, error) {
+	if strings.TrimSpace(override) != "" {
+		return config.ExpandPath(strings.TrimSpace(override))
+	}
+	layout, err := commandLayout(ctx, config.PathKindState)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.ToLower(account)))
+	return filepath.Join(layout.PhotosUploadsDir(), hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func loadPhotosUploadState(path, account string) (*photosUploadState, error) {
+	state := &photosUploadState{Account: strings.ToLower(account)}
+	data, err := os.ReadFile(path) //nolint:gosec // state path from layout or --state
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, err
+	default:
+		if err := json.Unmarshal(data, state); err != nil {
+			return nil, fmt.Errorf("read upload state %s: %w", path, err)
+		}
+		if !strings.EqualFold(state.Account, account) {
+			return nil, usagef("upload state %s belongs to %s, not %s", path, state.Account, account)
+		}
+	}
+	if state.Items == nil {
+		state.Items = map[string]*photosUploadedItem{}
+	}
+	if state.Hashes == nil {
+		state.Hashes = map[string]*photosFileHash{}
+	}
+	if state.Pending == nil {
+		state.Pending = map[string]*photosPendingToken{}
+	}
+	return state, nil
+}
+
+func savePhotosUploadState(path string, state *photosUploadState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create upload state dir: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(path, data, 0o600)
+}
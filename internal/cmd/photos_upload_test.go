@@ -0,0 +1,189 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steipete/gogcli/internal/app"
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+)
+
+func TestPhotosUpload_DirectorySyncSkipsUploadedContent(t *testing.T) {
+	origSleep := sleepBeforePhotosRetry
+	sleepBeforePhotosRetry = func(context.Context, time.Duration) error { return nil }
+	t.Cleanup(func() { sleepBeforePhotosRetry = origSleep })
+
+	dir := t.TempDir()
+	writeFiles := map[string]string{
+		"a.jpg":       "photo-a",
+		"b.png":       "photo-a", // same bytes as a.jpg
+		"sub/c.mp4":   "video-c",
+		".hidden.jpg": "hidden",
+		"notes.txt":   "not media",
+	}
+	for name, content := range writeFiles {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var mu sync.Mutex
+	var uploads []string
+	var batches [][]string
+	failedOnce := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.URL.Path {
+		case "/uploads":
+			body, _ := io.ReadAll(r.Body)
+			name := r.Header.Get("X-Goog-Upload-File-Name")
+			if r.Header.Get("X-Goog-Upload-Protocol") != "raw" || (name == "c.mp4" && r.Header.Get("X-Goog-Upload-Content-Type") != "video/mp4") {
+				t.Errorf("upload headers = %v", r.Header)
+			}
+			uploads = append(uploads, name+"="+string(body))
+			_, _ = io.WriteString(w, "tok-"+name)
+		case "/mediaItems:batchCreate":
+			var req struct {
+				AlbumID       string `json:"albumId"`
+				NewMediaItems []struct {
+					SimpleMediaItem struct {
+						UploadToken string `json:"uploadToken"`
+					} `json:"simpleMediaItem"`
+				} `json:"newMediaItems"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&req)
+			if req.AlbumID != "alb1" {
+				t.Errorf("albumId = %q", req.AlbumID)
+			}
+			var tokens []string
+			var results []map[string]any
+			for _, item := range req.NewMediaItems {
+				token := item.SimpleMediaItem.UploadToken
+				tokens = append(tokens, token)
+				if token == "tok-c.mp4" && !failedOnce {
+					failedOnce = true
+					results = append(results, map[string]any{"uploadToken": token, "status": map[string]any{"code": 14, "message": "unavailable"}})
+					continue
+				}
+				results = append(results, map[string]any{
+					"uploadToken": token,
+					"status":      map[string]any{"message": "Success"},
+					"mediaItem":   map[string]any{"id": "m-" + strings.TrimPrefix(token, "tok-")},
+				})
+			}
+			batches = append(batches, tokens)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"newMediaItemResults": results})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := googleapi.NewPhotosClient(srv.Client(), googleapi.WithPhotosBaseURL(srv.URL))
+	stateDir := t.TempDir()
+	runtime := &app.Runtime{
+		Layout:   config.Layout{StateDir: stateDir, ExplicitState: true},
+		Services: app.Services{PhotosWrite: fixedPhotosTestService(client)},
+	}
+	args := []string{"--json", "--account", "a@example.com", "photos", "upload", dir, "--album", "alb1"}
+
+	first := executeWithTestRuntime(t, args, runtime)
+	if first.err != nil {
+		t.Fatalf("first upload: %v\nstderr=%s", first.err, first.stderr)
+	}
+	var out struct {
+		Created int `json:"created"`
+		Skipped int `json:"skipped"`
+		Files   []struct {
+			Path        string `json:"path"`
+			Status      string `json:"status"`
+			Reason      string `json:"reason"`
+			MediaItemID string `json:"media_item_id"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal([]byte(first.stdout), &out); err != nil {
+		t.Fatalf("json: %v\n%s", err, first.stdout)
+	}
+	if out.Created != 2 || out.Skipped != 1 || len(out.Files) != 3 {
+		t.Fatalf("first run = %+v", out)
+	}
+	if out.Files[1].Status != "skipped" || !strings.Contains(out.Files[1].Reason, "same content as") {
+		t.Fatalf("duplicate file = %+v", out.Files[1])
+	}
+	if strings.Join(uploads, ",") != "a.jpg=photo-a,c.mp4=video-c" {
+		t.Fatalf("uploads = %v", uploads)
+	}
+	if len(batches) != 2 || len(batches[1]) != 1 || batches[1][0] != "tok-c.mp4" {
+		t.Fatalf("batchCreate calls = %v (failed item should be retried alone)", batches)
+	}
+
+	// Adding a copy of existing content and re-running uploads nothing.
+	if err := os.WriteFile(filepath.Join(dir, "copy.jpeg"), []byte("video-c"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	second := executeWithTestRuntime(t, args, runtime)
+	if second.err != nil {
+		t.Fatalf("second upload: %v", second.err)
+	}
+	if len(uploads) != 2 || len(batches) != 2 {
+		t.Fatalf("second run sent uploads=%v batches=%v", uploads, batches)
+	}
+	if !strings.Contains(second.stdout, `"created": 0`) || !strings.Contains(second.stdout, `"media_item_id": "m-c.mp4"`) {
+		t.Fatalf("second run stdout = %s", second.stdout)
+	}
+}
+
+func TestPhotosAlbumsAdd_ChunksByFifty(t *testing.T) {
+	var sizes []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/albums/alb1:batchAddMediaItems" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		var body struct {
+			MediaItemIDs []string `json:"mediaItemIds"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		sizes = append(sizes, len(body.MediaItemIDs))
+		_, _ = io.WriteString(w, "{}")
+	}))
+	defer srv.Close()
+
+	args := []string{"--json", "--account", "a@example.com", "photos", "albums", "add", "alb1"}
+	for i := range 120 {
+		args = append(args, "m"+strconv.Itoa(i))
+	}
+	client := googleapi.NewPhotosClient(srv.Client(), googleapi.WithPhotosBaseURL(srv.URL))
+	result := executeWithPhotosTestServices(t, args, photosTestServices{
+		Photos:      unexpectedPhotosTestService(t, "album changes must use the write client"),
+		PhotosWrite: fixedPhotosTestService(client),
+	})
+	if result.err != nil {
+		t.Fatalf("albums add: %v", result.err)
+	}
+	if len(sizes) != 3 || sizes[0] != 50 || sizes[1] != 50 || sizes[2] != 20 {
+		t.Fatalf("batch sizes = %v", sizes)
+	}
+	if !strings.Contains(result.stdout, `"added": 120`) {
+		t.Fatalf("stdout = %s", result.stdout)
+	}
+}
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/alecthomas/kong"
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/app"
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+const (
+	pluginPrefix   = "gog-"
+	pluginsDirName = "plugins"
+)
+
+var pluginNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// cliPlugin is an external gog-<name> executable run as `gog <name>`.
+type cliPlugin struct {
+	Name string
+	Path string
+}
+
+// PluginCmd runs a plugin with every argument after its name. gog flags
+// must come before the plugin name.
+type PluginCmd struct {
+	Args []string `arg:"" optional:"" name:"args" help:"Arguments passed to the plugin"`
+
+	plugin cliPlugin
+}
+
+func (c *PluginCmd) Run(ctx context.Context, flags *RootFlags) error {
+	env, err := pluginEnv(ctx, flags, c.plugin)
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // plugins are executables the operator installed on PATH or in the config directory.
+	cmd := exec.CommandContext(ctx, c.plugin.Path, c.Args...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin = stdinReader(ctx)
+	cmd.Stdout = stdoutWriter(ctx)
+	cmd.Stderr = stderrWriter(ctx)
+
+	// The plugin shares the terminal and gets Ctrl-C itself; gog waits for
+	// it to exit instead of dying first.
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	defer signal.Stop(interrupts)
+
+	err = cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &ExitError{Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fmt.Errorf("run plugin %s: %w", c.plugin.Path, err)
+	}
+	return nil
+}
+
+// pluginEnv describes the resolved invocation to the plugin. Nested gog
+// calls honor GOG_ACCOUNT, GOG_OUTPUT, GOG_READONLY, the command policy
+// variables, and GOG_ACCESS_TOKEN or GOG_BROKER_SOCKET. A token is minted
+// only when the plugin's manifest asks for one.
+func pluginEnv(ctx context.Context, flags *RootFlags, plugin cliPlugin) ([]string, error) {
+	env := []string{
+		"GOG_PLUGIN_NAME=" + plugin.Name,
+		"GOG_OUTPUT=" + pluginOutputName(outfmt.FromContext(ctx), flags.Output),
+		"GOG_READONLY=" + boolString(flags.ReadOnly),
+		"GOG_DRY_RUN=" + boolString(flags.DryRun),
+		"GOG_NO_INPUT=" + boolString(flags.NoInput),
+		"GOG_GMAIL_NO_SEND=" + boolString(flags.GmailNoSend),
+		"GOG_ENABLE_COMMANDS=" + flags.EnableCommands,
+		"GOG_ENABLE_COMMANDS_EXACT=" + flags.EnableCommandsExact,
+		"GOG_DISABLE_COMMANDS=" + flags.DisableCommands,
+		"GOG_CLIENT=" + flags.Client,
+	}
+	if self, err := os.Executable(); err == nil {
+		env = append(env, "GOG_BIN="+self)
+	}
+	if home := strings.TrimSpace(flags.Home); home != "" {
+		env = append(env, "GOG_HOME="+home)
+	}
+
+	manifest, err := readPluginManifest(plugin)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := pluginAccount(flags, manifest.wantsToken())
+	if err != nil || account == "" {
+		// Plugins that never call Google APIs must work without an account.
+		slog.Debug("plugin: no account resolved", "plugin", plugin.Name, "err", err)
+		return env, nil
+	}
+	if isKeylessAuthMode(flags) && account == keylessPlaceholderAccount(flags) {
+		return env, nil
+	}
+	env = append(env, "GOG_ACCOUNT="+account)
+
+	switch {
+	case hasDirectAccessToken(flags):
+		env = append(env, "GOG_ACCESS_TOKEN="+directAccessToken(flags))
+	case flags.brokerSocket != "":
+		env = append(env, "GOG_BROKER_SOCKET="+flags.brokerSocket)
+	case isKeylessAuthMode(flags), !manifest.wantsToken():
+		// Nested calls authenticate themselves and enforce their own guards.
+	case bakedSafetyEnabled():
+		// A token would let the plugin bypass the baked command policy.
+		slog.Warn("plugin: no access token under a baked safety profile", "plugin", plugin.Name, "profile", bakedSafetyProfileName())
+	default:
+		tok, mintErr := mintPluginToken(ctx, flags, account, manifest.AccessToken.Services)
+		if mintErr != nil {
+			slog.Warn("plugin: no access token", "plugin", plugin.Name, "account", account, "err", mintErr)
+			return env, nil
+		}
+		env = append(env, "GOG_ACCESS_TOKEN="+tok.AccessToken)
+		if !tok.Expiry.IsZero() {
+			env = append(env, "GOG_ACCESS_TOKEN_EXPIRES_AT="+tok.Expiry.UTC().Format("2006-01-02T15:04:05Z"))
+		}
+	}
+	return env, nil
+}
+
+// pluginAccount resolves the account to hand the plugin. Only a plugin that
+// needs a token may fall back to inferring the account from the keyring.
+func pluginAccount(flags *RootFlags, wantsToken bool) (string, error) {
+	if wantsToken || isKeylessAuthMode(flags) {
+		return requireAccount(flags)
+	}
+	account, ok, err := configuredAccount(flags)
+	if err != nil || !ok {
+		return "", err
+	}
+	return account, nil
+}
+
+// mintPluginToken mints a token limited to the manifest's services, with
+// read-only scopes under --readonly.
+func mintPluginToken(ctx context.Context, flags *RootFlags, account string, names []string) (*oauth2.Token, error) {
+	services := make([]googleauth.Service, 0, len(names))
+	for _, name := range names {
+		svc, err := googleauth.ParseService(name)
+		if err != nil {
+			return nil, err
+		}
+		services = append(services, svc)
+	}
+	scopes, err := googleauth.ScopesForServicesWithOptions(services, googleauth.ScopeOptions{Readonly: flags.ReadOnly})
+	if err != nil {
+		return nil, err
+	}
+	return googleapi.MintAccessToken(ctx, "", account, scopes)
+}
+
+// pluginManifest is the optional gog-<name>.json next to a plugin.
+type pluginManifest struct {
+	AccessToken *struct {
+		Services []string `json:"services"`
+	} `json:"access_token"`
+}
+
+func (m pluginManifest) wantsToken() bool {
+	return m.AccessToken != nil && len(m.AccessToken.Services) > 0
+}
+
+func pluginManifestPath(plugin cliPlugin) string {
+	return strings.TrimSuffix(plugin.Path, filepath.Ext(plugin.Path)) + ".json"
+}
+
+func readPluginManifest(plugin cliPlugin) (pluginManifest, error) {
+	var manifest pluginManifest
+	path := pluginManifestPath(plugin)
+	if path == plugin.Path {
+		return manifest, nil
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // manifest sits next to the operator-installed plugin
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return manifest, fmt.Errorf("read plugin manifest: %w", err)
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return manifest, fmt.Errorf("parse plugin manifest %s: %w", path, err)
+	}
+	return manifest, nil
+}
+
+// pluginOutputName turns the resolved output mode back into a GOG_OUTPUT
+// value.
+func pluginOutputName(mode outfmt.Mode, output string) string {
+	switch {
+	case mode.Format == outfmt.FormatTemplate:
+		return strings.TrimSpace(output)
+	case mode.Format != "":
+		return string(mode.Format)
+	case mode.JSON:
+		return "json"
+	case mode.Plain:
+		return "plain"
+	default:
+		return "table"
+	}
+}
+
+// pluginOptions registers discovered plugins as top-level commands so help,
+// schema, completion, and the command policy flags see them.
+func pluginOptions(plugins []cliPlugin) []kong.Option {
+	options := make([]kong.Option, 0, len(plugins))
+	for _, plugin := range plugins {
+		options = append(options, kong.DynamicCommand(
+			plugin.Name,
+			"Plugin ("+plugin.Path+")",
+			"",
+			&PluginCmd{plugin: plugin},
+			"cmd", "passthrough",
+		))
+	}
+	return options
+}
+
+// runtimePlugins discovers plugins in the config directory's plugins/ and
+// then on PATH. GOG_NO_PLUGINS=1 turns discovery off.
+func runtimePlugins(runtime *app.Runtime) []cliPlugin {
+	if envBool("GOG_NO_PLUGINS") {
+		return nil
+	}
+	var dirs []string
+	if err := configureRuntimeLayout(runtime, config.PathKindConfig); err == nil && runtime.Layout.ConfigDir != "" {
+		dirs = append(dirs, filepath.Join(runtime.Layout.ConfigDir, pluginsDirName))
+	}
+	dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+	return discoverPlugins(dirs)
+}
+
+// discoverPlugins returns gog-<name> executables in dirs, sorted by name. The
+// first directory wins, and built-in commands and aliases cannot be shadowed.
+func discoverPlugins(dirs []string) []cliPlugin {
+	builtin := builtinCommandNames()
+	seen := map[string]bool{}
+	var plugins []cliPlugin
+	for _, dir := range dirs {
+		if strings.TrimSpace(dir) == "" {
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(dir, pluginPrefix+"*"))
+		if err != nil {
+			continue
+		}
+		for _, path := range matches {
+			name, ok := pluginName(path)
+			if !ok || seen[name] {
+				continue
+			}
+			if builtin[name] {
+				slog.Debug("plugin shadowed by built-in command", "path", path)
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, cliPlugin{Name: name, Path: path})
+		}
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+func pluginName(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return "", false
+	}
+	base := filepath.Base(path)
+	if runtime.GOOS == literalWindows {
+		ext := strings.ToLower(filepath.Ext(base))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		base = strings.TrimSuffix(base, filepath.Ext(base))
+	} else if info.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+	name := strings.ToLower(strings.TrimPrefix(base, pluginPrefix))
+	if !pluginNamePattern.MatchString(name) {
+		return "", false
+	}
+	return name, true
+}
+
+var builtinCommandNames = sync.OnceValue(func() map[string]bool {
+	names := map[string]bool{}
+	t := reflect.TypeOf(CLI{})
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if _, ok := field.Tag.Lookup("cmd"); !ok {
+			continue
+		}
+		name := field.Tag.Get("name")
+		if name == "" {
+			name = dashedCommandName(field.Name)
+		}
+		names[name] = true
+		for _, alias := range strings.Split(field.Tag.Get("aliases"), ",") {
+			if alias = strings.TrimSpace(alias); alias != "" {
+				names[alias] = true
+			}
+		}
+	}
+	names["help"] = true
+	return names
+})
+
+// dashedCommandName mirrors kong's default command naming.
+func dashedCommandName(field string) string {
+	var b strings.Builder
+	for i, r := range field {
+		if i > 0 && unicode.IsUpper(r) && !unicode.IsUpper(rune(field[i-1])) {
+			b.WriteByte('-')
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
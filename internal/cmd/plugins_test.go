@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+)
+
+// installTestPlugin writes an executable gog-<name> script into dir that
+// records its arguments and GOG_* environment in <dir>/<name>.out.
+func installTestPlugin(t *testing.T, dir, name, exitCode string) string {
+	t.Helper()
+	if runtime.GOOS == literalWindows {
+		t.Skip("shell script plugins")
+	}
+	path := filepath.Join(dir, pluginPrefix+name)
+	script := "#!/bin/sh\n" +
+		"out=\"$(dirname \"$0\")/" + name + ".out\"\n" +
+		"echo \"args=$*\" > \"$out\"\n" +
+		"env | grep '^GOG_' | sort >> \"$out\"\n" +
+		"exit " + exitCode + "\n"
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatalf("write plugin: %v", err)
+	}
+	return path
+}
+
+func setupPluginEnv(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	t.Setenv("GOG_NO_PLUGINS", "")
+	dir := t.TempDir()
+	// Keep the system PATH for the tools the test plugins use.
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return dir
+}
+
+func TestExecute_RunsPluginWithEnv(t *testing.T) {
+	dir := setupPluginEnv(t)
+	installTestPlugin(t, dir, "hello", "3")
+	t.Setenv("GOG_ACCOUNT", "env@example.com")
+	t.Setenv("GOG_ACCESS_TOKEN", "tok-123")
+
+	err := Execute([]string{"--json", "--readonly", "hello", "--x", "y"})
+	if got := ExitCode(err); got != 3 {
+		t.Fatalf("exit code = %d (%v), want 3", got, err)
+	}
+
+	data, readErr := os.ReadFile(filepath.Join(dir, "hello.out"))
+	if readErr != nil {
+		t.Fatalf("plugin did not run: %v", readErr)
+	}
+	out := string(data)
+	for _, want := range []string{
+		"args=--x y\n",
+		"GOG_PLUGIN_NAME=hello\n",
+		"GOG_OUTPUT=json\n",
+		"GOG_READONLY=true\n",
+		"GOG_DRY_RUN=false\n",
+		"GOG_ACCOUNT=env@example.com\n",
+		"GOG_ACCESS_TOKEN=tok-123\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("plugin output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestExecute_PluginHonorsCommandPolicy(t *testing.T) {
+	dir := setupPluginEnv(t)
+	installTestPlugin(t, dir, "hello", "0")
+
+	_ = captureStderr(t, func() {
+		if got := ExitCode(Execute([]string{"--disable-commands", "hello", "hello"})); got != 2 {
+			t.Fatalf("disabled plugin exit code = %d, want 2", got)
+		}
+		if got := ExitCode(Execute([]string{"--enable-commands", "drive", "hello"})); got != 2 {
+			t.Fatalf("not-enabled plugin exit code = %d, want 2", got)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(dir, "hello.out")); !os.IsNotExist(err) {
+		t.Fatalf("blocked plugin ran: %v", err)
+	}
+}
+
+func TestExecute_PluginInSchemaAndCompletion(t *testing.T) {
+	dir := setupPluginEnv(t)
+	installTestPlugin(t, dir, "hello", "0")
+
+	schema := captureStdout(t, func() {
+		if err := Execute([]string{"schema", "hello", "--json"}); err != nil {
+			t.Fatalf("schema: %v", err)
+		}
+	})
+	if !strings.Contains(schema, `"path": "gog hello"`) || !strings.Contains(schema, `"passthrough": true`) {
+		t.Fatalf("schema missing plugin: %s", schema)
+	}
+
+	completions := captureStdout(t, func() {
+		if err := Execute([]string{"__complete", "--cword", "1", "--", "gog", "hel"}); err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+	})
+	if !strings.Contains(completions, "hello\n") {
+		t.Fatalf("completion missing plugin: %q", completions)
+	}
+}
+
+func TestRuntimePlugins_NoPluginsEnv(t *testing.T) {
+	dir := setupPluginEnv(t)
+	installTestPlugin(t, dir, "hello", "0")
+	t.Setenv("GOG_NO_PLUGINS", "1")
+
+	if got := discoverPlugins([]string{dir}); len(got) != 1 {
+		t.Fatalf("discoverPlugins = %+v", got)
+	}
+	var err error
+	_ = captureStderr(t, func() {
+		err = Execute([]string{"hello"})
+	})
+	if err == nil || ExitCode(err) != 2 {
+		t.Fatalf("expected unknown command with GOG_NO_PLUGINS, got %v", err)
+	}
+}
+
+func TestDiscoverPlugins(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	want := installTestPlugin(t, first, "hello", "0")
+	installTestPlugin(t, second, "hello", "0")
+	installTestPlugin(t, second, "drive", "0")
+	installTestPlugin(t, second, "mail", "0")
+	installTestPlugin(t, second, "Bad.Name", "0")
+	if err := os.WriteFile(filepath.Join(second, pluginPrefix+"noexec"), []byte("#!/bin/sh\n"), 0o600); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	installTestPlugin(t, second, "alpha", "0")
+
+	got := discoverPlugins([]string{"", first, second})
+	if len(got) != 2 || got[0].Name != "alpha" || got[1].Name != "hello" || got[1].Path != want {
+		t.Fatalf("discoverPlugins = %+v", got)
+	}
+}
+
+func TestPluginOutputName(t *testing.T) {
+	for _, value := range []string{"", "json", "plain", "csv", "yaml", "ndjson", "template={{.id}}"} {
+		mode, err := outfmt.ParseOutput(value)
+		if err != nil {
+			t.Fatalf("ParseOutput(%q): %v", value, err)
+		}
+		want := value
+		if want == "" {
+			want = "table"
+		}
+		if got := pluginOutputName(mode, value); got != want {
+			t.Fatalf("pluginOutputName(%q) = %q, want %q", value, got, want)
+		}
+	}
+}
+
+func TestPluginEnv_NoTokenWithoutManifest(t *testing.T) {
+	dir := setupPluginEnv(t)
+	installTestPlugin(t, dir, "hello", "0")
+	t.Setenv("GOG_ACCOUNT", "env@example.com")
+
+	if err := Execute([]string{"hello"}); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "hello.out"))
+	if err != nil {
+		t.Fatalf("plugin did not run: %v", err)
+	}
+	if out := string(data); strings.Contains(out, "GOG_ACCESS_TOKEN") || !strings.Contains(out, "GOG_ACCOUNT=env@example.com\n") {
+		t.Fatalf("unexpected plugin env:\n%s", out)
+	}
+}
+
+func TestReadPluginManifest(t *testing.T) {
+	dir := t.TempDir()
+	plugin := cliPlugin{Name: "report", Path: filepath.Join(dir, pluginPrefix+"report")}
+
+	manifest, err := readPluginManifest(plugin)
+	if err != nil || manifest.wantsToken() {
+		t.Fatalf("missing manifest = %+v, %v", manifest, err)
+	}
+
+	if err := os.WriteFile(pluginManifestPath(plugin), []byte(`{"access_token":{"services":["drive","gmail"]}}`), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	manifest, err = readPluginManifest(plugin)
+	if err != nil || !manifest.wantsToken() || strings.Join(manifest.AccessToken.Services, ",") != "drive,gmail" {
+		t.Fatalf("manifest = %+v, %v", manifest, err)
+	}
+
+	if err := os.WriteFile(pluginManifestPath(plugin), []byte(`{`), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	if _, err := readPluginManifest(plugin); err == nil {
+		t.Fatal("expected parse error")
+	}
+}
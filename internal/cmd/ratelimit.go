@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/ratelimit"
+)
+
+// commandRateLimiter throttles API calls with the rate_limits from
+// config.json. The state directory and config are only read once the first
+// Google API call is made.
+func commandRateLimiter(ctx context.Context) *ratelimit.Limiter {
+	var once sync.Once
+	var cfg config.File
+	return ratelimit.New(func() (string, error) {
+		layout, err := commandLayout(ctx, config.PathKindState)
+		if err != nil {
+			return "", err
+		}
+		return layout.StateDir, nil
+	}, func(service string) ratelimit.Limit {
+		once.Do(func() {
+			store, err := commandConfigStore(ctx)
+			if err == nil {
+				cfg, err = store.Read()
+			}
+			if err != nil {
+				slog.Debug("rate limits unavailable", "err", err)
+			}
+		})
+		limit := cfg.RateLimitFor(service)
+		return ratelimit.Limit{QPS: limit.QPS, Burst: limit.Burst, Daily: limit.Daily}
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/steipete/gogcli/internal/googleapi"
 	"github.com/steipete/gogcli/internal/googleauth"
 	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ratelimit"
 	"github.com/steipete/gogcli/internal/secrets"
 	"github.com/steipete/gogcli/internal/termutil"
 	"github.com/steipete/gogcli/internal/ui"
@@ -43,17 +44,20 @@ type RootFlags struct {
 	ReadOnly            bool   `name:"readonly" help:"Block mutating API requests at runtime; auth add also requests read-only OAuth scopes" default:"${readonly}"`
 	JSON                bool   `help:"Output JSON to stdout (best for scripting)" default:"${json}" aliases:"machine" short:"j"`
 	Plain               bool   `help:"Output stable, parseable text to stdout (TSV; no colors)" default:"${plain}" aliases:"tsv" short:"p"`
+	Output              string `name:"output" help:"Output format: table|plain|json|csv|yaml|ndjson|template=TEXT (Go text/template)" default:"${output}" placeholder:"FORMAT"`
 	WrapUntrusted       bool   `name:"wrap-untrusted" help:"In JSON/raw output, wrap fetched text fields in external untrusted-content markers" default:"${wrap_untrusted}"`
 	ResultsOnly         bool   `name:"results-only" help:"In JSON mode, emit only the primary result (drops envelope fields like nextPageToken)"`
 	Select              string `name:"select" aliases:"pick,project" help:"In JSON mode, select comma-separated fields (best-effort; supports dot paths). Desire path: use --fields for most commands."`
 	DryRun              bool   `help:"Do not make changes; print intended actions and exit successfully" aliases:"noop,preview,dryrun" short:"n"`
 	Force               bool   `help:"Skip confirmations for destructive commands" aliases:"yes,assume-yes" short:"y"`
 	NoInput             bool   `help:"Never prompt; fail instead (useful for CI)" aliases:"non-interactive,noninteractive"`
+	MaxAPICalls         int64  `name:"max-api-calls" help:"Fail once this invocation has made N Google API requests, retries included (0 = no limit)" env:"GOG_MAX_API_CALLS"`
 	Verbose             bool   `help:"Enable verbose logging" short:"v"`
 	diagnostics         io.Writer
 	authOperations      app.AuthOperations
 	configStoreResolver func() (*config.ConfigStore, error)
 	authMode            googleapi.AuthMode
+	brokerSocket        string
 }
 
 type CLI struct {
@@ -75,6 +79,9 @@ type CLI struct {
 	Whoami   PeopleMeCmd      `cmd:"" name:"whoami" aliases:"who-am-i" help:"Show your profile (alias for 'people me')"`
 
 	Auth          AuthCmd               `cmd:"" help:"Auth and credentials"`
+	Audit         AuditCmd              `cmd:"" help:"Audit log of mutating API requests"`
+	Undo          UndoCmd               `cmd:"" help:"Reverse recent reversible changes from the undo journal"`
+	History       HistoryCmd            `cmd:"" help:"List journaled changes that gog undo can reverse"`
 	Backup        BackupCmd             `cmd:"" help:"Encrypted Google account backups"`
 	Batch         BatchCmd              `cmd:"" help:"Build and submit persisted Google Docs request batches"`
 	Groups        GroupsCmd             `cmd:"" aliases:"group" help:"Cloud Identity Groups (Workspace only)"`
@@ -137,12 +144,15 @@ func executeWithRuntime(args []string, runtime *app.Runtime) (err error) {
 		}
 	}
 
-	parser, cli, err := newParserWithWriters(helpDescription(runtime), runtimeIO.Out, runtimeIO.Err)
+	parser, cli, err := newParserWithWriters(helpDescription(runtime), runtimeIO.Out, runtimeIO.Err, runtimePlugins(runtime)...)
 	if err != nil {
 		return reportEarlyError(runtimeIO.Err, err)
 	}
 	args = rewriteDocsCellUpdateContentArgs(parser.Model, args)
 	args = rewriteDesirePathArgs(parser.Model, args)
+	if err = checkOutputAfterOutFlag(parser.Model, args); err != nil {
+		return reportEarlyError(runtimeIO.Err, err)
+	}
 
 	defer func() {
 		if r := recover(); r != nil {
@@ -165,6 +175,7 @@ func executeWithRuntime(args []string, runtime *app.Runtime) (err error) {
 	cli.diagnostics = runtimeIO.Err
 	cli.authOperations = runtime.Auth
 	cli.authMode = googleapi.ParseAuthMode(os.Getenv("GOG_AUTH_MODE"))
+	cli.brokerSocket = strings.TrimSpace(os.Getenv("GOG_BROKER_SOCKET"))
 	applyExplicitOutputModePrecedence(kctx, &cli.RootFlags)
 
 	// Make config-backed account and alias resolution available to the
@@ -204,11 +215,11 @@ func executeWithRuntime(args []string, runtime *app.Runtime) (err error) {
 
 	// Optional automatic JSON output when stdout is piped/non-TTY.
 	// We intentionally do this after parsing so `--plain` can override it.
-	if envBool("GOG_AUTO_JSON") && !cli.JSON && !cli.Plain && !isTerminalWriter(runtimeIO.Out) {
+	if envBool("GOG_AUTO_JSON") && !cli.JSON && !cli.Plain && strings.TrimSpace(cli.Output) == "" && !isTerminalWriter(runtimeIO.Out) {
 		cli.JSON = true
 	}
 
-	mode, err := outfmt.FromFlags(cli.JSON, cli.Plain)
+	mode, err := resolveOutputMode(kctx, &cli.RootFlags)
 	if err != nil {
 		return reportEarlyError(runtimeIO.Err, newUsageError(err))
 	}
@@ -216,11 +227,33 @@ func executeWithRuntime(args []string, runtime *app.Runtime) (err error) {
 	if err != nil {
 		return reportEarlyError(runtimeIO.Err, err)
 	}
+	if cli.MaxAPICalls < 0 {
+		return reportEarlyError(runtimeIO.Err, newUsageError(errors.New("--max-api-calls must be >= 0")))
+	}
 
 	ctx := context.Background()
 	ctx = app.WithRuntime(ctx, runtime)
 	ctx = googleapi.WithReadOnly(ctx, cli.ReadOnly)
+	cassette, err := googleapi.CassetteFromEnv(os.Getenv("GOG_RECORD"), os.Getenv("GOG_REPLAY"))
+	if err != nil {
+		return reportEarlyError(runtimeIO.Err, newUsageError(err))
+	}
+	ctx = googleapi.WithCassette(ctx, cassette)
 	runtimeContext := ctx
+	ctx = googleapi.WithRateLimiter(ctx, commandRateLimiter(runtimeContext))
+	ctx = googleapi.WithCallBudget(ctx, ratelimit.NewCallBudget(cli.MaxAPICalls))
+	commandName := strings.Join(commandPath(kctx.Command()), " ")
+	ctx, finishTelemetry, err := startCommandTelemetry(ctx, commandName)
+	if err != nil {
+		return reportEarlyError(runtimeIO.Err, newUsageError(err))
+	}
+	defer func() { finishTelemetry(err) }()
+	ctx = googleapi.WithAudit(ctx, commandAuditLogger(runtimeContext, commandName))
+	// Dry runs change nothing, and the pre-change reads undo needs would
+	// spend a --max-api-calls budget.
+	if !cli.DryRun && cli.MaxAPICalls == 0 {
+		ctx = withUndoRecorder(ctx, runtimeContext, commandName)
+	}
 	serviceAccounts := func() (*config.ServiceAccountStore, error) {
 		return commandServiceAccountStore(runtimeContext)
 	}
@@ -248,14 +281,19 @@ func executeWithRuntime(args []string, runtime *app.Runtime) (err error) {
 		return resolveRuntimeClient(runtime, email, override)
 	}
 	authDependencies := googleapi.AuthDependencies{
-		ResolveClient:             resolveClient,
-		ReadCredentials:           readCredentials,
-		OpenTokens:                openTokens,
-		ServiceAccounts:           serviceAccounts,
-		UpdateEmailReferences:     updateEmailReferences,
-		Mode:                      cli.authMode,
-		ADCTokenSource:            googleapi.DefaultADCTokenSource,
-		ServiceAccountTokenSource: googleapi.DefaultServiceAccountTokenSource,
+		ResolveClient:              resolveClient,
+		ReadCredentials:            readCredentials,
+		OpenTokens:                 openTokens,
+		ServiceAccounts:            serviceAccounts,
+		UpdateEmailReferences:      updateEmailReferences,
+		Mode:                       cli.authMode,
+		ADCTokenSource:             googleapi.DefaultADCTokenSource,
+		ServiceAccountTokenSource:  googleapi.DefaultServiceAccountTokenSource,
+		ExternalAccountFile:        envOr("GOG_EXTERNAL_ACCOUNT_FILE", os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")),
+		ExternalAccountTokenSource: googleapi.DefaultExternalAccountTokenSource,
+	}
+	if cli.brokerSocket != "" {
+		authDependencies.Broker = brokerTokenSource(cli.brokerSocket)
 	}
 	ctx = googleapi.WithAuthDependencies(ctx, authDependencies)
 	composeRuntimeGoogleServices(runtime, googleapi.NewFactory(authDependencies, googleapi.FactoryOptions{
@@ -375,6 +413,26 @@ func validateJSONTransformFlags(mode outfmt.Mode, flags *RootFlags) error {
 	}
 }
 
+// resolveOutputMode combines --json/--plain with --output. Explicit flags
+// beat GOG_JSON, GOG_PLAIN, and GOG_OUTPUT defaults.
+func resolveOutputMode(kctx *kong.Context, flags *RootFlags) (outfmt.Mode, error) {
+	output := strings.TrimSpace(flags.Output)
+	if output == "" {
+		return outfmt.FromFlags(flags.JSON, flags.Plain)
+	}
+
+	outputSet := flagProvided(kctx, "output")
+	jsonOrPlainSet := flagProvided(kctx, "json") || flagProvided(kctx, "plain")
+	switch {
+	case outputSet && jsonOrPlainSet:
+		return outfmt.Mode{}, errors.New("invalid output mode (cannot combine --output with --json or --plain)")
+	case jsonOrPlainSet:
+		return outfmt.FromFlags(flags.JSON, flags.Plain)
+	}
+
+	return outfmt.ParseOutput(output)
+}
+
 func applyExplicitOutputModePrecedence(kctx *kong.Context, flags *RootFlags) {
 	if flags == nil {
 		return
@@ -433,7 +491,7 @@ func preScanHomeArg(args []string) (string, bool) {
 
 func globalFlagTakesValue(flag string) bool {
 	switch flag {
-	case "--color", "--account", "--acct", "--client", "--access-token", "--enable-commands", "--enable-commands-exact", "--disable-commands", "--select", "--pick", "--project", "--home", "-a":
+	case "--color", "--account", "--acct", "--client", "--access-token", "--enable-commands", "--enable-commands-exact", "--disable-commands", "--select", "--pick", "--project", "--home", "--output", "-a":
 		return true
 	default:
 		return false
@@ -479,7 +537,7 @@ func newParser(description string) (*kong.Kong, *CLI, error) {
 	return newParserWithWriters(description, os.Stdout, os.Stderr)
 }
 
-func newParserWithWriters(description string, stdout, stderr io.Writer) (*kong.Kong, *CLI, error) {
+func newParserWithWriters(description string, stdout, stderr io.Writer, plugins ...cliPlugin) (*kong.Kong, *CLI, error) {
 	envMode := outfmt.FromEnv()
 	vars := kong.Vars{
 		"auth_services":          googleauth.UserServiceCSV(),
@@ -492,14 +550,14 @@ func newParserWithWriters(description string, stdout, stderr io.Writer) (*kong.K
 		"gmail_no_send":          boolString(envBool("GOG_GMAIL_NO_SEND")),
 		"json":                   boolString(envMode.JSON),
 		"plain":                  boolString(envMode.Plain),
+		"output":                 envOr("GOG_OUTPUT", ""),
 		"readonly":               boolString(envBool("GOG_READONLY")),
 		"wrap_untrusted":         boolString(envBool("GOG_WRAP_UNTRUSTED")),
 		"version":                VersionString(),
 	}
 
 	cli := &CLI{}
-	parser, err := kong.New(
-		cli,
+	options := []kong.Option{
 		kong.Name("gog"),
 		kong.Description(description),
 		kong.ConfigureHelp(helpOptions()),
@@ -507,7 +565,8 @@ func newParserWithWriters(description string, stdout, stderr io.Writer) (*kong.K
 		kong.Vars(vars),
 		kong.Writers(stdout, stderr),
 		kong.Exit(func(code int) { panic(exitPanic{code: code}) }),
-	)
+	}
+	parser, err := kong.New(cli, append(options, pluginOptions(plugins)...)...)
 	if err != nil {
 		return nil, nil, err
 	}
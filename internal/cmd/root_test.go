@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -103,6 +106,9 @@ func TestExecute_EarlyUsageErrorsPrintToStderr(t *testing.T) {
 		{name: "invalid color", args: []string{"--color", "bogus", "version"}, want: "expected auto|always|never"},
 		{name: "results only without json", args: []string{"--results-only", "version"}, want: "--results-only requires --json"},
 		{name: "select without json", args: []string{"--select", "version", "version"}, want: "--select requires --json"},
+		{name: "output with json", args: []string{"--json", "--output", "yaml", "version"}, want: "cannot combine --output with --json or --plain"},
+		{name: "unknown output format", args: []string{"--output", "xml", "version"}, want: `invalid --output "xml"`},
+		{name: "bad output template", args: []string{"--output", "template={{.version", "version"}, want: "invalid --output template"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -157,6 +163,72 @@ func TestExecute_ExplicitOutputModeOverridesEnvironment(t *testing.T) {
 	}
 }
 
+func TestExecute_OutputFormatFromEnvironmentAndFlags(t *testing.T) {
+	t.Setenv("GOG_JSON", "")
+	t.Setenv("GOG_PLAIN", "")
+	t.Setenv("GOG_OUTPUT", "yaml")
+
+	run := func(args ...string) string {
+		t.Helper()
+		var runErr error
+		out := captureStdout(t, func() {
+			_ = captureStderr(t, func() {
+				runErr = Execute(args)
+			})
+		})
+		if runErr != nil {
+			t.Fatalf("Execute %v: %v", args, runErr)
+		}
+		return out
+	}
+
+	if out := run("version"); !strings.Contains(out, "\nversion: ") {
+		t.Fatalf("GOG_OUTPUT=yaml output = %q", out)
+	}
+	if out := run("--json", "version"); !strings.HasPrefix(strings.TrimSpace(out), "{") {
+		t.Fatalf("--json over GOG_OUTPUT = %q", out)
+	}
+	if out := run("--output", "template=v={{.version}}", "version"); !strings.HasPrefix(out, "v=") || strings.Contains(out, "{") {
+		t.Fatalf("template output = %q", out)
+	}
+}
+
+func TestExecute_WritesTelemetryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	t.Setenv("GOG_OTEL_FILE", path)
+	t.Setenv("GOG_OTEL_ENDPOINT", "")
+	t.Setenv("GOG_OTEL_HEADERS", "")
+
+	_ = captureStderr(t, func() {
+		if err := Execute([]string{"history", "--max", "0"}); ExitCode(err) != 2 {
+			t.Fatalf("Execute: %v", err)
+		}
+	})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read trace: %v", err)
+	}
+	var span struct {
+		Name       string
+		Attributes []struct {
+			Key   string
+			Value struct{ Value any }
+		}
+		Status struct{ Code string }
+	}
+	if err := json.Unmarshal([]byte(strings.SplitN(string(data), "\n", 2)[0]), &span); err != nil {
+		t.Fatalf("decode %q: %v", data, err)
+	}
+	attrs := map[string]any{}
+	for _, kv := range span.Attributes {
+		attrs[kv.Key] = kv.Value.Value
+	}
+	if span.Name != "gog history" || attrs["gog.command"] != "history" || attrs["gog.exit_code"] != float64(2) || span.Status.Code != "Error" {
+		t.Fatalf("span = %+v", span)
+	}
+}
+
 func TestExecute_Help_GmailHasGroupsAndRelativeCommands(t *testing.T) {
 	out := captureStdout(t, func() {
 		_ = captureStderr(t, func() {
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
 	admin "google.golang.org/api/admin/directory/v1"
 	analyticsadmin "google.golang.org/api/analyticsadmin/v1beta"
 	analyticsdata "google.golang.org/api/analyticsdata/v1beta"
@@ -31,6 +32,7 @@ import (
 
 	"github.com/steipete/gogcli/internal/app"
 	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
 )
 
 var errRuntimeServiceRequired = errors.New("runtime service is required")
@@ -47,6 +49,9 @@ func composeRuntimeGoogleServices(runtime *app.Runtime, factory googleapi.Factor
 	if services.AdminOrgUnit == nil {
 		services.AdminOrgUnit = factory.AdminOrgUnit
 	}
+	if services.AdminTransfer == nil {
+		services.AdminTransfer = factory.AdminDataTransfer
+	}
 	if services.AppScript == nil {
 		services.AppScript = factory.AppScript
 	}
@@ -56,6 +61,9 @@ func composeRuntimeGoogleServices(runtime *app.Runtime, factory googleapi.Factor
 	if services.AnalyticsData == nil {
 		services.AnalyticsData = factory.AnalyticsData
 	}
+	if services.Batch == nil {
+		services.Batch = factory.Batch
+	}
 	if services.Calendar == nil {
 		services.Calendar = factory.Calendar
 	}
@@ -113,6 +121,9 @@ func composeRuntimeGoogleServices(runtime *app.Runtime, factory googleapi.Factor
 	if services.Photos == nil {
 		services.Photos = factory.Photos
 	}
+	if services.PhotosWrite == nil {
+		services.PhotosWrite = factory.PhotosWrite
+	}
 	if services.PhotosPicker == nil {
 		services.PhotosPicker = factory.PhotosPicker
 	}
@@ -143,6 +154,9 @@ func composeRuntimeGoogleServices(runtime *app.Runtime, factory googleapi.Factor
 	if services.YouTubeWrite == nil {
 		services.YouTubeWrite = factory.YouTubeWrite
 	}
+	if services.YouTubeUpload == nil {
+		services.YouTubeUpload = factory.YouTubeUpload
+	}
 }
 
 func runtimeWithService(ctx context.Context, name string) (*app.Runtime, error) {
@@ -169,6 +183,14 @@ func adminOrgUnitDirectoryService(ctx context.Context, account string) (*admin.S
 	return runtime.Services.AdminOrgUnit(ctx, account)
 }
 
+func adminDataTransferService(ctx context.Context, account string) (*datatransfer.Service, error) {
+	runtime, err := runtimeWithService(ctx, "admin data transfer")
+	if err != nil || runtime.Services.AdminTransfer == nil {
+		return nil, serviceError(err, "admin data transfer")
+	}
+	return runtime.Services.AdminTransfer(ctx, account)
+}
+
 func appScriptService(ctx context.Context, account string) (*scriptapi.Service, error) {
 	runtime, err := runtimeWithService(ctx, "app script")
 	if err != nil || runtime.Services.AppScript == nil {
@@ -249,6 +271,14 @@ func photosService(ctx context.Context, account string) (*googleapi.PhotosClient
 	return runtime.Services.Photos(ctx, account)
 }
 
+func photosWriteService(ctx context.Context, account string) (*googleapi.PhotosClient, error) {
+	runtime, err := runtimeWithService(ctx, "photos write")
+	if err != nil || runtime.Services.PhotosWrite == nil {
+		return nil, serviceError(err, "photos write")
+	}
+	return runtime.Services.PhotosWrite(ctx, account)
+}
+
 func photosPickerService(ctx context.Context, account string) (*googleapi.PhotosPickerClient, error) {
 	runtime, err := runtimeWithService(ctx, "photos picker")
 	if err != nil || runtime.Services.PhotosPicker == nil {
@@ -313,6 +343,20 @@ func docsHTTPClient(ctx context.Context, account string) (*http.Client, error) {
 	return runtime.Services.DocsHTTP(ctx, account)
 }
 
+// googleBatch returns a batch client for the API served at basePath, usually
+// the BasePath of an already constructed generated service.
+func googleBatch(ctx context.Context, account string, service googleauth.Service, basePath string) (*googleapi.Batch, error) {
+	runtime, err := runtimeWithService(ctx, "batch HTTP")
+	if err != nil || runtime.Services.Batch == nil {
+		return nil, serviceError(err, "batch HTTP")
+	}
+	client, err := runtime.Services.Batch(ctx, account, service)
+	if err != nil {
+		return nil, err
+	}
+	return googleapi.NewBatch(client, basePath)
+}
+
 func formsService(ctx context.Context, account string) (*formsapi.Service, error) {
 	runtime, err := runtimeWithService(ctx, "forms")
 	if err != nil || runtime.Services.Forms == nil {
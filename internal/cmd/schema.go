@@ -183,7 +183,7 @@ func buildSchemaAutomation(ctx context.Context, flags *RootFlags, profile bakedS
 	}
 
 	return schemaAutomation{
-		OutputFormats: []string{"json", "plain"},
+		OutputFormats: outfmt.OutputFormats(),
 		ExitCodes:     stableExitCodes(),
 		Safety:        safety,
 	}, nil
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"strings"
 
 	"google.golang.org/api/drive/v3"
@@ -19,6 +18,7 @@ type SlidesCmd struct {
 	Info               SlidesInfoCmd               `cmd:"" name:"info" aliases:"get,show" help:"Get Google Slides presentation metadata"`
 	Create             SlidesCreateCmd             `cmd:"" name:"create" aliases:"add,new" help:"Create a Google Slides presentation"`
 	CreateFromMarkdown SlidesCreateFromMarkdownCmd `cmd:"" name:"create-from-markdown" help:"Create a Google Slides presentation from markdown"`
+	UpdateFromMarkdown SlidesUpdateFromMarkdownCmd `cmd:"" name:"update-from-markdown" help:"Patch an existing presentation from edited markdown (match, add, delete, reorder, replace)"`
 	CreateFromTemplate SlidesCreateFromTemplateCmd `cmd:"" name:"create-from-template" help:"Create a presentation from template with text replacements"`
 	Copy               SlidesCopyCmd               `cmd:"" name:"copy" aliases:"cp,duplicate" help:"Copy a Google Slides presentation"`
 	AddSlide           SlidesAddSlideCmd           `cmd:"" name:"add-slide" help:"Add a slide with a full-bleed image and optional speaker notes"`
@@ -85,11 +85,16 @@ func (c *SlidesRawCmd) Run(ctx context.Context, flags *RootFlags) error {
 type SlidesExportCmd struct {
 	PresentationID string         `arg:"" name:"presentationId" help:"Presentation ID"`
 	Output         OutputPathFlag `embed:""`
-	Format         string         `name:"format" help:"Export format: pdf|pptx" default:"pptx"`
+	Format         string         `name:"format" help:"Export format: pdf|pptx|md (md writes slidey markdown readable by create-from-markdown)" default:"pptx"`
 	Overwrite      bool           `name:"overwrite" help:"Overwrite an existing output file"`
+	AssetsDir      string         `name:"assets-dir" help:"With --format md: directory for downloaded slide images (default: <out>_assets)"`
+	NoAssets       bool           `name:"no-assets" help:"With --format md: link image URLs instead of downloading images"`
 }
 
 func (c *SlidesExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if isSlidesMarkdownExportFormat(c.Format) {
+		return c.runMarkdown(ctx, flags)
+	}
 	return exportViaDrive(ctx, flags, exportViaDriveOptions{
 		ArgName:       "presentationId",
 		ExpectedMime:  "application/vnd.google-apps.presentation",
@@ -209,20 +214,9 @@ func (c *SlidesCreateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags)
 		return usage("empty title")
 	}
 
-	var markdown string
-	var err error
-	switch {
-	case c.ContentFile != "":
-		var data []byte
-		data, err = os.ReadFile(c.ContentFile)
-		if err != nil {
-			return fmt.Errorf("read content file: %w", err)
-		}
-		markdown = string(data)
-	case c.Content != "":
-		markdown = c.Content
-	default:
-		return usage("either --content or --content-file is required")
+	markdown, err := readSlidesMarkdownInput(c.Content, c.ContentFile)
+	if err != nil {
+		return err
 	}
 
 	parsed, err := slidesmarkdown.Parse(markdown, slidesmarkdown.ParseOptions{DefaultFAStyle: c.FAStyle})
@@ -154,7 +154,7 @@ func (c *SlidesAddSlideCmd) Run(ctx context.Context, flags *RootFlags) error {
 	}
 
 	// Create the slide with a full-bleed image in one batch
-	err = batchUpdateSlidesImageRequests(ctx, slidesSvc, presentationID, &slides.BatchUpdatePresentationRequest{
+	_, err = batchUpdateSlidesImageRequests(ctx, slidesSvc, presentationID, &slides.BatchUpdatePresentationRequest{
 		Requests: []*slides.Request{
 			{
 				CreateSlide: createSlideReq,
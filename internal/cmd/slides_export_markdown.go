@@ -0,0 +1,752 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/slidesmarkdown"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	slidesExportFormatMarkdown = "md"
+
+	// Text boxes whose top edges differ by less than this are treated as
+	// one row when detecting column layouts.
+	slidesColumnTopTolerancePT = 2
+	// Fully bold paragraphs at or above these sizes export as # / ## headings.
+	slidesHeadingLevelOnePT = 32
+	slidesHeadingLevelTwoPT = 20
+)
+
+var (
+	// create-from-markdown names its generated boxes title_N / body_N /
+	// body_N_colK on BLANK slides; recognizing them keeps round trips exact.
+	slidesGeneratedTitleIDRE = regexp.MustCompile(`^title_\d+$`)
+	// create-from-markdown writes bullets as literal text prefixes.
+	slidesPlainBulletRE = regexp.MustCompile(`^( *)(•|→|-|\d+\.) `)
+)
+
+func isSlidesMarkdownExportFormat(format string) bool {
+	switch strings.ToLower(strings.TrimSpace(format)) {
+	case slidesExportFormatMarkdown, "markdown":
+		return true
+	default:
+		return false
+	}
+}
+
+func (c *SlidesExportCmd) runMarkdown(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	id := normalizeGoogleID(strings.TrimSpace(c.PresentationID))
+	if id == "" {
+		return usage("empty presentationId")
+	}
+	outPath := strings.TrimSpace(c.Output.Path)
+	if outPath != "" && !isStdoutPath(outPath) {
+		expanded, err := config.ExpandPath(outPath)
+		if err != nil {
+			return err
+		}
+		outPath = expanded
+	}
+	assetsDir := strings.TrimSpace(c.AssetsDir)
+	if assetsDir != "" {
+		expanded, err := config.ExpandPath(assetsDir)
+		if err != nil {
+			return err
+		}
+		assetsDir = expanded
+	}
+	noAssets := c.NoAssets || isStdoutPath(outPath)
+
+	var defaultDownloadsDir string
+	if outPath == "" {
+		layout, err := commandLayout(ctx, config.PathKindConfig)
+		if err != nil {
+			return err
+		}
+		defaultDownloadsDir = layout.DriveDownloadsDir()
+	}
+	if err := dryRunExit(ctx, flags, "slides.export", map[string]any{
+		"id":                    id,
+		"out":                   outPath,
+		"default_downloads_dir": defaultDownloadsDir,
+		"format":                slidesExportFormatMarkdown,
+		"assets_dir":            assetsDir,
+		"no_assets":             noAssets,
+		"overwrite":             c.Overwrite,
+	}); err != nil {
+		return err
+	}
+	if outfmt.IsJSON(ctx) && isStdoutPath(outPath) {
+		return usage("can't combine --json with --out -")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := slidesService(ctx, account)
+	if err != nil {
+		return err
+	}
+	pres, err := svc.Presentations.Get(id).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("get presentation: %w", err)
+	}
+
+	title := strings.TrimSpace(pres.Title)
+	if title == "" {
+		title = id
+	}
+	destPath, err := resolveDriveDownloadDestPath(&drive.File{Id: id, Name: title + ".md"}, outPath, defaultDownloadsDir)
+	if err != nil {
+		return err
+	}
+	if !noAssets && assetsDir == "" {
+		assetsDir = strings.TrimSuffix(destPath, filepath.Ext(destPath)) + "_assets"
+	}
+
+	images := 0
+	imageRef := func(slideNumber int, element *slides.PageElement) (string, error) {
+		images++
+		if noAssets {
+			return slidesImageSourceURL(element.Image), nil
+		}
+		written, err := downloadSlidesImageAsset(ctx, element.Image.ContentUrl, assetsDir, fmt.Sprintf("slide-%02d-%s", slideNumber, element.ObjectId), c.Overwrite)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(filepath.Dir(destPath), written)
+		if err != nil {
+			rel = written
+		}
+		return filepath.ToSlash(rel), nil
+	}
+
+	deck, err := slidesPresentationToMarkdown(pres, imageRef)
+	if err != nil {
+		return err
+	}
+	markdown := slidesmarkdown.Render(deck)
+
+	if isStdoutPath(destPath) {
+		_, err = io.WriteString(stdoutWriter(ctx), markdown)
+		return err
+	}
+	f, writtenPath, err := openUserOutputFile(destPath, outputFileOptions{Overwrite: c.Overwrite})
+	if err != nil {
+		return fmt.Errorf("create output file: %w", err)
+	}
+	if _, err := io.WriteString(f, markdown); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("write output file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("close output file: %w", err)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		result := map[string]any{
+			"path":   writtenPath,
+			"slides": len(deck),
+			"images": images,
+		}
+		if !noAssets && images > 0 {
+			result["assetsDir"] = assetsDir
+		}
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), result)
+	}
+	u.Out().Linef("path\t%s", writtenPath)
+	u.Out().Linef("slides\t%d", len(deck))
+	u.Out().Linef("images\t%d", images)
+	if !noAssets && images > 0 {
+		u.Out().Linef("assets\t%s", assetsDir)
+	}
+	return nil
+}
+
+func slidesImageSourceURL(image *slides.Image) string {
+	if image == nil {
+		return ""
+	}
+	if image.SourceUrl != "" {
+		return image.SourceUrl
+	}
+	return image.ContentUrl
+}
+
+func downloadSlidesImageAsset(ctx context.Context, url, dir, baseName string, overwrite bool) (string, error) {
+	if strings.TrimSpace(url) == "" {
+		return "", fmt.Errorf("image %s has no content URL", baseName)
+	}
+	resp, err := fetchSlidesContentURL(ctx, "image", url)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	path := filepath.Join(dir, baseName+slidesImageExtension(resp.Header.Get("Content-Type")))
+	f, writtenPath, err := openUserOutputFile(path, outputFileOptions{Overwrite: overwrite})
+	if err != nil {
+		return "", fmt.Errorf("create image file: %w", err)
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		_ = f.Close()
+		_ = os.Remove(writtenPath)
+		return "", fmt.Errorf("write image file: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return "", fmt.Errorf("close image file: %w", err)
+	}
+	return writtenPath, nil
+}
+
+func slidesImageExtension(contentType string) string {
+	switch strings.ToLower(strings.TrimSpace(strings.SplitN(contentType, ";", 2)[0])) {
+	case imageMimeJPEG:
+		return imageExtJPG
+	case imageMimeGIF:
+		return imageExtGIF
+	default:
+		return extPNG
+	}
+}
+
+// slidesMarkdownImageFunc returns the markdown destination for one image
+// element. slideNumber is one-based.
+type slidesMarkdownImageFunc func(slideNumber int, element *slides.PageElement) (string, error)
+
+// slidesPresentationToMarkdown maps a presentation onto the slidey AST:
+// layouts become `layout:` frontmatter, text boxes become headings,
+// paragraphs and bullets, tables become GFM tables, images become image
+// blocks and speaker notes become `## Notes`.
+func slidesPresentationToMarkdown(pres *slides.Presentation, image slidesMarkdownImageFunc) ([]slidesmarkdown.Slide, error) {
+	if pres == nil {
+		return nil, nil
+	}
+	layoutNames := map[string]string{}
+	for _, layout := range pres.Layouts {
+		if layout != nil && layout.LayoutProperties != nil {
+			layoutNames[layout.ObjectId] = layout.LayoutProperties.Name
+		}
+	}
+
+	out := make([]slidesmarkdown.Slide, 0, len(pres.Slides))
+	for i, page := range pres.Slides {
+		if page == nil {
+			continue
+		}
+		predefined := ""
+		if page.SlideProperties != nil {
+			predefined = layoutNames[page.SlideProperties.LayoutObjectId]
+		}
+		slide, err := slidesPageToMarkdown(page, predefined, i+1, image)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, slide)
+	}
+	return out, nil
+}
+
+type slidesExportElement struct {
+	element *slides.PageElement
+	bounds  slidesElementGeometry
+}
+
+func slidesPageToMarkdown(page *slides.Page, predefinedLayout string, slideNumber int, image slidesMarkdownImageFunc) (slidesmarkdown.Slide, error) {
+	var title string
+	var texts []slidesExportElement
+	var others []slidesExportElement
+
+	for _, item := range flattenSlidesExportElements(page.PageElements) {
+		el := item.element
+		switch {
+		case el.Shape != nil:
+			if el.Shape.Text == nil || slidesShapeIsChrome(el.Shape) {
+				continue
+			}
+			if title == "" && slidesShapeIsTitle(el) {
+				title = slidesPlainText(el.Shape.Text)
+				continue
+			}
+			if strings.TrimSpace(slidesPlainText(el.Shape.Text)) != "" {
+				texts = append(texts, item)
+			}
+		case el.Table != nil:
+			others = append(others, item)
+		case el.Image != nil:
+			if !slidesIsIconImage(item) {
+				others = append(others, item)
+			}
+		}
+	}
+
+	columns := slidesColumnGroups(texts)
+	layout := slideyLayoutForPredefined(predefinedLayout)
+	if layout == "" || layout == "two-cols" {
+		layout = slideyColumnsLayout(len(columns))
+	}
+	if layout == "" && slidesTextIsCentered(texts) {
+		if title == "" {
+			layout = literalTitle
+		} else {
+			layout = "center"
+		}
+	}
+	if layout != "two-cols" && layout != "three-cols" {
+		columns = nil
+	}
+
+	var body []slidesmarkdown.Block
+	if columns != nil {
+		cols := make([][]slidesmarkdown.Block, len(columns))
+		inColumn := map[*slides.PageElement]bool{}
+		for i, col := range columns {
+			for _, item := range col {
+				inColumn[item.element] = true
+				cols[i] = append(cols[i], slidesTextToBlocks(item.element.Shape.Text)...)
+			}
+		}
+		body = append(body, slidesmarkdown.ColumnsBlock{Columns: cols})
+		var rest []slidesExportElement
+		for _, item := range texts {
+			if !inColumn[item.element] {
+				rest = append(rest, item)
+			}
+		}
+		texts = rest
+	}
+
+	ordered := append(append([]slidesExportElement{}, texts...), others...)
+	sortSlidesExportElements(ordered)
+	for _, item := range ordered {
+		el := item.element
+		switch {
+		case el.Shape != nil:
+			body = append(body, slidesTextToBlocks(el.Shape.Text)...)
+		case el.Table != nil:
+			body = append(body, slidesTableToBlock(el.Table))
+		case el.Image != nil:
+			url, err := image(slideNumber, el)
+			if err != nil {
+				return slidesmarkdown.Slide{}, err
+			}
+			alt := strings.TrimSpace(el.Description)
+			if alt == "" {
+				alt = strings.TrimSpace(el.Title)
+			}
+			body = append(body, slidesmarkdown.ImageBlock{Alt: alt, URL: url})
+		}
+	}
+
+	// The frontmatter id lets update-from-markdown find this slide again.
+	notes := slidesSpeakerNotesText(page)
+	id := page.ObjectId
+	if key := slidesNotesSlideKey(notes); key != "" {
+		id = key
+		notes = stripSlidesNotesSlideKey(notes)
+	}
+
+	slide := slidesmarkdown.Slide{
+		Frontmatter: slidesmarkdown.SlideFrontmatter{Layout: layout, ID: id},
+		Title:       title,
+		Body:        body,
+		Notes:       notes,
+	}
+	if MapSlideyLayout(layout) == LayoutKindSectionHeader && title != "" {
+		// Section layouts keep their heading in the body; the parser does
+		// not hoist it into Slide.Title.
+		heading := slidesmarkdown.HeadingBlock{Level: 1, Inlines: []slidesmarkdown.Inline{slidesmarkdown.TextRun{Text: title}}}
+		slide.Body = append([]slidesmarkdown.Block{heading}, slide.Body...)
+		slide.Title = ""
+	}
+	return slide, nil
+}
+
+func slideyLayoutForPredefined(name string) string {
+	switch name {
+	case "TITLE":
+		return literalTitle
+	case "SECTION_HEADER", "SECTION_TITLE_AND_DESCRIPTION", "MAIN_POINT":
+		return "statement"
+	case "BIG_NUMBER":
+		return "hero"
+	case "TITLE_AND_TWO_COLUMNS":
+		return "two-cols"
+	default:
+		return ""
+	}
+}
+
+func slideyColumnsLayout(columns int) string {
+	switch columns {
+	case 2:
+		return "two-cols"
+	case 3:
+		return "three-cols"
+	default:
+		return ""
+	}
+}
+
+func flattenSlidesExportElements(elements []*slides.PageElement) []slidesExportElement {
+	var out []slidesExportElement
+	var walk func([]*slides.PageElement, slidesAffineMatrix)
+	walk = func(elements []*slides.PageElement, parent slidesAffineMatrix) {
+		for _, el := range elements {
+			if el == nil {
+				continue
+			}
+			local, ok := slidesTransformMatrix(el.Transform)
+			if !ok {
+				local = slidesAffineMatrix{a: 1, d: 1}
+			}
+			absolute := slidesComposeTransforms(parent, local)
+			if el.ElementGroup != nil {
+				walk(el.ElementGroup.Children, absolute)
+				continue
+			}
+			item := slidesExportElement{element: el}
+			if bounds := slidesElementBounds(el.Size, absolute); bounds != nil {
+				item.bounds = *bounds
+			}
+			out = append(out, item)
+		}
+	}
+	walk(elements, slidesAffineMatrix{a: 1, d: 1})
+	sortSlidesExportElements(out)
+	return out
+}
+
+// sortSlidesExportElements orders elements top-to-bottom, then
+// left-to-right, which is the reading order the markdown body follows.
+func sortSlidesExportElements(items []slidesExportElement) {
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i].bounds, items[j].bounds
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+		return a.X < b.X
+	})
+}
+
+// slidesIsIconImage reports the small images create-from-markdown places
+// next to icon shortcodes; the shortcode itself carries them in markdown.
+func slidesIsIconImage(item slidesExportElement) bool {
+	b := item.bounds
+	return b.Width > 0 && b.Height > 0 && b.Width <= iconImageSizePT && b.Height <= iconImageSizePT
+}
+
+func slidesShapeIsTitle(el *slides.PageElement) bool {
+	if el.Shape.Placeholder != nil {
+		switch el.Shape.Placeholder.Type {
+		case "TITLE", "CENTERED_TITLE":
+			return true
+		}
+	}
+	return slidesGeneratedTitleIDRE.MatchString(el.ObjectId)
+}
+
+// slidesShapeIsChrome reports placeholders that carry master-level chrome
+// rather than slide content.
+func slidesShapeIsChrome(shape *slides.Shape) bool {
+	if shape.Placeholder == nil {
+		return false
+	}
+	switch shape.Placeholder.Type {
+	case "SLIDE_NUMBER", "FOOTER", "DATE_AND_TIME", "HEADER":
+		return true
+	default:
+		return false
+	}
+}
+
+// slidesColumnGroups returns 2 or 3 side-by-side text boxes sharing a top
+// edge, left to right, or nil when the slide has no column arrangement.
+func slidesColumnGroups(texts []slidesExportElement) [][]slidesExportElement {
+	sorted := append([]slidesExportElement{}, texts...)
+	sortSlidesExportElements(sorted)
+	for start := 0; start < len(sorted); {
+		end := start + 1
+		for end < len(sorted) && sorted[end].bounds.Y-sorted[start].bounds.Y < slidesColumnTopTolerancePT {
+			end++
+		}
+		row := sorted[start:end]
+		start = end
+		if len(row) < 2 || len(row) > 3 {
+			continue
+		}
+		sort.SliceStable(row, func(i, j int) bool { return row[i].bounds.X < row[j].bounds.X })
+		overlap := false
+		for i := 1; i < len(row); i++ {
+			if row[i].bounds.X < row[i-1].bounds.X+row[i-1].bounds.Width {
+				overlap = true
+			}
+		}
+		if overlap {
+			continue
+		}
+		groups := make([][]slidesExportElement, len(row))
+		for i, item := range row {
+			groups[i] = []slidesExportElement{item}
+		}
+		return groups
+	}
+	return nil
+}
+
+func slidesTextIsCentered(texts []slidesExportElement) bool {
+	if len(texts) != 1 {
+		return false
+	}
+	paragraphs := 0
+	for _, te := range texts[0].element.Shape.Text.TextElements {
+		if te == nil || te.ParagraphMarker == nil {
+			continue
+		}
+		paragraphs++
+		style := te.ParagraphMarker.Style
+		if style == nil || style.Alignment != "CENTER" {
+			return false
+		}
+	}
+	return paragraphs > 0
+}
+
+func slidesPlainText(text *slides.TextContent) string {
+	if text == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, te := range text.TextElements {
+		switch {
+		case te == nil:
+		case te.TextRun != nil:
+			b.WriteString(te.TextRun.Content)
+		case te.AutoText != nil:
+			b.WriteString(te.AutoText.Content)
+		}
+	}
+	return strings.Join(strings.Fields(strings.ReplaceAll(b.String(), "\v", " ")), " ")
+}
+
+func slidesSpeakerNotesText(page *slides.Page) string {
+	notesID := findSpeakerNotesObjectID(page)
+	if notesID == "" {
+		return ""
+	}
+	for _, el := range page.SlideProperties.NotesPage.PageElements {
+		if el == nil || el.ObjectId != notesID || el.Shape == nil || el.Shape.Text == nil {
+			continue
+		}
+		var b strings.Builder
+		for _, te := range el.Shape.Text.TextElements {
+			if te != nil && te.TextRun != nil {
+				b.WriteString(te.TextRun.Content)
+			}
+		}
+		return strings.TrimSpace(strings.ReplaceAll(b.String(), "\v", "\n"))
+	}
+	return ""
+}
+
+type slidesExportParagraph struct {
+	runs      []slidesmarkdown.TextRun
+	bullet    *slides.Bullet
+	maxFontPT float64
+}
+
+func (p slidesExportParagraph) text() string {
+	var b strings.Builder
+	for _, run := range p.runs {
+		b.WriteString(run.Text)
+	}
+	return b.String()
+}
+
+func slidesTextParagraphs(text *slides.TextContent) []slidesExportParagraph {
+	var out []slidesExportParagraph
+	current := -1
+	for _, te := range text.TextElements {
+		if te == nil {
+			continue
+		}
+		if te.ParagraphMarker != nil {
+			out = append(out, slidesExportParagraph{bullet: te.ParagraphMarker.Bullet})
+			current = len(out) - 1
+			continue
+		}
+		if te.TextRun == nil {
+			continue
+		}
+		if current < 0 {
+			out = append(out, slidesExportParagraph{})
+			current = 0
+		}
+		content := strings.ReplaceAll(strings.TrimSuffix(te.TextRun.Content, "\n"), "\v", " ")
+		if content == "" {
+			continue
+		}
+		run := slidesmarkdown.TextRun{Text: content}
+		if style := te.TextRun.Style; style != nil {
+			run.Bold = style.Bold
+			run.Italic = style.Italic
+			family := strings.ToLower(style.FontFamily)
+			run.Code = strings.Contains(family, "mono") || strings.Contains(family, "courier") || strings.Contains(family, "consolas")
+			if style.FontSize != nil {
+				if pt, ok := slidesMagnitudeInPoints(style.FontSize.Magnitude, style.FontSize.Unit); ok && pt > out[current].maxFontPT {
+					out[current].maxFontPT = pt
+				}
+			}
+		}
+		out[current].runs = appendSlidesExportRun(out[current].runs, run)
+	}
+	return out
+}
+
+func appendSlidesExportRun(runs []slidesmarkdown.TextRun, run slidesmarkdown.TextRun) []slidesmarkdown.TextRun {
+	if n := len(runs); n > 0 {
+		last := runs[n-1]
+		if last.Bold == run.Bold && last.Italic == run.Italic && last.Code == run.Code {
+			runs[n-1].Text += run.Text
+			return runs
+		}
+	}
+	return append(runs, run)
+}
+
+// slidesTextToBlocks converts one text box into headings, paragraphs and
+// bullet lists. Native bullets keep their nesting level; the literal "• "
+// prefixes written by create-from-markdown are recognized too.
+func slidesTextToBlocks(text *slides.TextContent) []slidesmarkdown.Block {
+	var blocks []slidesmarkdown.Block
+	var list *slidesmarkdown.BulletsBlock
+	flushList := func() {
+		if list != nil {
+			blocks = append(blocks, *list)
+			list = nil
+		}
+	}
+
+	for _, p := range slidesTextParagraphs(text) {
+		plain := p.text()
+		if strings.TrimSpace(plain) == "" {
+			flushList()
+			continue
+		}
+
+		item, ordered, isBullet := slidesParagraphBullet(p, plain)
+		if isBullet {
+			if list != nil && list.Ordered != ordered {
+				flushList()
+			}
+			if list == nil {
+				list = &slidesmarkdown.BulletsBlock{Ordered: ordered}
+			}
+			list.Items = append(list.Items, item)
+			continue
+		}
+		flushList()
+
+		if level := slidesParagraphHeadingLevel(p); level > 0 {
+			blocks = append(blocks, slidesmarkdown.HeadingBlock{
+				Level:   level,
+				Inlines: []slidesmarkdown.Inline{slidesmarkdown.TextRun{Text: strings.TrimSpace(plain)}},
+			})
+			continue
+		}
+		blocks = append(blocks, slidesmarkdown.ParagraphBlock{Inlines: slidesExportInlines(p.runs)})
+	}
+	flushList()
+	return blocks
+}
+
+func slidesParagraphBullet(p slidesExportParagraph, plain string) (slidesmarkdown.BulletItem, bool, bool) {
+	if p.bullet != nil {
+		glyph := strings.TrimSpace(p.bullet.Glyph)
+		ordered := glyph != "" && (strings.HasSuffix(glyph, ".") || strings.HasSuffix(glyph, ")"))
+		return slidesmarkdown.BulletItem{
+			Indent:  int(p.bullet.NestingLevel),
+			Inlines: slidesExportInlines(p.runs),
+		}, ordered, true
+	}
+	m := slidesPlainBulletRE.FindStringSubmatch(plain)
+	if m == nil {
+		return slidesmarkdown.BulletItem{}, false, false
+	}
+	return slidesmarkdown.BulletItem{
+		Indent:  len(m[1]) / 2,
+		Inlines: slidesExportInlines(trimSlidesExportRuns(p.runs, len(m[0]))),
+	}, strings.HasSuffix(m[2], "."), true
+}
+
+func trimSlidesExportRuns(runs []slidesmarkdown.TextRun, n int) []slidesmarkdown.TextRun {
+	out := make([]slidesmarkdown.TextRun, 0, len(runs))
+	for _, run := range runs {
+		if n >= len(run.Text) {
+			n -= len(run.Text)
+			continue
+		}
+		run.Text = run.Text[n:]
+		n = 0
+		out = append(out, run)
+	}
+	return out
+}
+
+func slidesParagraphHeadingLevel(p slidesExportParagraph) int {
+	for _, run := range p.runs {
+		if strings.TrimSpace(run.Text) != "" && !run.Bold {
+			return 0
+		}
+	}
+	switch {
+	case p.maxFontPT >= slidesHeadingLevelOnePT:
+		return 1
+	case p.maxFontPT >= slidesHeadingLevelTwoPT:
+		return 2
+	default:
+		return 0
+	}
+}
+
+func slidesExportInlines(runs []slidesmarkdown.TextRun) []slidesmarkdown.Inline {
+	out := make([]slidesmarkdown.Inline, 0, len(runs))
+	for _, run := range runs {
+		out = append(out, run)
+	}
+	return out
+}
+
+func slidesTableToBlock(table *slides.Table) slidesmarkdown.TableBlock {
+	rows := make([][]string, len(table.TableRows))
+	for r, row := range table.TableRows {
+		cells := make([]string, table.Columns)
+		for c, cell := range row.TableCells {
+			col := int64(c)
+			if cell.Location != nil {
+				col = cell.Location.ColumnIndex
+			}
+			if col >= 0 && col < int64(len(cells)) {
+				cells[col] = slidesPlainText(cell.Text)
+			}
+		}
+		rows[r] = cells
+	}
+	return slidesmarkdown.TableBlock{Rows: rows}
+}
@@ -0,0 +1,244 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/slidesmarkdown"
+)
+
+func slidesExportTextRun(content string, style map[string]any) map[string]any {
+	run := map[string]any{"content": content}
+	if style != nil {
+		run["style"] = style
+	}
+	return map[string]any{"textRun": run}
+}
+
+func slidesExportParagraphMarker(bullet map[string]any) map[string]any {
+	marker := map[string]any{}
+	if bullet != nil {
+		marker["bullet"] = bullet
+	}
+	return map[string]any{"paragraphMarker": marker}
+}
+
+func slidesExportPresentation(imageURL string) map[string]any {
+	return map[string]any{
+		"presentationId": "pres1",
+		"title":          "Roadmap",
+		"layouts": []any{
+			map[string]any{"objectId": "layout_title", "layoutProperties": map[string]any{"name": "TITLE"}},
+			map[string]any{"objectId": "layout_body", "layoutProperties": map[string]any{"name": "TITLE_AND_BODY"}},
+		},
+		"slides": []any{
+			map[string]any{
+				"objectId":        "s1",
+				"slideProperties": map[string]any{"layoutObjectId": "layout_title"},
+				"pageElements": []any{
+					map[string]any{
+						"objectId": "s1_title",
+						"shape": map[string]any{
+							"placeholder": map[string]any{"type": "CENTERED_TITLE"},
+							"text":        map[string]any{"textElements": []any{slidesExportParagraphMarker(nil), slidesExportTextRun("Roadmap 2027\n", nil)}},
+						},
+					},
+					map[string]any{
+						"objectId":  "s1_sub",
+						"transform": map[string]any{"scaleX": 1, "scaleY": 1, "translateY": 200, "unit": "PT"},
+						"shape": map[string]any{
+							"placeholder": map[string]any{"type": "SUBTITLE"},
+							"text":        map[string]any{"textElements": []any{slidesExportParagraphMarker(nil), slidesExportTextRun("Platform team\n", nil)}},
+						},
+					},
+				},
+			},
+			map[string]any{
+				"objectId": "s2",
+				"slideProperties": map[string]any{
+					"layoutObjectId": "layout_body",
+					"notesPage": map[string]any{
+						"notesProperties": map[string]any{"speakerNotesObjectId": "s2_notes"},
+						"pageElements": []any{
+							map[string]any{
+								"objectId": "s2_notes",
+								"shape": map[string]any{
+									"placeholder": map[string]any{"type": "BODY"},
+									"text":        map[string]any{"textElements": []any{slidesExportTextRun("Mention the migration.\n", nil)}},
+								},
+							},
+						},
+					},
+				},
+				"pageElements": []any{
+					map[string]any{
+						"objectId": "s2_title",
+						"shape": map[string]any{
+							"placeholder": map[string]any{"type": "TITLE"},
+							"text":        map[string]any{"textElements": []any{slidesExportParagraphMarker(nil), slidesExportTextRun("Goals\n", nil)}},
+						},
+					},
+					map[string]any{
+						"objectId":  "s2_body",
+						"transform": map[string]any{"scaleX": 1, "scaleY": 1, "translateY": 100, "unit": "PT"},
+						"shape": map[string]any{
+							"placeholder": map[string]any{"type": "BODY"},
+							"text": map[string]any{"textElements": []any{
+								slidesExportParagraphMarker(map[string]any{"glyph": "●"}),
+								slidesExportTextRun("Ship ", nil),
+								slidesExportTextRun("export", map[string]any{"bold": true}),
+								slidesExportTextRun("\n", nil),
+								slidesExportParagraphMarker(map[string]any{"glyph": "○", "nestingLevel": 1}),
+								slidesExportTextRun("with notes\n", nil),
+							}},
+						},
+					},
+					map[string]any{
+						"objectId":  "s2_table",
+						"transform": map[string]any{"scaleX": 1, "scaleY": 1, "translateY": 200, "unit": "PT"},
+						"table": map[string]any{
+							"rows":    2,
+							"columns": 2,
+							"tableRows": []any{
+								map[string]any{"tableCells": []any{
+									map[string]any{"location": map[string]any{"rowIndex": 0, "columnIndex": 0}, "text": map[string]any{"textElements": []any{slidesExportTextRun("Metric\n", nil)}}},
+									map[string]any{"location": map[string]any{"rowIndex": 0, "columnIndex": 1}, "text": map[string]any{"textElements": []any{slidesExportTextRun("Target\n", nil)}}},
+								}},
+								map[string]any{"tableCells": []any{
+									map[string]any{"location": map[string]any{"rowIndex": 1, "columnIndex": 0}, "text": map[string]any{"textElements": []any{slidesExportTextRun("p99\n", nil)}}},
+									map[string]any{"location": map[string]any{"rowIndex": 1, "columnIndex": 1}, "text": map[string]any{"textElements": []any{slidesExportTextRun("100ms\n", nil)}}},
+								}},
+							},
+						},
+					},
+					map[string]any{
+						"objectId":    "s2_img",
+						"description": "latency chart",
+						"transform":   map[string]any{"scaleX": 1, "scaleY": 1, "translateY": 300, "unit": "PT"},
+						"image":       map[string]any{"contentUrl": imageURL},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestSlidesExportMarkdown_WritesDeckAndAssets(t *testing.T) {
+	imageServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("jpeg-bytes"))
+	}))
+	t.Cleanup(imageServer.Close)
+
+	svc := newSlidesReadTestService(t, slidesExportPresentation(imageServer.URL+"/img"))
+	dir := t.TempDir()
+	outPath := filepath.Join(dir, "deck.md")
+
+	var out bytes.Buffer
+	ctx := withSlidesTestService(newCmdRuntimeJSONOutputContext(t, &out, io.Discard), svc)
+	cmd := &SlidesExportCmd{PresentationID: "pres1", Format: "md", Output: OutputPathFlag{Path: outPath}}
+	if err := cmd.Run(ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(out.Bytes(), &result); err != nil {
+		t.Fatalf("JSON parse: %v\n%s", err, out.String())
+	}
+	if result["slides"] != float64(2) || result["images"] != float64(1) {
+		t.Fatalf("unexpected result: %v", result)
+	}
+
+	data, err := os.ReadFile(outPath)
+	if err != nil {
+		t.Fatalf("read markdown: %v", err)
+	}
+	md := string(data)
+	for _, want := range []string{
+		"---\nlayout: title\nid: s1\n---\n\n# Roadmap 2027\n\nPlatform team\n",
+		"---\nid: s2\n---\n\n# Goals\n\n- Ship **export**\n  - with notes",
+		"| Metric | Target |\n| --- | --- |\n| p99 | 100ms |",
+		"![latency chart](deck_assets/slide-02-s2_img.jpg)",
+		"## Notes\n\nMention the migration.",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	asset, err := os.ReadFile(filepath.Join(dir, "deck_assets", "slide-02-s2_img.jpg"))
+	if err != nil || string(asset) != "jpeg-bytes" {
+		t.Fatalf("asset = %q, %v", asset, err)
+	}
+
+	parsed, err := slidesmarkdown.Parse(md, slidesmarkdown.ParseOptions{Exported: true})
+	if err != nil || len(parsed) != 2 {
+		t.Fatalf("exported markdown does not parse back: %v (%d slides)", err, len(parsed))
+	}
+}
+
+func TestSlidesExportMarkdown_StdoutLinksImages(t *testing.T) {
+	svc := newSlidesReadTestService(t, slidesExportPresentation("https://lh3.example.com/img"))
+
+	var out bytes.Buffer
+	ctx := withSlidesTestService(newCmdRuntimeOutputContext(t, &out, io.Discard), svc)
+	cmd := &SlidesExportCmd{PresentationID: "pres1", Format: "markdown", Output: OutputPathFlag{Path: "-"}}
+	if err := cmd.Run(ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if !strings.Contains(out.String(), "![latency chart](https://lh3.example.com/img)") {
+		t.Fatalf("expected linked image, got:\n%s", out.String())
+	}
+}
+
+func TestSlidesPresentationToMarkdown_GeneratedBlankSlides(t *testing.T) {
+	box := func(id string, x, y float64, text *slides.TextContent) *slides.PageElement {
+		return &slides.PageElement{
+			ObjectId:  id,
+			Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: x, TranslateY: y, Unit: "PT"},
+			Size: &slides.Size{
+				Width:  &slides.Dimension{Magnitude: 300, Unit: "PT"},
+				Height: &slides.Dimension{Magnitude: 200, Unit: "PT"},
+			},
+			Shape: &slides.Shape{ShapeType: "TEXT_BOX", Text: text},
+		}
+	}
+	plain := func(lines ...string) *slides.TextContent {
+		var elements []*slides.TextElement
+		for _, line := range lines {
+			elements = append(elements,
+				&slides.TextElement{ParagraphMarker: &slides.ParagraphMarker{}},
+				&slides.TextElement{TextRun: &slides.TextRun{Content: line + "\n"}},
+			)
+		}
+		return &slides.TextContent{TextElements: elements}
+	}
+
+	pres := &slides.Presentation{Slides: []*slides.Page{{
+		ObjectId: "slide_1",
+		PageElements: []*slides.PageElement{
+			box("title_1", 36, 36, plain("Compare")),
+			box("body_1_col1", 36, 108, plain("• Before", "  • slow")),
+			box("body_1_col2", 360, 108, plain("1. After")),
+		},
+	}}}
+
+	got, err := slidesPresentationToMarkdown(pres, nil)
+	if err != nil {
+		t.Fatalf("convert: %v", err)
+	}
+	md := slidesmarkdown.Render(got)
+	want := "---\nlayout: two-cols\nid: slide_1\n---\n\n# Compare\n\n::cols::\n\n- Before\n  - slow\n\n::col2::\n\n1. After\n\n::/cols::\n"
+	if md != want {
+		t.Fatalf("markdown:\n%s\nwant:\n%s", md, want)
+	}
+}
@@ -73,34 +73,7 @@ func RenderSlides(in []slidesmarkdown.Slide, assets AssetMap, g LayoutGeometry)
 					InsertText: &slides.InsertTextRequest{ObjectId: bodyID, Text: text},
 				})
 			}
-			// Style first paragraph (the h1 line) at 44pt bold.
-			if firstLineLen := utf16CodeUnits(strings.SplitN(text, "\n", 2)[0]); firstLineLen > 0 {
-				reqs = append(reqs, &slides.Request{
-					UpdateTextStyle: &slides.UpdateTextStyleRequest{
-						ObjectId: bodyID,
-						TextRange: &slides.Range{
-							Type:       "FIXED_RANGE",
-							StartIndex: int64Ptr(0),
-							EndIndex:   int64Ptr(firstLineLen),
-						},
-						Style: &slides.TextStyle{
-							Bold:     true,
-							FontSize: &slides.Dimension{Magnitude: 44, Unit: "PT"},
-						},
-						Fields: "bold,fontSize",
-					},
-				})
-			}
-			if text != "" {
-				reqs = append(reqs, &slides.Request{
-					UpdateParagraphStyle: &slides.UpdateParagraphStyleRequest{
-						ObjectId:  bodyID,
-						TextRange: &slides.Range{Type: "ALL"},
-						Style:     &slides.ParagraphStyle{Alignment: "CENTER"},
-						Fields:    "alignment",
-					},
-				})
-			}
+			reqs = append(reqs, slideyBodyStyleRequests(bodyID, text, layout)...)
 			reqs = appendBlockImages(reqs, slide.Body, slideID, assets, SingleBodyBox(g))
 		case LayoutKindTwoCols, LayoutKindThreeCols:
 			n := 2
@@ -131,16 +104,7 @@ func RenderSlides(in []slidesmarkdown.Slide, assets AssetMap, g LayoutGeometry)
 					InsertText: &slides.InsertTextRequest{ObjectId: bodyID, Text: bodyText},
 				})
 			}
-			if layout == LayoutKindCenter && bodyText != "" {
-				reqs = append(reqs, &slides.Request{
-					UpdateParagraphStyle: &slides.UpdateParagraphStyleRequest{
-						ObjectId:  bodyID,
-						TextRange: &slides.Range{Type: "ALL"},
-						Style:     &slides.ParagraphStyle{Alignment: "CENTER"},
-						Fields:    "alignment",
-					},
-				})
-			}
+			reqs = append(reqs, slideyBodyStyleRequests(bodyID, bodyText, layout)...)
 			reqs = appendBlockImages(reqs, slide.Body, slideID, assets, SingleBodyBox(g))
 		}
 
@@ -220,6 +184,8 @@ func blockVisualLines(block slidesmarkdown.Block) int {
 		return diagramVisualLines
 	case slidesmarkdown.ColumnsBlock:
 		return textVisualLines(blocksToPlainText([]slidesmarkdown.Block{v}))
+	case slidesmarkdown.TableBlock:
+		return maxInt(1, len(v.Rows))
 	default:
 		return 1
 	}
@@ -279,20 +245,64 @@ func minFloat(a, b float64) float64 {
 
 func renderTitleBox(slideID string, oneBased int, title string, g LayoutGeometry) []*slides.Request {
 	titleID := fmt.Sprintf("title_%d", oneBased)
-	box := TitleBox(g)
 	return []*slides.Request{
-		createTextBox(titleID, slideID, box),
+		createTextBox(titleID, slideID, TitleBox(g)),
 		{InsertText: &slides.InsertTextRequest{ObjectId: titleID, Text: title}},
-		{UpdateTextStyle: &slides.UpdateTextStyleRequest{
-			ObjectId:  titleID,
-			TextRange: &slides.Range{Type: "ALL"},
-			Style: &slides.TextStyle{
-				Bold:     true,
-				FontSize: &slides.Dimension{Magnitude: 28, Unit: "PT"},
+		slideyTitleStyleRequest(titleID),
+	}
+}
+
+func slideyTitleStyleRequest(titleID string) *slides.Request {
+	return &slides.Request{UpdateTextStyle: &slides.UpdateTextStyleRequest{
+		ObjectId:  titleID,
+		TextRange: &slides.Range{Type: "ALL"},
+		Style: &slides.TextStyle{
+			Bold:     true,
+			FontSize: &slides.Dimension{Magnitude: 28, Unit: "PT"},
+		},
+		Fields: "bold,fontSize",
+	}}
+}
+
+// slideyBodyStyleRequests styles a single body box after its text was
+// inserted: section layouts get a 44pt bold first line and centered
+// paragraphs, center layouts only the alignment.
+func slideyBodyStyleRequests(bodyID, text string, layout LayoutKind) []*slides.Request {
+	if text == "" {
+		return nil
+	}
+	var reqs []*slides.Request
+	if layout == LayoutKindSectionHeader {
+		// Style first paragraph (the h1 line) at 44pt bold.
+		if firstLineLen := utf16CodeUnits(strings.SplitN(text, "\n", 2)[0]); firstLineLen > 0 {
+			reqs = append(reqs, &slides.Request{
+				UpdateTextStyle: &slides.UpdateTextStyleRequest{
+					ObjectId: bodyID,
+					TextRange: &slides.Range{
+						Type:       "FIXED_RANGE",
+						StartIndex: int64Ptr(0),
+						EndIndex:   int64Ptr(firstLineLen),
+					},
+					Style: &slides.TextStyle{
+						Bold:     true,
+						FontSize: &slides.Dimension{Magnitude: 44, Unit: "PT"},
+					},
+					Fields: "bold,fontSize",
+				},
+			})
+		}
+	}
+	if layout == LayoutKindSectionHeader || layout == LayoutKindCenter {
+		reqs = append(reqs, &slides.Request{
+			UpdateParagraphStyle: &slides.UpdateParagraphStyleRequest{
+				ObjectId:  bodyID,
+				TextRange: &slides.Range{Type: "ALL"},
+				Style:     &slides.ParagraphStyle{Alignment: "CENTER"},
+				Fields:    "alignment",
 			},
-			Fields: "bold,fontSize",
-		}},
+		})
 	}
+	return reqs
 }
 
 func createTextBox(objectID, slideID string, box BoxRect) *slides.Request {
@@ -319,7 +329,8 @@ func createTextBox(objectID, slideID string, box BoxRect) *slides.Request {
 // blocksToPlainText is the simplest body-text extraction: paragraphs
 // joined by blank lines, bullets prefixed with "• ", code blocks shown
 // verbatim. Inline icons are skipped; diagrams reserve blank text lines
-// so later text does not overlap the CreateImage request.
+// so later text does not overlap the CreateImage request. Tables flatten to
+// pipe-joined rows and standalone images fall back to their alt text.
 func blocksToPlainText(blocks []slidesmarkdown.Block) string {
 	var b strings.Builder
 	for i, blk := range blocks {
@@ -369,6 +380,15 @@ func blocksToPlainText(blocks []slidesmarkdown.Block) string {
 			}
 		case slidesmarkdown.DiagramBlock:
 			b.WriteString(strings.Repeat("\n", diagramVisualLines-1))
+		case slidesmarkdown.TableBlock:
+			for j, row := range v.Rows {
+				if j > 0 {
+					b.WriteString("\n")
+				}
+				b.WriteString(strings.Join(row, " | "))
+			}
+		case slidesmarkdown.ImageBlock:
+			b.WriteString(v.Alt)
 		}
 	}
 	return b.String()
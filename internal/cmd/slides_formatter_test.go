@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 
@@ -371,3 +372,17 @@ func TestDeleteExistingSlideRequests(t *testing.T) {
 	assert.Equal(t, "p", reqs[0].DeleteObject.ObjectId)
 	assert.Equal(t, "slide_existing", reqs[1].DeleteObject.ObjectId)
 }
+
+// create-from-markdown keeps rendering image-only paragraphs and pipe tables
+// as plain body text; only update-from-markdown reads them as native elements.
+func TestRenderSlides_CreateFromMarkdownKeepsImagesAndTablesAsText(t *testing.T) {
+	md := "# Numbers\n\n![chart](https://example.com/chart.png)\n\n| Metric | Value |\n| --- | --- |\n| p99 | 120ms |\n"
+	parsed, err := sm.Parse(md, sm.ParseOptions{DefaultFAStyle: "solid"})
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+
+	reqs, _ := RenderSlides(parsed, NewAssetMap(), defaultGeometry())
+	got, err := json.Marshal(reqs)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"createSlide":{"objectId":"slide_1","slideLayoutReference":{"predefinedLayout":"BLANK"}}},{"createShape":{"elementProperties":{"pageObjectId":"slide_1","size":{"height":{"magnitude":72,"unit":"PT"},"width":{"magnitude":648,"unit":"PT"}},"transform":{"scaleX":1,"scaleY":1,"translateX":36,"translateY":36,"unit":"PT"}},"objectId":"title_1","shapeType":"TEXT_BOX"}},{"insertText":{"objectId":"title_1","text":"Numbers"}},{"updateTextStyle":{"fields":"bold,fontSize","objectId":"title_1","style":{"bold":true,"fontSize":{"magnitude":28,"unit":"PT"}},"textRange":{"type":"ALL"}}},{"createShape":{"elementProperties":{"pageObjectId":"slide_1","size":{"height":{"magnitude":261,"unit":"PT"},"width":{"magnitude":648,"unit":"PT"}},"transform":{"scaleX":1,"scaleY":1,"translateX":36,"translateY":108,"unit":"PT"}},"objectId":"body_1","shapeType":"TEXT_BOX"}},{"insertText":{"objectId":"body_1","text":"chart\n\n| Metric | Value | | --- | --- | | p99 | 120ms |"}}]`, string(got))
+}
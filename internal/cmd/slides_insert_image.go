@@ -118,7 +118,7 @@ func (c *SlidesInsertImageCmd) Run(ctx context.Context, flags *RootFlags) error
 
 	imageID := fmt.Sprintf("img_%d", time.Now().UnixNano())
 
-	err = batchUpdateSlidesImageRequests(ctx, slidesSvc, presentationID, &slides.BatchUpdatePresentationRequest{
+	_, err = batchUpdateSlidesImageRequests(ctx, slidesSvc, presentationID, &slides.BatchUpdatePresentationRequest{
 		Requests: []*slides.Request{
 			{
 				CreateImage: &slides.CreateImageRequest{
@@ -281,19 +281,19 @@ func slidesTableCellLocation(row, col int64) *slides.TableCellLocation {
 	}
 }
 
-func batchUpdateSlidesImageRequests(ctx context.Context, svc *slides.Service, presentationID string, req *slides.BatchUpdatePresentationRequest) error {
+func batchUpdateSlidesImageRequests(ctx context.Context, svc *slides.Service, presentationID string, req *slides.BatchUpdatePresentationRequest) (*slides.BatchUpdatePresentationResponse, error) {
 	var lastErr error
 	for attempt := 0; ; attempt++ {
-		_, err := svc.Presentations.BatchUpdate(presentationID, req).Context(ctx).Do()
+		resp, err := svc.Presentations.BatchUpdate(presentationID, req).Context(ctx).Do()
 		if err == nil {
-			return nil
+			return resp, nil
 		}
 		lastErr = err
 		if attempt >= len(docsImageInsertRetryDelays) || !isRetryableSlidesImageRequestError(err) {
-			return lastErr
+			return nil, lastErr
 		}
 		if err := waitDocsImageInsertRetry(ctx, docsImageInsertRetryDelays[attempt]); err != nil {
-			return err
+			return nil, err
 		}
 	}
 }
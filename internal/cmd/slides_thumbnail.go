@@ -17,7 +17,7 @@ type SlidesThumbnailCmd struct {
 	SlideID        string `arg:"" name:"slideId" help:"Slide object ID (use 'slides list-slides' to find IDs)"`
 	Size           string `name:"size" help:"Thumbnail size: small|medium|large" default:"large"`
 	Format         string `name:"format" help:"Thumbnail format: png|jpeg" default:"png"`
-	Output         string `name:"out" aliases:"output" help:"Write the thumbnail image to a local file"`
+	Output         string `name:"out" help:"Write the thumbnail image to a local file"`
 	Overwrite      bool   `name:"overwrite" help:"Overwrite an existing output file"`
 }
 
@@ -150,21 +150,32 @@ func normalizeSlidesThumbnailFormat(v string) (string, error) {
 	}
 }
 
-func downloadSlidesThumbnail(ctx context.Context, url, outputPath string, overwrite bool) (int64, string, error) {
+// fetchSlidesContentURL GETs a short-lived Slides content URL (thumbnails,
+// image contentUrl). The caller closes the body.
+func fetchSlidesContentURL(ctx context.Context, label, url string) (*http.Response, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return 0, "", fmt.Errorf("build thumbnail download request: %w", err)
+		return nil, fmt.Errorf("build %s download request: %w", label, err)
 	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return 0, "", fmt.Errorf("download thumbnail: %w", err)
+		return nil, fmt.Errorf("download %s: %w", label, err)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, "", fmt.Errorf("download thumbnail: unexpected status %s", resp.Status)
+		_ = resp.Body.Close()
+		return nil, fmt.Errorf("download %s: unexpected status %s", label, resp.Status)
+	}
+	return resp, nil
+}
+
+func downloadSlidesThumbnail(ctx context.Context, url, outputPath string, overwrite bool) (int64, string, error) {
+	resp, err := fetchSlidesContentURL(ctx, "thumbnail", url)
+	if err != nil {
+		return 0, "", err
 	}
+	defer resp.Body.Close()
 
 	f, expandedPath, err := openUserOutputFile(outputPath, outputFileOptions{
 		Overwrite: overwrite,
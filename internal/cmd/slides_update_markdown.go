@@ -0,0 +1,991 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/slidesmarkdown"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	slidesUpdateActionAdd    = "add"
+	slidesUpdateActionUpdate = "update"
+	slidesUpdateActionKeep   = "keep"
+	slidesUpdateActionDelete = "delete"
+
+	slidesBulletPresetDisc     = "BULLET_DISC_CIRCLE_SQUARE"
+	slidesBulletPresetNumbered = "NUMBERED_DIGIT_ALPHA_ROMAN"
+
+	slidesNotesSlideKeyPrefix = "slide-id:"
+)
+
+var (
+	// A "slide-id: <key>" line in speaker notes pins a slide to a markdown
+	// `id:` when the key is not usable as a Slides object ID.
+	slidesNotesSlideKeyRE = regexp.MustCompile(`(?m)^[ \t]*slide-id:[ \t]*(\S+)[ \t]*$`)
+	// Slides object IDs: 5-50 characters, word characters plus "-" and ":".
+	slidesObjectIDRE = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_:-]{4,49}$`)
+)
+
+type SlidesUpdateFromMarkdownCmd struct {
+	PresentationID string `arg:"" name:"presentationId" help:"Presentation ID"`
+	Content        string `name:"content" help:"Markdown content (inline)"`
+	ContentFile    string `name:"content-file" help:"Read markdown content from file"`
+	FAStyle        string `name:"fa-style" help:"Default Font Awesome style when shortcode has no prefix" default:"solid"`
+	KeepUnmatched  bool   `name:"keep-unmatched" help:"Keep deck slides no markdown slide matches (moved to the end) instead of deleting them"`
+}
+
+func (c *SlidesUpdateFromMarkdownCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+
+	presentationID := normalizeGoogleID(strings.TrimSpace(c.PresentationID))
+	if presentationID == "" {
+		return usage("empty presentationId")
+	}
+	markdown, err := readSlidesMarkdownInput(c.Content, c.ContentFile)
+	if err != nil {
+		return err
+	}
+	parsed, err := slidesmarkdown.Parse(markdown, slidesmarkdown.ParseOptions{DefaultFAStyle: c.FAStyle, Exported: true})
+	if err != nil {
+		return fmt.Errorf("parse markdown: %w", err)
+	}
+	if len(parsed) == 0 {
+		return fmt.Errorf("no slides found in markdown")
+	}
+	baseDir := "."
+	if c.ContentFile != "" {
+		baseDir = filepath.Dir(c.ContentFile)
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	slidesSvc, err := slidesService(ctx, account)
+	if err != nil {
+		return err
+	}
+	pres, err := slidesSvc.Presentations.Get(presentationID).Context(ctx).Do()
+	if err != nil {
+		return fmt.Errorf("get presentation: %w", err)
+	}
+
+	plan, err := buildSlidesMarkdownUpdatePlan(pres, parsed, slidesMarkdownUpdateOptions{
+		KeepUnmatched: c.KeepUnmatched,
+		BaseDir:       baseDir,
+	})
+	if err != nil {
+		return err
+	}
+	body := &slides.BatchUpdatePresentationRequest{Requests: plan.Requests}
+	dryRunRequest := map[string]any{
+		"presentation_id": presentationID,
+		"content_file":    strings.TrimSpace(c.ContentFile),
+		"keep_unmatched":  c.KeepUnmatched,
+		"slides":          plan.Changes,
+		"summary":         plan.summary(),
+		"batch_update":    body,
+	}
+	if deleted := plan.summary()["deleted"]; deleted > 0 {
+		if err := dryRunAndConfirmDestructive(ctx, flags, "slides.update-from-markdown", dryRunRequest,
+			fmt.Sprintf("delete %d unmatched slide(s) from presentation %s", deleted, presentationID)); err != nil {
+			return err
+		}
+	} else if err := dryRunExit(ctx, flags, "slides.update-from-markdown", dryRunRequest); err != nil {
+		return err
+	}
+
+	revisionID := pres.RevisionId
+	if len(plan.Requests) > 0 {
+		for _, pending := range plan.Images {
+			source, sourceErr := resolveSlidesImageSource(pending.Path, "")
+			if sourceErr != nil {
+				return sourceErr
+			}
+			imageURL, cleanup, prepErr := prepareSlidesImageURL(ctx, account, source)
+			if prepErr != nil {
+				return prepErr
+			}
+			defer cleanup()
+			pending.setURL(imageURL)
+		}
+		if pres.RevisionId != "" {
+			body.WriteControl = &slides.WriteControl{RequiredRevisionId: pres.RevisionId}
+		}
+		resp, updateErr := batchUpdateSlidesImageRequests(ctx, slidesSvc, presentationID, body)
+		if updateErr != nil {
+			return fmt.Errorf("update slides: %w", updateErr)
+		}
+		if resp.WriteControl != nil && resp.WriteControl.RequiredRevisionId != "" {
+			revisionID = resp.WriteControl.RequiredRevisionId
+		}
+	}
+
+	if len(plan.Notes) > 0 {
+		updated, err := slidesSvc.Presentations.Get(presentationID).Context(ctx).Do()
+		if err != nil {
+			return fmt.Errorf("re-fetch presentation: %w", err)
+		}
+		if notesReqs := buildNotesRequests(updated, plan.Notes); len(notesReqs) > 0 {
+			// Pin the notes pass to our own revision so edits made by
+			// someone else since the first update fail instead of being
+			// overwritten.
+			notesBody := &slides.BatchUpdatePresentationRequest{Requests: notesReqs}
+			if revisionID != "" {
+				notesBody.WriteControl = &slides.WriteControl{RequiredRevisionId: revisionID}
+			}
+			if _, err := slidesSvc.Presentations.BatchUpdate(presentationID, notesBody).Context(ctx).Do(); err != nil {
+				return fmt.Errorf("apply notes: %w", err)
+			}
+		}
+	}
+
+	link := fmt.Sprintf("https://docs.google.com/presentation/d/%s/edit", presentationID)
+	summary := plan.summary()
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"presentationId": presentationID,
+			"slides":         plan.Changes,
+			"summary":        summary,
+			"requests":       len(plan.Requests),
+			"link":           link,
+		})
+	}
+
+	for _, change := range plan.Changes {
+		index := "-"
+		if change.Index > 0 {
+			index = strconv.Itoa(change.Index)
+		}
+		action := change.Action
+		if change.Moved {
+			action += "+move"
+		}
+		u.Out().Linef("%s\t%s\t%s\t%s", index, action, change.SlideID, strings.Join(change.Changes, ","))
+	}
+	u.Out().Linef("added=%d updated=%d deleted=%d moved=%d unchanged=%d",
+		summary["added"], summary["updated"], summary["deleted"], summary["moved"], summary["unchanged"])
+	u.Out().Linef("link\t%s", link)
+	return nil
+}
+
+// readSlidesMarkdownInput returns markdown from --content-file or --content.
+func readSlidesMarkdownInput(content, contentFile string) (string, error) {
+	switch {
+	case contentFile != "":
+		data, err := os.ReadFile(contentFile)
+		if err != nil {
+			return "", fmt.Errorf("read content file: %w", err)
+		}
+		return string(data), nil
+	case content != "":
+		return content, nil
+	default:
+		return "", usage("either --content or --content-file is required")
+	}
+}
+
+type slidesMarkdownUpdateOptions struct {
+	KeepUnmatched bool
+	// BaseDir resolves relative image paths in the markdown.
+	BaseDir string
+	// IDBase seeds generated object IDs; empty uses the current time.
+	IDBase string
+}
+
+// slidesMarkdownSlideChange is one slide-level line of the update plan.
+type slidesMarkdownSlideChange struct {
+	Index   int      `json:"index,omitempty"`
+	SlideID string   `json:"slideId"`
+	Key     string   `json:"key,omitempty"`
+	Action  string   `json:"action"`
+	Moved   bool     `json:"moved,omitempty"`
+	Changes []string `json:"changes,omitempty"`
+}
+
+// slidesPendingImage is a CreateImage/ReplaceImage request whose URL is a
+// local file that must be uploaded before the batch is sent.
+type slidesPendingImage struct {
+	Path    string
+	Request *slides.Request
+}
+
+func (p slidesPendingImage) setURL(url string) {
+	switch {
+	case p.Request.CreateImage != nil:
+		p.Request.CreateImage.Url = url
+	case p.Request.ReplaceImage != nil:
+		p.Request.ReplaceImage.Url = url
+	}
+}
+
+type slidesMarkdownUpdatePlan struct {
+	Changes  []slidesMarkdownSlideChange
+	Requests []*slides.Request
+	Notes    []SlideNotesPlan
+	Images   []slidesPendingImage
+}
+
+func (p slidesMarkdownUpdatePlan) summary() map[string]int {
+	out := map[string]int{"added": 0, "updated": 0, "deleted": 0, "moved": 0, "unchanged": 0}
+	for _, change := range p.Changes {
+		switch change.Action {
+		case slidesUpdateActionAdd:
+			out["added"]++
+		case slidesUpdateActionUpdate:
+			out["updated"]++
+		case slidesUpdateActionDelete:
+			out["deleted"]++
+		case slidesUpdateActionKeep:
+			out["unchanged"]++
+		}
+		if change.Moved {
+			out["moved"]++
+		}
+	}
+	return out
+}
+
+type slidesMarkdownPlanner struct {
+	opts     slidesMarkdownUpdateOptions
+	geometry LayoutGeometry
+	usedIDs  map[string]bool
+	idBase   string
+	idSeq    int
+	images   []slidesPendingImage
+}
+
+func (p *slidesMarkdownPlanner) newObjectID() string {
+	for {
+		p.idSeq++
+		id := fmt.Sprintf("gog_%s_%d", p.idBase, p.idSeq)
+		if !p.usedIDs[id] {
+			p.usedIDs[id] = true
+			return id
+		}
+	}
+}
+
+// buildSlidesMarkdownUpdatePlan diffs parsed markdown against an existing
+// presentation. Markdown slides match deck slides by frontmatter `id:`
+// (a slide object ID or a "slide-id:" line in speaker notes), then by
+// title. Matched slides are patched in place, new ones are appended as
+// BLANK slides, unmatched ones are deleted, and the result is reordered to
+// the markdown order. Everything lands in one BatchUpdate; notes for new
+// slides need a second pass once their notes pages exist.
+func buildSlidesMarkdownUpdatePlan(pres *slides.Presentation, in []slidesmarkdown.Slide, opts slidesMarkdownUpdateOptions) (slidesMarkdownUpdatePlan, error) {
+	planner := &slidesMarkdownPlanner{
+		opts:     opts,
+		geometry: geometryFromPresentation(pres),
+		usedIDs:  slidesPresentationObjectIDs(pres),
+		idBase:   opts.IDBase,
+	}
+	if planner.idBase == "" {
+		planner.idBase = strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+
+	deck := make([]*slides.Page, 0, len(pres.Slides))
+	for _, page := range pres.Slides {
+		if page != nil && page.ObjectId != "" {
+			deck = append(deck, page)
+		}
+	}
+	matches, err := matchSlidesMarkdownToDeck(deck, in)
+	if err != nil {
+		return slidesMarkdownUpdatePlan{}, err
+	}
+
+	var plan slidesMarkdownUpdatePlan
+	var createReqs, deleteReqs, contentReqs []*slides.Request
+	desiredOrder := make([]string, 0, len(in))
+	changes := make([]slidesMarkdownSlideChange, 0, len(in))
+	matched := map[*slides.Page]bool{}
+
+	for i, md := range in {
+		page := matches[i]
+		change := slidesMarkdownSlideChange{Index: i + 1, Key: md.Frontmatter.ID}
+		notesKey := ""
+		if page == nil {
+			change.Action = slidesUpdateActionAdd
+			change.SlideID = planner.newSlideID(md.Frontmatter.ID)
+			if md.Frontmatter.ID != "" && change.SlideID != md.Frontmatter.ID {
+				notesKey = md.Frontmatter.ID
+			}
+			createReqs = append(createReqs, &slides.Request{CreateSlide: &slides.CreateSlideRequest{
+				ObjectId:             change.SlideID,
+				SlideLayoutReference: &slides.LayoutReference{PredefinedLayout: "BLANK"},
+			}})
+		} else {
+			matched[page] = true
+			change.SlideID = page.ObjectId
+			notesKey = slidesNotesSlideKey(slidesSpeakerNotesText(page))
+		}
+
+		reqs, what, err := planner.syncSlideContent(change.SlideID, page, md)
+		if err != nil {
+			return slidesMarkdownUpdatePlan{}, fmt.Errorf("slide %d: %w", i+1, err)
+		}
+		contentReqs = append(contentReqs, reqs...)
+
+		notes := withSlidesNotesSlideKey(md.Notes, notesKey)
+		if page == nil {
+			if notes != "" {
+				plan.Notes = append(plan.Notes, SlideNotesPlan{SlideIndex: i, SlideID: change.SlideID, Text: notes})
+			}
+		} else if notesReqs := slidesNotesSyncRequests(page, notes); len(notesReqs) > 0 {
+			contentReqs = append(contentReqs, notesReqs...)
+			what = append(what, "notes")
+		}
+
+		if page != nil {
+			change.Action = slidesUpdateActionKeep
+			if len(what) > 0 {
+				change.Action = slidesUpdateActionUpdate
+			}
+		}
+		change.Changes = what
+		changes = append(changes, change)
+		desiredOrder = append(desiredOrder, change.SlideID)
+	}
+
+	// Simulate the deck after deletions and appends to derive the moves.
+	var current []string
+	var deletes []slidesMarkdownSlideChange
+	for _, page := range deck {
+		switch {
+		case matched[page]:
+			current = append(current, page.ObjectId)
+		case opts.KeepUnmatched:
+			current = append(current, page.ObjectId)
+			desiredOrder = append(desiredOrder, page.ObjectId)
+			changes = append(changes, slidesMarkdownSlideChange{Index: len(desiredOrder), SlideID: page.ObjectId, Action: slidesUpdateActionKeep})
+		default:
+			deleteReqs = append(deleteReqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: page.ObjectId}})
+			deletes = append(deletes, slidesMarkdownSlideChange{SlideID: page.ObjectId, Action: slidesUpdateActionDelete})
+		}
+	}
+	for _, change := range changes {
+		if change.Action == slidesUpdateActionAdd {
+			current = append(current, change.SlideID)
+		}
+	}
+
+	moved := map[string]bool{}
+	var moveReqs []*slides.Request
+	for i, id := range desiredOrder {
+		if current[i] == id {
+			continue
+		}
+		from := i
+		for current[from] != id {
+			from++
+		}
+		moveReqs = append(moveReqs, &slides.Request{UpdateSlidesPosition: &slides.UpdateSlidesPositionRequest{
+			SlideObjectIds:  []string{id},
+			InsertionIndex:  int64(i),
+			ForceSendFields: []string{"InsertionIndex"},
+		}})
+		current = append(current[:from], current[from+1:]...)
+		current = append(current[:i], append([]string{id}, current[i:]...)...)
+		moved[id] = true
+	}
+	for i := range changes {
+		if moved[changes[i].SlideID] && changes[i].Action != slidesUpdateActionAdd {
+			changes[i].Moved = true
+		}
+	}
+
+	// Creates run first so the deck never drops to zero slides.
+	plan.Requests = append(append(append(append(plan.Requests, createReqs...), deleteReqs...), contentReqs...), moveReqs...)
+	plan.Changes = append(changes, deletes...)
+	plan.Images = planner.images
+	return plan, nil
+}
+
+func (p *slidesMarkdownPlanner) newSlideID(key string) string {
+	if key != "" && slidesObjectIDRE.MatchString(key) && !p.usedIDs[key] {
+		p.usedIDs[key] = true
+		return key
+	}
+	return p.newObjectID()
+}
+
+// matchSlidesMarkdownToDeck returns, per markdown slide, the deck slide it
+// updates or nil when it is new.
+func matchSlidesMarkdownToDeck(deck []*slides.Page, in []slidesmarkdown.Slide) ([]*slides.Page, error) {
+	byKey := map[string]*slides.Page{}
+	for _, page := range deck {
+		byKey[page.ObjectId] = page
+	}
+	// Notes keys win over object IDs so a pinned slide stays pinned.
+	for _, page := range deck {
+		if key := slidesNotesSlideKey(slidesSpeakerNotesText(page)); key != "" {
+			byKey[key] = page
+		}
+	}
+
+	out := make([]*slides.Page, len(in))
+	taken := map[*slides.Page]bool{}
+	seen := map[string]int{}
+	for i, md := range in {
+		key := strings.TrimSpace(md.Frontmatter.ID)
+		if key == "" {
+			continue
+		}
+		if prev, ok := seen[key]; ok {
+			return nil, usagef("duplicate slide id %q on slides %d and %d", key, prev+1, i+1)
+		}
+		seen[key] = i
+		if page := byKey[key]; page != nil {
+			out[i] = page
+			taken[page] = true
+		}
+	}
+
+	deckTitles := make(map[*slides.Page]string, len(deck))
+	for _, page := range deck {
+		exported, err := slidesPageToMarkdown(page, "", 0, func(int, *slides.PageElement) (string, error) { return "", nil })
+		if err == nil {
+			deckTitles[page] = slidesMarkdownTitleKey(exported)
+		}
+	}
+	for i, md := range in {
+		if out[i] != nil || strings.TrimSpace(md.Frontmatter.ID) != "" {
+			continue
+		}
+		title := slidesMarkdownTitleKey(md)
+		if title == "" {
+			continue
+		}
+		for _, page := range deck {
+			if !taken[page] && deckTitles[page] == title {
+				out[i] = page
+				taken[page] = true
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+// slidesMarkdownTitleKey is the slide title, or the leading H1 of section
+// layouts that keep their heading in the body.
+func slidesMarkdownTitleKey(slide slidesmarkdown.Slide) string {
+	if title := strings.TrimSpace(slide.Title); title != "" {
+		return title
+	}
+	if len(slide.Body) > 0 {
+		if h, ok := slide.Body[0].(slidesmarkdown.HeadingBlock); ok && h.Level == 1 {
+			return strings.TrimSpace(slidesmarkdown.InlineText(h.Inlines))
+		}
+	}
+	return ""
+}
+
+// syncSlideContent returns the requests that make page show md, plus the
+// names of the parts that changed. page is nil for a slide created earlier
+// in the same batch.
+func (p *slidesMarkdownPlanner) syncSlideContent(slideID string, page *slides.Page, md slidesmarkdown.Slide) ([]*slides.Request, []string, error) {
+	layout := MapSlideyLayout(md.Frontmatter.Layout)
+	if n := explicitColumnsCount(md.Body); (layout == LayoutKindDefault || layout == LayoutKindCenter) && n > 0 {
+		layout = LayoutKindTwoCols
+		if n == 3 {
+			layout = LayoutKindThreeCols
+		}
+	}
+
+	var textBlocks []slidesmarkdown.Block
+	var tables []slidesmarkdown.TableBlock
+	var images []slidesmarkdown.ImageBlock
+	for _, block := range md.Body {
+		switch v := block.(type) {
+		case slidesmarkdown.TableBlock:
+			tables = append(tables, v)
+		case slidesmarkdown.ImageBlock:
+			images = append(images, v)
+		default:
+			textBlocks = append(textBlocks, block)
+		}
+	}
+
+	var titleEl *slides.PageElement
+	var bodyEls, tableEls, imageEls []*slides.PageElement
+	if page != nil {
+		for _, item := range flattenSlidesExportElements(page.PageElements) {
+			el := item.element
+			switch {
+			case el.Shape != nil:
+				if slidesShapeIsChrome(el.Shape) || (el.Shape.Text == nil && el.Shape.Placeholder == nil) {
+					continue
+				}
+				if titleEl == nil && slidesShapeIsTitle(el) {
+					titleEl = el
+					continue
+				}
+				bodyEls = append(bodyEls, el)
+			case el.Table != nil:
+				tableEls = append(tableEls, el)
+			case el.Image != nil:
+				if !slidesIsIconImage(item) {
+					imageEls = append(imageEls, el)
+				}
+			}
+		}
+	}
+
+	title := strings.TrimSpace(md.Title)
+	if title == "" && titleEl != nil && len(textBlocks) > 0 {
+		// Section layouts keep the heading in the body; a deck slide with a
+		// real title placeholder takes it back.
+		if h, ok := textBlocks[0].(slidesmarkdown.HeadingBlock); ok && h.Level == 1 {
+			title = strings.TrimSpace(slidesmarkdown.InlineText(h.Inlines))
+			textBlocks = textBlocks[1:]
+		}
+	}
+
+	var reqs []*slides.Request
+	var changed []string
+
+	switch {
+	case titleEl != nil:
+		if slidesComparableText(slidesRawText(titleEl.Shape.Text)) != slidesComparableText(title) {
+			reqs = append(reqs, buildSlidesReplaceTextRequests(titleEl.ObjectId, title, slidesTextContentHasDeletableText(titleEl.Shape.Text))...)
+			if title != "" && titleEl.Shape.Placeholder == nil {
+				reqs = append(reqs, slideyTitleStyleRequest(titleEl.ObjectId))
+			}
+			changed = append(changed, literalTitle)
+		}
+	case title != "" && layout != LayoutKindSectionHeader:
+		titleID := p.newObjectID()
+		reqs = append(reqs,
+			createTextBox(titleID, slideID, TitleBox(p.geometry)),
+			&slides.Request{InsertText: &slides.InsertTextRequest{ObjectId: titleID, Text: title}},
+			slideyTitleStyleRequest(titleID),
+		)
+		changed = append(changed, literalTitle)
+	}
+
+	bodyReqs := p.syncSlidesBody(slideID, bodyEls, textBlocks, layout)
+	if len(bodyReqs) > 0 {
+		reqs = append(reqs, bodyReqs...)
+		changed = append(changed, "body")
+	}
+
+	for i, table := range tables {
+		var el *slides.PageElement
+		if i < len(tableEls) {
+			el = tableEls[i]
+		}
+		tableReqs := p.syncSlidesTable(slideID, el, table)
+		if len(tableReqs) > 0 {
+			reqs = append(reqs, tableReqs...)
+			changed = append(changed, fmt.Sprintf("table %d", i+1))
+		}
+	}
+	for i, el := range tableEls[min(len(tables), len(tableEls)):] {
+		reqs = append(reqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId}})
+		changed = append(changed, fmt.Sprintf("table %d", len(tables)+i+1))
+	}
+
+	for i, image := range images {
+		var el *slides.PageElement
+		if i < len(imageEls) {
+			el = imageEls[i]
+		}
+		imageReqs, err := p.syncSlidesImage(slideID, el, image)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(imageReqs) > 0 {
+			reqs = append(reqs, imageReqs...)
+			changed = append(changed, fmt.Sprintf("image %d", i+1))
+		}
+	}
+	for i, el := range imageEls[min(len(images), len(imageEls)):] {
+		reqs = append(reqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId}})
+		changed = append(changed, fmt.Sprintf("image %d", len(images)+i+1))
+	}
+
+	return reqs, changed, nil
+}
+
+// syncSlidesBody pairs the body boxes (one, or one per column) with the
+// slide's body shapes in reading order. Theme placeholders get native
+// bullets; plain text boxes get the literal bullets create-from-markdown
+// writes. Surplus shapes are cleared (placeholders) or deleted.
+func (p *slidesMarkdownPlanner) syncSlidesBody(slideID string, bodyEls []*slides.PageElement, textBlocks []slidesmarkdown.Block, layout LayoutKind) []*slides.Request {
+	var reqs []*slides.Request
+	groups := [][]slidesmarkdown.Block{textBlocks}
+	boxes := []BoxRect{SingleBodyBox(p.geometry)}
+	if layout == LayoutKindTwoCols || layout == LayoutKindThreeCols {
+		n := 2
+		if layout == LayoutKindThreeCols {
+			n = 3
+		}
+		groups = findColumnsBlock(textBlocks, n)
+		boxes = ColumnBoxes(p.geometry, n)
+	}
+	for i, group := range groups {
+		if i < len(bodyEls) {
+			el := bodyEls[i]
+			if el.Shape.Placeholder != nil {
+				text, lists := slidesNativeText(group)
+				if slidesComparableText(slidesRawText(el.Shape.Text)) != slidesComparableText(strings.ReplaceAll(text, "\t", "")) {
+					reqs = append(reqs, buildSlidesNativeTextRequests(el.ObjectId, text, lists, slidesTextContentHasDeletableText(el.Shape.Text))...)
+				}
+				continue
+			}
+			text := blocksToPlainText(group)
+			if slidesComparableText(slidesRawText(el.Shape.Text)) != slidesComparableText(text) {
+				reqs = append(reqs, buildSlidesReplaceTextRequests(el.ObjectId, text, slidesTextContentHasDeletableText(el.Shape.Text))...)
+				reqs = append(reqs, slideyBodyStyleRequests(el.ObjectId, text, layout)...)
+			}
+			continue
+		}
+		text := blocksToPlainText(group)
+		if text == "" {
+			continue
+		}
+		bodyID := p.newObjectID()
+		reqs = append(reqs,
+			createTextBox(bodyID, slideID, boxes[i]),
+			&slides.Request{InsertText: &slides.InsertTextRequest{ObjectId: bodyID, Text: text}},
+		)
+		reqs = append(reqs, slideyBodyStyleRequests(bodyID, text, layout)...)
+	}
+	for _, el := range bodyEls[min(len(groups), len(bodyEls)):] {
+		switch {
+		case el.Shape.Placeholder != nil:
+			if slidesTextContentHasDeletableText(el.Shape.Text) {
+				reqs = append(reqs, buildSlidesReplaceTextRequests(el.ObjectId, "", true)...)
+			}
+		default:
+			reqs = append(reqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId}})
+		}
+	}
+	return reqs
+}
+
+// syncSlidesTable edits cells in place when the shape matches and
+// recreates the table otherwise. el is nil when the slide has no table
+// at this position yet.
+func (p *slidesMarkdownPlanner) syncSlidesTable(slideID string, el *slides.PageElement, want slidesmarkdown.TableBlock) []*slides.Request {
+	rows, cols := int64(len(want.Rows)), int64(0)
+	for _, row := range want.Rows {
+		cols = max(cols, int64(len(row)))
+	}
+	cell := func(r, c int) string {
+		if c < len(want.Rows[r]) {
+			return strings.TrimSpace(want.Rows[r][c])
+		}
+		return ""
+	}
+
+	var reqs []*slides.Request
+	if el != nil && el.Table.Rows == rows && el.Table.Columns == cols {
+		current := slidesTableToBlock(el.Table)
+		for r := range want.Rows {
+			for c := 0; c < int(cols); c++ {
+				text := cell(r, c)
+				if current.Rows[r][c] == text {
+					continue
+				}
+				_, hasText := slidesTableCellContentState(el.Table, int64(r), int64(c))
+				reqs = append(reqs, buildSlidesReplaceTextRequestsAt(el.ObjectId, text, hasText, slidesTableCellLocation(int64(r), int64(c)))...)
+			}
+		}
+		return reqs
+	}
+	if rows == 0 || cols == 0 {
+		if el != nil {
+			reqs = append(reqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId}})
+		}
+		return reqs
+	}
+
+	if el != nil {
+		reqs = append(reqs, &slides.Request{DeleteObject: &slides.DeleteObjectRequest{ObjectId: el.ObjectId}})
+	}
+	tableID := p.newObjectID()
+	reqs = append(reqs, &slides.Request{CreateTable: buildSlidesCreateTableRequest(slideID, tableID, rows, cols)})
+	for r := range want.Rows {
+		for c := 0; c < int(cols); c++ {
+			if text := cell(r, c); text != "" {
+				reqs = append(reqs, buildSlidesReplaceTextRequestsAt(tableID, text, false, slidesTableCellLocation(int64(r), int64(c)))...)
+			}
+		}
+	}
+	return reqs
+}
+
+// syncSlidesImage swaps the picture behind an existing image element (the
+// replace-slide path) or places a new one in the body area.
+func (p *slidesMarkdownPlanner) syncSlidesImage(slideID string, el *slides.PageElement, want slidesmarkdown.ImageBlock) ([]*slides.Request, error) {
+	alt := strings.TrimSpace(want.Alt)
+	var reqs []*slides.Request
+
+	if el != nil && !slidesImageMatches(el, want.URL) {
+		req := &slides.Request{ReplaceImage: &slides.ReplaceImageRequest{
+			ImageObjectId:      el.ObjectId,
+			ImageReplaceMethod: "CENTER_CROP",
+		}}
+		if err := p.resolveImageURL(req, want.URL); err != nil {
+			return nil, err
+		}
+		reqs = append(reqs, req)
+	}
+	if el != nil {
+		if alt != strings.TrimSpace(el.Description) {
+			reqs = append(reqs, &slides.Request{UpdatePageElementAltText: &slides.UpdatePageElementAltTextRequest{
+				ObjectId:    el.ObjectId,
+				Description: alt,
+			}})
+		}
+		return reqs, nil
+	}
+
+	box := SingleBodyBox(p.geometry)
+	req := createImageRequest(slideID, "", box.LeftPT, box.TopPT, box.WidthPT, minFloat(box.HeightPT, maxDiagramHeightPT))
+	imageID := p.newObjectID()
+	req.CreateImage.ObjectId = imageID
+	if err := p.resolveImageURL(req, want.URL); err != nil {
+		return nil, err
+	}
+	reqs = append(reqs, req)
+	if alt != "" {
+		reqs = append(reqs, &slides.Request{UpdatePageElementAltText: &slides.UpdatePageElementAltTextRequest{
+			ObjectId:    imageID,
+			Description: alt,
+		}})
+	}
+	return reqs, nil
+}
+
+// resolveImageURL fills the request URL for public HTTPS images and queues
+// local files for upload; until then the request carries a placeholder.
+func (p *slidesMarkdownPlanner) resolveImageURL(req *slides.Request, ref string) error {
+	ref = strings.TrimSpace(ref)
+	pending := slidesPendingImage{Request: req}
+	if strings.Contains(ref, "://") {
+		source, err := resolveSlidesImageSource("", ref)
+		if err != nil {
+			return err
+		}
+		pending.setURL(source.imageURL)
+		return nil
+	}
+
+	local := filepath.FromSlash(ref)
+	if !filepath.IsAbs(local) {
+		local = filepath.Join(p.opts.BaseDir, local)
+	}
+	if _, err := resolveSlidesImageSource(local, ""); err != nil {
+		return err
+	}
+	if _, err := os.Stat(local); err != nil {
+		return fmt.Errorf("image %s: %w", ref, err)
+	}
+	pending.Path = local
+	pending.setURL("gogcli://pending/" + filepath.ToSlash(ref))
+	p.images = append(p.images, pending)
+	return nil
+}
+
+// slidesImageMatches reports whether an image element already shows ref:
+// either the same URL, or an asset file written by `slides export --format
+// md`, whose name ends in the element's object ID.
+func slidesImageMatches(el *slides.PageElement, ref string) bool {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || el.Image == nil {
+		return false
+	}
+	if ref == el.Image.SourceUrl || ref == el.Image.ContentUrl {
+		return true
+	}
+	base := path.Base(filepath.ToSlash(ref))
+	return strings.HasSuffix(strings.TrimSuffix(base, path.Ext(base)), "-"+el.ObjectId)
+}
+
+func slidesNotesSyncRequests(page *slides.Page, notes string) []*slides.Request {
+	notesID := findSpeakerNotesObjectID(page)
+	if notesID == "" || slidesComparableText(slidesSpeakerNotesText(page)) == slidesComparableText(notes) {
+		return nil
+	}
+	return buildSlidesReplaceTextRequests(notesID, notes, slidesPageElementHasText(page.SlideProperties.NotesPage, notesID))
+}
+
+// slidesNativeTextList is a run of paragraphs that should carry native
+// bullets, as a UTF-16 range into the inserted text.
+type slidesNativeTextList struct {
+	Start, End int64
+	Ordered    bool
+}
+
+// slidesNativeText flattens blocks for a theme placeholder: one paragraph
+// per line, list items prefixed with one tab per nesting level so
+// CreateParagraphBullets can turn them into native nested bullets.
+func slidesNativeText(blocks []slidesmarkdown.Block) (string, []slidesNativeTextList) {
+	var lines []string
+	var lists []slidesNativeTextList
+	var offset int64
+	add := func(line string) {
+		lines = append(lines, line)
+		offset += utf16CodeUnits(line) + 1
+	}
+
+	var walk func([]slidesmarkdown.Block)
+	walk = func(blocks []slidesmarkdown.Block) {
+		for _, block := range blocks {
+			switch v := block.(type) {
+			case slidesmarkdown.ParagraphBlock:
+				add(slidesmarkdown.InlineText(v.Inlines))
+			case slidesmarkdown.HeadingBlock:
+				add(slidesmarkdown.InlineText(v.Inlines))
+			case slidesmarkdown.BulletsBlock:
+				if len(v.Items) == 0 {
+					continue
+				}
+				list := slidesNativeTextList{Start: offset, Ordered: v.Ordered}
+				for _, item := range v.Items {
+					add(strings.Repeat("\t", max(item.Indent, 0)) + slidesmarkdown.InlineText(item.Inlines))
+				}
+				list.End = offset - 1
+				lists = append(lists, list)
+			case slidesmarkdown.CodeBlock:
+				for _, line := range strings.Split(v.Source, "\n") {
+					add(line)
+				}
+			case slidesmarkdown.IconRowsBlock:
+				for _, row := range v.Rows {
+					add(row.Text)
+				}
+			case slidesmarkdown.ColumnsBlock:
+				for _, col := range v.Columns {
+					walk(col)
+				}
+			}
+		}
+	}
+	walk(blocks)
+	return strings.Join(lines, "\n"), lists
+}
+
+// buildSlidesNativeTextRequests replaces placeholder text and rebuilds
+// native bullets. CreateParagraphBullets strips the leading nesting tabs,
+// so lists are applied back to front to keep earlier ranges valid.
+func buildSlidesNativeTextRequests(objectID, text string, lists []slidesNativeTextList, hasExistingText bool) []*slides.Request {
+	reqs := buildSlidesReplaceTextRequests(objectID, text, hasExistingText)
+	if text == "" {
+		return reqs
+	}
+	reqs = append(reqs, &slides.Request{DeleteParagraphBullets: &slides.DeleteParagraphBulletsRequest{
+		ObjectId:  objectID,
+		TextRange: &slides.Range{Type: "ALL"},
+	}})
+	for i := len(lists) - 1; i >= 0; i-- {
+		list := lists[i]
+		preset := slidesBulletPresetDisc
+		if list.Ordered {
+			preset = slidesBulletPresetNumbered
+		}
+		reqs = append(reqs, &slides.Request{CreateParagraphBullets: &slides.CreateParagraphBulletsRequest{
+			ObjectId: objectID,
+			TextRange: &slides.Range{
+				Type:       "FIXED_RANGE",
+				StartIndex: int64Ptr(list.Start),
+				EndIndex:   int64Ptr(list.End),
+			},
+			BulletPreset: preset,
+		}})
+	}
+	return reqs
+}
+
+// slidesRawText returns the text of a shape without whitespace folding.
+func slidesRawText(text *slides.TextContent) string {
+	if text == nil {
+		return ""
+	}
+	var b strings.Builder
+	for _, te := range text.TextElements {
+		switch {
+		case te == nil:
+		case te.TextRun != nil:
+			b.WriteString(te.TextRun.Content)
+		case te.AutoText != nil:
+			b.WriteString(te.AutoText.Content)
+		}
+	}
+	return strings.ReplaceAll(b.String(), "\v", "\n")
+}
+
+// slidesComparableText normalizes trailing whitespace so a freshly written
+// box compares equal to the text it was written from.
+func slidesComparableText(text string) string {
+	lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+func slidesNotesSlideKey(notes string) string {
+	if m := slidesNotesSlideKeyRE.FindStringSubmatch(notes); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+func stripSlidesNotesSlideKey(notes string) string {
+	return strings.TrimSpace(slidesNotesSlideKeyRE.ReplaceAllString(notes, ""))
+}
+
+func withSlidesNotesSlideKey(notes, key string) string {
+	notes = strings.TrimSpace(notes)
+	if key == "" {
+		return notes
+	}
+	marker := slidesNotesSlideKeyPrefix + " " + key
+	if notes == "" {
+		return marker
+	}
+	return notes + "\n\n" + marker
+}
+
+func slidesPresentationObjectIDs(pres *slides.Presentation) map[string]bool {
+	ids := map[string]bool{}
+	var walk func([]*slides.PageElement)
+	walk = func(elements []*slides.PageElement) {
+		for _, el := range elements {
+			if el == nil {
+				continue
+			}
+			ids[el.ObjectId] = true
+			if el.ElementGroup != nil {
+				walk(el.ElementGroup.Children)
+			}
+		}
+	}
+	for _, pages := range [][]*slides.Page{pres.Slides, pres.Layouts, pres.Masters} {
+		for _, page := range pages {
+			if page == nil {
+				continue
+			}
+			ids[page.ObjectId] = true
+			walk(page.PageElements)
+		}
+	}
+	return ids
+}
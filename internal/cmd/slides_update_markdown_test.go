@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/slides/v1"
+
+	"github.com/steipete/gogcli/internal/slidesmarkdown"
+)
+
+func slidesUpdateText(lines ...string) *slides.TextContent {
+	var elements []*slides.TextElement
+	for _, line := range lines {
+		elements = append(elements,
+			&slides.TextElement{ParagraphMarker: &slides.ParagraphMarker{}},
+			&slides.TextElement{TextRun: &slides.TextRun{Content: line + "\n"}},
+		)
+	}
+	return &slides.TextContent{TextElements: elements}
+}
+
+func slidesUpdateNotesPage(slideID, notes string) *slides.SlideProperties {
+	return &slides.SlideProperties{NotesPage: &slides.Page{
+		NotesProperties: &slides.NotesProperties{SpeakerNotesObjectId: slideID + "_notes"},
+		PageElements: []*slides.PageElement{{
+			ObjectId: slideID + "_notes",
+			Shape: &slides.Shape{
+				Placeholder: &slides.Placeholder{Type: placeholderTypeBody},
+				Text:        slidesUpdateText(notes),
+			},
+		}},
+	}}
+}
+
+func slidesUpdateBox(id string, y float64, text *slides.TextContent, placeholder string) *slides.PageElement {
+	el := &slides.PageElement{
+		ObjectId:  id,
+		Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateX: 36, TranslateY: y, Unit: "PT"},
+		Size: &slides.Size{
+			Width:  &slides.Dimension{Magnitude: 600, Unit: "PT"},
+			Height: &slides.Dimension{Magnitude: 60, Unit: "PT"},
+		},
+		Shape: &slides.Shape{ShapeType: "TEXT_BOX", Text: text},
+	}
+	if placeholder != "" {
+		el.Shape.Placeholder = &slides.Placeholder{Type: placeholder}
+	}
+	return el
+}
+
+func slidesUpdateDeck() *slides.Presentation {
+	return &slides.Presentation{
+		PresentationId: "pres1",
+		RevisionId:     "rev1",
+		Slides: []*slides.Page{
+			{
+				ObjectId: "slide_1",
+				PageElements: []*slides.PageElement{
+					slidesUpdateBox("title_1", 36, slidesUpdateText("Intro"), ""),
+					slidesUpdateBox("body_1", 108, slidesUpdateText("• one", "• two"), ""),
+				},
+			},
+			{
+				ObjectId:        "s_kpi",
+				SlideProperties: slidesUpdateNotesPage("s_kpi", "Read slowly.\n\nslide-id: kpi"),
+				PageElements: []*slides.PageElement{
+					slidesUpdateBox("s_kpi_title", 36, slidesUpdateText("KPIs"), "TITLE"),
+				},
+			},
+			{
+				ObjectId: "s_goals",
+				PageElements: []*slides.PageElement{
+					slidesUpdateBox("s_goals_title", 36, slidesUpdateText("Goals"), "TITLE"),
+					slidesUpdateBox("s_goals_body", 108, slidesUpdateText("Ship it"), placeholderTypeBody),
+					{
+						ObjectId:  "s_goals_table",
+						Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateY: 200, Unit: "PT"},
+						Table: &slides.Table{Rows: 2, Columns: 2, TableRows: []*slides.TableRow{
+							{TableCells: []*slides.TableCell{
+								{Location: &slides.TableCellLocation{RowIndex: 0, ColumnIndex: 0}, Text: slidesUpdateText("Metric")},
+								{Location: &slides.TableCellLocation{RowIndex: 0, ColumnIndex: 1}, Text: slidesUpdateText("Target")},
+							}},
+							{TableCells: []*slides.TableCell{
+								{Location: &slides.TableCellLocation{RowIndex: 1, ColumnIndex: 0}, Text: slidesUpdateText("p99")},
+								{Location: &slides.TableCellLocation{RowIndex: 1, ColumnIndex: 1}, Text: slidesUpdateText("200ms")},
+							}},
+						}},
+					},
+					{
+						ObjectId:  "s_goals_img",
+						Transform: &slides.AffineTransform{ScaleX: 1, ScaleY: 1, TranslateY: 300, Unit: "PT"},
+						Image:     &slides.Image{SourceUrl: "https://example.com/chart.png"},
+					},
+				},
+			},
+			{ObjectId: "s_stale", PageElements: []*slides.PageElement{slidesUpdateBox("s_stale_title", 36, slidesUpdateText("Stale"), "TITLE")}},
+		},
+	}
+}
+
+const slidesUpdateMarkdown = `---
+id: s_goals
+---
+
+# Goals
+
+- Ship it
+  - with tests
+
+| Metric | Target |
+| --- | --- |
+| p99 | 100ms |
+
+![](https://example.com/chart.png)
+
+---
+
+# Intro
+
+- one
+- two
+
+---
+id: kpi
+---
+
+# KPIs
+
+## Notes
+
+Read slowly.
+
+---
+id: wrapup
+---
+
+# Wrap up
+
+Thanks
+`
+
+func parseSlidesUpdateMarkdown(t *testing.T, md string) []slidesmarkdown.Slide {
+	t.Helper()
+	parsed, err := slidesmarkdown.Parse(md, slidesmarkdown.ParseOptions{Exported: true})
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return parsed
+}
+
+func TestBuildSlidesMarkdownUpdatePlan_MatchesPatchesAndReorders(t *testing.T) {
+	plan, err := buildSlidesMarkdownUpdatePlan(slidesUpdateDeck(), parseSlidesUpdateMarkdown(t, slidesUpdateMarkdown), slidesMarkdownUpdateOptions{IDBase: "t"})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+
+	got := map[string]slidesMarkdownSlideChange{}
+	for _, change := range plan.Changes {
+		got[change.SlideID] = change
+	}
+	if c := got["s_goals"]; c.Index != 1 || c.Action != slidesUpdateActionUpdate || !c.Moved || strings.Join(c.Changes, ",") != "body,table 1" {
+		t.Fatalf("s_goals change = %+v", c)
+	}
+	if c := got["slide_1"]; c.Index != 2 || c.Action != slidesUpdateActionKeep || c.Moved {
+		t.Fatalf("slide_1 change = %+v", c)
+	}
+	if c := got["s_kpi"]; c.Index != 3 || c.Action != slidesUpdateActionKeep {
+		t.Fatalf("s_kpi change = %+v", c)
+	}
+	if c := got["wrapup"]; c.Index != 4 || c.Action != slidesUpdateActionAdd || strings.Join(c.Changes, ",") != "title,body" {
+		t.Fatalf("wrap change = %+v", c)
+	}
+	if c := got["s_stale"]; c.Action != slidesUpdateActionDelete {
+		t.Fatalf("s_stale change = %+v", c)
+	}
+
+	reqs := plan.Requests
+	if reqs[0].CreateSlide == nil || reqs[0].CreateSlide.ObjectId != "wrapup" {
+		t.Fatalf("first request should create the new slide: %+v", reqs[0])
+	}
+	if reqs[1].DeleteObject == nil || reqs[1].DeleteObject.ObjectId != "s_stale" {
+		t.Fatalf("second request should delete the stale slide: %+v", reqs[1])
+	}
+
+	var bullets, cellEdits, moves int
+	for _, req := range reqs {
+		switch {
+		case req.CreateParagraphBullets != nil:
+			bullets++
+			r := req.CreateParagraphBullets.TextRange
+			if req.CreateParagraphBullets.ObjectId != "s_goals_body" || *r.StartIndex != 0 || *r.EndIndex != 19 {
+				t.Fatalf("bullets = %+v range %d:%d", req.CreateParagraphBullets, *r.StartIndex, *r.EndIndex)
+			}
+		case req.InsertText != nil && req.InsertText.CellLocation != nil:
+			cellEdits++
+			if req.InsertText.Text != "100ms" || req.InsertText.CellLocation.RowIndex != 1 || req.InsertText.CellLocation.ColumnIndex != 1 {
+				t.Fatalf("cell edit = %+v", req.InsertText)
+			}
+		case req.InsertText != nil && req.InsertText.ObjectId == "s_goals_body":
+			if req.InsertText.Text != "Ship it\n\twith tests" {
+				t.Fatalf("body text = %q", req.InsertText.Text)
+			}
+		case req.UpdateSlidesPosition != nil:
+			moves++
+			if req.UpdateSlidesPosition.SlideObjectIds[0] != "s_goals" || req.UpdateSlidesPosition.InsertionIndex != 0 {
+				t.Fatalf("move = %+v", req.UpdateSlidesPosition)
+			}
+		case req.ReplaceImage != nil, req.DeleteText != nil && req.DeleteText.ObjectId == "s_kpi_notes":
+			t.Fatalf("unchanged content should not be rewritten: %+v", req)
+		}
+	}
+	if bullets != 1 || cellEdits != 1 || moves != 1 {
+		t.Fatalf("bullets=%d cellEdits=%d moves=%d", bullets, cellEdits, moves)
+	}
+
+	if len(plan.Notes) != 0 {
+		t.Fatalf("notes plan = %+v", plan.Notes)
+	}
+}
+
+func TestBuildSlidesMarkdownUpdatePlan_PinsShortKeysInNotes(t *testing.T) {
+	md := "---\nid: x1\n---\n\n# Fresh\n\n## Notes\n\nSay hi.\n"
+	plan, err := buildSlidesMarkdownUpdatePlan(&slides.Presentation{}, parseSlidesUpdateMarkdown(t, md), slidesMarkdownUpdateOptions{IDBase: "t"})
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if len(plan.Changes) != 1 || plan.Changes[0].SlideID != "gog_t_1" {
+		t.Fatalf("changes = %+v", plan.Changes)
+	}
+	if len(plan.Notes) != 1 || plan.Notes[0].Text != "Say hi.\n\nslide-id: x1" {
+		t.Fatalf("notes = %+v", plan.Notes)
+	}
+	if slidesNotesSlideKey(plan.Notes[0].Text) != "x1" || stripSlidesNotesSlideKey(plan.Notes[0].Text) != "Say hi." {
+		t.Fatalf("notes key round trip failed: %q", plan.Notes[0].Text)
+	}
+}
+
+func TestBuildSlidesMarkdownUpdatePlan_RejectsDuplicateIDs(t *testing.T) {
+	md := "---\nid: same1\n---\n\n# A\n\n---\nid: same1\n---\n\n# B\n"
+	_, err := buildSlidesMarkdownUpdatePlan(&slides.Presentation{}, parseSlidesUpdateMarkdown(t, md), slidesMarkdownUpdateOptions{})
+	if err == nil || !strings.Contains(err.Error(), `duplicate slide id "same1"`) {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestSlidesUpdateFromMarkdown_AppliesWithRevisionAndDryRun(t *testing.T) {
+	var captured []*slides.BatchUpdatePresentationRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":batchUpdate") && r.Method == http.MethodPost:
+			req := &slides.BatchUpdatePresentationRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			captured = append(captured, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"presentationId": "pres1",
+				"writeControl":   map[string]any{"requiredRevisionId": "rev2"},
+			})
+		case strings.Contains(r.URL.Path, "/presentations/pres1") && r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(slidesUpdateDeck())
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := slides.NewService(context.Background(),
+		option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("slides.NewService: %v", err)
+	}
+
+	md := strings.Replace(slidesUpdateMarkdown, "---\nid: wrapup\n---\n\n# Wrap up\n\nThanks\n", "", 1)
+	cmd := &SlidesUpdateFromMarkdownCmd{PresentationID: "pres1", Content: md}
+
+	var dry bytes.Buffer
+	dryCtx := withSlidesTestService(newCmdRuntimeJSONOutputContext(t, &dry, io.Discard), svc)
+	if err := cmd.Run(dryCtx, &RootFlags{Account: "a@b.com", DryRun: true}); ExitCode(err) != 0 {
+		t.Fatalf("dry run: %v", err)
+	}
+	if captured != nil {
+		t.Fatal("dry run sent a batchUpdate")
+	}
+	var payload struct {
+		Request struct {
+			Summary map[string]int `json:"summary"`
+		} `json:"request"`
+	}
+	if err := json.Unmarshal(dry.Bytes(), &payload); err != nil {
+		t.Fatalf("decode dry run: %v\n%s", err, dry.String())
+	}
+	if s := payload.Request.Summary; s["updated"] != 1 || s["deleted"] != 1 || s["moved"] != 1 || s["unchanged"] != 2 {
+		t.Fatalf("summary = %v", s)
+	}
+
+	var out bytes.Buffer
+	ctx := withSlidesTestService(newCmdRuntimeJSONOutputContext(t, &out, io.Discard), svc)
+	if err := cmd.Run(ctx, &RootFlags{Account: "a@b.com", NoInput: true}); err == nil || !strings.Contains(err.Error(), "without --force") {
+		t.Fatalf("expected delete confirmation error, got %v", err)
+	}
+	if captured != nil {
+		t.Fatal("unconfirmed delete sent a batchUpdate")
+	}
+	if err := cmd.Run(ctx, &RootFlags{Account: "a@b.com", Force: true}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(captured) != 1 || captured[0].WriteControl == nil || captured[0].WriteControl.RequiredRevisionId != "rev1" {
+		t.Fatalf("batchUpdates = %+v", captured)
+	}
+	if !strings.Contains(out.String(), `"presentationId": "pres1"`) {
+		t.Fatalf("output = %s", out.String())
+	}
+}
+
+func TestSlidesUpdateFromMarkdown_NotesPassUsesUpdatedRevision(t *testing.T) {
+	var captured []*slides.BatchUpdatePresentationRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasSuffix(r.URL.Path, ":batchUpdate") && r.Method == http.MethodPost:
+			req := &slides.BatchUpdatePresentationRequest{}
+			if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				t.Fatalf("decode batchUpdate: %v", err)
+			}
+			captured = append(captured, req)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"presentationId": "pres1",
+				"writeControl":   map[string]any{"requiredRevisionId": "rev2"},
+			})
+		case strings.Contains(r.URL.Path, "/presentations/pres1") && r.Method == http.MethodGet:
+			deck := slidesUpdateDeck()
+			if len(captured) > 0 {
+				deck.Slides = append(deck.Slides, &slides.Page{ObjectId: "wrapup", SlideProperties: slidesUpdateNotesPage("wrapup", "")})
+			}
+			_ = json.NewEncoder(w).Encode(deck)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc, err := slides.NewService(context.Background(),
+		option.WithoutAuthentication(), option.WithHTTPClient(srv.Client()), option.WithEndpoint(srv.URL+"/"))
+	if err != nil {
+		t.Fatalf("slides.NewService: %v", err)
+	}
+
+	md := slidesUpdateMarkdown + "\n## Notes\n\nSay thanks.\n"
+	cmd := &SlidesUpdateFromMarkdownCmd{PresentationID: "pres1", Content: md, KeepUnmatched: true}
+	ctx := withSlidesTestService(newCmdRuntimeJSONOutputContext(t, io.Discard, io.Discard), svc)
+	if err := cmd.Run(ctx, &RootFlags{Account: "a@b.com"}); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if len(captured) != 2 {
+		t.Fatalf("batchUpdates = %d, want 2", len(captured))
+	}
+	for i, want := range []string{"rev1", "rev2"} {
+		if wc := captured[i].WriteControl; wc == nil || wc.RequiredRevisionId != want {
+			t.Fatalf("batchUpdate[%d].writeControl = %+v, want %s", i, wc, want)
+		}
+	}
+}
+
+func TestSlidesNativeText_TabsNestedBullets(t *testing.T) {
+	text, lists := slidesNativeText([]slidesmarkdown.Block{
+		slidesmarkdown.ParagraphBlock{Inlines: []slidesmarkdown.Inline{slidesmarkdown.TextRun{Text: "Lead"}}},
+		slidesmarkdown.BulletsBlock{Ordered: true, Items: []slidesmarkdown.BulletItem{
+			{Inlines: []slidesmarkdown.Inline{slidesmarkdown.TextRun{Text: "a"}}},
+			{Indent: 1, Inlines: []slidesmarkdown.Inline{slidesmarkdown.TextRun{Text: "b"}}},
+		}},
+	})
+	if text != "Lead\na\n\tb" {
+		t.Fatalf("text = %q", text)
+	}
+	if len(lists) != 1 || lists[0].Start != 5 || lists[0].End != 9 || !lists[0].Ordered {
+		t.Fatalf("lists = %+v", lists)
+	}
+}
@@ -10,5 +10,7 @@ type TasksCmd struct {
 	Undo   TasksUndoCmd   `cmd:"" name:"undo" help:"Mark task needs action" aliases:"uncomplete,undone"`
 	Delete TasksDeleteCmd `cmd:"" name:"delete" aliases:"rm,del,remove" help:"Delete a task"`
 	Clear  TasksClearCmd  `cmd:"" name:"clear" help:"Clear completed tasks"`
+	Export TasksExportCmd `cmd:"" name:"export" help:"Export a task list with its subtask hierarchy (json, md, ics)"`
+	Sync   TasksSyncCmd   `cmd:"" name:"sync" help:"Reconcile a task list with a Markdown checklist"`
 	Raw    TasksRawCmd    `cmd:"" name:"raw" help:"Dump raw Google Tasks API response as JSON (Tasks.Get; lossless; for scripting and LLM consumption)"`
 }
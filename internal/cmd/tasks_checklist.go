@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	tasksChecklistItemRE = regexp.MustCompile(`^([ \t]*)[-*+][ \t]+\[([ xX])\][ \t]+(.+?)[ \t]*$`)
+	tasksChecklistDueRE  = regexp.MustCompile(`\s*\(due (\d{4}-\d{2}-\d{2})\)$`)
+)
+
+// tasksChecklistItem is one "- [ ] title" line. Google Tasks only nests one
+// level, so Children of a child are always empty.
+type tasksChecklistItem struct {
+	Title    string
+	Done     bool
+	Due      string
+	Line     int
+	Children []*tasksChecklistItem
+}
+
+// parseTasksChecklist reads the checkbox items of a Markdown file. Other
+// lines (headings, notes, prose) are ignored. An item indented deeper than
+// the previous one becomes its subtask.
+func parseTasksChecklist(r io.Reader) ([]*tasksChecklistItem, error) {
+	var roots []*tasksChecklistItem
+	rootIndent, childIndent := -1, -1
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		m := tasksChecklistItemRE.FindStringSubmatch(scanner.Text())
+		if m == nil {
+			continue
+		}
+		item := &tasksChecklistItem{
+			Title: strings.TrimSpace(m[3]),
+			Done:  m[2] != " ",
+			Line:  line,
+		}
+		if due := tasksChecklistDueRE.FindStringSubmatch(item.Title); due != nil {
+			item.Due = due[1]
+			item.Title = strings.TrimSpace(strings.TrimSuffix(item.Title, due[0]))
+		}
+		if item.Title == "" {
+			return nil, usagef("line %d: empty checklist item", line)
+		}
+
+		indent := checklistIndentWidth(m[1])
+		switch {
+		case len(roots) == 0 || indent <= rootIndent:
+			roots = append(roots, item)
+			rootIndent, childIndent = indent, -1
+		case childIndent == -1 || indent <= childIndent:
+			parent := roots[len(roots)-1]
+			parent.Children = append(parent.Children, item)
+			if childIndent == -1 {
+				childIndent = indent
+			}
+		default:
+			return nil, usagef("line %d: Google Tasks supports only one level of subtasks", line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read checklist: %w", err)
+	}
+	return roots, nil
+}
+
+func checklistIndentWidth(prefix string) int {
+	width := 0
+	for _, r := range prefix {
+		if r == '\t' {
+			width += 4
+			continue
+		}
+		width++
+	}
+	return width
+}
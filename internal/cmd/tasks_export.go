@@ -0,0 +1,238 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/api/tasks/v1"
+
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+type TasksExportCmd struct {
+	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID or title"`
+	Format     string `name:"format" short:"f" help:"Export format: json|md|ics" enum:"json,md,markdown,ics" default:"json"`
+	Out        string `name:"out" short:"o" help:"Output path, or - for stdout" default:"-"`
+	Completed  bool   `name:"completed" negatable:"" default:"true" help:"Include completed tasks"`
+}
+
+type tasksExportItem struct {
+	ID        string             `json:"id"`
+	Title     string             `json:"title"`
+	Notes     string             `json:"notes,omitempty"`
+	Status    string             `json:"status"`
+	Due       string             `json:"due,omitempty"`
+	Completed string             `json:"completed,omitempty"`
+	Updated   string             `json:"updated,omitempty"`
+	Position  string             `json:"position"`
+	Parent    string             `json:"parent,omitempty"`
+	Children  []*tasksExportItem `json:"children,omitempty"`
+}
+
+func (c *TasksExportCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	tasklistID := strings.TrimSpace(c.TasklistID)
+	if tasklistID == "" {
+		return usage("empty tasklistId")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := tasksService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tasklistID, err = resolveTasklistID(ctx, svc, tasklistID)
+	if err != nil {
+		return err
+	}
+	list, err := svc.Tasklists.Get(tasklistID).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+	items, err := listAllTasks(ctx, svc, tasklistID)
+	if err != nil {
+		return err
+	}
+	tree := buildTaskTree(items)
+	if !c.Completed {
+		tree = withoutCompletedTasks(tree)
+	}
+
+	var buf bytes.Buffer
+	switch strings.ToLower(c.Format) {
+	case "md", "markdown":
+		err = writeTasksMarkdown(&buf, list.Title, tree)
+	case "ics":
+		err = writeTasksICS(&buf, list, tree, time.Now().UTC())
+	default:
+		err = writeTasksJSON(&buf, list, tree)
+	}
+	if err != nil {
+		return err
+	}
+
+	outPath := strings.TrimSpace(c.Out)
+	if isStdoutPath(outPath) {
+		_, err = stdoutWriter(ctx).Write(buf.Bytes())
+		return err
+	}
+	if err := os.WriteFile(outPath, buf.Bytes(), 0o600); err != nil {
+		return err
+	}
+	count := countTaskNodes(tree)
+	u.Err().Linef("Exported %d task%s to %s", count, pluralS(count), outPath)
+	return nil
+}
+
+func withoutCompletedTasks(nodes []*taskNode) []*taskNode {
+	out := make([]*taskNode, 0, len(nodes))
+	for _, node := range nodes {
+		if taskCompleted(node.Task) {
+			continue
+		}
+		out = append(out, &taskNode{Task: node.Task, Children: withoutCompletedTasks(node.Children)})
+	}
+	return out
+}
+
+func countTaskNodes(nodes []*taskNode) int {
+	count := len(nodes)
+	for _, node := range nodes {
+		count += countTaskNodes(node.Children)
+	}
+	return count
+}
+
+func tasksExportItems(nodes []*taskNode) []*tasksExportItem {
+	out := make([]*tasksExportItem, 0, len(nodes))
+	for _, node := range nodes {
+		t := node.Task
+		item := &tasksExportItem{
+			ID:       t.Id,
+			Title:    t.Title,
+			Notes:    t.Notes,
+			Status:   firstNonEmpty(t.Status, taskStatusNeedsAction),
+			Due:      t.Due,
+			Updated:  t.Updated,
+			Position: t.Position,
+			Parent:   t.Parent,
+			Children: tasksExportItems(node.Children),
+		}
+		if t.Completed != nil {
+			item.Completed = *t.Completed
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+func writeTasksJSON(w io.Writer, list *tasks.TaskList, tree []*taskNode) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(map[string]any{
+		"tasklist": map[string]any{"id": list.Id, "title": list.Title},
+		"tasks":    tasksExportItems(tree),
+	})
+}
+
+// writeTasksMarkdown renders a GitHub-style checklist that `tasks sync` reads
+// back: subtasks are indented two spaces and due dates trail as "(due DATE)".
+func writeTasksMarkdown(w io.Writer, title string, tree []*taskNode) error {
+	var b strings.Builder
+	if strings.TrimSpace(title) != "" {
+		fmt.Fprintf(&b, "# %s\n\n", strings.TrimSpace(title))
+	}
+	var walk func(nodes []*taskNode, depth int)
+	walk = func(nodes []*taskNode, depth int) {
+		indent := strings.Repeat("  ", depth)
+		for _, node := range nodes {
+			mark := " "
+			if taskCompleted(node.Task) {
+				mark = "x"
+			}
+			fmt.Fprintf(&b, "%s- [%s] %s", indent, mark, strings.TrimSpace(node.Task.Title))
+			if due := taskDueDate(node.Task); due != "" {
+				fmt.Fprintf(&b, " (due %s)", due)
+			}
+			b.WriteString("\n")
+			for _, line := range strings.Split(strings.TrimSpace(node.Task.Notes), "\n") {
+				if strings.TrimSpace(line) != "" {
+					fmt.Fprintf(&b, "%s  %s\n", indent, strings.TrimSpace(line))
+				}
+			}
+			walk(node.Children, depth+1)
+		}
+	}
+	walk(tree, 0)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeTasksICS writes one VTODO per task. RELATED-TO carries the parent and
+// X-GOOGLE-TASKS-POSITION the sibling order, which iCalendar has no field for.
+func writeTasksICS(w io.Writer, list *tasks.TaskList, tree []*taskNode, now time.Time) error {
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//gogcli//tasks export//EN",
+		"X-WR-CALNAME:" + vcardEscapeText(list.Title),
+	}
+	var walk func(nodes []*taskNode)
+	walk = func(nodes []*taskNode) {
+		for _, node := range nodes {
+			lines = append(lines, taskVTODOLines(node.Task, now)...)
+			walk(node.Children)
+		}
+	}
+	walk(tree)
+	lines = append(lines, "END:VCALENDAR")
+	for _, line := range lines {
+		if err := writeFoldedVCardLine(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func taskVTODOLines(t *tasks.Task, now time.Time) []string {
+	lines := []string{
+		"BEGIN:VTODO",
+		"UID:" + t.Id,
+		"DTSTAMP:" + now.Format("20060102T150405Z"),
+		"SUMMARY:" + vcardEscapeText(t.Title),
+	}
+	if notes := strings.TrimSpace(t.Notes); notes != "" {
+		lines = append(lines, "DESCRIPTION:"+vcardEscapeText(notes))
+	}
+	if taskCompleted(t) {
+		lines = append(lines, "STATUS:COMPLETED")
+		if t.Completed != nil {
+			if completed, err := time.Parse(time.RFC3339, *t.Completed); err == nil {
+				lines = append(lines, "COMPLETED:"+completed.UTC().Format("20060102T150405Z"))
+			}
+		}
+	} else {
+		lines = append(lines, "STATUS:NEEDS-ACTION")
+	}
+	if due := taskDueDate(t); due != "" {
+		lines = append(lines, "DUE;VALUE=DATE:"+strings.ReplaceAll(due, "-", ""))
+	}
+	if updated, err := time.Parse(time.RFC3339, t.Updated); err == nil {
+		lines = append(lines, "LAST-MODIFIED:"+updated.UTC().Format("20060102T150405Z"))
+	}
+	if parent := strings.TrimSpace(t.Parent); parent != "" {
+		lines = append(lines, "RELATED-TO;RELTYPE=PARENT:"+parent)
+	}
+	if t.Position != "" {
+		lines = append(lines, "X-GOOGLE-TASKS-POSITION:"+t.Position)
+	}
+	return append(lines, "END:VTODO")
+}
@@ -90,7 +90,7 @@ func (c *TasksListCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return resp.Items, resp.NextPageToken, nil
 	}
 
-	items, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	items, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
@@ -446,10 +446,12 @@ func (c *TasksDoneCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	previous := taskStatusSnapshot(ctx, svc, tasklistID, taskID)
 	updated, err := svc.Tasks.Patch(tasklistID, taskID, &tasks.Task{Status: taskStatusCompleted}).Do()
 	if err != nil {
 		return err
 	}
+	recordTaskStatusUndo(ctx, account, "completed task "+taskID, tasklistID, taskID, previous, taskStatusCompleted)
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"task": updated})
 	}
@@ -495,10 +497,12 @@ func (c *TasksUndoCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return err
 	}
 
+	previous := taskStatusSnapshot(ctx, svc, tasklistID, taskID)
 	updated, err := svc.Tasks.Patch(tasklistID, taskID, &tasks.Task{Status: "needsAction"}).Do()
 	if err != nil {
 		return err
 	}
+	recordTaskStatusUndo(ctx, account, "reopened task "+taskID, tasklistID, taskID, previous, taskStatusNeedsAction)
 	if outfmt.IsJSON(ctx) {
 		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"task": updated})
 	}
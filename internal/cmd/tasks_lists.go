@@ -50,7 +50,7 @@ func (c *TasksListsListCmd) Run(ctx context.Context, flags *RootFlags) error {
 		return resp.Items, resp.NextPageToken, nil
 	}
 
-	items, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	items, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,352 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/api/tasks/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	tasksSyncCreate   = "create"
+	tasksSyncComplete = "complete"
+	tasksSyncReopen   = "reopen"
+	tasksSyncSetDue   = "set_due"
+	tasksSyncMove     = "move"
+
+	tasksSyncNewRefPrefix = "new:"
+)
+
+type TasksSyncCmd struct {
+	TasklistID string `arg:"" name:"tasklistId" help:"Task list ID or title"`
+	File       string `arg:"" name:"checklist" help:"Markdown checklist file (- [ ] / - [x] items, indented subtasks)"`
+}
+
+// tasksSyncAction is one API call in a sync plan. Task, Parent, and Previous
+// are task IDs, or "new:<line>" for tasks the plan creates first.
+type tasksSyncAction struct {
+	Action   string `json:"action"`
+	Title    string `json:"title"`
+	Line     int    `json:"line"`
+	Task     string `json:"task,omitempty"`
+	Parent   string `json:"parent,omitempty"`
+	Previous string `json:"previous,omitempty"`
+	Done     bool   `json:"done,omitempty"`
+	Due      string `json:"due,omitempty"`
+}
+
+type tasksSyncPlan struct {
+	Actions []tasksSyncAction `json:"actions"`
+	// Extra lists tasks in the list that the checklist does not mention.
+	// Sync leaves them in place.
+	Extra []string `json:"extra"`
+}
+
+func (c *TasksSyncCmd) Run(ctx context.Context, flags *RootFlags) error {
+	tasklistID := strings.TrimSpace(c.TasklistID)
+	if tasklistID == "" {
+		return usage("empty tasklistId")
+	}
+	path, err := config.ExpandPath(strings.TrimSpace(c.File))
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path) //nolint:gosec // path is user-supplied by design (local CLI)
+	if err != nil {
+		return err
+	}
+	checklist, err := parseTasksChecklist(f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+	if len(checklist) == 0 {
+		return usagef("%s has no checklist items (- [ ] title)", c.File)
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := tasksService(ctx, account)
+	if err != nil {
+		return err
+	}
+	tasklistID, err = resolveTasklistID(ctx, svc, tasklistID)
+	if err != nil {
+		return err
+	}
+	items, err := listAllTasks(ctx, svc, tasklistID)
+	if err != nil {
+		return err
+	}
+	plan := buildTasksSyncPlan(checklist, buildTaskTree(items))
+
+	payload := map[string]any{
+		"tasklist": tasklistID,
+		"file":     path,
+		"actions":  plan.Actions,
+		"extra":    plan.Extra,
+		"counts":   plan.counts(),
+	}
+	if err := dryRunExit(ctx, flags, "tasks.sync", payload); err != nil {
+		return err
+	}
+	if err := applyTasksSyncPlan(ctx, svc, tasklistID, plan); err != nil {
+		return err
+	}
+
+	if outfmt.IsJSON(ctx) {
+		payload["applied"] = true
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), payload)
+	}
+	u := ui.FromContext(ctx)
+	for _, action := range plan.Actions {
+		u.Out().Linef("%s\t%s", action.Action, action.Title)
+	}
+	if len(plan.Actions) == 0 {
+		u.Err().Println("Already in sync")
+	}
+	if len(plan.Extra) > 0 {
+		u.Err().Linef("%d task%s in the list not in %s (left unchanged)", len(plan.Extra), pluralS(len(plan.Extra)), c.File)
+	}
+	return nil
+}
+
+func (p tasksSyncPlan) counts() map[string]int {
+	counts := map[string]int{}
+	for _, action := range p.Actions {
+		counts[action.Action]++
+	}
+	return counts
+}
+
+// tasksSyncState simulates sibling order while the plan is built, so a move
+// is only planned when the task is not already in place.
+type tasksSyncState struct {
+	siblings map[string][]string
+	parentOf map[string]string
+	plan     tasksSyncPlan
+}
+
+func buildTasksSyncPlan(checklist []*tasksChecklistItem, remote []*taskNode) tasksSyncPlan {
+	matches := matchTasksChecklist(checklist, remote)
+	state := &tasksSyncState{siblings: map[string][]string{}, parentOf: map[string]string{}}
+	var index func(nodes []*taskNode, parent string)
+	index = func(nodes []*taskNode, parent string) {
+		for _, node := range nodes {
+			state.siblings[parent] = append(state.siblings[parent], node.Task.Id)
+			state.parentOf[node.Task.Id] = parent
+			index(node.Children, node.Task.Id)
+		}
+	}
+	index(remote, "")
+
+	previous := ""
+	for _, item := range checklist {
+		ref := state.reconcile(item, matches[item], "", previous)
+		childPrevious := ""
+		for _, child := range item.Children {
+			childPrevious = state.reconcile(child, matches[child], ref, childPrevious)
+		}
+		previous = ref
+	}
+
+	matched := map[string]bool{}
+	for _, task := range matches {
+		matched[task.Id] = true
+	}
+	var extra func(nodes []*taskNode)
+	extra = func(nodes []*taskNode) {
+		for _, node := range nodes {
+			if !matched[node.Task.Id] {
+				state.plan.Extra = append(state.plan.Extra, node.Task.Title)
+			}
+			extra(node.Children)
+		}
+	}
+	extra(remote)
+	if state.plan.Actions == nil {
+		state.plan.Actions = []tasksSyncAction{}
+	}
+	return state.plan
+}
+
+func (s *tasksSyncState) reconcile(item *tasksChecklistItem, task *tasks.Task, parent, previous string) string {
+	if task == nil {
+		ref := fmt.Sprintf("%s%d", tasksSyncNewRefPrefix, item.Line)
+		s.plan.Actions = append(s.plan.Actions, tasksSyncAction{
+			Action: tasksSyncCreate, Title: item.Title, Line: item.Line,
+			Task: ref, Parent: parent, Previous: previous, Done: item.Done, Due: item.Due,
+		})
+		s.place(ref, parent, previous)
+		return ref
+	}
+
+	ref := task.Id
+	if s.parentOf[ref] != parent || s.previousOf(ref) != previous {
+		s.plan.Actions = append(s.plan.Actions, tasksSyncAction{
+			Action: tasksSyncMove, Title: item.Title, Line: item.Line,
+			Task: ref, Parent: parent, Previous: previous,
+		})
+		s.place(ref, parent, previous)
+	}
+	switch {
+	case item.Done && !taskCompleted(task):
+		s.plan.Actions = append(s.plan.Actions, tasksSyncAction{Action: tasksSyncComplete, Title: item.Title, Line: item.Line, Task: ref})
+	case !item.Done && taskCompleted(task):
+		s.plan.Actions = append(s.plan.Actions, tasksSyncAction{Action: tasksSyncReopen, Title: item.Title, Line: item.Line, Task: ref})
+	}
+	if item.Due != "" && item.Due != taskDueDate(task) {
+		s.plan.Actions = append(s.plan.Actions, tasksSyncAction{Action: tasksSyncSetDue, Title: item.Title, Line: item.Line, Task: ref, Due: item.Due})
+	}
+	return ref
+}
+
+func (s *tasksSyncState) previousOf(ref string) string {
+	list := s.siblings[s.parentOf[ref]]
+	i := slices.Index(list, ref)
+	if i <= 0 {
+		return ""
+	}
+	return list[i-1]
+}
+
+func (s *tasksSyncState) place(ref, parent, previous string) {
+	if old, ok := s.parentOf[ref]; ok {
+		s.siblings[old] = slices.DeleteFunc(s.siblings[old], func(id string) bool { return id == ref })
+	}
+	list := s.siblings[parent]
+	at := 0
+	if previous != "" {
+		at = slices.Index(list, previous) + 1
+	}
+	s.siblings[parent] = slices.Insert(list, at, ref)
+	s.parentOf[ref] = parent
+}
+
+// matchTasksChecklist pairs checklist items with existing tasks by title:
+// first among the same parent's subtasks, then anywhere in the list so a task
+// that moved under another parent is moved rather than duplicated.
+func matchTasksChecklist(checklist []*tasksChecklistItem, remote []*taskNode) map[*tasksChecklistItem]*tasks.Task {
+	matches := map[*tasksChecklistItem]*tasks.Task{}
+	used := map[string]bool{}
+	take := func(nodes []*taskNode, title string) *taskNode {
+		for _, node := range nodes {
+			if !used[node.Task.Id] && strings.TrimSpace(node.Task.Title) == title {
+				used[node.Task.Id] = true
+				return node
+			}
+		}
+		return nil
+	}
+
+	for _, item := range checklist {
+		node := take(remote, item.Title)
+		if node == nil {
+			continue
+		}
+		matches[item] = node.Task
+		for _, child := range item.Children {
+			if childNode := take(node.Children, child.Title); childNode != nil {
+				matches[child] = childNode.Task
+			}
+		}
+	}
+
+	var all []*taskNode
+	var flatten func(nodes []*taskNode)
+	flatten = func(nodes []*taskNode) {
+		for _, node := range nodes {
+			all = append(all, node)
+			flatten(node.Children)
+		}
+	}
+	flatten(remote)
+	for _, item := range checklist {
+		for _, candidate := range append([]*tasksChecklistItem{item}, item.Children...) {
+			if matches[candidate] != nil {
+				continue
+			}
+			if node := take(all, candidate.Title); node != nil {
+				matches[candidate] = node.Task
+			}
+		}
+	}
+	return matches
+}
+
+func applyTasksSyncPlan(ctx context.Context, svc *tasks.Service, tasklistID string, plan tasksSyncPlan) error {
+	ids := map[string]string{}
+	resolve := func(ref string) string {
+		if id, ok := ids[ref]; ok {
+			return id
+		}
+		return ref
+	}
+	for _, action := range plan.Actions {
+		taskID := resolve(action.Task)
+		switch action.Action {
+		case tasksSyncCreate:
+			task := &tasks.Task{Title: action.Title}
+			if action.Done {
+				task.Status = taskStatusCompleted
+			}
+			if action.Due != "" {
+				due, err := normalizeTaskDue(action.Due)
+				if err != nil {
+					return err
+				}
+				task.Due = due
+			}
+			call := svc.Tasks.Insert(tasklistID, task).Context(ctx)
+			if parent := resolve(action.Parent); parent != "" {
+				call = call.Parent(parent)
+			}
+			if previous := resolve(action.Previous); previous != "" {
+				call = call.Previous(previous)
+			}
+			created, err := call.Do()
+			if err != nil {
+				return fmt.Errorf("create %q (line %d): %w", action.Title, action.Line, err)
+			}
+			ids[action.Task] = created.Id
+		case tasksSyncMove:
+			call := svc.Tasks.Move(tasklistID, taskID).Context(ctx)
+			if parent := resolve(action.Parent); parent != "" {
+				call = call.Parent(parent)
+			}
+			if previous := resolve(action.Previous); previous != "" {
+				call = call.Previous(previous)
+			}
+			if _, err := call.Do(); err != nil {
+				return fmt.Errorf("move %q (line %d): %w", action.Title, action.Line, err)
+			}
+		case tasksSyncComplete, tasksSyncReopen, tasksSyncSetDue:
+			patch := &tasks.Task{}
+			switch action.Action {
+			case tasksSyncComplete:
+				patch.Status = taskStatusCompleted
+			case tasksSyncReopen:
+				patch.Status = taskStatusNeedsAction
+			default:
+				due, err := normalizeTaskDue(action.Due)
+				if err != nil {
+					return err
+				}
+				patch.Due = due
+			}
+			if _, err := svc.Tasks.Patch(tasklistID, taskID, patch).Context(ctx).Do(); err != nil {
+				return fmt.Errorf("%s %q (line %d): %w", action.Action, action.Title, action.Line, err)
+			}
+		}
+	}
+	return nil
+}
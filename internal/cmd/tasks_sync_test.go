@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"google.golang.org/api/tasks/v1"
+)
+
+func TestParseTasksChecklist(t *testing.T) {
+	input := "# Sprint 12\n\n- [ ] Ship API (due 2026-10-20)\n  - [x] Write tests\n  - [ ] Review\n\nSome notes.\n* [X] Retro\n"
+	items, err := parseTasksChecklist(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseTasksChecklist: %v", err)
+	}
+	if len(items) != 2 || items[0].Title != "Ship API" || items[0].Due != "2026-10-20" || items[0].Done {
+		t.Fatalf("unexpected roots: %#v", items)
+	}
+	if len(items[0].Children) != 2 || !items[0].Children[0].Done || items[0].Children[1].Title != "Review" {
+		t.Fatalf("unexpected children: %#v", items[0].Children)
+	}
+	if !items[1].Done || items[1].Line != 8 {
+		t.Fatalf("unexpected second root: %#v", items[1])
+	}
+
+	_, err = parseTasksChecklist(strings.NewReader("- [ ] a\n  - [ ] b\n    - [ ] c\n"))
+	if err == nil || !strings.Contains(err.Error(), "line 3: Google Tasks supports only one level") {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestBuildTasksSyncPlan(t *testing.T) {
+	completed := "2026-10-01T00:00:00Z"
+	remote := buildTaskTree([]*tasks.Task{
+		{Id: "a", Title: "Alpha", Position: "001", Status: "needsAction"},
+		{Id: "b", Title: "Beta", Position: "002", Status: "completed", Completed: &completed},
+		{Id: "c", Title: "Gamma", Position: "003", Status: "needsAction"},
+		{Id: "b1", Title: "Beta child", Parent: "b", Position: "001", Status: "needsAction"},
+		{Id: "old", Title: "Old", Position: "004", Status: "needsAction"},
+	})
+	checklist, err := parseTasksChecklist(strings.NewReader(
+		"- [x] Alpha\n- [ ] Gamma (due 2026-11-01)\n- [ ] Beta\n  - [ ] New sub\n  - [ ] Beta child\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plan := buildTasksSyncPlan(checklist, remote)
+	var got []string
+	for _, a := range plan.Actions {
+		got = append(got, fmt.Sprintf("%s %s parent=%s prev=%s", a.Action, a.Task, a.Parent, a.Previous))
+	}
+	want := []string{
+		"complete a parent= prev=",
+		"move c parent= prev=a",
+		"set_due c parent= prev=",
+		"reopen b parent= prev=",
+		"create new:4 parent=b prev=",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("actions:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !reflect.DeepEqual(plan.Extra, []string{"Old"}) {
+		t.Fatalf("extra = %#v", plan.Extra)
+	}
+}
+
+type tasksSyncTestServer struct {
+	mu      sync.Mutex
+	calls   []string
+	created int
+}
+
+func (s *tasksSyncTestServer) handler(t *testing.T) http.HandlerFunc {
+	t.Helper()
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks/v1/users/@me/lists":
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{{"id": "l1", "title": "Sprint"}}})
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks/v1/users/@me/lists/l1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "l1", "title": "Sprint"})
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks/v1/lists/l1/tasks":
+			if r.URL.Query().Get("showHidden") != "true" {
+				t.Errorf("list without showHidden: %s", r.URL.RawQuery)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"id": "t2", "title": "Deploy", "position": "002", "status": "needsAction", "due": "2026-10-20T00:00:00.000Z"},
+				{"id": "t1", "title": "Build", "position": "001", "status": "completed", "completed": "2026-10-02T10:00:00Z"},
+				{"id": "t1a", "title": "Lint; fix", "parent": "t1", "position": "001", "status": "needsAction", "notes": "run make lint"},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks/v1/lists/l1/tasks":
+			s.created++
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			s.calls = append(s.calls, fmt.Sprintf("insert %v parent=%s previous=%s", body["title"], r.URL.Query().Get("parent"), r.URL.Query().Get("previous")))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": fmt.Sprintf("n%d", s.created), "title": body["title"]})
+		case r.Method == http.MethodPost && strings.HasSuffix(r.URL.Path, "/move"):
+			s.calls = append(s.calls, fmt.Sprintf("move %s parent=%s previous=%s", strings.Split(r.URL.Path, "/")[6], r.URL.Query().Get("parent"), r.URL.Query().Get("previous")))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "moved"})
+		case r.Method == http.MethodPatch:
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			s.calls = append(s.calls, fmt.Sprintf("patch %s status=%v", strings.Split(r.URL.Path, "/")[6], body["status"]))
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "patched"})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func runTasksSyncTest(t *testing.T, srv *tasksSyncTestServer, args ...string) executeTestResult {
+	t.Helper()
+	server := httptest.NewServer(srv.handler(t))
+	t.Cleanup(server.Close)
+	result := executeWithTasksTestService(t, append([]string{"--account", "a@b.com"}, args...), newTasksServiceFromServer(t, server))
+	if result.err != nil {
+		t.Fatalf("Execute %v: %v\nstderr=%s", args, result.err, result.stderr)
+	}
+	return result
+}
+
+func TestExecute_TasksExportFormats(t *testing.T) {
+	srv := &tasksSyncTestServer{}
+	result := runTasksSyncTest(t, srv, "tasks", "export", "l1", "--format", "md")
+	want := "# Sprint\n\n- [x] Build\n  - [ ] Lint; fix\n    run make lint\n- [ ] Deploy (due 2026-10-20)\n"
+	if result.stdout != want {
+		t.Fatalf("markdown:\n%s\nwant:\n%s", result.stdout, want)
+	}
+
+	result = runTasksSyncTest(t, srv, "tasks", "export", "l1", "--format", "ics")
+	for _, line := range []string{"BEGIN:VTODO\r\nUID:t1\r\n", "STATUS:COMPLETED\r\nCOMPLETED:20261002T100000Z", `SUMMARY:Lint\; fix`, "RELATED-TO;RELTYPE=PARENT:t1", "DUE;VALUE=DATE:20261020"} {
+		if !strings.Contains(result.stdout, line) {
+			t.Fatalf("ics missing %q:\n%s", line, result.stdout)
+		}
+	}
+
+	out := filepath.Join(t.TempDir(), "tasks.json")
+	runTasksSyncTest(t, srv, "tasks", "export", "l1", "--out", out, "--no-completed")
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var exported struct {
+		Tasks []tasksExportItem `json:"tasks"`
+	}
+	if err := json.Unmarshal(data, &exported); err != nil || len(exported.Tasks) != 1 || exported.Tasks[0].ID != "t2" {
+		t.Fatalf("json export = %s (%v)", data, err)
+	}
+}
+
+func TestExecute_TasksSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "checklist.md")
+	checklist := "# Sprint\n- [ ] Deploy (due 2026-10-20)\n- [ ] Build\n  - [x] Lint; fix\n  - [ ] Changelog\n"
+	if err := os.WriteFile(path, []byte(checklist), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := &tasksSyncTestServer{}
+	result := runTasksSyncTest(t, srv, "--dry-run", "--json", "tasks", "sync", "Sprint", path)
+	if len(srv.calls) != 0 || !strings.Contains(result.stdout, `"dry_run": true`) {
+		t.Fatalf("dry-run calls=%v out=%s", srv.calls, result.stdout)
+	}
+
+	runTasksSyncTest(t, srv, "tasks", "sync", "Sprint", path)
+	want := []string{
+		"move t2 parent= previous=",
+		"patch t1 status=needsAction",
+		"patch t1a status=completed",
+		"insert Changelog parent=t1 previous=t1a",
+	}
+	if !reflect.DeepEqual(srv.calls, want) {
+		t.Fatalf("calls:\n%s\nwant:\n%s", strings.Join(srv.calls, "\n"), strings.Join(want, "\n"))
+	}
+}
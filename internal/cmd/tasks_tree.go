@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"google.golang.org/api/tasks/v1"
+)
+
+// taskNode is a task with its subtasks, ordered by the API position string.
+type taskNode struct {
+	Task     *tasks.Task
+	Children []*taskNode
+}
+
+// listAllTasks loads every task in a list, including completed tasks that
+// clients have hidden, so whole-list operations see the full hierarchy.
+func listAllTasks(ctx context.Context, svc *tasks.Service, tasklistID string) ([]*tasks.Task, error) {
+	items, _, err := loadPagedItems("", true, func(pageToken string) ([]*tasks.Task, string, error) {
+		call := svc.Tasks.List(tasklistID).
+			MaxResults(100).
+			ShowCompleted(true).
+			ShowHidden(true).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+		resp, err := call.Do()
+		if err != nil {
+			return nil, "", err
+		}
+		return resp.Items, resp.NextPageToken, nil
+	})
+	return items, err
+}
+
+// buildTaskTree nests tasks under their parents. Tasks whose parent is not in
+// the list are kept at the top level rather than dropped.
+func buildTaskTree(items []*tasks.Task) []*taskNode {
+	nodes := make(map[string]*taskNode, len(items))
+	for _, item := range items {
+		if item == nil || item.Deleted {
+			continue
+		}
+		nodes[item.Id] = &taskNode{Task: item}
+	}
+	var roots []*taskNode
+	for _, item := range items {
+		node := nodes[item.Id]
+		if node == nil {
+			continue
+		}
+		if parent := nodes[strings.TrimSpace(item.Parent)]; parent != nil && parent != node {
+			parent.Children = append(parent.Children, node)
+			continue
+		}
+		roots = append(roots, node)
+	}
+	sortTaskNodes(roots)
+	return roots
+}
+
+func sortTaskNodes(nodes []*taskNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Task.Position < nodes[j].Task.Position
+	})
+	for _, node := range nodes {
+		sortTaskNodes(node.Children)
+	}
+}
+
+func taskCompleted(task *tasks.Task) bool {
+	return task != nil && strings.TrimSpace(task.Status) == taskStatusCompleted
+}
+
+// taskDueDate returns the YYYY-MM-DD part of a due timestamp.
+func taskDueDate(task *tasks.Task) string {
+	if task == nil {
+		return ""
+	}
+	due := strings.TrimSpace(task.Due)
+	if len(due) >= len("2006-01-02") {
+		return due[:len("2006-01-02")]
+	}
+	return due
+}
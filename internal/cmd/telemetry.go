@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/steipete/gogcli/internal/telemetry"
+)
+
+// startCommandTelemetry traces the command when GOG_OTEL_ENDPOINT or
+// GOG_OTEL_FILE is set. finish ends the command span with the command's
+// final error and flushes the exporters.
+func startCommandTelemetry(ctx context.Context, command string) (context.Context, func(error), error) {
+	cfg, err := telemetry.ConfigFromEnv()
+	if err != nil {
+		return ctx, nil, err
+	}
+	if !cfg.Enabled() {
+		return ctx, func(error) {}, nil
+	}
+
+	shutdown, err := telemetry.Setup(ctx, cfg, command, VersionString())
+	if err != nil {
+		// Telemetry never fails the command it observes.
+		slog.Warn("telemetry disabled", "err", err)
+		return ctx, func(error) {}, nil
+	}
+
+	ctx, end := telemetry.StartCommand(ctx, command)
+	return ctx, func(err error) {
+		end(ExitCode(err), err)
+		if shutdownErr := shutdown(context.Background()); shutdownErr != nil {
+			slog.Warn("telemetry export failed", "err", shutdownErr)
+		}
+	}, nil
+}
@@ -0,0 +1,543 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/drive/v3"
+	"google.golang.org/api/gmail/v1"
+	"google.golang.org/api/tasks/v1"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/googleapi"
+	"github.com/steipete/gogcli/internal/googleauth"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+	"github.com/steipete/gogcli/internal/undo"
+)
+
+const (
+	undoJournalDirName  = "undo"
+	gmailBatchModifyMax = 1000
+	// undoSnapshotMax caps the IDs whose labels are read for the journal,
+	// so the snapshot is one batch request. Larger changes are not journaled.
+	undoSnapshotMax = googleapi.MaxBatchCalls
+)
+
+type undoRecorderContextKey struct{}
+
+// undoRecorder journals inverse operations for the running command.
+type undoRecorder struct {
+	command string
+	journal func() (undo.Journal, error)
+
+	once     sync.Once
+	disabled bool
+	config   func() (config.File, error)
+}
+
+// withUndoRecorder installs the journal for command unless GOG_NO_UNDO is
+// set. no_undo in config.json is read on first use.
+func withUndoRecorder(ctx context.Context, runtimeContext context.Context, command string) context.Context {
+	if envBool("GOG_NO_UNDO") {
+		return ctx
+	}
+	return context.WithValue(ctx, undoRecorderContextKey{}, &undoRecorder{
+		command: command,
+		journal: func() (undo.Journal, error) { return commandUndoJournal(runtimeContext) },
+		config:  func() (config.File, error) { return readCommandConfig(runtimeContext) },
+	})
+}
+
+func undoRecorderFromContext(ctx context.Context) *undoRecorder {
+	recorder, _ := ctx.Value(undoRecorderContextKey{}).(*undoRecorder)
+	if recorder == nil {
+		return nil
+	}
+	recorder.once.Do(func() {
+		if recorder.config == nil {
+			return
+		}
+		cfg, err := recorder.config()
+		if err != nil {
+			slog.Debug("undo config unavailable", "err", err)
+			return
+		}
+		recorder.disabled = cfg.NoUndo
+	})
+	if recorder.disabled {
+		return nil
+	}
+	return recorder
+}
+
+// recordUndo journals the inverse of a mutation that already succeeded, so
+// failures only warn.
+func recordUndo(ctx context.Context, account, summary string, inverse ...undo.Op) {
+	recorder := undoRecorderFromContext(ctx)
+	if recorder == nil || len(inverse) == 0 {
+		return
+	}
+	journal, err := recorder.journal()
+	if err == nil {
+		_, err = journal.Record(undo.Entry{
+			Account: account,
+			Command: recorder.command,
+			Summary: summary,
+			Inverse: inverse,
+		})
+	}
+	if err != nil {
+		slog.Warn("undo journal not updated", "err", err)
+	}
+}
+
+func commandUndoJournal(ctx context.Context) (undo.Journal, error) {
+	layout, err := commandLayout(ctx, config.PathKindState)
+	if err != nil {
+		return undo.Journal{}, err
+	}
+	return undo.Journal{Dir: filepath.Join(layout.StateDir, undoJournalDirName)}, nil
+}
+
+// undoEnabled reports whether the running command journals undo entries,
+// so callers only read pre-change state when it will be used.
+func undoEnabled(ctx context.Context) bool {
+	return undoRecorderFromContext(ctx) != nil
+}
+
+// gmailLabelState maps message IDs to their label IDs.
+type gmailLabelState map[string][]string
+
+// gmailLabelSnapshot reads the labels of each message, or of every message
+// in each thread, before a label change. The result is keyed by the given
+// IDs. A nil map with a nil error means nothing is journaled: undo is off, or
+// the change is larger than one snapshot batch.
+func gmailLabelSnapshot(ctx context.Context, account string, svc *gmail.Service, ids []string, threads bool) (map[string]gmailLabelState, error) {
+	if !undoEnabled(ctx) || len(ids) == 0 {
+		return nil, nil
+	}
+	if len(ids) > undoSnapshotMax {
+		slog.Info("undo journal skipped: label change is too large to snapshot", "ids", len(ids), "max", undoSnapshotMax)
+		return nil, nil
+	}
+	batch, err := googleBatch(ctx, account, googleauth.ServiceGmail, svc.BasePath)
+	if err != nil {
+		return nil, err
+	}
+	calls := make([]googleapi.BatchCall, 0, len(ids))
+	for _, id := range ids {
+		call := googleapi.BatchCall{Method: http.MethodGet, Path: "gmail/v1/users/me/messages/" + url.PathEscape(id)}
+		call.Query = url.Values{"format": {"minimal"}, "fields": {"id,labelIds"}}
+		if threads {
+			call.Path = "gmail/v1/users/me/threads/" + url.PathEscape(id)
+			call.Query.Set("fields", "messages(id,labelIds)")
+		}
+		calls = append(calls, call)
+	}
+	results, err := batch.Do(ctx, calls)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := make(map[string]gmailLabelState, len(ids))
+	for i, id := range ids {
+		var messages []*gmail.Message
+		if threads {
+			var thread gmail.Thread
+			if err := results[i].Decode(&thread); err != nil {
+				return nil, fmt.Errorf("read labels of thread %s: %w", id, err)
+			}
+			messages = thread.Messages
+		} else {
+			var msg gmail.Message
+			if err := results[i].Decode(&msg); err != nil {
+				return nil, fmt.Errorf("read labels of message %s: %w", id, err)
+			}
+			msg.Id = id
+			messages = []*gmail.Message{&msg}
+		}
+		state := make(gmailLabelState, len(messages))
+		for _, msg := range messages {
+			state[msg.Id] = msg.LabelIds
+		}
+		snapshot[id] = state
+	}
+	return snapshot, nil
+}
+
+// gmailLabelInverse reverses a label change on the snapshot entries for ids,
+// which succeeded. Only what actually changed is undone: added labels a
+// message already had stay, and removed labels it never had are not added.
+// Messages that need the same restore share one op.
+func gmailLabelInverse(snapshot map[string]gmailLabelState, ids, added, removed []string) []undo.Op {
+	type restore struct{ add, remove []string }
+	byKey := map[string]*undo.Op{}
+	seen := map[string]bool{}
+	var keys []string
+	for _, id := range ids {
+		state := snapshot[id]
+		messageIDs := make([]string, 0, len(state))
+		for messageID := range state {
+			messageIDs = append(messageIDs, messageID)
+		}
+		sort.Strings(messageIDs)
+		for _, messageID := range messageIDs {
+			if seen[messageID] {
+				continue
+			}
+			seen[messageID] = true
+			had := make(map[string]bool, len(state[messageID]))
+			for _, label := range state[messageID] {
+				had[label] = true
+			}
+			var r restore
+			for _, label := range removed {
+				if had[label] {
+					r.add = append(r.add, label)
+				}
+			}
+			for _, label := range added {
+				if !had[label] {
+					r.remove = append(r.remove, label)
+				}
+			}
+			if len(r.add) == 0 && len(r.remove) == 0 {
+				continue
+			}
+			key := strings.Join(r.add, ",") + "|" + strings.Join(r.remove, ",")
+			op, ok := byKey[key]
+			if !ok {
+				op = &undo.Op{Kind: undo.KindGmailModify, AddLabels: r.add, RemoveLabels: r.remove}
+				byKey[key] = op
+				keys = append(keys, key)
+			}
+			op.IDs = append(op.IDs, messageID)
+		}
+	}
+	ops := make([]undo.Op, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, *byKey[key])
+	}
+	return ops
+}
+
+// recordGmailLabelUndo journals the inverse of a label change, or warns when
+// the pre-change labels could not be read.
+func recordGmailLabelUndo(ctx context.Context, account, summary string, snapshot map[string]gmailLabelState, snapshotErr error, ids, added, removed []string) {
+	if snapshotErr != nil {
+		slog.Warn("undo journal not updated: labels could not be read before the change", "err", snapshotErr)
+		return
+	}
+	recordUndo(ctx, account, summary, gmailLabelInverse(snapshot, ids, added, removed)...)
+}
+
+// taskStatusSnapshot reads a task's status before it changes. It returns ""
+// when undo is not recording or the status could not be read.
+func taskStatusSnapshot(ctx context.Context, svc *tasks.Service, tasklistID, taskID string) string {
+	if !undoEnabled(ctx) {
+		return ""
+	}
+	task, err := svc.Tasks.Get(tasklistID, taskID).Fields("status").Context(ctx).Do()
+	if err != nil {
+		slog.Warn("undo journal not updated: task status could not be read before the change", "err", err)
+		return ""
+	}
+	return task.Status
+}
+
+// recordTaskStatusUndo journals restoring previous, unless the task already
+// had the new status.
+func recordTaskStatusUndo(ctx context.Context, account, summary, tasklistID, taskID, previous, status string) {
+	if previous == "" || previous == status {
+		return
+	}
+	recordUndo(ctx, account, summary, undo.Op{Kind: undo.KindTaskStatus, TasklistID: tasklistID, TaskID: taskID, Status: previous})
+}
+
+func drivePermissionInverse(fileID string, perm *drive.Permission) undo.Op {
+	return undo.Op{
+		Kind:   undo.KindDrivePermissionCreate,
+		FileID: fileID,
+		Permission: &undo.Permission{
+			Type:               perm.Type,
+			Role:               perm.Role,
+			EmailAddress:       perm.EmailAddress,
+			Domain:             perm.Domain,
+			AllowFileDiscovery: perm.AllowFileDiscovery,
+		},
+	}
+}
+
+type UndoCmd struct {
+	OpID string `arg:"" optional:"" name:"opId" help:"Journal entry to undo (see 'gog history'); default: the most recent"`
+	Last int    `name:"last" help:"Undo the N most recent entries that have not been undone" default:"0"`
+}
+
+func (c *UndoCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	opID := strings.TrimSpace(c.OpID)
+	if c.Last < 0 {
+		return usage("--last must be > 0")
+	}
+	if opID != "" && c.Last > 0 {
+		return usage("opId cannot be combined with --last")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	journal, err := commandUndoJournal(ctx)
+	if err != nil {
+		return err
+	}
+
+	var entries []undo.Entry
+	if opID != "" {
+		entry, getErr := journal.Get(account, opID)
+		if errors.Is(getErr, undo.ErrNotFound) {
+			return usagef("no undo entry %q for %s (see 'gog history')", opID, account)
+		}
+		if getErr != nil {
+			return getErr
+		}
+		if entry.UndoneAt != nil {
+			return usagef("%s was already undone at %s", opID, entry.UndoneAt.Local().Format(time.RFC3339))
+		}
+		entries = []undo.Entry{entry}
+	} else {
+		n := c.Last
+		if n == 0 {
+			n = 1
+		}
+		entries, err = journal.Pending(account, n)
+		if err != nil {
+			return err
+		}
+	}
+	if len(entries) == 0 {
+		u.Err().Println("Nothing to undo")
+		return nil
+	}
+
+	if err := dryRunExit(ctx, flags, "undo", map[string]any{
+		"account": account,
+		"entries": undoPlan(entries),
+	}); err != nil {
+		return err
+	}
+
+	undone := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if err := replayUndo(ctx, account, entry); err != nil {
+			if len(undone) > 0 {
+				return fmt.Errorf("undo %s (after undoing %s): %w", entry.ID, strings.Join(undone, ", "), err)
+			}
+			return fmt.Errorf("undo %s: %w", entry.ID, err)
+		}
+		if err := journal.MarkUndone(account, entry.ID); err != nil {
+			return err
+		}
+		undone = append(undone, entry.ID)
+		if !outfmt.IsJSON(ctx) {
+			u.Out().Linef("undone\t%s\t%s", entry.ID, entry.Summary)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"undone": undone,
+			"count":  len(undone),
+		})
+	}
+	return nil
+}
+
+func undoPlan(entries []undo.Entry) []map[string]any {
+	plan := make([]map[string]any, 0, len(entries))
+	for _, entry := range entries {
+		steps := make([]string, 0, len(entry.Inverse))
+		for _, op := range entry.Inverse {
+			steps = append(steps, op.Describe())
+		}
+		plan = append(plan, map[string]any{
+			"id":      entry.ID,
+			"command": entry.Command,
+			"summary": entry.Summary,
+			"steps":   steps,
+			"inverse": entry.Inverse,
+		})
+	}
+	return plan
+}
+
+func replayUndo(ctx context.Context, account string, entry undo.Entry) error {
+	for _, op := range entry.Inverse {
+		if err := replayUndoOp(ctx, account, op); err != nil {
+			return fmt.Errorf("%s: %w", op.Describe(), err)
+		}
+	}
+	return nil
+}
+
+func replayUndoOp(ctx context.Context, account string, op undo.Op) error {
+	switch op.Kind {
+	case undo.KindGmailModify:
+		svc, err := gmailService(ctx, account)
+		if err != nil {
+			return err
+		}
+		return replayGmailModify(ctx, svc, op)
+	case undo.KindDriveUpdate:
+		svc, err := driveService(ctx, account)
+		if err != nil {
+			return err
+		}
+		call := svc.Files.Update(op.FileID, &drive.File{Name: op.Name}).SupportsAllDrives(true).Fields("id")
+		if len(op.AddParents) > 0 {
+			call = call.AddParents(strings.Join(op.AddParents, ","))
+		}
+		if len(op.RemoveParents) > 0 {
+			call = call.RemoveParents(strings.Join(op.RemoveParents, ","))
+		}
+		_, err = call.Context(ctx).Do()
+		return err
+	case undo.KindDrivePermissionCreate:
+		if op.Permission == nil {
+			return errors.New("journal entry has no permission to restore")
+		}
+		svc, err := driveService(ctx, account)
+		if err != nil {
+			return err
+		}
+		_, err = svc.Permissions.Create(op.FileID, &drive.Permission{
+			Type:               op.Permission.Type,
+			Role:               op.Permission.Role,
+			EmailAddress:       op.Permission.EmailAddress,
+			Domain:             op.Permission.Domain,
+			AllowFileDiscovery: op.Permission.AllowFileDiscovery,
+		}).SupportsAllDrives(true).SendNotificationEmail(false).Fields("id").Context(ctx).Do()
+		return err
+	case undo.KindDrivePermissionDelete:
+		svc, err := driveService(ctx, account)
+		if err != nil {
+			return err
+		}
+		return svc.Permissions.Delete(op.FileID, op.PermissionID).SupportsAllDrives(true).Context(ctx).Do()
+	case undo.KindCalendarMove:
+		svc, err := calendarService(ctx, account)
+		if err != nil {
+			return err
+		}
+		_, err = svc.Events.Move(op.CalendarID, op.EventID, op.Destination).Context(ctx).Do()
+		return err
+	case undo.KindTaskStatus:
+		svc, err := tasksService(ctx, account)
+		if err != nil {
+			return err
+		}
+		_, err = svc.Tasks.Patch(op.TasklistID, op.TaskID, &tasks.Task{Status: op.Status}).Context(ctx).Do()
+		return err
+	default:
+		return fmt.Errorf("unknown undo operation %q", op.Kind)
+	}
+}
+
+func replayGmailModify(ctx context.Context, svc *gmail.Service, op undo.Op) error {
+	if op.Threads {
+		for _, id := range op.IDs {
+			if _, err := svc.Users.Threads.Modify("me", id, &gmail.ModifyThreadRequest{
+				AddLabelIds:    op.AddLabels,
+				RemoveLabelIds: op.RemoveLabels,
+			}).Context(ctx).Do(); err != nil {
+				return fmt.Errorf("thread %s: %w", id, err)
+			}
+		}
+		return nil
+	}
+	for i := 0; i < len(op.IDs); i += gmailBatchModifyMax {
+		end := min(i+gmailBatchModifyMax, len(op.IDs))
+		if err := svc.Users.Messages.BatchModify("me", &gmail.BatchModifyMessagesRequest{
+			Ids:            op.IDs[i:end],
+			AddLabelIds:    op.AddLabels,
+			RemoveLabelIds: op.RemoveLabels,
+		}).Context(ctx).Do(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type HistoryCmd struct {
+	Max       int  `name:"max" aliases:"limit" help:"Max entries" default:"20"`
+	All       bool `name:"all" help:"Include entries that were already undone"`
+	FailEmpty bool `name:"fail-empty" aliases:"non-empty,require-results" help:"Exit with code 3 if no results"`
+}
+
+func (c *HistoryCmd) Run(ctx context.Context, flags *RootFlags) error {
+	if c.Max <= 0 {
+		return usage("max must be > 0")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	journal, err := commandUndoJournal(ctx)
+	if err != nil {
+		return err
+	}
+	all, err := journal.List(account)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]undo.Entry, 0, min(len(all), c.Max))
+	for _, entry := range all {
+		if len(entries) >= c.Max {
+			break
+		}
+		if c.All || entry.UndoneAt == nil {
+			entries = append(entries, entry)
+		}
+	}
+
+	if outfmt.IsJSON(ctx) {
+		if err := outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"account": account,
+			"entries": entries,
+		}); err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return failEmptyExit(c.FailEmpty)
+		}
+		return nil
+	}
+
+	if len(entries) == 0 {
+		ui.FromContext(ctx).Err().Println("No undo history")
+		return failEmptyExit(c.FailEmpty)
+	}
+	return outfmt.WriteTable(ctx, stdoutWriter(ctx), entries, []outfmt.Column[undo.Entry]{
+		{Header: "ID", Value: func(e undo.Entry) string { return e.ID }},
+		{Header: "TIME", Value: func(e undo.Entry) string { return e.Time.Local().Format(time.RFC3339) }},
+		{Header: "COMMAND", Value: func(e undo.Entry) string { return e.Command }},
+		{Header: "SUMMARY", Value: func(e undo.Entry) string { return e.Summary }},
+		{Header: "UNDONE", Value: func(e undo.Entry) string {
+			if e.UndoneAt == nil {
+				return ""
+			}
+			return e.UndoneAt.Local().Format(time.RFC3339)
+		}},
+	})
+}
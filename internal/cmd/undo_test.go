@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/undo"
+)
+
+func TestUndo_ReplaysInverseOfDriveShareAndMove(t *testing.T) {
+	t.Setenv("GOG_STATE_DIR", t.TempDir())
+
+	var mu sync.Mutex
+	var calls []string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/drive/v3")
+		mu.Lock()
+		calls = append(calls, r.Method+" "+path+" "+r.URL.Query().Get("addParents")+"|"+r.URL.Query().Get("removeParents"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodPost && path == "/files/id1/permissions":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "perm9", "type": "user", "role": "writer", "emailAddress": "x@b.com"})
+		case r.Method == http.MethodDelete && path == "/files/id1/permissions/perm9":
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && path == "/files/id1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "id1", "name": "Doc", "parents": []string{"p0"}, "webViewLink": "https://example.com/id1"})
+		case r.Method == http.MethodPatch && path == "/files/id1":
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "id1", "name": "Doc"})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer closeSrv()
+
+	run := func(args ...string) executeTestResult {
+		t.Helper()
+		result := executeWithDriveTestService(t, append([]string{"--account", "a@b.com"}, args...), svc)
+		if result.err != nil {
+			t.Fatalf("%v: %v (stderr %q)", args, result.err, result.stderr)
+		}
+		return result
+	}
+
+	run("drive", "share", "id1", "--to", "user", "--email", "x@b.com", "--role", "writer")
+	run("drive", "move", "id1", "--parent", "np")
+
+	var history struct {
+		Entries []undo.Entry `json:"entries"`
+	}
+	if err := json.Unmarshal([]byte(run("--json", "history").stdout), &history); err != nil {
+		t.Fatalf("history json: %v", err)
+	}
+	if len(history.Entries) != 2 || history.Entries[0].Command != "drive move" || history.Entries[1].Command != "drive share" {
+		t.Fatalf("history = %+v", history.Entries)
+	}
+	move := history.Entries[0].Inverse[0]
+	if move.Kind != undo.KindDriveUpdate || move.AddParents[0] != "p0" || move.RemoveParents[0] != "np" {
+		t.Fatalf("move inverse = %+v", move)
+	}
+
+	mu.Lock()
+	calls = nil
+	mu.Unlock()
+	preview := run("--dry-run", "undo", "--last", "2")
+	if !strings.Contains(preview.stdout, "drive: move id1 into p0 out of np") || !strings.Contains(preview.stdout, "remove permission perm9") || len(calls) != 0 {
+		t.Fatalf("preview = %q, calls = %v", preview.stdout, calls)
+	}
+
+	out := run("undo", "--last", "2").stdout
+	if !strings.Contains(out, history.Entries[0].ID) || !strings.Contains(out, history.Entries[1].ID) {
+		t.Fatalf("undo output = %q", out)
+	}
+	if strings.Join(calls, ",") != "PATCH /files/id1 p0|np,DELETE /files/id1/permissions/perm9 |" {
+		t.Fatalf("calls = %v", calls)
+	}
+
+	if err := json.Unmarshal([]byte(run("--json", "history").stdout), &history); err != nil || len(history.Entries) != 0 {
+		t.Fatalf("pending history after undo = %+v, %v", history.Entries, err)
+	}
+	if err := json.Unmarshal([]byte(run("--json", "history", "--all").stdout), &history); err != nil || len(history.Entries) != 2 || history.Entries[0].UndoneAt == nil {
+		t.Fatalf("full history after undo = %+v, %v", history.Entries, err)
+	}
+	if result := executeWithDriveTestService(t, []string{"--account", "a@b.com", "undo", history.Entries[0].ID}, svc); result.err == nil || !strings.Contains(result.err.Error(), "already undone") {
+		t.Fatalf("second undo err = %v", result.err)
+	}
+}
+
+func TestUndo_OptOutSkipsJournalAndPreReads(t *testing.T) {
+	t.Setenv("GOG_STATE_DIR", t.TempDir())
+
+	var mu sync.Mutex
+	var calls []string
+	svc, closeSrv := newDriveTestService(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+strings.TrimPrefix(r.URL.Path, "/drive/v3"))
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": "id1", "name": "New"})
+	}))
+	defer closeSrv()
+
+	history := func() int {
+		t.Helper()
+		result := executeWithDriveTestService(t, []string{"--account", "a@b.com", "--json", "history"}, svc)
+		var out struct {
+			Entries []undo.Entry `json:"entries"`
+		}
+		if err := json.Unmarshal([]byte(result.stdout), &out); err != nil {
+			t.Fatalf("history json: %v (%q)", err, result.stdout)
+		}
+		return len(out.Entries)
+	}
+
+	t.Setenv("GOG_NO_UNDO", "1")
+	if result := executeWithDriveTestService(t, []string{"--account", "a@b.com", "drive", "rename", "id1", "New"}, svc); result.err != nil {
+		t.Fatalf("rename: %v", result.err)
+	}
+	t.Setenv("GOG_NO_UNDO", "")
+	if result := executeWithDriveTestService(t, []string{"--account", "a@b.com", "--max-api-calls", "5", "drive", "rename", "id1", "New"}, svc); result.err != nil {
+		t.Fatalf("rename with --max-api-calls: %v", result.err)
+	}
+	if strings.Join(calls, ",") != "PATCH /files/id1,PATCH /files/id1" || history() != 0 {
+		t.Fatalf("calls = %v, history = %d", calls, history())
+	}
+}
+
+func TestGmailLabelSnapshot_SkipsLargeChanges(t *testing.T) {
+	var out strings.Builder
+	ctx := newCmdRuntimeJSONOutputContext(t, &out, &out)
+	ctx = withUndoRecorder(ctx, ctx, "gmail batch modify")
+	ids := make([]string, undoSnapshotMax+1)
+	for i := range ids {
+		ids[i] = "m" + strconv.Itoa(i)
+	}
+	// svc is never used: the snapshot is skipped before any request.
+	snapshot, err := gmailLabelSnapshot(ctx, "a@b.com", nil, ids, false)
+	if err != nil || snapshot != nil {
+		t.Fatalf("snapshot = %v, %v", snapshot, err)
+	}
+}
+
+func TestGmailLabelInverse_OnlyUndoesActualChanges(t *testing.T) {
+	snapshot := map[string]gmailLabelState{
+		"t1": {"m1": {"INBOX", "UNREAD"}, "m2": {"STARRED"}},
+		"t2": {"m3": {"INBOX", "STARRED"}},
+		"t3": {"m4": {"INBOX"}},
+	}
+
+	ops := gmailLabelInverse(snapshot, []string{"t1", "t2"}, []string{"STARRED"}, []string{"INBOX"})
+	if len(ops) != 2 {
+		t.Fatalf("ops = %+v", ops)
+	}
+	// m1 was in the inbox and unstarred; m2 was already starred and archived.
+	if strings.Join(ops[0].IDs, ",") != "m1" || strings.Join(ops[0].AddLabels, ",") != "INBOX" || strings.Join(ops[0].RemoveLabels, ",") != "STARRED" || ops[0].Threads {
+		t.Fatalf("ops[0] = %+v", ops[0])
+	}
+	if strings.Join(ops[1].IDs, ",") != "m3" || strings.Join(ops[1].AddLabels, ",") != "INBOX" || len(ops[1].RemoveLabels) != 0 {
+		t.Fatalf("ops[1] = %+v", ops[1])
+	}
+
+	if ops := gmailLabelInverse(snapshot, []string{"t1"}, []string{"UNREAD"}, nil); len(ops) != 1 || strings.Join(ops[0].IDs, ",") != "m2" {
+		t.Fatalf("unread ops = %+v", ops)
+	}
+	if ops := gmailLabelInverse(snapshot, []string{"t1"}, nil, []string{"TRASH"}); len(ops) != 0 {
+		t.Fatalf("no-op change journaled: %+v", ops)
+	}
+}
+
+func TestRecordTaskStatusUndo_SkipsUnchangedStatus(t *testing.T) {
+	t.Setenv("GOG_STATE_DIR", t.TempDir())
+	var out strings.Builder
+	ctx := newCmdRuntimeJSONOutputContext(t, &out, &out)
+	ctx = withUndoRecorder(ctx, ctx, "tasks done")
+
+	recordTaskStatusUndo(ctx, "a@b.com", "completed task t1", "l1", "t1", taskStatusCompleted, taskStatusCompleted)
+	recordTaskStatusUndo(ctx, "a@b.com", "completed task t2", "l1", "t2", taskStatusNeedsAction, taskStatusCompleted)
+
+	journal, err := commandUndoJournal(ctx)
+	if err != nil {
+		t.Fatalf("journal: %v", err)
+	}
+	entries, err := journal.List("a@b.com")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Inverse[0].TaskID != "t2" || entries[0].Inverse[0].Status != taskStatusNeedsAction {
+		t.Fatalf("entries = %+v", entries)
+	}
+}
@@ -41,12 +41,14 @@ var youtubeVideoAllNonOwnerParts = []string{
 
 type YouTubeCmd struct {
 	Activities    YouTubeActivitiesCmd    `cmd:"" name:"activities" aliases:"activity" help:"List channel activities"`
-	Videos        YouTubeVideosCmd        `cmd:"" name:"videos" aliases:"video" help:"List or get videos"`
+	Videos        YouTubeVideosCmd        `cmd:"" name:"videos" aliases:"video" help:"List, upload, or update videos"`
 	Playlists     YouTubePlaylistsCmd     `cmd:"" name:"playlists" aliases:"playlist" help:"Manage playlists"`
 	Comments      YouTubeCommentsCmd      `cmd:"" name:"comments" aliases:"comment" help:"List comment threads"`
 	Channels      YouTubeChannelsCmd      `cmd:"" name:"channels" aliases:"channel" help:"List channels"`
 	Search        YouTubeSearchCmd        `cmd:"" name:"search" aliases:"find" help:"Search YouTube for videos, channels, or playlists"`
 	Subscriptions YouTubeSubscriptionsCmd `cmd:"" name:"subscriptions" aliases:"subscription" help:"Manage channel subscriptions"`
+	Thumbnails    YouTubeThumbnailsCmd    `cmd:"" name:"thumbnails" aliases:"thumbnail" help:"Set video thumbnails"`
+	Captions      YouTubeCaptionsCmd      `cmd:"" name:"captions" aliases:"caption" help:"List, upload, and download caption tracks"`
 }
 
 type YouTubeActivitiesCmd struct {
@@ -87,7 +89,9 @@ func (c *YouTubeActivitiesListCmd) Run(ctx context.Context, flags *RootFlags) er
 }
 
 type YouTubeVideosCmd struct {
-	List YouTubeVideosListCmd `cmd:"" name:"list" aliases:"ls" help:"List videos by ID, chart, or your rating"`
+	List   YouTubeVideosListCmd   `cmd:"" name:"list" aliases:"ls" help:"List videos by ID, chart, or your rating"`
+	Upload YouTubeVideosUploadCmd `cmd:"" name:"upload" help:"Upload a video (resumable; rerun to resume)"`
+	Update YouTubeVideosUpdateCmd `cmd:"" name:"update" help:"Update video title, description, tags, category, or privacy"`
 }
 
 type YouTubeVideosListCmd struct {
@@ -283,7 +287,7 @@ func (c *YouTubePlaylistsItemsListCmd) Run(ctx context.Context, flags *RootFlags
 		}
 		return youtubeItemsOrEmpty(resp.Items), resp.NextPageToken, nil
 	}
-	items, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	items, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
@@ -807,7 +811,7 @@ func (c *YouTubeSubscriptionsListCmd) Run(ctx context.Context, flags *RootFlags)
 		}
 		return youtubeItemsOrEmpty(resp.Items), resp.NextPageToken, nil
 	}
-	items, nextPageToken, err := loadPagedItems(c.Page, c.All, fetch)
+	items, nextPageToken, err := loadPagedItems(c.Page, c.All, streamPageItems(ctx, fetch))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,385 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/alecthomas/kong"
+	gapi "google.golang.org/api/googleapi"
+	youtube "google.golang.org/api/youtube/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+// youtubeThumbnailMaxBytes is the thumbnails.set upload limit.
+const youtubeThumbnailMaxBytes = 2 << 20
+
+type YouTubeVideosUpdateCmd struct {
+	VideoID     string   `arg:"" name:"videoId" help:"Video ID"`
+	Title       string   `name:"title" help:"New title"`
+	Description string   `name:"description" help:"New description"`
+	Tags        []string `name:"tags" help:"Replace tags (comma-separated; empty to clear)" sep:","`
+	Category    string   `name:"category" help:"Category ID"`
+	Privacy     string   `name:"privacy" help:"Privacy: public, unlisted, private" enum:"public,unlisted,private," default:""`
+	PublishAt   string   `name:"publish-at" help:"Scheduled publish time (RFC3339 or YYYY-MM-DD HH:MM); the video must be private"`
+	MadeForKids *bool    `name:"made-for-kids" help:"Declare whether the video is made for kids" negatable:""`
+}
+
+// Run fetches the video first: videos.update replaces whole parts, so unset
+// snippet fields (title and category are required) must be sent back as-is.
+func (c *YouTubeVideosUpdateCmd) Run(ctx context.Context, kctx *kong.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	videoID := strings.TrimSpace(c.VideoID)
+	if videoID == "" {
+		return usage("empty videoId")
+	}
+	snippetChanged := flagProvided(kctx, "title") || flagProvided(kctx, "description") ||
+		flagProvided(kctx, "tags") || flagProvided(kctx, "category")
+	statusChanged := c.Privacy != "" || flagProvided(kctx, "publish-at") || c.MadeForKids != nil
+	if !snippetChanged && !statusChanged {
+		return usage("no updates specified")
+	}
+	if flagProvided(kctx, "title") && strings.TrimSpace(c.Title) == "" {
+		return usage("--title cannot be empty")
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := getYouTubeWriteServiceForAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	resp, err := svc.Videos.List([]string{"snippet", "status"}).Id(videoID).Context(ctx).Do()
+	if err != nil {
+		return wrapYouTubeWriteError(err, flags)
+	}
+	if len(resp.Items) == 0 || resp.Items[0] == nil {
+		return usagef("video %s not found (or not yours)", videoID)
+	}
+	current := resp.Items[0]
+
+	video := &youtube.Video{Id: videoID}
+	var parts []string
+	if snippetChanged {
+		snippet := current.Snippet
+		if snippet == nil {
+			snippet = &youtube.VideoSnippet{}
+		}
+		// Read-only snippet fields are rejected on update.
+		video.Snippet = &youtube.VideoSnippet{
+			Title:                snippet.Title,
+			Description:          snippet.Description,
+			Tags:                 snippet.Tags,
+			CategoryId:           snippet.CategoryId,
+			DefaultLanguage:      snippet.DefaultLanguage,
+			DefaultAudioLanguage: snippet.DefaultAudioLanguage,
+		}
+		if flagProvided(kctx, "title") {
+			video.Snippet.Title = strings.TrimSpace(c.Title)
+		}
+		if flagProvided(kctx, "description") {
+			video.Snippet.Description = c.Description
+			video.Snippet.ForceSendFields = append(video.Snippet.ForceSendFields, "Description")
+		}
+		if flagProvided(kctx, "tags") {
+			video.Snippet.Tags = youtubeTags(c.Tags)
+			video.Snippet.ForceSendFields = append(video.Snippet.ForceSendFields, "Tags")
+		}
+		if flagProvided(kctx, "category") {
+			video.Snippet.CategoryId = strings.TrimSpace(c.Category)
+		}
+		parts = append(parts, "snippet")
+	}
+	if statusChanged {
+		status := current.Status
+		if status == nil {
+			status = &youtube.VideoStatus{}
+		}
+		video.Status = &youtube.VideoStatus{
+			PrivacyStatus:           firstNonEmpty(c.Privacy, status.PrivacyStatus),
+			PublishAt:               status.PublishAt,
+			Embeddable:              status.Embeddable,
+			License:                 status.License,
+			PublicStatsViewable:     status.PublicStatsViewable,
+			SelfDeclaredMadeForKids: status.SelfDeclaredMadeForKids,
+			ForceSendFields:         []string{"Embeddable", "PublicStatsViewable", "SelfDeclaredMadeForKids"},
+		}
+		if flagProvided(kctx, "publish-at") {
+			video.Status.PublishAt = ""
+			if strings.TrimSpace(c.PublishAt) != "" {
+				publishAt, publishErr := youtubePublishAt(c.PublishAt, video.Status.PrivacyStatus)
+				if publishErr != nil {
+					return publishErr
+				}
+				video.Status.PublishAt = publishAt
+			}
+		} else if video.Status.PrivacyStatus != "private" {
+			// Scheduling only applies to private videos.
+			video.Status.PublishAt = ""
+		}
+		if c.MadeForKids != nil {
+			video.Status.SelfDeclaredMadeForKids = *c.MadeForKids
+		}
+		parts = append(parts, "status")
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "youtube.videos.update", map[string]any{
+		"part":  parts,
+		"video": video,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	updated, err := svc.Videos.Update(parts, video).Context(ctx).Do()
+	if err != nil {
+		return wrapYouTubeWriteError(err, flags)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"video": updated})
+	}
+	u.Out().Linef("id\t%s", updated.Id)
+	if updated.Snippet != nil {
+		u.Out().Linef("title\t%s", updated.Snippet.Title)
+	}
+	if updated.Status != nil {
+		u.Out().Linef("privacy\t%s", updated.Status.PrivacyStatus)
+		if updated.Status.PublishAt != "" {
+			u.Out().Linef("publish_at\t%s", updated.Status.PublishAt)
+		}
+	}
+	return nil
+}
+
+type YouTubeThumbnailsCmd struct {
+	Set YouTubeThumbnailsSetCmd `cmd:"" name:"set" help:"Upload a custom thumbnail (JPEG or PNG, max 2 MB)"`
+}
+
+type YouTubeThumbnailsSetCmd struct {
+	VideoID string `arg:"" name:"videoId" help:"Video ID"`
+	Image   string `arg:"" name:"image" help:"Thumbnail image (JPEG or PNG, max 2 MB)" type:"existingfile"`
+}
+
+func (c *YouTubeThumbnailsSetCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	videoID := strings.TrimSpace(c.VideoID)
+	if videoID == "" {
+		return usage("empty videoId")
+	}
+	path, err := config.ExpandPath(strings.TrimSpace(c.Image))
+	if err != nil {
+		return err
+	}
+	contentType := ""
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".jpg", ".jpeg":
+		contentType = "image/jpeg"
+	case extPNG:
+		contentType = mimePNG
+	default:
+		return usagef("thumbnail must be a .jpg or .png file: %s", c.Image)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.Size() > youtubeThumbnailMaxBytes {
+		return usagef("thumbnail is %s; YouTube accepts at most 2 MB", formatDriveSize(info.Size()))
+	}
+
+	if dryRunErr := dryRunExit(ctx, flags, "youtube.thumbnails.set", map[string]any{
+		"video_id": videoID,
+		"image":    path,
+		"size":     info.Size(),
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := getYouTubeWriteServiceForAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	resp, err := svc.Thumbnails.Set(videoID).Media(file, gapi.ContentType(contentType)).Context(ctx).Do()
+	if err != nil {
+		return wrapYouTubeWriteError(err, flags)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"videoId": videoID, "thumbnails": resp.Items})
+	}
+	u.Out().Linef("video_id\t%s", videoID)
+	for _, set := range resp.Items {
+		if set != nil && set.Default != nil {
+			u.Out().Linef("default\t%s", set.Default.Url)
+		}
+	}
+	return nil
+}
+
+type YouTubeCaptionsCmd struct {
+	List     YouTubeCaptionsListCmd     `cmd:"" name:"list" aliases:"ls" help:"List caption tracks of a video"`
+	Upload   YouTubeCaptionsUploadCmd   `cmd:"" name:"upload" help:"Upload a caption track (SRT, VTT, SBV, ...)"`
+	Download YouTubeCaptionsDownloadCmd `cmd:"" name:"download" aliases:"get" help:"Download a caption track"`
+}
+
+type YouTubeCaptionsListCmd struct {
+	VideoID string `arg:"" name:"videoId" help:"Video ID"`
+}
+
+func (c *YouTubeCaptionsListCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	videoID := strings.TrimSpace(c.VideoID)
+	if videoID == "" {
+		return usage("empty videoId")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := getYouTubeWriteServiceForAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	resp, err := svc.Captions.List([]string{"snippet"}, videoID).Context(ctx).Do()
+	if err != nil {
+		return wrapYouTubeWriteError(err, flags)
+	}
+	items := youtubeItemsOrEmpty(resp.Items)
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"items": items})
+	}
+	if len(items) == 0 {
+		u.Err().Println("No captions")
+		return nil
+	}
+	return outfmt.WriteTable(ctx, stdoutWriter(ctx), compactYouTubeRows(items), youtubeCaptionColumns())
+}
+
+type YouTubeCaptionsUploadCmd struct {
+	VideoID  string `arg:"" name:"videoId" help:"Video ID"`
+	File     string `arg:"" name:"file" help:"Caption file" type:"existingfile"`
+	Language string `name:"language" short:"l" required:"" help:"BCP-47 language of the track (e.g. en, de, pt-BR)"`
+	Name     string `name:"name" help:"Track name shown to viewers"`
+	Draft    bool   `name:"draft" help:"Upload as a draft (not shown to viewers)"`
+}
+
+func (c *YouTubeCaptionsUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	videoID := strings.TrimSpace(c.VideoID)
+	language := strings.TrimSpace(c.Language)
+	if videoID == "" {
+		return usage("empty videoId")
+	}
+	if language == "" {
+		return usage("empty --language")
+	}
+	path, err := config.ExpandPath(strings.TrimSpace(c.File))
+	if err != nil {
+		return err
+	}
+	caption := &youtube.Caption{Snippet: &youtube.CaptionSnippet{
+		VideoId:  videoID,
+		Language: language,
+		Name:     strings.TrimSpace(c.Name),
+		IsDraft:  c.Draft,
+	}}
+
+	if dryRunErr := dryRunExit(ctx, flags, "youtube.captions.upload", map[string]any{
+		"file":    path,
+		"caption": caption,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := getYouTubeWriteServiceForAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	created, err := svc.Captions.Insert([]string{"snippet"}, caption).Media(file).Context(ctx).Do()
+	if err != nil {
+		return wrapYouTubeWriteError(err, flags)
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{"caption": created})
+	}
+	u.Out().Linef("id\t%s", created.Id)
+	u.Out().Linef("language\t%s", language)
+	return nil
+}
+
+type YouTubeCaptionsDownloadCmd struct {
+	CaptionID string `arg:"" name:"captionId" help:"Caption track ID (from captions list)"`
+	Format    string `name:"format" short:"f" help:"Convert to: srt, vtt, sbv, scc, ttml (default: the uploaded format)" enum:"srt,vtt,sbv,scc,ttml," default:""`
+	Language  string `name:"language" help:"Translate the track to this language"`
+	Out       string `name:"out" short:"o" help:"Output path, or - for stdout" default:"-"`
+}
+
+func (c *YouTubeCaptionsDownloadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	captionID := strings.TrimSpace(c.CaptionID)
+	if captionID == "" {
+		return usage("empty captionId")
+	}
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	svc, err := getYouTubeWriteServiceForAccount(ctx, account)
+	if err != nil {
+		return err
+	}
+	call := svc.Captions.Download(captionID).Context(ctx)
+	if c.Format != "" {
+		call = call.Tfmt(c.Format)
+	}
+	if lang := strings.TrimSpace(c.Language); lang != "" {
+		call = call.Tlang(lang)
+	}
+	resp, err := call.Download()
+	if err != nil {
+		return wrapYouTubeWriteError(err, flags)
+	}
+	defer resp.Body.Close()
+
+	outPath := strings.TrimSpace(c.Out)
+	if isStdoutPath(outPath) {
+		_, err = io.Copy(stdoutWriter(ctx), resp.Body)
+		return err
+	}
+	outPath, err = config.ExpandPath(outPath)
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outPath, data, 0o600); err != nil {
+		return err
+	}
+	ui.FromContext(ctx).Err().Linef("Saved caption %s to %s", captionID, outPath)
+	return nil
+}
@@ -276,3 +276,32 @@ func youtubeSearchResultID(result *youtube.SearchResult) string {
 		return ""
 	}
 }
+
+func youtubeCaptionColumns() []outfmt.Column[*youtube.Caption] {
+	snippet := func(caption *youtube.Caption, value func(*youtube.CaptionSnippet) string) string {
+		if caption.Snippet == nil {
+			return ""
+		}
+		return sanitizeTab(value(caption.Snippet))
+	}
+	return []outfmt.Column[*youtube.Caption]{
+		{Header: "ID", Value: func(caption *youtube.Caption) string { return caption.Id }},
+		{Header: "LANGUAGE", Value: func(caption *youtube.Caption) string {
+			return snippet(caption, func(s *youtube.CaptionSnippet) string { return s.Language })
+		}},
+		{Header: "NAME", Value: func(caption *youtube.Caption) string {
+			return snippet(caption, func(s *youtube.CaptionSnippet) string { return s.Name })
+		}},
+		{Header: "KIND", Value: func(caption *youtube.Caption) string {
+			return snippet(caption, func(s *youtube.CaptionSnippet) string { return s.TrackKind })
+		}},
+		{Header: "STATUS", Value: func(caption *youtube.Caption) string {
+			return snippet(caption, func(s *youtube.CaptionSnippet) string {
+				if s.IsDraft {
+					return fmt.Sprintf("%s (draft)", s.Status)
+				}
+				return s.Status
+			})
+		}},
+	}
+}
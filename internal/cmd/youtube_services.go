@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"net/http"
 
 	youtube "google.golang.org/api/youtube/v3"
 
@@ -55,3 +56,11 @@ func getYouTubeWriteServiceForAccount(ctx context.Context, account string) (*you
 	}
 	return runtime.Services.YouTubeWrite(ctx, account)
 }
+
+func getYouTubeUploadHTTPClient(ctx context.Context, account string) (*http.Client, error) {
+	runtime, err := runtimeWithService(ctx, "youtube upload")
+	if err != nil || runtime.Services.YouTubeUpload == nil {
+		return nil, serviceError(err, "youtube upload")
+	}
+	return runtime.Services.YouTubeUpload(ctx, account)
+}
@@ -0,0 +1,445 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	gapi "google.golang.org/api/googleapi"
+	youtube "google.golang.org/api/youtube/v3"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/outfmt"
+	"github.com/steipete/gogcli/internal/timeparse"
+	"github.com/steipete/gogcli/internal/ui"
+)
+
+const (
+	youtubeUploadBaseURLDefault = "https://www.googleapis.com/upload/youtube/v3"
+	// Resumable chunks must be multiples of 256 KiB (except the last one).
+	youtubeUploadChunkUnit = 256 << 10
+)
+
+var youtubeUploadBaseURL = youtubeUploadBaseURLDefault
+
+var errYouTubeUploadSessionExpired = errors.New("upload session expired")
+
+type YouTubeVideosUploadCmd struct {
+	File        string   `arg:"" name:"file" help:"Video file to upload" type:"existingfile"`
+	Title       string   `name:"title" help:"Video title (default: file name)"`
+	Description string   `name:"description" help:"Video description"`
+	Tags        []string `name:"tags" help:"Comma-separated tags" sep:","`
+	Category    string   `name:"category" help:"Category ID (e.g. 22 People & Blogs, 27 Education, 28 Science & Technology)"`
+	Privacy     string   `name:"privacy" help:"Privacy: public, unlisted, private" default:"private" enum:"public,unlisted,private"`
+	PublishAt   string   `name:"publish-at" help:"Scheduled publish time (RFC3339 or YYYY-MM-DD HH:MM); requires --privacy private"`
+	MadeForKids *bool    `name:"made-for-kids" help:"Declare whether the video is made for kids" negatable:""`
+	Playlist    string   `name:"playlist" help:"Playlist ID to add the uploaded video to"`
+	ChunkSize   int      `name:"chunk-size" help:"Upload chunk size in MiB" default:"8"`
+	Restart     bool     `name:"restart" help:"Ignore a saved upload session and start over"`
+}
+
+// youtubeUploadState is saved after the upload session starts so an
+// interrupted upload of the same file can continue where it stopped.
+type youtubeUploadState struct {
+	SessionURL string    `json:"session_url"`
+	File       string    `json:"file"`
+	Size       int64     `json:"size"`
+	ModTime    time.Time `json:"mod_time"`
+	Account    string    `json:"account"`
+	Title      string    `json:"title"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func (c *YouTubeVideosUploadCmd) Run(ctx context.Context, flags *RootFlags) error {
+	u := ui.FromContext(ctx)
+	path, err := config.ExpandPath(strings.TrimSpace(c.File))
+	if err != nil {
+		return err
+	}
+	if path, err = filepath.Abs(path); err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() || info.Size() == 0 {
+		return usagef("%s is not a video file", c.File)
+	}
+	if c.ChunkSize < 1 {
+		return usage("--chunk-size must be >= 1")
+	}
+	video, err := youtubeUploadMetadata(c, filepath.Base(path))
+	if err != nil {
+		return err
+	}
+	playlistID := strings.TrimSpace(c.Playlist)
+
+	if dryRunErr := dryRunExit(ctx, flags, "youtube.videos.upload", map[string]any{
+		"file":     path,
+		"size":     info.Size(),
+		"video":    video,
+		"playlist": playlistID,
+	}); dryRunErr != nil {
+		return dryRunErr
+	}
+
+	account, err := requireAccount(flags)
+	if err != nil {
+		return err
+	}
+	client, err := getYouTubeUploadHTTPClient(ctx, account)
+	if err != nil {
+		return err
+	}
+	statePath, err := youtubeUploadStatePath(ctx, account, path, info)
+	if err != nil {
+		return err
+	}
+
+	uploader := &youtubeUploader{
+		client:    client,
+		path:      path,
+		size:      info.Size(),
+		mimeType:  youtubeVideoMimeType(path),
+		chunkSize: int64(c.ChunkSize) << 20,
+		progress:  newUploadProgress(ctx, info.Size()),
+	}
+	state, resumed := loadYouTubeUploadState(statePath)
+	if c.Restart || state == nil || state.Size != info.Size() || !state.ModTime.Equal(info.ModTime()) {
+		state, resumed = nil, false
+	}
+	if state != nil {
+		u.Err().Linef("Resuming upload of %s", filepath.Base(path))
+	} else {
+		sessionURL, startErr := uploader.start(ctx, video)
+		if startErr != nil {
+			return wrapYouTubeWriteError(startErr, flags)
+		}
+		state = &youtubeUploadState{
+			SessionURL: sessionURL,
+			File:       path,
+			Size:       info.Size(),
+			ModTime:    info.ModTime(),
+			Account:    account,
+			Title:      video.Snippet.Title,
+			CreatedAt:  time.Now().UTC(),
+		}
+		if saveErr := saveYouTubeUploadState(statePath, state); saveErr != nil {
+			return saveErr
+		}
+	}
+
+	uploaded, err := uploader.upload(ctx, state.SessionURL, resumed)
+	if errors.Is(err, errYouTubeUploadSessionExpired) && resumed {
+		u.Err().Println("Saved upload session expired; starting over")
+		sessionURL, startErr := uploader.start(ctx, video)
+		if startErr != nil {
+			return wrapYouTubeWriteError(startErr, flags)
+		}
+		state.SessionURL = sessionURL
+		if saveErr := saveYouTubeUploadState(statePath, state); saveErr != nil {
+			return saveErr
+		}
+		uploaded, err = uploader.upload(ctx, state.SessionURL, false)
+	}
+	if err != nil {
+		if errors.Is(err, errYouTubeUploadSessionExpired) {
+			_ = os.Remove(statePath)
+		}
+		return fmt.Errorf("%w (run the same command again to resume)", wrapYouTubeWriteError(err, flags))
+	}
+	_ = os.Remove(statePath)
+
+	var playlistItemID string
+	if playlistID != "" {
+		svc, svcErr := getYouTubeWriteServiceForAccount(ctx, account)
+		if svcErr != nil {
+			return svcErr
+		}
+		item, insertErr := svc.PlaylistItems.Insert([]string{"snippet"}, &youtube.PlaylistItem{
+			Snippet: &youtube.PlaylistItemSnippet{
+				PlaylistId: playlistID,
+				ResourceId: &youtube.ResourceId{Kind: "youtube#video", VideoId: uploaded.Id},
+			},
+		}).Context(ctx).Do()
+		if insertErr != nil {
+			return fmt.Errorf("uploaded video %s but could not add it to playlist %s: %w", uploaded.Id, playlistID, wrapYouTubeWriteError(insertErr, flags))
+		}
+		playlistItemID = item.Id
+	}
+
+	if outfmt.IsJSON(ctx) {
+		return outfmt.WriteJSON(ctx, stdoutWriter(ctx), map[string]any{
+			"video":          uploaded,
+			"url":            youtubeWatchURL(uploaded.Id),
+			"playlistItemId": playlistItemID,
+		})
+	}
+	u.Out().Linef("id\t%s", uploaded.Id)
+	u.Out().Linef("url\t%s", youtubeWatchURL(uploaded.Id))
+	if uploaded.Status != nil {
+		u.Out().Linef("privacy\t%s", uploaded.Status.PrivacyStatus)
+		if uploaded.Status.PublishAt != "" {
+			u.Out().Linef("publish_at\t%s", uploaded.Status.PublishAt)
+		}
+	}
+	if playlistItemID != "" {
+		u.Out().Linef("playlist_item\t%s", playlistItemID)
+	}
+	return nil
+}
+
+func youtubeUploadMetadata(c *YouTubeVideosUploadCmd, fileName string) (*youtube.Video, error) {
+	title := strings.TrimSpace(c.Title)
+	if title == "" {
+		title = strings.TrimSuffix(fileName, filepath.Ext(fileName))
+	}
+	if len([]rune(title)) > 100 {
+		return nil, usage("--title must be at most 100 characters")
+	}
+	video := &youtube.Video{
+		Snippet: &youtube.VideoSnippet{
+			Title:       title,
+			Description: c.Description,
+			Tags:        youtubeTags(c.Tags),
+			CategoryId:  strings.TrimSpace(c.Category),
+		},
+		Status: &youtube.VideoStatus{PrivacyStatus: c.Privacy},
+	}
+	if c.PublishAt != "" {
+		publishAt, err := youtubePublishAt(c.PublishAt, c.Privacy)
+		if err != nil {
+			return nil, err
+		}
+		video.Status.PublishAt = publishAt
+	}
+	if c.MadeForKids != nil {
+		video.Status.SelfDeclaredMadeForKids = *c.MadeForKids
+		video.Status.ForceSendFields = []string{"SelfDeclaredMadeForKids"}
+	}
+	return video, nil
+}
+
+func youtubeTags(raw []string) []string {
+	var tags []string
+	for _, tag := range raw {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// youtubePublishAt validates a scheduled publish time. YouTube only honors
+// publishAt on private videos and rejects times in the past.
+func youtubePublishAt(value, privacy string) (string, error) {
+	if privacy != "private" {
+		return "", usage("--publish-at requires --privacy private (YouTube makes the video public at that time)")
+	}
+	parsed, err := timeparse.ParseDateTimeOrDate(value, time.Local)
+	if err != nil || !parsed.HasTime {
+		return "", usagef("invalid --publish-at %q (use RFC3339 or YYYY-MM-DD HH:MM)", value)
+	}
+	if !parsed.Time.After(time.Now()) {
+		return "", usagef("--publish-at %s is in the past", value)
+	}
+	return parsed.Time.UTC().Format(time.RFC3339), nil
+}
+
+func youtubeVideoMimeType(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mp4", ".m4v":
+		return "video/mp4"
+	case ".mov":
+		return "video/quicktime"
+	case ".webm":
+		return "video/webm"
+	case ".mkv":
+		return "video/x-matroska"
+	case ".avi":
+		return "video/x-msvideo"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+func youtubeWatchURL(id string) string {
+	return "https://www.youtube.com/watch?v=" + url.QueryEscape(id)
+}
+
+// youtubeUploadStatePath keys the saved session by account and file
+// identity, so editing the file or uploading as someone else starts over.
+func youtubeUploadStatePath(ctx context.Context, account, path string, info os.FileInfo) (string, error) {
+	layout, err := commandLayout(ctx, config.PathKindState)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		strings.ToLower(account), path, strconv.FormatInt(info.Size(), 10), info.ModTime().UTC().Format(time.RFC3339Nano),
+	}, "\x00")))
+	return filepath.Join(layout.YouTubeUploadsDir(), hex.EncodeToString(sum[:8])+".json"), nil
+}
+
+func loadYouTubeUploadState(path string) (*youtubeUploadState, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	var state youtubeUploadState
+	if json.Unmarshal(data, &state) != nil || state.SessionURL == "" {
+		return nil, false
+	}
+	return &state, true
+}
+
+func saveYouTubeUploadState(path string, state *youtubeUploadState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create upload state dir: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return config.WriteFileAtomic(path, data, 0o600)
+}
+
+type youtubeUploader struct {
+	client    *http.Client
+	path      string
+	size      int64
+	mimeType  string
+	chunkSize int64
+	progress  *driveUploadProgressReader
+}
+
+// start opens a resumable upload session and returns its URI.
+func (up *youtubeUploader) start(ctx context.Context, video *youtube.Video) (string, error) {
+	body, err := json.Marshal(video)
+	if err != nil {
+		return "", err
+	}
+	endpoint := youtubeUploadBaseURL + "/videos?uploadType=resumable&part=" + url.QueryEscape("snippet,status")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=UTF-8")
+	req.Header.Set("X-Upload-Content-Length", strconv.FormatInt(up.size, 10))
+	req.Header.Set("X-Upload-Content-Type", up.mimeType)
+	resp, err := up.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("start upload: %w", err)
+	}
+	defer resp.Body.Close()
+	if err := gapi.CheckResponse(resp); err != nil {
+		return "", err
+	}
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return "", errors.New("start upload: response has no session URI")
+	}
+	return location, nil
+}
+
+// upload sends the file in chunks. A resumed session first asks the server
+// for its offset so bytes already stored are not sent again.
+func (up *youtubeUploader) upload(ctx context.Context, sessionURL string, resume bool) (*youtube.Video, error) {
+	var offset int64
+	if resume {
+		next, video, err := up.put(ctx, sessionURL, -1, nil)
+		if err != nil || video != nil {
+			return video, err
+		}
+		offset = next
+	}
+	chunkSize := max(up.chunkSize/youtubeUploadChunkUnit, 1) * youtubeUploadChunkUnit
+
+	file, err := os.Open(up.path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	buf := make([]byte, chunkSize)
+	for {
+		if offset >= up.size {
+			return nil, errors.New("upload: server has every byte but did not finish the video")
+		}
+		up.progress.advanceTo(offset)
+		n, readErr := file.ReadAt(buf[:min(chunkSize, up.size-offset)], offset)
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return nil, readErr
+		}
+		next, video, err := up.put(ctx, sessionURL, offset, buf[:n])
+		offset = next
+		if err != nil || video != nil {
+			if video != nil {
+				up.progress.advanceTo(up.size)
+			}
+			return video, err
+		}
+	}
+}
+
+// put sends one chunk starting at offset, or a status query when offset is
+// negative. It returns the next offset, or the video once the upload is done.
+func (up *youtubeUploader) put(ctx context.Context, sessionURL string, offset int64, chunk []byte) (int64, *youtube.Video, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, sessionURL, bytes.NewReader(chunk))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.ContentLength = int64(len(chunk))
+	if offset < 0 {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes */%d", up.size))
+	} else {
+		req.Header.Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, offset+int64(len(chunk))-1, up.size))
+	}
+	resp, err := up.client.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusPermanentRedirect:
+		return youtubeUploadNextOffset(resp.Header.Get("Range")), nil, nil
+	case http.StatusOK, http.StatusCreated:
+		var video youtube.Video
+		if err := json.NewDecoder(resp.Body).Decode(&video); err != nil {
+			return 0, nil, fmt.Errorf("decode uploaded video: %w", err)
+		}
+		return 0, &video, nil
+	case http.StatusNotFound, http.StatusGone:
+		return 0, nil, errYouTubeUploadSessionExpired
+	}
+	if err := gapi.CheckResponse(resp); err != nil {
+		return 0, nil, err
+	}
+	return 0, nil, fmt.Errorf("upload: unexpected status %s", resp.Status)
+}
+
+// youtubeUploadNextOffset parses "bytes=0-N" from a 308 response. No Range
+// header means nothing has been stored yet.
+func youtubeUploadNextOffset(rangeHeader string) int64 {
+	_, last, ok := strings.Cut(strings.TrimPrefix(rangeHeader, "bytes="), "-")
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(last), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n + 1
+}
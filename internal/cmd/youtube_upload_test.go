@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	youtube "google.golang.org/api/youtube/v3"
+
+	"github.com/steipete/gogcli/internal/app"
+	"github.com/steipete/gogcli/internal/config"
+)
+
+func TestYouTubeUploadMetadata(t *testing.T) {
+	kids := false
+	video, err := youtubeUploadMetadata(&YouTubeVideosUploadCmd{
+		Tags:        []string{" go ", "", "talks"},
+		Privacy:     "private",
+		PublishAt:   time.Now().Add(48 * time.Hour).UTC().Format(time.RFC3339),
+		MadeForKids: &kids,
+	}, "GopherCon Keynote.mp4")
+	if err != nil {
+		t.Fatalf("metadata: %v", err)
+	}
+	if video.Snippet.Title != "GopherCon Keynote" || strings.Join(video.Snippet.Tags, ",") != "go,talks" {
+		t.Fatalf("snippet = %#v", video.Snippet)
+	}
+	if video.Status.PublishAt == "" || len(video.Status.ForceSendFields) != 1 {
+		t.Fatalf("status = %#v", video.Status)
+	}
+
+	if _, err := youtubeUploadMetadata(&YouTubeVideosUploadCmd{Privacy: "public", PublishAt: "2099-01-01T10:00:00Z"}, "a.mp4"); err == nil || !strings.Contains(err.Error(), "requires --privacy private") {
+		t.Fatalf("expected privacy error, got %v", err)
+	}
+	if _, err := youtubeUploadMetadata(&YouTubeVideosUploadCmd{Privacy: "private", PublishAt: "2001-01-01T10:00:00Z"}, "a.mp4"); err == nil || !strings.Contains(err.Error(), "in the past") {
+		t.Fatalf("expected past error, got %v", err)
+	}
+	if got := youtubeUploadNextOffset("bytes=0-1048575"); got != 1<<20 {
+		t.Fatalf("next offset = %d", got)
+	}
+	if got := youtubeUploadNextOffset(""); got != 0 {
+		t.Fatalf("next offset without range = %d", got)
+	}
+}
+
+func TestYouTubeVideosUpload_ResumesAfterInterrupt(t *testing.T) {
+	data := bytes.Repeat([]byte("0123456789abcdef"), (5<<20)/32) // 2.5 MiB: three 1 MiB chunks
+	videoPath := filepath.Join(t.TempDir(), "talk.mp4")
+	if err := os.WriteFile(videoPath, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	var received bytes.Buffer
+	var starts, chunkPuts int
+	var metadata youtube.Video
+	failChunk := 2
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/videos":
+			starts++
+			if r.URL.Query().Get("uploadType") != "resumable" || r.Header.Get("X-Upload-Content-Length") != fmt.Sprint(len(data)) ||
+				r.Header.Get("X-Upload-Content-Type") != "video/mp4" {
+				t.Errorf("start request %s headers=%v", r.URL.String(), r.Header)
+			}
+			_ = json.NewDecoder(r.Body).Decode(&metadata)
+			w.Header().Set("Location", "http://"+r.Host+"/session/1")
+			w.WriteHeader(http.StatusOK)
+		case r.Method == http.MethodPut && r.URL.Path == "/session/1":
+			contentRange := r.Header.Get("Content-Range")
+			body, _ := io.ReadAll(r.Body)
+			if strings.HasPrefix(contentRange, "bytes */") {
+				w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", received.Len()-1))
+				w.WriteHeader(http.StatusPermanentRedirect)
+				return
+			}
+			chunkPuts++
+			if chunkPuts == failChunk {
+				http.Error(w, `{"error":{"code":503,"message":"backend error"}}`, http.StatusServiceUnavailable)
+				return
+			}
+			var first, last, total int
+			if _, err := fmt.Sscanf(contentRange, "bytes %d-%d/%d", &first, &last, &total); err != nil || first != received.Len() || last-first+1 != len(body) {
+				t.Errorf("content-range %q with %d bytes after %d", contentRange, len(body), received.Len())
+			}
+			received.Write(body)
+			if received.Len() < len(data) {
+				w.Header().Set("Range", fmt.Sprintf("bytes=0-%d", received.Len()-1))
+				w.WriteHeader(http.StatusPermanentRedirect)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": "vid123", "status": map[string]any{"privacyStatus": "unlisted"}})
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	origBase := youtubeUploadBaseURL
+	youtubeUploadBaseURL = srv.URL
+	t.Cleanup(func() { youtubeUploadBaseURL = origBase })
+
+	stateDir := t.TempDir()
+	runtime := &app.Runtime{
+		Layout: config.Layout{StateDir: stateDir, ExplicitState: true},
+		Services: app.Services{
+			YouTubeUpload: func(context.Context, string) (*http.Client, error) { return srv.Client(), nil },
+		},
+	}
+	args := []string{"--account", "me@example.com", "--json", "youtube", "videos", "upload", videoPath,
+		"--title", "Keynote", "--tags", "go,talks", "--privacy", "unlisted", "--chunk-size", "1"}
+
+	first := executeWithTestRuntime(t, args, runtime)
+	if first.err == nil || !strings.Contains(first.err.Error(), "run the same command again to resume") {
+		t.Fatalf("first run err = %v", first.err)
+	}
+	states, _ := filepath.Glob(filepath.Join(stateDir, "youtube-uploads", "*.json"))
+	if len(states) != 1 {
+		t.Fatalf("saved states = %v", states)
+	}
+	if metadata.Snippet == nil || metadata.Snippet.Title != "Keynote" || metadata.Status.PrivacyStatus != "unlisted" {
+		t.Fatalf("metadata = %#v", metadata)
+	}
+
+	second := executeWithTestRuntime(t, args, runtime)
+	if second.err != nil {
+		t.Fatalf("resume: %v\nstderr=%s", second.err, second.stderr)
+	}
+	if starts != 1 {
+		t.Fatalf("upload sessions started = %d, want 1", starts)
+	}
+	if !bytes.Equal(received.Bytes(), data) {
+		t.Fatalf("received %d bytes, want %d", received.Len(), len(data))
+	}
+	if !strings.Contains(second.stdout, `"url": "https://www.youtube.com/watch?v=vid123"`) {
+		t.Fatalf("stdout = %s", second.stdout)
+	}
+	if states, _ := filepath.Glob(filepath.Join(stateDir, "youtube-uploads", "*.json")); len(states) != 0 {
+		t.Fatalf("state not cleaned up: %v", states)
+	}
+}
+
+func TestYouTubeVideosUpdate_KeepsUnchangedSnippetFields(t *testing.T) {
+	var updated map[string]any
+	var part string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			_ = json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{{
+				"id":      "vid123",
+				"snippet": map[string]any{"title": "Old", "description": "Keep me", "categoryId": "28", "channelId": "read-only"},
+				"status":  map[string]any{"privacyStatus": "private", "embeddable": true},
+			}}})
+		case http.MethodPut:
+			part = r.URL.Query().Get("part")
+			_ = json.NewDecoder(r.Body).Decode(&updated)
+			_ = json.NewEncoder(w).Encode(updated)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	svc := newGoogleTestServiceWithEndpoint(t, srv.Client(), srv.URL+"/", youtube.NewService)
+	result := executeWithTestRuntime(t, []string{
+		"--account", "me@example.com", "--json", "youtube", "videos", "update", "vid123", "--title", "New", "--tags", "",
+	}, &app.Runtime{Services: app.Services{YouTubeWrite: fixedYouTubeTestService(svc)}})
+	if result.err != nil {
+		t.Fatalf("update: %v", result.err)
+	}
+	if part != "snippet" {
+		t.Fatalf("part = %q", part)
+	}
+	snippet, _ := updated["snippet"].(map[string]any)
+	if snippet["title"] != "New" || snippet["description"] != "Keep me" || snippet["categoryId"] != "28" {
+		t.Fatalf("snippet = %#v", snippet)
+	}
+	if _, ok := snippet["channelId"]; ok {
+		t.Fatalf("read-only field sent: %#v", snippet)
+	}
+	if tags, ok := snippet["tags"].([]any); !ok || len(tags) != 0 {
+		t.Fatalf("tags should be cleared: %#v", snippet["tags"])
+	}
+}
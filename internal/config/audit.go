@@ -0,0 +1,16 @@
+package config
+
+// AuditConfig enables the audit log of mutating Google API requests.
+type AuditConfig struct {
+	// Enabled writes audit.jsonl in the state directory unless File is set.
+	Enabled bool   `json:"enabled,omitempty"`
+	File    string `json:"file,omitempty"`
+	// Syslog also sends each entry to the local syslog daemon.
+	Syslog    bool   `json:"syslog,omitempty"`
+	SyslogTag string `json:"syslog_tag,omitempty"`
+	// HTTPURL also POSTs each entry as JSON to a collector.
+	HTTPURL     string            `json:"http_url,omitempty"`
+	HTTPHeaders map[string]string `json:"http_headers,omitempty"`
+	// Bodies records JSON request bodies with credential fields redacted.
+	Bodies bool `json:"bodies,omitempty"`
+}
@@ -0,0 +1,19 @@
+package config
+
+// BrokerConfig is the `gog auth broker serve` caller policy.
+type BrokerConfig struct {
+	// TTL is the default token lifetime handed to callers, e.g. "10m".
+	TTL     string         `json:"ttl,omitempty"`
+	Callers []BrokerCaller `json:"callers,omitempty"`
+}
+
+// BrokerCaller limits what one unix user may request from the broker. Empty
+// lists allow everything; UID nil matches any user.
+type BrokerCaller struct {
+	Name     string   `json:"name,omitempty"`
+	UID      *int     `json:"uid,omitempty"`
+	Accounts []string `json:"accounts,omitempty"`
+	Services []string `json:"services,omitempty"`
+	Scopes   []string `json:"scopes,omitempty"`
+	TTL      string   `json:"ttl,omitempty"`
+}
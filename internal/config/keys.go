@@ -14,6 +14,7 @@ const (
 	KeyTimezone       Key = "timezone"
 	KeyKeyringBackend Key = "keyring_backend"
 	KeyGmailNoSend    Key = "gmail_no_send"
+	KeyNoUndo         Key = "no_undo"
 	KeyYoutubeAPIKey  Key = "youtube_api_key"
 	KeyPlacesAPIKey   Key = "places_api_key"
 )
@@ -30,6 +31,7 @@ var keyOrder = []Key{
 	KeyTimezone,
 	KeyKeyringBackend,
 	KeyGmailNoSend,
+	KeyNoUndo,
 	KeyYoutubeAPIKey,
 	KeyPlacesAPIKey,
 }
@@ -93,6 +95,27 @@ var keySpecs = map[Key]KeySpec{
 			return "false"
 		},
 	},
+	KeyNoUndo: {
+		Key: KeyNoUndo,
+		Get: func(cfg File) string {
+			return boolConfigString(cfg.NoUndo)
+		},
+		Set: func(cfg *File, value string) error {
+			parsed, err := parseConfigBool(value)
+			if err != nil {
+				return err
+			}
+			cfg.NoUndo = parsed
+
+			return nil
+		},
+		Unset: func(cfg *File) {
+			cfg.NoUndo = false
+		},
+		EmptyHint: func() string {
+			return "false"
+		},
+	},
 	KeyYoutubeAPIKey: {
 		Key: KeyYoutubeAPIKey,
 		Get: func(cfg File) string {
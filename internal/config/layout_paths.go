@@ -16,6 +16,14 @@ func (l Layout) BatchDir() string {
 	return filepath.Join(l.StateDir, "batches")
 }
 
+func (l Layout) YouTubeUploadsDir() string {
+	return filepath.Join(l.StateDir, "youtube-uploads")
+}
+
+func (l Layout) PhotosUploadsDir() string {
+	return filepath.Join(l.StateDir, "photos-uploads")
+}
+
 func (l Layout) PrimaryKeyringDir() string {
 	return filepath.Join(l.DataDir, "keyring")
 }
@@ -0,0 +1,24 @@
+package config
+
+import "strings"
+
+// RateLimitDefault is the rate_limits entry used for services without their own.
+const RateLimitDefault = "default"
+
+// RateLimit throttles calls to one Google API service per account. Zero
+// fields are unlimited.
+type RateLimit struct {
+	QPS   float64 `json:"qps,omitempty"`
+	Burst int     `json:"burst,omitempty"`
+	Daily int64   `json:"daily,omitempty"`
+}
+
+// RateLimitFor returns the rate_limits entry for service, falling back to
+// the "default" entry.
+func (f File) RateLimitFor(service string) RateLimit {
+	if limit, ok := f.RateLimits[strings.ToLower(strings.TrimSpace(service))]; ok {
+		return limit
+	}
+
+	return f.RateLimits[RateLimitDefault]
+}
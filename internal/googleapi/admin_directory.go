@@ -3,12 +3,16 @@ package googleapi
 import (
 	"context"
 
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
 	admin "google.golang.org/api/admin/directory/v1"
 
 	"github.com/steipete/gogcli/internal/googleauth"
 )
 
-var adminOrgUnitScopes = []string{"https://www.googleapis.com/auth/admin.directory.orgunit"}
+var (
+	adminOrgUnitScopes      = []string{"https://www.googleapis.com/auth/admin.directory.orgunit"}
+	adminDataTransferScopes = []string{datatransfer.AdminDatatransferScope}
+)
 
 // NewAdminDirectory creates an Admin SDK Directory service for user and group management.
 // This API requires domain-wide delegation with a service account to manage Workspace users.
@@ -21,3 +25,9 @@ func NewAdminDirectory(ctx context.Context, email string) (*admin.Service, error
 func NewAdminDirectoryOrgUnit(ctx context.Context, email string) (*admin.Service, error) {
 	return newGoogleServiceForScopes(ctx, email, "admin orgunits", "admin orgunits", adminOrgUnitScopes, admin.NewService)
 }
+
+// NewAdminDataTransfer creates an Admin SDK Data Transfer service with only the
+// data-transfer scope, used to move a user's Drive files to another owner.
+func NewAdminDataTransfer(ctx context.Context, email string) (*datatransfer.Service, error) {
+	return newGoogleServiceForScopes(ctx, email, "admin data transfer", "admin data transfer", adminDataTransferScopes, datatransfer.NewService)
+}
@@ -0,0 +1,292 @@
+package googleapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/audit"
+)
+
+// maxAuditBodyBytes bounds the JSON request bodies copied into audit entries
+// when bodies are enabled; larger bodies (uploads) are only counted.
+const maxAuditBodyBytes = 64 << 10
+
+type auditContextKey struct{}
+
+// WithAudit records mutating requests made by clients created with ctx.
+func WithAudit(ctx context.Context, logger *audit.Logger) context.Context {
+	if logger == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, auditContextKey{}, logger)
+}
+
+func auditLoggerFromContext(ctx context.Context) *audit.Logger {
+	if ctx == nil {
+		return nil
+	}
+
+	logger, _ := ctx.Value(auditContextKey{}).(*audit.Logger)
+
+	return logger
+}
+
+// auditedTransport sits above RetryTransport, so one entry describes the
+// final outcome of a call rather than each attempt.
+func auditedTransport(ctx context.Context, serviceLabel, email string, base http.RoundTripper) http.RoundTripper {
+	logger := auditLoggerFromContext(ctx)
+	if logger == nil {
+		return base
+	}
+
+	return &auditTransport{base: base, logger: logger, service: serviceLabel, account: email}
+}
+
+type auditTransport struct {
+	base    http.RoundTripper
+	logger  *audit.Logger
+	service string
+	account string
+}
+
+func (t *auditTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if ReadOnlyRequestAllowed(req) || !t.logger.Enabled() {
+		return t.base.RoundTrip(req)
+	}
+
+	bodies := t.logger.Bodies()
+	body := auditRequestBody(req, isBatchRequest(req) || bodies)
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	elapsed := time.Since(start).Milliseconds()
+
+	if isBatchRequest(req) && err == nil && body != nil {
+		if entries, ok := t.batchEntries(req, body, resp, bodies); ok {
+			for _, entry := range entries {
+				entry.DurationMS = elapsed
+				t.logger.Record(req.Context(), entry)
+			}
+			return resp, nil
+		}
+	}
+
+	entry := t.entry(req.Method, req.URL, req.ContentLength, req.Header.Get("Content-Type"), body, bodies)
+	entry.DurationMS = elapsed
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.Status = resp.StatusCode
+		entry.RequestID = googleRequestID(resp.Header)
+	}
+	t.logger.Record(req.Context(), entry)
+
+	return resp, err
+}
+
+func (t *auditTransport) entry(method string, u *url.URL, size int64, contentType string, body []byte, bodies bool) audit.Entry {
+	apiMethod, resources := audit.DescribePath(u.Path)
+	entry := audit.Entry{
+		Account:   t.account,
+		Service:   t.service,
+		Method:    method,
+		APIMethod: apiMethod,
+		URL:       auditURL(u),
+		Resources: resources,
+	}
+	if size > 0 {
+		entry.RequestBytes = int(size)
+	} else {
+		entry.RequestBytes = len(body)
+	}
+	if bodies && len(body) > 0 && len(body) <= maxAuditBodyBytes && strings.Contains(contentType, "json") {
+		entry.Body = redactAuditBody(body)
+	}
+
+	return entry
+}
+
+// batchEntries expands a multipart batch into one entry per mutating
+// sub-request, paired with its response part by Content-ID. The response
+// body is buffered and restored for the caller.
+func (t *auditTransport) batchEntries(req *http.Request, body []byte, resp *http.Response, bodies bool) ([]audit.Entry, bool) {
+	requests, ok := parseBatchRequests(req, body)
+	if !ok {
+		return nil, false
+	}
+
+	responses := map[int]*http.Response{}
+	if data, readErr := io.ReadAll(io.LimitReader(resp.Body, maxBatchResponseBytes)); readErr == nil {
+		_ = resp.Body.Close()
+		resp.Body = io.NopCloser(bytes.NewReader(data))
+		responses = parseBatchResponses(resp.Header.Get("Content-Type"), data)
+	}
+
+	var entries []audit.Entry
+	for index, sub := range requests {
+		if ReadOnlyRequestAllowed(sub.req) {
+			continue
+		}
+		entry := t.entry(sub.req.Method, sub.req.URL, int64(len(sub.body)), sub.req.Header.Get("Content-Type"), sub.body, bodies)
+		entry.Batch = true
+		if part, ok := responses[index]; ok {
+			entry.Status = part.StatusCode
+			entry.RequestID = googleRequestID(part.Header)
+		} else {
+			entry.Status = resp.StatusCode
+			entry.RequestID = googleRequestID(resp.Header)
+			if resp.StatusCode < 300 {
+				entry.Error = "batch response has no part for this call"
+			}
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, true
+}
+
+type batchSubRequest struct {
+	req  *http.Request
+	body []byte
+}
+
+func isBatchRequest(req *http.Request) bool {
+	return req.Method == http.MethodPost && req.URL != nil &&
+		strings.HasPrefix(req.URL.Path, "/batch") &&
+		strings.HasPrefix(req.Header.Get("Content-Type"), "multipart/")
+}
+
+func parseBatchRequests(req *http.Request, body []byte) ([]batchSubRequest, bool) {
+	_, params, err := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if err != nil {
+		return nil, false
+	}
+
+	var out []batchSubRequest
+	mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			return out, true
+		}
+		if err != nil {
+			return nil, false
+		}
+		index, ok := batchPartIndex(part.Header.Get("Content-ID"), batchContentIDPrefix)
+		if !ok || index != len(out) {
+			return nil, false
+		}
+		sub, err := http.ReadRequest(bufio.NewReader(part))
+		if err != nil {
+			return nil, false
+		}
+		subBody, _ := io.ReadAll(sub.Body)
+		sub.URL.Scheme = req.URL.Scheme
+		sub.URL.Host = req.URL.Host
+		out = append(out, batchSubRequest{req: sub, body: subBody})
+	}
+}
+
+func parseBatchResponses(contentType string, data []byte) map[int]*http.Response {
+	out := map[int]*http.Response{}
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return out
+	}
+
+	mr := multipart.NewReader(bytes.NewReader(data), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			return out
+		}
+		index, ok := batchPartIndex(part.Header.Get("Content-ID"), batchResponseIDPrefix)
+		if !ok {
+			continue
+		}
+		if resp, err := http.ReadResponse(bufio.NewReader(part), nil); err == nil {
+			_ = resp.Body.Close()
+			out[index] = resp
+		}
+	}
+}
+
+func batchPartIndex(contentID, prefix string) (int, bool) {
+	id, ok := strings.CutPrefix(strings.Trim(strings.TrimSpace(contentID), "<>"), prefix)
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(id)
+	if err != nil || index < 0 {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// auditRequestBody copies a replayable request body without consuming it.
+func auditRequestBody(req *http.Request, want bool) []byte {
+	if !want || req.GetBody == nil || req.ContentLength > maxBatchResponseBytes {
+		return nil
+	}
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil
+	}
+
+	return body
+}
+
+// redactAuditBody keeps JSON bodies with credential fields masked and drops
+// anything that is not JSON.
+func redactAuditBody(body []byte) json.RawMessage {
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+	out, err := json.Marshal(redactJSONFields(v, DefaultCassetteRules().JSONFields))
+	if err != nil {
+		return nil
+	}
+
+	return out
+}
+
+// auditURL drops credential query parameters and the transport-only alt and
+// prettyPrint parameters.
+func auditURL(u *url.URL) string {
+	clean := *u
+	clean.User = nil
+	query := clean.Query()
+	for _, key := range DefaultCassetteRules().Query {
+		if query.Has(key) {
+			query.Set(key, cassetteRedacted)
+		}
+	}
+	query.Del("alt")
+	query.Del("prettyPrint")
+	clean.RawQuery = query.Encode()
+
+	return clean.String()
+}
+
+func googleRequestID(header http.Header) string {
+	for _, key := range []string{"X-Goog-Request-Id", "X-Request-Id", "X-Guploader-Uploadid"} {
+		if id := header.Get(key); id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
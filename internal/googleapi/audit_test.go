@@ -0,0 +1,175 @@
+package googleapi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/audit"
+)
+
+type memorySink struct {
+	mu      sync.Mutex
+	entries []audit.Entry
+}
+
+func (s *memorySink) Write(_ context.Context, entry audit.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func auditContext(sink audit.Sink, bodies bool) context.Context {
+	return WithAudit(context.Background(), audit.New("drive permissions delete", func() (audit.Options, error) {
+		return audit.Options{Sinks: []audit.Sink{sink}, Bodies: bodies}, nil
+	}))
+}
+
+func TestAuditedTransport_RecordsMutatingRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Goog-Request-Id", "req-"+r.Method)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	sink := &memorySink{}
+	ctx := auditContext(sink, false)
+	client := &http.Client{Transport: auditedTransport(ctx, "drive", "a@b.com", srv.Client().Transport)}
+
+	get, _ := http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/drive/v3/files/F1", nil)
+	del, _ := http.NewRequestWithContext(ctx, http.MethodDelete, srv.URL+"/drive/v3/files/F1/permissions/P1?key=secret&alt=json", nil)
+	for _, req := range []*http.Request{get, del} {
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", req.Method, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	if len(sink.entries) != 1 {
+		t.Fatalf("entries = %+v", sink.entries)
+	}
+	got := sink.entries[0]
+	if got.Command != "drive permissions delete" || got.Account != "a@b.com" || got.Service != "drive" ||
+		got.Method != http.MethodDelete || got.APIMethod != "drive/v3/files/{id}/permissions/{id}" ||
+		strings.Join(got.Resources, ",") != "files/F1,permissions/P1" ||
+		got.Status != http.StatusNoContent || got.RequestID != "req-DELETE" {
+		t.Fatalf("entry = %+v", got)
+	}
+	if strings.Contains(got.URL, "secret") || strings.Contains(got.URL, "alt=") || !strings.Contains(got.URL, "key=REDACTED") {
+		t.Fatalf("url = %q", got.URL)
+	}
+}
+
+func TestAuditedTransport_BodiesAreOptInAndRedacted(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), "hunter2") {
+			t.Errorf("server body = %s", body)
+		}
+	}))
+	defer srv.Close()
+
+	for _, bodies := range []bool{false, true} {
+		sink := &memorySink{}
+		ctx := auditContext(sink, bodies)
+		client := &http.Client{Transport: auditedTransport(ctx, "admin", "a@b.com", srv.Client().Transport)}
+		req, _ := http.NewRequestWithContext(ctx, http.MethodPost, srv.URL+"/admin/directory/v1/users",
+			strings.NewReader(`{"primaryEmail":"x@b.com","password":"hunter2"}`))
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+
+		got := sink.entries[0]
+		if got.RequestBytes == 0 {
+			t.Fatalf("request bytes not recorded: %+v", got)
+		}
+		switch {
+		case !bodies && got.Body != nil:
+			t.Fatalf("body recorded by default: %s", got.Body)
+		case bodies && (!strings.Contains(string(got.Body), `"password":"REDACTED"`) || !strings.Contains(string(got.Body), "x@b.com")):
+			t.Fatalf("body = %s", got.Body)
+		}
+	}
+}
+
+func TestAuditedTransport_ExpandsBatchSubRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, params, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		out := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+out.Boundary())
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Errorf("next part: %v", err)
+				return
+			}
+			req, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				t.Errorf("read sub-request: %v", err)
+				return
+			}
+			status := http.StatusOK
+			if strings.HasSuffix(req.URL.Path, "/missing") {
+				status = http.StatusNotFound
+			}
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Type", "application/http")
+			header.Set("Content-ID", "<response-"+strings.Trim(part.Header.Get("Content-ID"), "<>")+">")
+			pw, _ := out.CreatePart(header)
+			fmt.Fprintf(pw, "HTTP/1.1 %d %s\r\nX-Goog-Request-Id: sub-%s\r\nContent-Type: application/json\r\n\r\n{}", status, http.StatusText(status), req.Method)
+		}
+		_ = out.Close()
+	}))
+	defer srv.Close()
+
+	sink := &memorySink{}
+	ctx := auditContext(sink, false)
+	client := &http.Client{Transport: auditedTransport(ctx, "drive", "a@b.com", srv.Client().Transport)}
+	b, err := NewBatch(client, srv.URL+"/drive/v3/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.Retries = 0
+
+	results, err := b.Do(ctx, []BatchCall{
+		{Method: http.MethodPatch, Path: "files/a", Body: map[string]string{"name": "x"}},
+		{Method: http.MethodGet, Path: "files/b"},
+		{Method: http.MethodDelete, Path: "files/missing"},
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if results[0].Err != nil || results[0].Status != http.StatusOK {
+		t.Fatalf("batch results not passed through: %+v", results[0])
+	}
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("entries = %+v", sink.entries)
+	}
+	patch, del := sink.entries[0], sink.entries[1]
+	if !patch.Batch || patch.Method != http.MethodPatch || patch.Resources[0] != "files/a" || patch.Status != http.StatusOK || patch.RequestID != "sub-PATCH" {
+		t.Fatalf("patch entry = %+v", patch)
+	}
+	if del.Method != http.MethodDelete || del.Status != http.StatusNotFound || del.RequestID != "sub-DELETE" {
+		t.Fatalf("delete entry = %+v", del)
+	}
+}
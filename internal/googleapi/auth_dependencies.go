@@ -2,8 +2,11 @@ package googleapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
 
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
@@ -19,12 +22,17 @@ type AuthMode string
 const (
 	AuthModeStored AuthMode = ""
 	AuthModeADC    AuthMode = "adc"
+	// AuthModeExternalAccount authenticates with an external_account
+	// (workload identity federation) credential file instead of a stored
+	// refresh token.
+	AuthModeExternalAccount AuthMode = "external-account"
 )
 
 type (
-	ServiceAccountStoreResolver   func() (*config.ServiceAccountStore, error)
-	ADCTokenSourceFunc            func(context.Context, ...string) (oauth2.TokenSource, error)
-	ServiceAccountTokenSourceFunc func(context.Context, []byte, string, []string) (oauth2.TokenSource, error)
+	ServiceAccountStoreResolver    func() (*config.ServiceAccountStore, error)
+	ADCTokenSourceFunc             func(context.Context, ...string) (oauth2.TokenSource, error)
+	ServiceAccountTokenSourceFunc  func(context.Context, []byte, string, []string) (oauth2.TokenSource, error)
+	ExternalAccountTokenSourceFunc func(context.Context, string, ...string) (oauth2.TokenSource, error)
 )
 
 type AuthDependencies struct {
@@ -36,27 +44,46 @@ type AuthDependencies struct {
 	Mode                      AuthMode
 	ADCTokenSource            ADCTokenSourceFunc
 	ServiceAccountTokenSource ServiceAccountTokenSourceFunc
+	// ExternalAccountFile is the external_account credential JSON used in
+	// AuthModeExternalAccount.
+	ExternalAccountFile        string
+	ExternalAccountTokenSource ExternalAccountTokenSourceFunc
+	// Broker, when set, replaces stored refresh tokens and service account
+	// keys with tokens minted by a credential broker.
+	Broker BrokerTokenSourceFunc
 }
 
 var (
-	errAuthDependenciesRequired          = errors.New("google API auth dependencies are required")
-	errAuthClientResolverRequired        = errors.New("google API auth client resolver is required")
-	errAuthCredentialsReaderRequired     = errors.New("google API auth credentials reader is required")
-	errAuthTokenStoreOpenerRequired      = errors.New("google API auth token store opener is required")
-	errServiceAccountStoreRequired       = errors.New("service account store resolver is required")
-	errEmailReferenceUpdaterRequired     = errors.New("google API auth email reference updater is required")
-	errADCTokenSourceRequired            = errors.New("ADC token source factory is required")
-	errServiceAccountTokenSourceRequired = errors.New("service account token source factory is required")
+	errAuthDependenciesRequired           = errors.New("google API auth dependencies are required")
+	errAuthClientResolverRequired         = errors.New("google API auth client resolver is required")
+	errAuthCredentialsReaderRequired      = errors.New("google API auth credentials reader is required")
+	errAuthTokenStoreOpenerRequired       = errors.New("google API auth token store opener is required")
+	errServiceAccountStoreRequired        = errors.New("service account store resolver is required")
+	errEmailReferenceUpdaterRequired      = errors.New("google API auth email reference updater is required")
+	errADCTokenSourceRequired             = errors.New("ADC token source factory is required")
+	errServiceAccountTokenSourceRequired  = errors.New("service account token source factory is required")
+	errExternalAccountTokenSourceRequired = errors.New("external account token source factory is required")
+	errExternalAccountFileRequired        = errors.New("external account mode needs GOG_EXTERNAL_ACCOUNT_FILE or GOOGLE_APPLICATION_CREDENTIALS")
+	errNotExternalAccountCredentials      = errors.New(`credential file is not an "external_account" configuration`)
 )
 
 type authDependenciesContextKey struct{}
 
 func ParseAuthMode(value string) AuthMode {
-	if value == string(AuthModeADC) {
+	switch value {
+	case string(AuthModeADC):
 		return AuthModeADC
+	case string(AuthModeExternalAccount), "external_account", "wif":
+		return AuthModeExternalAccount
+	default:
+		return AuthModeStored
 	}
+}
 
-	return AuthModeStored
+// Keyless reports whether the mode authenticates from ambient credentials
+// rather than a stored account token, so no account email is required.
+func (m AuthMode) Keyless() bool {
+	return m == AuthModeADC || m == AuthModeExternalAccount
 }
 
 func WithAuthDependencies(ctx context.Context, dependencies AuthDependencies) context.Context {
@@ -167,6 +194,38 @@ func (d AuthDependencies) adcTokenSource(ctx context.Context, scopes []string) (
 	return tokenSource, nil
 }
 
+func (d AuthDependencies) externalAccountTokenSource(ctx context.Context, scopes []string) (oauth2.TokenSource, error) {
+	if d.ExternalAccountTokenSource == nil {
+		return nil, errExternalAccountTokenSourceRequired
+	}
+
+	if strings.TrimSpace(d.ExternalAccountFile) == "" {
+		return nil, errExternalAccountFileRequired
+	}
+
+	tokenSource, err := d.ExternalAccountTokenSource(ctx, d.ExternalAccountFile, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("external account token source: %w", err)
+	}
+
+	return tokenSource, nil
+}
+
+// keylessTokenSource returns the token source for ADC and external-account
+// modes. ok is false for stored-token auth.
+func (d AuthDependencies) keylessTokenSource(ctx context.Context, scopes []string) (oauth2.TokenSource, bool, error) {
+	switch d.Mode {
+	case AuthModeADC:
+		ts, err := d.adcTokenSource(ctx, scopes)
+		return ts, true, err
+	case AuthModeExternalAccount:
+		ts, err := d.externalAccountTokenSource(ctx, scopes)
+		return ts, true, err
+	default:
+		return nil, false, nil
+	}
+}
+
 func (d AuthDependencies) serviceAccountTokenSource(ctx context.Context, keyJSON []byte, subject string, scopes []string) (oauth2.TokenSource, error) {
 	if d.ServiceAccountTokenSource == nil {
 		return nil, errServiceAccountTokenSourceRequired
@@ -183,3 +242,31 @@ func DefaultADCTokenSource(ctx context.Context, scopes ...string) (oauth2.TokenS
 
 	return tokenSource, nil
 }
+
+// DefaultExternalAccountTokenSource reads a workload identity federation
+// credential file. Other credential types are rejected so a stray service
+// account key is not silently used in external-account mode.
+func DefaultExternalAccountTokenSource(ctx context.Context, path string, scopes ...string) (oauth2.TokenSource, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // user-provided credential path
+	if err != nil {
+		return nil, fmt.Errorf("read external account file: %w", err)
+	}
+
+	var probe struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, fmt.Errorf("parse external account file: %w", err)
+	}
+
+	if probe.Type != string(google.ExternalAccount) {
+		return nil, fmt.Errorf("%s: %w (type %q)", path, errNotExternalAccountCredentials, probe.Type)
+	}
+
+	creds, err := google.CredentialsFromJSONWithType(ctx, data, google.ExternalAccount, scopes...)
+	if err != nil {
+		return nil, fmt.Errorf("external account credentials: %w", err)
+	}
+
+	return creds.TokenSource, nil
+}
@@ -3,8 +3,12 @@ package googleapi
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"golang.org/x/oauth2"
+
 	"github.com/steipete/gogcli/internal/config"
 )
 
@@ -75,3 +79,65 @@ func TestEmailReferenceUpdateRequiresDependency(t *testing.T) {
 		t.Fatalf("error = %v, want %v", err, errEmailReferenceUpdaterRequired)
 	}
 }
+
+func TestParseAuthMode_ExternalAccountAliases(t *testing.T) {
+	t.Parallel()
+
+	for _, value := range []string{"external-account", "external_account", "wif"} {
+		if got := ParseAuthMode(value); got != AuthModeExternalAccount || !got.Keyless() {
+			t.Fatalf("ParseAuthMode(%q) = %q", value, got)
+		}
+	}
+	if ParseAuthMode("").Keyless() {
+		t.Fatal("stored mode must not be keyless")
+	}
+}
+
+func TestExternalAccountModeUsesConfiguredFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := WithAuthDependencies(context.Background(), AuthDependencies{Mode: AuthModeExternalAccount})
+
+	_, err := optionsForAccountScopes(ctx, "drive", "external-account", []string{"scope"})
+	if !errors.Is(err, errExternalAccountTokenSourceRequired) {
+		t.Fatalf("error = %v, want %v", err, errExternalAccountTokenSourceRequired)
+	}
+
+	var gotPath string
+	var gotScopes []string
+	factory := func(_ context.Context, path string, scopes ...string) (oauth2.TokenSource, error) {
+		gotPath, gotScopes = path, scopes
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "federated"}), nil
+	}
+
+	ctx = WithAuthDependencies(context.Background(), AuthDependencies{Mode: AuthModeExternalAccount, ExternalAccountTokenSource: factory})
+	if _, err := optionsForAccountScopes(ctx, "drive", "external-account", []string{"scope"}); !errors.Is(err, errExternalAccountFileRequired) {
+		t.Fatalf("missing file error = %v", err)
+	}
+
+	ctx = WithAuthDependencies(context.Background(), AuthDependencies{
+		Mode:                       AuthModeExternalAccount,
+		ExternalAccountFile:        "/run/wif.json",
+		ExternalAccountTokenSource: factory,
+	})
+	if _, err := optionsForAccountScopes(ctx, "drive", "external-account", []string{"scope"}); err != nil {
+		t.Fatalf("options: %v", err)
+	}
+	if gotPath != "/run/wif.json" || len(gotScopes) != 1 || gotScopes[0] != "scope" {
+		t.Fatalf("factory got path=%q scopes=%v", gotPath, gotScopes)
+	}
+}
+
+func TestDefaultExternalAccountTokenSource_RejectsOtherCredentialTypes(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "key.json")
+	if err := os.WriteFile(path, []byte(`{"type":"service_account","client_email":"sa@example.iam.gserviceaccount.com"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := DefaultExternalAccountTokenSource(context.Background(), path, "scope")
+	if !errors.Is(err, errNotExternalAccountCredentials) {
+		t.Fatalf("error = %v, want %v", err, errNotExternalAccountCredentials)
+	}
+}
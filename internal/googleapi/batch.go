@@ -0,0 +1,357 @@
+package googleapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	gapi "google.golang.org/api/googleapi"
+)
+
+const (
+	// MaxBatchCalls is the most calls Google accepts in one batch request.
+	MaxBatchCalls = 100
+	// BatchRetries is how many times a failed call is re-sent on its own.
+	BatchRetries = 2
+
+	batchContentIDPrefix  = "item-"
+	batchResponseIDPrefix = "response-" + batchContentIDPrefix
+	maxBatchResponseBytes = int64(64 << 20)
+)
+
+// BatchCall is one API call inside a batch request. Path and Query are
+// relative to the generated service's BasePath, the same way the generated
+// clients build their URLs; path segments must already be escaped.
+type BatchCall struct {
+	Method string
+	Path   string
+	Query  url.Values
+	// Body is sent as JSON when non-nil.
+	Body any
+}
+
+// BatchResult is the outcome of one BatchCall. Err is a *googleapi.Error for
+// non-2xx responses, so callers can keep using the usual status checks.
+type BatchResult struct {
+	Status   int
+	Header   http.Header
+	Body     []byte
+	Err      error
+	Attempts int
+}
+
+// Decode unmarshals a successful response body into v.
+func (r BatchResult) Decode(v any) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	if len(bytes.TrimSpace(r.Body)) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Body, v)
+}
+
+// Batch packs calls into Google's multipart/mixed batch endpoint. Calls that
+// fail with a rate limit, a server error or a transport error are re-sent
+// individually, so one flaky sub-request does not fail its neighbours. Its
+// requests bypass RetryTransport: re-sending a whole batch would repeat
+// sub-requests that already succeeded.
+type Batch struct {
+	Client     *http.Client
+	BasePath   string
+	Endpoint   string
+	MaxCalls   int
+	Retries    int
+	RetryDelay time.Duration
+}
+
+// NewBatch returns a batch client for the service whose generated BasePath
+// is basePath. The batch endpoint follows each API's discovery batchPath:
+// https://www.googleapis.com/drive/v3/ batches through /batch/drive/v3, while
+// APIs rooted at their own host (Gmail, People) use /batch.
+func NewBatch(client *http.Client, basePath string) (*Batch, error) {
+	base, err := url.Parse(basePath)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("invalid batch base path %q", basePath)
+	}
+	if !strings.HasSuffix(base.Path, "/") {
+		base.Path += "/"
+	}
+	endpoint := *base
+	endpoint.Path = "/batch" + strings.TrimSuffix(base.Path, "/")
+	endpoint.RawQuery = ""
+	return &Batch{
+		Client:     client,
+		BasePath:   base.String(),
+		Endpoint:   endpoint.String(),
+		MaxCalls:   MaxBatchCalls,
+		Retries:    BatchRetries,
+		RetryDelay: RateLimitBaseDelay,
+	}, nil
+}
+
+// Do runs calls and returns one result per call, in the same order. It only
+// returns an error when ctx is done; per-call failures are in the results.
+func (b *Batch) Do(ctx context.Context, calls []BatchCall) ([]BatchResult, error) {
+	results := make([]BatchResult, len(calls))
+	size := b.MaxCalls
+	if size <= 0 || size > MaxBatchCalls {
+		size = MaxBatchCalls
+	}
+	for start := 0; start < len(calls); start += size {
+		end := min(start+size, len(calls))
+		if err := b.send(ctx, calls[start:end], results[start:end]); err != nil {
+			return results, err
+		}
+	}
+
+	delay := b.RetryDelay
+	for round := 0; round < b.Retries; round++ {
+		var pending []int
+		for i := range results {
+			if retryableBatchResult(results[i]) {
+				pending = append(pending, i)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		if err := sleepBatchRetry(ctx, delay); err != nil {
+			return results, err
+		}
+		delay *= 2
+		for _, i := range pending {
+			attempts := results[i].Attempts
+			results[i] = b.sendOne(ctx, calls[i])
+			results[i].Attempts = attempts + 1
+		}
+	}
+	return results, ctx.Err()
+}
+
+func (b *Batch) send(ctx context.Context, calls []BatchCall, results []BatchResult) error {
+	for i := range results {
+		results[i] = BatchResult{Attempts: 1}
+	}
+	if len(calls) == 1 {
+		results[0] = b.sendOne(ctx, calls[0])
+		results[0].Attempts = 1
+		return ctx.Err()
+	}
+
+	body, contentType, err := b.encode(calls)
+	if err == nil {
+		err = b.roundTrip(ctx, body, contentType, results)
+	}
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		for i := range results {
+			results[i].Err = fmt.Errorf("batch request: %w", err)
+		}
+	}
+	return nil
+}
+
+func (b *Batch) encode(calls []BatchCall) (*bytes.Buffer, string, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for i, call := range calls {
+		target, payload, err := b.resolve(call)
+		if err != nil {
+			return nil, "", err
+		}
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Type", "application/http")
+		header.Set("Content-ID", "<"+batchContentIDPrefix+strconv.Itoa(i)+">")
+		part, err := mw.CreatePart(header)
+		if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(part, "%s %s HTTP/1.1\r\n", call.Method, target.RequestURI())
+		if payload != nil {
+			fmt.Fprintf(part, "Content-Type: application/json\r\nContent-Length: %d\r\n", len(payload))
+		}
+		_, _ = io.WriteString(part, "\r\n")
+		_, _ = part.Write(payload)
+	}
+	if err := mw.Close(); err != nil {
+		return nil, "", err
+	}
+	return &buf, "multipart/mixed; boundary=" + mw.Boundary(), nil
+}
+
+func (b *Batch) roundTrip(ctx context.Context, body io.Reader, contentType string, results []BatchResult) error {
+	req, err := http.NewRequestWithContext(WithoutRetries(ctx), http.MethodPost, b.Endpoint, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if err := gapi.CheckResponse(resp); err != nil {
+		return err
+	}
+
+	mediaType, params, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") {
+		return fmt.Errorf("unexpected batch response content type %q", resp.Header.Get("Content-Type"))
+	}
+	seen := make([]bool, len(results))
+	mr := multipart.NewReader(io.LimitReader(resp.Body, maxBatchResponseBytes), params["boundary"])
+	for {
+		part, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("read batch response: %w", err)
+		}
+		index, ok := batchResponseIndex(part.Header.Get("Content-ID"), len(results))
+		if !ok {
+			continue
+		}
+		results[index] = readBatchPart(part)
+		results[index].Attempts = 1
+		seen[index] = true
+	}
+	for i := range results {
+		if !seen[i] {
+			results[i].Err = errors.New("batch response has no part for this call")
+		}
+	}
+	return nil
+}
+
+func (b *Batch) sendOne(ctx context.Context, call BatchCall) BatchResult {
+	target, payload, err := b.resolve(call)
+	if err != nil {
+		return BatchResult{Err: err}
+	}
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(WithoutRetries(ctx), call.Method, target.String(), body)
+	if err != nil {
+		return BatchResult{Err: err}
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := b.Client.Do(req)
+	if err != nil {
+		return BatchResult{Err: err}
+	}
+	defer resp.Body.Close()
+	return batchResultFromResponse(resp)
+}
+
+func (b *Batch) resolve(call BatchCall) (*url.URL, []byte, error) {
+	target, err := url.Parse(b.BasePath + strings.TrimPrefix(call.Path, "/"))
+	if err != nil {
+		return nil, nil, fmt.Errorf("batch call %s %s: %w", call.Method, call.Path, err)
+	}
+	query := url.Values{}
+	for key, values := range call.Query {
+		query[key] = append([]string(nil), values...)
+	}
+	query.Set("alt", "json")
+	query.Set("prettyPrint", "false")
+	target.RawQuery = query.Encode()
+	if call.Body == nil {
+		return target, nil, nil
+	}
+	payload, err := json.Marshal(call.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("encode batch call body: %w", err)
+	}
+	return target, payload, nil
+}
+
+func readBatchPart(part io.Reader) BatchResult {
+	resp, err := http.ReadResponse(bufio.NewReader(part), nil)
+	if err != nil {
+		return BatchResult{Err: fmt.Errorf("parse batch response part: %w", err)}
+	}
+	defer resp.Body.Close()
+	return batchResultFromResponse(resp)
+}
+
+func batchResultFromResponse(resp *http.Response) BatchResult {
+	body, err := io.ReadAll(resp.Body)
+	result := BatchResult{Status: resp.StatusCode, Header: resp.Header, Body: body}
+	if err != nil {
+		result.Err = err
+		return result
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	result.Err = gapi.CheckResponse(resp)
+	return result
+}
+
+func batchResponseIndex(contentID string, n int) (int, bool) {
+	id := strings.Trim(strings.TrimSpace(contentID), "<>")
+	id, ok := strings.CutPrefix(id, batchResponseIDPrefix)
+	if !ok {
+		return 0, false
+	}
+	index, err := strconv.Atoi(id)
+	if err != nil || index < 0 || index >= n {
+		return 0, false
+	}
+	return index, true
+}
+
+func retryableBatchResult(r BatchResult) bool {
+	if r.Err == nil {
+		return false
+	}
+	if r.Status == 0 {
+		// Transport failures and parts missing from the batch response.
+		return true
+	}
+	if r.Status == http.StatusTooManyRequests || r.Status >= http.StatusInternalServerError {
+		return true
+	}
+	// Drive reports per-user rate limits inside batches as 403s.
+	var apiErr *gapi.Error
+	if r.Status == http.StatusForbidden && errors.As(r.Err, &apiErr) {
+		for _, item := range apiErr.Errors {
+			if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func sleepBatchRetry(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,187 @@
+package googleapi
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+
+	gapi "google.golang.org/api/googleapi"
+)
+
+func TestNewBatchEndpoint(t *testing.T) {
+	for basePath, want := range map[string]string{
+		"https://www.googleapis.com/drive/v3/":    "https://www.googleapis.com/batch/drive/v3",
+		"https://www.googleapis.com/calendar/v3/": "https://www.googleapis.com/batch/calendar/v3",
+		"https://gmail.googleapis.com/":           "https://gmail.googleapis.com/batch",
+		"https://people.googleapis.com/":          "https://people.googleapis.com/batch",
+	} {
+		b, err := NewBatch(http.DefaultClient, basePath)
+		if err != nil {
+			t.Fatalf("NewBatch(%q): %v", basePath, err)
+		}
+		if b.Endpoint != want {
+			t.Fatalf("endpoint for %s = %s, want %s", basePath, b.Endpoint, want)
+		}
+	}
+	if _, err := NewBatch(http.DefaultClient, "drive/v3/"); err == nil {
+		t.Fatal("relative base path should fail")
+	}
+}
+
+func TestBatchDo_PacksCallsAndRetriesFailuresIndividually(t *testing.T) {
+	var mu sync.Mutex
+	var batches []int
+	var single []string
+	flaky := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/batch/drive/v3" {
+			mu.Lock()
+			single = append(single, r.Method+" "+r.URL.Path)
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = io.WriteString(w, `{"id":"single `+r.URL.Path+`"}`)
+			return
+		}
+		_, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil {
+			t.Errorf("content type: %v", err)
+			return
+		}
+		mr := multipart.NewReader(r.Body, params["boundary"])
+		out := multipart.NewWriter(w)
+		w.Header().Set("Content-Type", "multipart/mixed; boundary="+out.Boundary())
+		parts := 0
+		for {
+			part, err := mr.NextPart()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				t.Errorf("next part: %v", err)
+				return
+			}
+			parts++
+			req, err := http.ReadRequest(bufio.NewReader(part))
+			if err != nil {
+				t.Errorf("read sub-request: %v", err)
+				return
+			}
+			body, _ := io.ReadAll(req.Body)
+			status, payload := http.StatusOK, `{"id":"`+strings.TrimPrefix(req.URL.Path, "/drive/v3/files/")+`"}`
+			switch {
+			case strings.HasSuffix(req.URL.Path, "/flaky"):
+				flaky++
+				status, payload = http.StatusServiceUnavailable, `{"error":{"code":503,"message":"backend error"}}`
+			case strings.HasSuffix(req.URL.Path, "/missing"):
+				status, payload = http.StatusNotFound, `{"error":{"code":404,"message":"File not found"}}`
+			case req.Method == http.MethodPatch && string(body) != `{"role":"reader"}`:
+				t.Errorf("patch body = %s", body)
+			}
+			if req.URL.Query().Get("supportsAllDrives") != "true" || req.URL.Query().Get("alt") != "json" {
+				t.Errorf("sub-request query = %s", req.URL.RawQuery)
+			}
+			header := textproto.MIMEHeader{}
+			header.Set("Content-Type", "application/http")
+			header.Set("Content-ID", "<response-"+strings.Trim(part.Header.Get("Content-ID"), "<>")+">")
+			pw, _ := out.CreatePart(header)
+			fmt.Fprintf(pw, "HTTP/1.1 %d %s\r\nContent-Type: application/json\r\n\r\n%s", status, http.StatusText(status), payload)
+		}
+		_ = out.Close()
+		mu.Lock()
+		batches = append(batches, parts)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	b, err := NewBatch(srv.Client(), srv.URL+"/drive/v3/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.MaxCalls = 2
+	b.RetryDelay = 0
+
+	q := map[string][]string{"supportsAllDrives": {"true"}}
+	results, err := b.Do(context.Background(), []BatchCall{
+		{Method: http.MethodPatch, Path: "files/a", Query: q, Body: map[string]string{"role": "reader"}},
+		{Method: http.MethodDelete, Path: "files/b/flaky", Query: q},
+		{Method: http.MethodDelete, Path: "files/c/missing", Query: q},
+		{Method: http.MethodGet, Path: "files/d", Query: q},
+		{Method: http.MethodGet, Path: "files/e", Query: q},
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if fmt.Sprint(batches) != "[2 2]" {
+		t.Fatalf("batch sizes = %v", batches)
+	}
+	if flaky != 1 || strings.Join(single, ",") != "GET /drive/v3/files/e,DELETE /drive/v3/files/b/flaky" {
+		t.Fatalf("individual retries = %v (flaky %d)", single, flaky)
+	}
+
+	var got struct{ ID string }
+	if err := results[0].Decode(&got); err != nil || got.ID != "a" {
+		t.Fatalf("result 0 = %+v, %v", got, err)
+	}
+	if results[1].Err != nil || results[1].Attempts != 2 || !strings.Contains(string(results[1].Body), "single") {
+		t.Fatalf("flaky result = %+v", results[1])
+	}
+	var apiErr *gapi.Error
+	if !errors.As(results[2].Err, &apiErr) || apiErr.Code != http.StatusNotFound || results[2].Attempts != 1 {
+		t.Fatalf("missing result = %+v", results[2])
+	}
+	if results[4].Err != nil || !strings.Contains(string(results[4].Body), "files/e") {
+		t.Fatalf("single-call chunk = %+v", results[4])
+	}
+}
+
+func TestBatchDo_BypassesRetryTransportForBatchPost(t *testing.T) {
+	var mu sync.Mutex
+	var posts int
+	var single []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/batch/drive/v3" {
+			posts++
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = io.WriteString(w, `{"error":{"code":502,"message":"bad gateway"}}`)
+			return
+		}
+		single = append(single, r.Method+" "+r.URL.Path)
+		_, _ = io.WriteString(w, `{}`)
+	}))
+	defer srv.Close()
+
+	retry := NewRetryTransport(srv.Client().Transport)
+	retry.BaseDelay = 0
+	b, err := NewBatch(&http.Client{Transport: retry}, srv.URL+"/drive/v3/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b.RetryDelay = 0
+
+	results, err := b.Do(context.Background(), []BatchCall{
+		{Method: http.MethodDelete, Path: "files/a"},
+		{Method: http.MethodDelete, Path: "files/b"},
+	})
+	if err != nil {
+		t.Fatalf("Do: %v", err)
+	}
+	if posts != 1 {
+		t.Fatalf("batch POSTs = %d, want 1", posts)
+	}
+	if len(single) != 2 || results[0].Err != nil || results[1].Err != nil || results[0].Attempts != 2 {
+		t.Fatalf("individual retries = %v, results = %+v", single, results)
+	}
+}
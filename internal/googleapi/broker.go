@@ -0,0 +1,146 @@
+package googleapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/99designs/keyring"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/steipete/gogcli/internal/secrets"
+)
+
+// BrokerTokenSourceFunc asks a credential broker for tokens instead of
+// reading stored refresh tokens (GOG_BROKER_SOCKET).
+type BrokerTokenSourceFunc func(ctx context.Context, email, serviceLabel string, scopes []string) (oauth2.TokenSource, error)
+
+// scopedRefreshTokenURL is Google's token endpoint; tests replace it.
+var scopedRefreshTokenURL = google.Endpoint.TokenURL
+
+func (d AuthDependencies) brokerTokenSource(ctx context.Context, serviceLabel, email string, scopes []string) (oauth2.TokenSource, bool, error) {
+	if d.Broker == nil {
+		return nil, false, nil
+	}
+
+	slog.Debug("using credential broker", "serviceLabel", serviceLabel, "email", email)
+
+	ts, err := d.Broker(ctx, email, serviceLabel, normalizeScopeList(scopes))
+	if err != nil {
+		return nil, true, fmt.Errorf("credential broker: %w", err)
+	}
+
+	return ts, true, nil
+}
+
+// MintAccessToken returns a fresh access token for email limited to scopes,
+// from a configured service account or the stored refresh token. It never
+// consults a broker, so a broker process can serve its own clients.
+func MintAccessToken(ctx context.Context, serviceLabel, email string, scopes []string) (*oauth2.Token, error) {
+	dependencies, err := requireAuthDependencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceAccountTS, _, ok, err := tokenSourceForServiceAccountScopes(ctx, dependencies, serviceLabel, email, scopes)
+	if err != nil {
+		return nil, fmt.Errorf("service account token source: %w", err)
+	}
+
+	if ok {
+		tok, err := serviceAccountTS.Token()
+		if err != nil {
+			return nil, fmt.Errorf("service account token: %w", err)
+		}
+
+		return tok, nil
+	}
+
+	client, creds, err := clientCredentialsForAccount(ctx, dependencies, email)
+	if err != nil {
+		return nil, err
+	}
+
+	store, err := dependencies.openTokens()
+	if err != nil {
+		return nil, err
+	}
+
+	stored, err := store.GetToken(client, email)
+	if err != nil {
+		if errors.Is(err, keyring.ErrKeyNotFound) || errors.Is(err, secrets.ErrCorruptStoredToken) {
+			return nil, &AuthRequiredError{Service: serviceLabel, Email: email, Client: client, Cause: err}
+		}
+
+		return nil, fmt.Errorf("get token for %s: %w", email, err)
+	}
+
+	return refreshWithScopes(ctx, creds.ClientID, creds.ClientSecret, stored.RefreshToken, scopes)
+}
+
+// refreshWithScopes runs a refresh_token grant with an explicit scope
+// parameter, which Google honors by issuing an access token limited to that
+// subset of the granted scopes. oauth2.Config never sends scope on refresh.
+// Like oauth2, it uses the *http.Client in ctx under oauth2.HTTPClient.
+func refreshWithScopes(ctx context.Context, clientID, clientSecret, refreshToken string, scopes []string) (*oauth2.Token, error) {
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"scope":         {strings.Join(scopes, " ")},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, scopedRefreshTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("build token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := &http.Client{Timeout: tokenExchangeTimeout}
+	if c, ok := ctx.Value(oauth2.HTTPClient).(*http.Client); ok && c != nil {
+		client = c
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("refresh token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("read token response: %w", err)
+	}
+
+	var out struct {
+		AccessToken      string `json:"access_token"`
+		TokenType        string `json:"token_type"`
+		ExpiresIn        int64  `json:"expires_in"`
+		Scope            string `json:"scope"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, fmt.Errorf("decode token response (HTTP %d): %w", resp.StatusCode, err)
+	}
+
+	if resp.StatusCode != http.StatusOK || out.AccessToken == "" {
+		return nil, fmt.Errorf("refresh token: %s %s (HTTP %d)", out.Error, out.ErrorDescription, resp.StatusCode)
+	}
+
+	tok := &oauth2.Token{AccessToken: out.AccessToken, TokenType: out.TokenType}
+	if out.ExpiresIn > 0 {
+		tok.Expiry = time.Now().Add(time.Duration(out.ExpiresIn) * time.Second)
+	}
+
+	return tok.WithExtra(map[string]any{"scope": out.Scope}), nil
+}
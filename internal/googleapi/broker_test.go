@@ -0,0 +1,76 @@
+package googleapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/secrets"
+)
+
+func TestMintAccessToken_RefreshesWithNarrowedScopes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("refresh_token") != "rt" || r.Form.Get("client_id") != "id" {
+			t.Errorf("form = %v", r.Form)
+		}
+		if got := r.Form.Get("scope"); got != "https://www.googleapis.com/auth/drive.readonly" {
+			t.Errorf("scope = %q", got)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": "narrow",
+			"token_type":   "Bearer",
+			"expires_in":   3599,
+			"scope":        "https://www.googleapis.com/auth/drive.readonly",
+		})
+	}))
+	defer srv.Close()
+
+	orig := scopedRefreshTokenURL
+	scopedRefreshTokenURL = srv.URL
+	t.Cleanup(func() { scopedRefreshTokenURL = orig })
+
+	ctx, _ := testServiceAccountContext(t, context.WithValue(context.Background(), oauth2.HTTPClient, srv.Client()))
+
+	tok, err := MintAccessToken(ctx, "drive", "a@b.com", []string{"https://www.googleapis.com/auth/drive.readonly"})
+	if err != nil {
+		t.Fatalf("MintAccessToken: %v", err)
+	}
+	if tok.AccessToken != "narrow" || tok.Expiry.IsZero() || tok.Extra("scope") != "https://www.googleapis.com/auth/drive.readonly" {
+		t.Fatalf("token = %+v", tok)
+	}
+}
+
+func TestBrokerReplacesStoredTokens(t *testing.T) {
+	var gotEmail, gotService string
+	ctx := WithAuthDependencies(context.Background(), AuthDependencies{
+		Broker: func(_ context.Context, email, serviceLabel string, scopes []string) (oauth2.TokenSource, error) {
+			gotEmail, gotService = email, serviceLabel
+			if len(scopes) != 1 || scopes[0] != "scope" {
+				t.Errorf("scopes = %v", scopes)
+			}
+			return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "brokered"}), nil
+		},
+		OpenTokens: func() (secrets.Store, error) {
+			t.Fatal("OpenTokens should not be called with a broker")
+			return nil, errBoom
+		},
+	})
+
+	if _, err := optionsForAccountScopes(ctx, "gmail", "a@b.com", []string{"scope"}); err != nil {
+		t.Fatalf("optionsForAccountScopes: %v", err)
+	}
+	if gotEmail != "a@b.com" || gotService != "gmail" {
+		t.Fatalf("broker got %q %q", gotEmail, gotService)
+	}
+
+	gotService = ""
+	if _, err := optionsForServiceAccountScopes(ctx, "keep", "a@b.com", []string{"scope"}); err != nil || gotService != "keep" {
+		t.Fatalf("service account path: service=%q err=%v", gotService, err)
+	}
+}
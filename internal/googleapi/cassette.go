@@ -0,0 +1,571 @@
+package googleapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// CassetteMode selects whether a Cassette records live traffic or replays it.
+type CassetteMode string
+
+const (
+	CassetteRecord CassetteMode = "record"
+	CassetteReplay CassetteMode = "replay"
+
+	// CassetteRulesFile holds the redaction rules inside a cassette directory.
+	// Recording writes the defaults when it is missing; replay reads the same
+	// rules so incoming requests are scrubbed exactly like the recorded ones.
+	CassetteRulesFile = "redact.json"
+
+	cassetteInteractionsDir = "interactions"
+	cassetteRedacted        = "REDACTED"
+)
+
+var (
+	errCassetteModeConflict = errors.New("GOG_RECORD and GOG_REPLAY cannot both be set")
+	emailPattern            = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+)
+
+// CassetteRules controls how requests and responses are scrubbed before they
+// are written to disk and before replayed requests are matched.
+type CassetteRules struct {
+	// Headers are dropped from stored requests and responses.
+	Headers []string `json:"headers"`
+	// Query parameter values are replaced with REDACTED.
+	Query []string `json:"query"`
+	// JSONFields are replaced with REDACTED at any depth of a JSON body.
+	JSONFields []string `json:"json_fields"`
+	// Emails replaces email addresses in URLs and bodies with stable
+	// placeholders (user-<hash>@redacted.invalid).
+	Emails bool `json:"emails"`
+	// Replace applies extra regular expression substitutions to URLs and bodies.
+	Replace []CassetteReplaceRule `json:"replace,omitempty"`
+	// DropBodies stores "[body redacted]" instead of the request and response
+	// bodies. Request bodies are still hashed for matching.
+	DropBodies bool `json:"drop_bodies,omitempty"`
+}
+
+type CassetteReplaceRule struct {
+	Pattern string `json:"pattern"`
+	With    string `json:"with"`
+}
+
+// DefaultCassetteRules scrubs credentials and email addresses.
+func DefaultCassetteRules() CassetteRules {
+	return CassetteRules{
+		Headers: []string{
+			"Authorization", "Cookie", "Set-Cookie", "X-Goog-Api-Key",
+			"X-Goog-Upload-Url", "Proxy-Authorization",
+		},
+		Query:      []string{"key", "access_token", "oauth_token"},
+		JSONFields: []string{"access_token", "refresh_token", "id_token", "client_secret", "password"},
+		Emails:     true,
+	}
+}
+
+// Cassette stores or replays Google API request/response pairs in a directory.
+type Cassette struct {
+	Mode CassetteMode
+	Dir  string
+
+	rules   CassetteRules
+	replace []*regexp.Regexp
+	base    http.RoundTripper
+
+	mu       sync.Mutex
+	recorded map[string][]*cassetteInteraction
+	next     map[string]int
+}
+
+type cassetteInteraction struct {
+	Key      string           `json:"key"`
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+	Recorded time.Time        `json:"recorded"`
+
+	file string
+	seq  int
+}
+
+type cassetteRequest struct {
+	Method   string      `json:"method"`
+	URL      string      `json:"url"`
+	Header   http.Header `json:"header,omitempty"`
+	BodyHash string      `json:"body_sha256,omitempty"`
+	Body     string      `json:"body,omitempty"`
+}
+
+type cassetteResponse struct {
+	Status     int         `json:"status"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+	BodyBase64 string      `json:"body_base64,omitempty"`
+}
+
+// CassetteMissError reports a replayed request with no recorded response.
+type CassetteMissError struct {
+	Method string
+	URL    string
+	Key    string
+	Dir    string
+}
+
+func (e *CassetteMissError) Error() string {
+	return fmt.Sprintf("replay: no recorded response for %s %s (key %s) in %s", e.Method, e.URL, e.Key, e.Dir)
+}
+
+// CassetteFromEnv returns the cassette selected by GOG_RECORD or GOG_REPLAY,
+// or nil when neither is set.
+func CassetteFromEnv(recordDir, replayDir string) (*Cassette, error) {
+	recordDir, replayDir = strings.TrimSpace(recordDir), strings.TrimSpace(replayDir)
+	switch {
+	case recordDir != "" && replayDir != "":
+		return nil, errCassetteModeConflict
+	case recordDir != "":
+		return OpenCassette(CassetteRecord, recordDir)
+	case replayDir != "":
+		return OpenCassette(CassetteReplay, replayDir)
+	default:
+		return nil, nil
+	}
+}
+
+// OpenCassette prepares dir for recording, or loads its interactions for replay.
+func OpenCassette(mode CassetteMode, dir string) (*Cassette, error) {
+	c := &Cassette{Mode: mode, Dir: dir, recorded: map[string][]*cassetteInteraction{}, next: map[string]int{}}
+	switch mode {
+	case CassetteRecord:
+		if err := os.MkdirAll(filepath.Join(dir, cassetteInteractionsDir), 0o700); err != nil {
+			return nil, fmt.Errorf("create cassette dir: %w", err)
+		}
+	case CassetteReplay:
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+	if err := c.loadRules(); err != nil {
+		return nil, err
+	}
+	if mode == CassetteReplay {
+		if err := c.loadInteractions(); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Cassette) loadRules() error {
+	path := filepath.Join(c.Dir, CassetteRulesFile)
+	data, err := os.ReadFile(path) //nolint:gosec // user-selected cassette directory
+	switch {
+	case errors.Is(err, os.ErrNotExist) && c.Mode == CassetteRecord:
+		c.rules = DefaultCassetteRules()
+		data, err = json.MarshalIndent(c.rules, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode cassette rules: %w", err)
+		}
+		if err := os.WriteFile(path, append(data, '\n'), 0o600); err != nil {
+			return fmt.Errorf("write cassette rules: %w", err)
+		}
+	case errors.Is(err, os.ErrNotExist):
+		c.rules = DefaultCassetteRules()
+	case err != nil:
+		return fmt.Errorf("read cassette rules: %w", err)
+	default:
+		if err := json.Unmarshal(data, &c.rules); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+	for _, rule := range c.rules.Replace {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("cassette replace rule %q: %w", rule.Pattern, err)
+		}
+		c.replace = append(c.replace, re)
+	}
+	return nil
+}
+
+func (c *Cassette) loadInteractions() error {
+	dir := filepath.Join(c.Dir, cassetteInteractionsDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read cassette: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path) //nolint:gosec // user-selected cassette directory
+		if err != nil {
+			return fmt.Errorf("read cassette: %w", err)
+		}
+		var interaction cassetteInteraction
+		if err := json.Unmarshal(data, &interaction); err != nil {
+			return fmt.Errorf("parse %s: %w", path, err)
+		}
+		interaction.file = entry.Name()
+		interaction.seq = cassetteFileSeq(entry.Name())
+		c.recorded[interaction.Key] = append(c.recorded[interaction.Key], &interaction)
+	}
+	for _, list := range c.recorded {
+		sort.Slice(list, func(i, j int) bool { return list[i].seq < list[j].seq })
+	}
+	return nil
+}
+
+// Transport wraps base so requests are recorded through it or answered from
+// the cassette. A nil cassette returns base unchanged.
+func (c *Cassette) Transport(base http.RoundTripper) http.RoundTripper {
+	if c == nil {
+		return base
+	}
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &cassetteTransport{cassette: c, base: base}
+}
+
+// Replaying reports whether requests are answered from disk, in which case
+// no credentials are needed.
+func (c *Cassette) Replaying() bool {
+	return c != nil && c.Mode == CassetteReplay
+}
+
+type cassetteTransport struct {
+	cassette *Cassette
+	base     http.RoundTripper
+}
+
+func (t *cassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	c := t.cassette
+	reqURL := c.scrubURL(req.URL)
+	reqBody := c.scrubBody(normalizeMultipartBoundary(req.Header.Get("Content-Type"), body))
+	key := cassetteKey(req.Method, reqURL, reqBody)
+
+	if c.Mode == CassetteReplay {
+		interaction := c.take(key)
+		if interaction == nil {
+			return nil, &CassetteMissError{Method: req.Method, URL: reqURL, Key: key, Dir: c.Dir}
+		}
+		return interaction.Response.httpResponse(req)
+	}
+
+	live := req.Clone(req.Context())
+	live.Body = io.NopCloser(bytes.NewReader(body))
+	live.ContentLength = int64(len(body))
+	resp, err := t.base.RoundTrip(live)
+	if err != nil {
+		return nil, err
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read response for cassette: %w", err)
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	resp.ContentLength = int64(len(respBody))
+
+	interaction := &cassetteInteraction{
+		Key: key,
+		Request: cassetteRequest{
+			Method:   req.Method,
+			URL:      reqURL,
+			Header:   c.scrubHeader(req.Header),
+			BodyHash: bodyHash(reqBody),
+			Body:     c.storedText(reqBody),
+		},
+		Response: cassetteResponse{Status: resp.StatusCode, Header: c.scrubHeader(resp.Header)},
+		Recorded: time.Now().UTC(),
+	}
+	switch {
+	case c.rules.DropBodies && len(respBody) > 0:
+		interaction.Response.Body = "[body redacted]"
+	case utf8.Valid(respBody):
+		interaction.Response.Body = string(c.scrubBody(respBody))
+	default:
+		interaction.Response.BodyBase64 = base64.StdEncoding.EncodeToString(respBody)
+	}
+	if err := c.save(interaction); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// take returns the next recorded response for key. Repeated requests are
+// answered in recording order; once they run out the last one is reused.
+func (c *Cassette) take(key string) *cassetteInteraction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	list := c.recorded[key]
+	if len(list) == 0 {
+		return nil
+	}
+	i := min(c.next[key], len(list)-1)
+	c.next[key]++
+	return list[i]
+}
+
+func (c *Cassette) save(interaction *cassetteInteraction) error {
+	data, err := json.MarshalIndent(interaction, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encode cassette interaction: %w", err)
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	dir := filepath.Join(c.Dir, cassetteInteractionsDir)
+	for seq := c.next[interaction.Key]; ; seq++ {
+		name := fmt.Sprintf("%s-%04d.json", interaction.Key, seq)
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600) //nolint:gosec // user-selected cassette directory
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("write cassette: %w", err)
+		}
+		_, err = f.Write(append(data, '\n'))
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("write cassette: %w", err)
+		}
+		c.next[interaction.Key] = seq + 1
+		return nil
+	}
+}
+
+func (r cassetteResponse) httpResponse(req *http.Request) (*http.Response, error) {
+	body := []byte(r.Body)
+	if r.BodyBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(r.BodyBase64)
+		if err != nil {
+			return nil, fmt.Errorf("decode cassette response: %w", err)
+		}
+		body = decoded
+	}
+	header := r.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		Status:        strconv.Itoa(r.Status) + " " + http.StatusText(r.Status),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}, nil
+}
+
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	var body io.ReadCloser
+	if req.GetBody != nil {
+		fresh, err := req.GetBody()
+		if err != nil {
+			return nil, fmt.Errorf("read request for cassette: %w", err)
+		}
+		body = fresh
+	} else {
+		body = req.Body
+	}
+	data, err := io.ReadAll(body)
+	_ = body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("read request for cassette: %w", err)
+	}
+	return data, nil
+}
+
+// cassetteKey matches requests on method, path, canonical (sorted) query,
+// and the hash of the scrubbed body.
+func cassetteKey(method, scrubbedURL string, scrubbedBody []byte) string {
+	sum := sha256.Sum256([]byte(method + "\n" + scrubbedURL + "\n" + bodyHash(scrubbedBody)))
+	return hex.EncodeToString(sum[:8])
+}
+
+func bodyHash(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *Cassette) scrubURL(u *url.URL) string {
+	query := u.Query()
+	for _, name := range c.rules.Query {
+		if _, ok := query[name]; ok {
+			query.Set(name, cassetteRedacted)
+		}
+	}
+	// Encode sorts by key, which makes the query canonical.
+	out := u.Scheme + "://" + u.Host + u.Path
+	if encoded := query.Encode(); encoded != "" {
+		out += "?" + encoded
+	}
+	return string(c.scrubText([]byte(out)))
+}
+
+func (c *Cassette) scrubHeader(header http.Header) http.Header {
+	out := header.Clone()
+	for _, name := range c.rules.Headers {
+		out.Del(name)
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+func (c *Cassette) scrubBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	if len(c.rules.JSONFields) > 0 && json.Valid(body) {
+		var v any
+		if err := json.Unmarshal(body, &v); err == nil {
+			// Re-encoding also sorts object keys, so field order does not
+			// change the body hash.
+			if encoded, err := json.Marshal(redactJSONFields(v, c.rules.JSONFields)); err == nil {
+				body = encoded
+			}
+		}
+	}
+	return c.scrubText(body)
+}
+
+func (c *Cassette) scrubText(data []byte) []byte {
+	if c.rules.Emails {
+		data = emailPattern.ReplaceAllFunc(data, func(email []byte) []byte {
+			sum := sha256.Sum256(bytes.ToLower(email))
+			return []byte("user-" + hex.EncodeToString(sum[:4]) + "@redacted.invalid")
+		})
+	}
+	for i, re := range c.replace {
+		data = re.ReplaceAll(data, []byte(c.rules.Replace[i].With))
+	}
+	return data
+}
+
+func (c *Cassette) storedText(body []byte) string {
+	switch {
+	case len(body) == 0:
+		return ""
+	case c.rules.DropBodies, !utf8.Valid(body):
+		return "[body redacted]"
+	default:
+		return string(body)
+	}
+}
+
+func redactJSONFields(v any, fields []string) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, child := range value {
+			if containsFold(fields, k) {
+				value[k] = cassetteRedacted
+				continue
+			}
+			value[k] = redactJSONFields(child, fields)
+		}
+	case []any:
+		for i, child := range value {
+			value[i] = redactJSONFields(child, fields)
+		}
+	}
+	return v
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeMultipartBoundary replaces the random multipart boundary so
+// uploads hash the same on every run.
+func normalizeMultipartBoundary(contentType string, body []byte) []byte {
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil || !strings.HasPrefix(mediaType, "multipart/") || params["boundary"] == "" {
+		return body
+	}
+	return bytes.ReplaceAll(body, []byte(params["boundary"]), []byte("BOUNDARY"))
+}
+
+func cassetteFileSeq(name string) int {
+	base := strings.TrimSuffix(name, filepath.Ext(name))
+	i := strings.LastIndexByte(base, '-')
+	if i < 0 {
+		return 0
+	}
+	seq, _ := strconv.Atoi(base[i+1:])
+	return seq
+}
+
+type cassetteContextKey struct{}
+
+// WithCassette makes Google API clients created with ctx record to or replay
+// from c. A nil cassette leaves ctx unchanged.
+func WithCassette(ctx context.Context, c *Cassette) context.Context {
+	if c == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, cassetteContextKey{}, c)
+}
+
+func cassetteFromContext(ctx context.Context) *Cassette {
+	if ctx == nil {
+		return nil
+	}
+	c, _ := ctx.Value(cassetteContextKey{}).(*Cassette)
+	return c
+}
+
+// baseTransport is newBaseTransport wrapped by the context's cassette, if any.
+func baseTransport(ctx context.Context) http.RoundTripper {
+	return cassetteFromContext(ctx).Transport(newBaseTransport())
+}
+
+// replayTransport serves requests from a replay cassette without credentials.
+func replayTransport(ctx context.Context) (http.RoundTripper, bool) {
+	c := cassetteFromContext(ctx)
+	if !c.Replaying() {
+		return nil, false
+	}
+	retry := NewRetryTransport(c.Transport(nil))
+	retry.BaseDelay = 0
+	return readOnlyTransportFromContext(ctx, retry), true
+}
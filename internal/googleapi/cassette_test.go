@@ -0,0 +1,152 @@
+package googleapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCassette_RecordThenReplayOffline(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if !strings.Contains(string(body), `"name"`) {
+			t.Errorf("live request body = %s", body)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		_, _ = io.WriteString(w, `{"owner":"alice@example.com","access_token":"ya29.secret","n":`+string(rune('0'+calls))+`}`)
+	}))
+
+	dir := t.TempDir()
+	recorder, err := OpenCassette(CassetteRecord, dir)
+	if err != nil {
+		t.Fatalf("OpenCassette record: %v", err)
+	}
+	client := &http.Client{Transport: recorder.Transport(srv.Client().Transport)}
+	send := func(client *http.Client, body string) (string, error) {
+		req, _ := http.NewRequestWithContext(context.Background(), http.MethodPost,
+			srv.URL+"/v1/users/alice@example.com/files?b=2&a=1&key=AIzaSecret", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer ya29.secret")
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		out, _ := io.ReadAll(resp.Body)
+		return string(out), nil
+	}
+	for range 2 {
+		if _, err := send(client, `{"name":"a","size":1}`); err != nil {
+			t.Fatalf("record: %v", err)
+		}
+	}
+	srv.Close()
+
+	if _, err := os.Stat(filepath.Join(dir, CassetteRulesFile)); err != nil {
+		t.Fatalf("rules file: %v", err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, cassetteInteractionsDir, "*.json"))
+	if len(files) != 2 {
+		t.Fatalf("interaction files = %v", files)
+	}
+	for _, file := range files {
+		data, _ := os.ReadFile(file)
+		for _, secret := range []string{"ya29.secret", "AIzaSecret", "alice@example.com", "session=secret", "Bearer"} {
+			if strings.Contains(string(data), secret) {
+				t.Fatalf("%s leaks %q:\n%s", file, secret, data)
+			}
+		}
+	}
+
+	player, err := OpenCassette(CassetteReplay, dir)
+	if err != nil {
+		t.Fatalf("OpenCassette replay: %v", err)
+	}
+	client = &http.Client{Transport: player.Transport(nil)}
+	// Key order in the JSON body and query does not affect matching.
+	first, err := send(client, `{"size":1,"name":"a"}`)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	second, _ := send(client, `{"name":"a","size":1}`)
+	if !strings.Contains(first, `"n":1`) || !strings.Contains(second, `"n":2`) {
+		t.Fatalf("replayed responses = %s / %s (want recording order)", first, second)
+	}
+	if !strings.Contains(first, "@redacted.invalid") || !strings.Contains(first, `"access_token":"REDACTED"`) {
+		t.Fatalf("replayed body not scrubbed: %s", first)
+	}
+
+	_, err = send(client, `{"name":"b"}`)
+	var miss *CassetteMissError
+	if !errors.As(err, &miss) || miss.Method != http.MethodPost {
+		t.Fatalf("unmatched request error = %v", err)
+	}
+}
+
+func TestCassette_ReplayNeedsNoCredentials(t *testing.T) {
+	dir := t.TempDir()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, `{"ok":true}`)
+	}))
+	recorder, err := OpenCassette(CassetteRecord, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, srv.URL+"/drive/v3/about", nil)
+	resp, err := recorder.Transport(srv.Client().Transport).RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	srv.Close()
+
+	player, err := OpenCassette(CassetteReplay, dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// No auth dependencies in ctx: replay must not touch the token store.
+	ctx := WithCassette(context.Background(), player)
+	client, err := NewHTTPClientForScopes(ctx, "drive", "a@example.com", []string{"https://www.googleapis.com/auth/drive"})
+	if err != nil {
+		t.Fatalf("NewHTTPClientForScopes: %v", err)
+	}
+	req, _ = http.NewRequestWithContext(ctx, http.MethodGet, srv.URL+"/drive/v3/about", nil)
+	resp, err = client.Do(req)
+	if err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || string(body) != `{"ok":true}` {
+		t.Fatalf("replayed %d %s", resp.StatusCode, body)
+	}
+}
+
+func TestCassetteFromEnv(t *testing.T) {
+	if c, err := CassetteFromEnv("", ""); c != nil || err != nil {
+		t.Fatalf("no env = %v, %v", c, err)
+	}
+	if _, err := CassetteFromEnv(t.TempDir(), t.TempDir()); !errors.Is(err, errCassetteModeConflict) {
+		t.Fatalf("both set err = %v", err)
+	}
+	if _, err := CassetteFromEnv("", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("replay from a missing dir should fail")
+	}
+}
+
+func TestNormalizeMultipartBoundary(t *testing.T) {
+	a := normalizeMultipartBoundary("multipart/related; boundary=abc123", []byte("--abc123\r\nx\r\n--abc123--"))
+	b := normalizeMultipartBoundary("multipart/related; boundary=zzz999", []byte("--zzz999\r\nx\r\n--zzz999--"))
+	if string(a) != string(b) {
+		t.Fatalf("boundaries not normalized: %q vs %q", a, b)
+	}
+}
@@ -117,6 +117,19 @@ func newGoogleService[T any](
 	return svc, nil
 }
 
+// keylessTokenSourceFromContext resolves ADC or external-account credentials
+// when GOG_AUTH_MODE selects them; ok is false for stored-token auth.
+func keylessTokenSourceFromContext(ctx context.Context, serviceLabel string, scopes []string) (oauth2.TokenSource, bool, error) {
+	dependencies, ok := authDependenciesFromContext(ctx)
+	if !ok || !dependencies.Mode.Keyless() {
+		return nil, false, nil
+	}
+
+	slog.Debug("using keyless credentials", "mode", string(dependencies.Mode), "serviceLabel", serviceLabel)
+
+	return dependencies.keylessTokenSource(ctx, scopes)
+}
+
 func authenticatedTransport(ctx context.Context, serviceLabel string, email string, scopes []string) (http.RoundTripper, error) {
 	return authenticatedTransportWithStoredScopeCheck(ctx, serviceLabel, email, scopes, false)
 }
@@ -128,17 +141,20 @@ func authenticatedTransportWithStoredScopeCheck(
 	scopes []string,
 	requireStoredGrant bool,
 ) (http.RoundTripper, error) {
-	var ts oauth2.TokenSource
+	if transport, ok := replayTransport(ctx); ok {
+		slog.Debug("replaying recorded responses (GOG_REPLAY)", "serviceLabel", serviceLabel)
+
+		return transport, nil
+	}
 
-	if dependencies, ok := authDependenciesFromContext(ctx); ok && dependencies.Mode == AuthModeADC {
-		slog.Debug("using Application Default Credentials (GOG_AUTH_MODE=adc)", "serviceLabel", serviceLabel)
+	var ts oauth2.TokenSource
 
-		adcTS, err := dependencies.adcTokenSource(ctx, scopes)
+	if keylessTS, ok, err := keylessTokenSourceFromContext(ctx, serviceLabel, scopes); ok {
 		if err != nil {
 			return nil, err
 		}
 
-		ts = adcTS
+		ts = keylessTS
 	} else {
 		var err error
 
@@ -148,10 +164,10 @@ func authenticatedTransportWithStoredScopeCheck(
 		}
 	}
 
-	retryTransport := NewRetryTransport(&oauth2.Transport{
+	retryTransport := newServiceRetryTransport(serviceLabel, rateLimitedTransport(ctx, serviceLabel, email, &oauth2.Transport{
 		Source: ts,
-		Base:   newBaseTransport(),
-	})
+		Base:   baseTransport(ctx),
+	}))
 
 	if refresher, ok := ts.(interface {
 		ForceRefresh(context.Context) error
@@ -159,7 +175,7 @@ func authenticatedTransportWithStoredScopeCheck(
 		retryTransport.RefreshAuth = refresher.ForceRefresh
 	}
 
-	return readOnlyTransportFromContext(ctx, retryTransport), nil
+	return readOnlyTransportFromContext(ctx, auditedTransport(ctx, serviceLabel, email, retryTransport)), nil
 }
 
 func optionsForAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string) ([]option.ClientOption, error) {
@@ -171,21 +187,22 @@ func optionsForAccountScopesRequiringStoredGrant(ctx context.Context, serviceLab
 }
 
 func optionsForServiceAccountScopes(ctx context.Context, serviceLabel string, email string, scopes []string) ([]option.ClientOption, error) {
-	if dependencies, ok := authDependenciesFromContext(ctx); ok && dependencies.Mode == AuthModeADC {
-		slog.Debug("using Application Default Credentials (GOG_AUTH_MODE=adc)", "serviceLabel", serviceLabel)
+	if transport, ok := replayTransport(ctx); ok {
+		return []option.ClientOption{option.WithHTTPClient(&http.Client{Transport: transport})}, nil
+	}
 
-		ts, err := dependencies.adcTokenSource(ctx, scopes)
+	if ts, ok, err := keylessTokenSourceFromContext(ctx, serviceLabel, scopes); ok {
 		if err != nil {
 			return nil, err
 		}
 
-		return tokenSourceClientOptions(ctx, ts), nil
+		return tokenSourceClientOptions(ctx, serviceLabel, email, ts), nil
 	}
 
 	if accessToken := authclient.AccessTokenFromContext(ctx); accessToken != "" {
 		slog.Debug("using direct access token", "serviceLabel", serviceLabel)
 
-		return tokenSourceClientOptions(ctx, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken})), nil
+		return tokenSourceClientOptions(ctx, serviceLabel, email, oauth2.StaticTokenSource(&oauth2.Token{AccessToken: accessToken})), nil
 	}
 
 	dependencies, err := requireAuthDependencies(ctx)
@@ -193,6 +210,14 @@ func optionsForServiceAccountScopes(ctx context.Context, serviceLabel string, em
 		return nil, err
 	}
 
+	if brokerTS, ok, err := dependencies.brokerTokenSource(ctx, serviceLabel, email, scopes); ok {
+		if err != nil {
+			return nil, err
+		}
+
+		return tokenSourceClientOptions(ctx, serviceLabel, email, brokerTS), nil
+	}
+
 	ts, path, ok, err := tokenSourceForServiceAccountScopes(ctx, dependencies, serviceLabel, email, scopes)
 	if err != nil {
 		return nil, fmt.Errorf("service account token source: %w", err)
@@ -204,15 +229,15 @@ func optionsForServiceAccountScopes(ctx context.Context, serviceLabel string, em
 
 	slog.Debug("using required service account credentials", "email", email, "path", path)
 
-	return tokenSourceClientOptions(ctx, ts), nil
+	return tokenSourceClientOptions(ctx, serviceLabel, email, ts), nil
 }
 
-func tokenSourceClientOptions(ctx context.Context, ts oauth2.TokenSource) []option.ClientOption {
+func tokenSourceClientOptions(ctx context.Context, serviceLabel, email string, ts oauth2.TokenSource) []option.ClientOption {
 	return []option.ClientOption{option.WithHTTPClient(&http.Client{
-		Transport: readOnlyTransportFromContext(ctx, NewRetryTransport(&oauth2.Transport{
+		Transport: readOnlyTransportFromContext(ctx, auditedTransport(ctx, serviceLabel, email, newServiceRetryTransport(serviceLabel, rateLimitedTransport(ctx, serviceLabel, email, &oauth2.Transport{
 			Source: ts,
-			Base:   newBaseTransport(),
-		})),
+			Base:   baseTransport(ctx),
+		})))),
 	})}
 }
 
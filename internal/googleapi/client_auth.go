@@ -274,6 +274,10 @@ func tokenSourceForAvailableAccountAuthWithStoredScopeCheck(
 		return nil, err
 	}
 
+	if brokerTS, ok, err := dependencies.brokerTokenSource(ctx, serviceLabel, email, scopes); ok {
+		return brokerTS, err
+	}
+
 	serviceAccountTS, saPath, ok, err := tokenSourceForServiceAccountScopes(ctx, dependencies, serviceLabel, email, scopes)
 	if err != nil {
 		return nil, fmt.Errorf("service account token source: %w", err)
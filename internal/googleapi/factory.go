@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	datatransfer "google.golang.org/api/admin/datatransfer/v1"
 	admin "google.golang.org/api/admin/directory/v1"
 	analyticsadmin "google.golang.org/api/analyticsadmin/v1beta"
 	analyticsdata "google.golang.org/api/analyticsdata/v1beta"
@@ -62,6 +63,10 @@ func (f Factory) AdminOrgUnit(ctx context.Context, account string) (*admin.Servi
 	return NewAdminDirectoryOrgUnit(f.withAuth(ctx), account)
 }
 
+func (f Factory) AdminDataTransfer(ctx context.Context, account string) (*datatransfer.Service, error) {
+	return NewAdminDataTransfer(f.withAuth(ctx), account)
+}
+
 func (f Factory) AppScript(ctx context.Context, account string) (*script.Service, error) {
 	return NewAppScript(f.withAuth(ctx), account)
 }
@@ -82,6 +87,12 @@ func (f Factory) Chat(ctx context.Context, account string) (*chat.Service, error
 	return NewChat(f.withAuth(ctx), account)
 }
 
+// Batch returns the raw client that Batch uses to reach a service's /batch
+// endpoint with that service's grant.
+func (f Factory) Batch(ctx context.Context, account string, service googleauth.Service) (*http.Client, error) {
+	return NewHTTPClient(f.withAuth(ctx), service, account)
+}
+
 func (f Factory) Classroom(ctx context.Context, account string) (*classroom.Service, error) {
 	return NewClassroom(f.withAuth(ctx), account)
 }
@@ -150,6 +161,10 @@ func (f Factory) Photos(ctx context.Context, account string) (*PhotosClient, err
 	return NewPhotosClientForAccount(f.withAuth(ctx), account, WithPhotosBaseURL(f.photosBaseURL))
 }
 
+func (f Factory) PhotosWrite(ctx context.Context, account string) (*PhotosClient, error) {
+	return NewPhotosWriteClientForAccount(f.withAuth(ctx), account, WithPhotosBaseURL(f.photosBaseURL))
+}
+
 func (f Factory) PhotosPicker(ctx context.Context, account string) (*PhotosPickerClient, error) {
 	return NewPhotosPickerClientForAccount(f.withAuth(ctx), account, WithPhotosPickerBaseURL(f.photosPickerBaseURL))
 }
@@ -189,3 +204,7 @@ func (f Factory) YouTubeComments(ctx context.Context, account string) (*youtube.
 func (f Factory) YouTubeWrite(ctx context.Context, account string) (*youtube.Service, error) {
 	return NewYouTubeWriteForAccount(f.withAuth(ctx), account)
 }
+
+func (f Factory) YouTubeUpload(ctx context.Context, account string) (*http.Client, error) {
+	return NewYouTubeUploadHTTPClient(f.withAuth(ctx), account)
+}
@@ -43,7 +43,7 @@ func newKeepWithServiceAccount(
 		return nil, err
 	}
 
-	svc, err := keep.NewService(ctx, tokenSourceClientOptions(ctx, tokenSource)...)
+	svc, err := keep.NewService(ctx, tokenSourceClientOptions(ctx, "keep", impersonateEmail, tokenSource)...)
 	if err != nil {
 		return nil, fmt.Errorf("create keep service: %w", err)
 	}
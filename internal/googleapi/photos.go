@@ -239,6 +239,10 @@ func (c *PhotosClient) doJSON(ctx context.Context, method, endpoint string, body
 		return photosAPIError(resp.StatusCode, respBody)
 	}
 
+	if out == nil {
+		return nil
+	}
+
 	if err := json.Unmarshal(respBody, out); err != nil {
 		return fmt.Errorf("decode Photos API response: %w", err)
 	}
@@ -0,0 +1,231 @@
+package googleapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/steipete/gogcli/internal/googleauth"
+)
+
+const (
+	scopePhotosAppendOnly       = "https://www.googleapis.com/auth/photoslibrary.appendonly"
+	scopePhotosEditAppCreated   = "https://www.googleapis.com/auth/photoslibrary.edit.appcreateddata"
+	PhotosBatchCreateMaxItems   = 50
+	PhotosAlbumBatchMaxItems    = 50
+	photosUploadTokenLimitBytes = 64 << 10
+)
+
+var (
+	errEmptyPhotosAlbumID     = errors.New("empty album id")
+	errEmptyPhotosUploadToken = errors.New("photos upload returned an empty upload token")
+)
+
+// PhotosWriteScopes are the explicit extra scopes needed to upload media and
+// manage app-created albums. The default photos service stays read-only.
+var PhotosWriteScopes = []string{scopePhotosAppendOnly, scopePhotosEditAppCreated}
+
+// NewPhotosWriteClientForAccount creates a Photos Library client for uploads
+// and album changes using PhotosWriteScopes.
+func NewPhotosWriteClientForAccount(ctx context.Context, email string, opts ...PhotosClientOption) (*PhotosClient, error) {
+	client, err := NewHTTPClientForScopes(ctx, string(googleauth.ServicePhotos), email, PhotosWriteScopes)
+	if err != nil {
+		return nil, fmt.Errorf("photos write OAuth client: %w", err)
+	}
+
+	return NewPhotosClient(client, opts...), nil
+}
+
+//nolint:tagliatelle // Google Photos API uses lowerCamelCase JSON fields.
+type PhotosAlbum struct {
+	ID                    string `json:"id,omitempty"`
+	Title                 string `json:"title,omitempty"`
+	ProductURL            string `json:"productUrl,omitempty"`
+	IsWriteable           bool   `json:"isWriteable,omitempty"`
+	MediaItemsCount       string `json:"mediaItemsCount,omitempty"`
+	CoverPhotoMediaItemID string `json:"coverPhotoMediaItemId,omitempty"`
+}
+
+//nolint:tagliatelle // Google Photos API uses lowerCamelCase JSON fields.
+type PhotosAlbumsResponse struct {
+	Albums        []*PhotosAlbum `json:"albums,omitempty"`
+	NextPageToken string         `json:"nextPageToken,omitempty"`
+}
+
+// PhotosNewMediaItem is one uploaded file to turn into a media item.
+type PhotosNewMediaItem struct {
+	UploadToken string
+	FileName    string
+	Description string
+}
+
+type PhotosStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+//nolint:tagliatelle // Google Photos API uses lowerCamelCase JSON fields.
+type PhotosNewMediaItemResult struct {
+	UploadToken string           `json:"uploadToken,omitempty"`
+	Status      *PhotosStatus    `json:"status,omitempty"`
+	MediaItem   *PhotosMediaItem `json:"mediaItem,omitempty"`
+}
+
+//nolint:tagliatelle // Google Photos API uses lowerCamelCase JSON fields.
+type PhotosBatchCreateResponse struct {
+	NewMediaItemResults []*PhotosNewMediaItemResult `json:"newMediaItemResults,omitempty"`
+}
+
+// UploadMedia sends raw bytes to the uploads endpoint and returns the upload
+// token that BatchCreateMediaItems turns into a media item. The body is
+// rewound for transport retries instead of being buffered in memory.
+func (c *PhotosClient) UploadMedia(ctx context.Context, body io.ReadSeeker, size int64, fileName, mimeType string) (string, error) {
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("rewind Photos upload body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/uploads", io.NopCloser(body))
+	if err != nil {
+		return "", fmt.Errorf("build Photos upload request: %w", err)
+	}
+
+	req.ContentLength = size
+	req.GetBody = func() (io.ReadCloser, error) {
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		return io.NopCloser(body), nil
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("X-Goog-Upload-Protocol", "raw")
+	req.Header.Set("X-Goog-Upload-File-Name", fileName)
+
+	if mimeType != "" {
+		req.Header.Set("X-Goog-Upload-Content-Type", mimeType)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("send Photos upload: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, photosUploadTokenLimitBytes))
+	if err != nil {
+		return "", fmt.Errorf("read Photos upload response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", photosAPIError(resp.StatusCode, respBody)
+	}
+
+	token := strings.TrimSpace(string(respBody))
+	if token == "" {
+		return "", errEmptyPhotosUploadToken
+	}
+
+	return token, nil
+}
+
+// BatchCreateMediaItems creates up to PhotosBatchCreateMaxItems media items,
+// optionally adding them to an app-created album. Per-item failures are
+// reported in the result statuses rather than as an error.
+func (c *PhotosClient) BatchCreateMediaItems(ctx context.Context, albumID string, items []PhotosNewMediaItem) (*PhotosBatchCreateResponse, error) {
+	if len(items) > PhotosBatchCreateMaxItems {
+		return nil, fmt.Errorf("batchCreate accepts at most %d items, got %d", PhotosBatchCreateMaxItems, len(items))
+	}
+
+	newItems := make([]map[string]any, 0, len(items))
+	for _, item := range items {
+		entry := map[string]any{
+			"simpleMediaItem": map[string]any{
+				"uploadToken": item.UploadToken,
+				"fileName":    item.FileName,
+			},
+		}
+		if item.Description != "" {
+			entry["description"] = item.Description
+		}
+
+		newItems = append(newItems, entry)
+	}
+
+	body := map[string]any{"newMediaItems": newItems}
+	if strings.TrimSpace(albumID) != "" {
+		body["albumId"] = strings.TrimSpace(albumID)
+	}
+
+	var out PhotosBatchCreateResponse
+	if err := c.doJSON(ctx, http.MethodPost, c.baseURL+"/mediaItems:batchCreate", body, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (c *PhotosClient) ListAlbums(ctx context.Context, opts PhotosListOptions) (*PhotosAlbumsResponse, error) {
+	u, err := url.Parse(c.baseURL + "/albums")
+	if err != nil {
+		return nil, fmt.Errorf("build Photos albums URL: %w", err)
+	}
+
+	q := u.Query()
+	if opts.PageSize > 0 {
+		q.Set("pageSize", fmt.Sprint(opts.PageSize))
+	}
+
+	if strings.TrimSpace(opts.PageToken) != "" {
+		q.Set("pageToken", strings.TrimSpace(opts.PageToken))
+	}
+
+	u.RawQuery = q.Encode()
+
+	var out PhotosAlbumsResponse
+	if err := c.doJSON(ctx, http.MethodGet, u.String(), nil, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+func (c *PhotosClient) CreateAlbum(ctx context.Context, title string) (*PhotosAlbum, error) {
+	var out PhotosAlbum
+	if err := c.doJSON(ctx, http.MethodPost, c.baseURL+"/albums", map[string]any{"album": map[string]any{"title": title}}, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
+}
+
+// AddMediaItemsToAlbum adds up to PhotosAlbumBatchMaxItems app-created media
+// items to an app-created album.
+func (c *PhotosClient) AddMediaItemsToAlbum(ctx context.Context, albumID string, mediaItemIDs []string) error {
+	return c.albumBatch(ctx, albumID, "batchAddMediaItems", mediaItemIDs)
+}
+
+// RemoveMediaItemsFromAlbum removes media items from an app-created album.
+// The media items themselves are kept.
+func (c *PhotosClient) RemoveMediaItemsFromAlbum(ctx context.Context, albumID string, mediaItemIDs []string) error {
+	return c.albumBatch(ctx, albumID, "batchRemoveMediaItems", mediaItemIDs)
+}
+
+func (c *PhotosClient) albumBatch(ctx context.Context, albumID, method string, mediaItemIDs []string) error {
+	albumID = strings.TrimSpace(albumID)
+	if albumID == "" {
+		return errEmptyPhotosAlbumID
+	}
+
+	if len(mediaItemIDs) > PhotosAlbumBatchMaxItems {
+		return fmt.Errorf("%s accepts at most %d items, got %d", method, PhotosAlbumBatchMaxItems, len(mediaItemIDs))
+	}
+
+	endpoint := c.baseURL + "/albums/" + url.PathEscape(albumID) + ":" + method
+
+	return c.doJSON(ctx, http.MethodPost, endpoint, map[string]any{"mediaItemIds": mediaItemIDs}, nil)
+}
@@ -0,0 +1,84 @@
+package googleapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/steipete/gogcli/internal/ratelimit"
+)
+
+type (
+	rateLimiterContextKey struct{}
+	callBudgetContextKey  struct{}
+)
+
+// WithRateLimiter throttles API clients created with ctx through limiter.
+func WithRateLimiter(ctx context.Context, limiter *ratelimit.Limiter) context.Context {
+	if limiter == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, rateLimiterContextKey{}, limiter)
+}
+
+// WithCallBudget caps the API calls made by clients created with ctx.
+func WithCallBudget(ctx context.Context, budget *ratelimit.CallBudget) context.Context {
+	if budget == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, callBudgetContextKey{}, budget)
+}
+
+// RateLimiterFromContext returns the limiter installed by WithRateLimiter.
+func RateLimiterFromContext(ctx context.Context) *ratelimit.Limiter {
+	if ctx == nil {
+		return nil
+	}
+
+	limiter, _ := ctx.Value(rateLimiterContextKey{}).(*ratelimit.Limiter)
+
+	return limiter
+}
+
+func callBudgetFromContext(ctx context.Context) *ratelimit.CallBudget {
+	if ctx == nil {
+		return nil
+	}
+
+	budget, _ := ctx.Value(callBudgetContextKey{}).(*ratelimit.CallBudget)
+
+	return budget
+}
+
+// rateLimitedTransport sits below RetryTransport, so every attempt, retries
+// included, spends a token and counts against the budgets.
+func rateLimitedTransport(ctx context.Context, serviceLabel, email string, base http.RoundTripper) http.RoundTripper {
+	limiter := RateLimiterFromContext(ctx)
+	budget := callBudgetFromContext(ctx)
+	if limiter == nil && budget == nil {
+		return base
+	}
+
+	return &rateLimitTransport{base: base, service: serviceLabel, account: email, limiter: limiter, budget: budget}
+}
+
+type rateLimitTransport struct {
+	base    http.RoundTripper
+	service string
+	account string
+	limiter *ratelimit.Limiter
+	budget  *ratelimit.CallBudget
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.budget.Take(); err != nil {
+		return nil, err
+	}
+
+	if err := t.limiter.Wait(req.Context(), t.account, t.service); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,45 @@
+package googleapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/steipete/gogcli/internal/ratelimit"
+)
+
+func TestRateLimitedTransport_CallBudgetCountsRetries(t *testing.T) {
+	mock := &mockTransport{
+		responses: []*http.Response{
+			{StatusCode: 429, Body: io.NopCloser(strings.NewReader("slow down"))},
+			{StatusCode: 429, Body: io.NopCloser(strings.NewReader("slow down"))},
+			{StatusCode: 200, Body: io.NopCloser(strings.NewReader("ok"))},
+		},
+	}
+	ctx := WithCallBudget(context.Background(), ratelimit.NewCallBudget(2))
+	rt := NewRetryTransport(rateLimitedTransport(ctx, "drive", "me@example.com", mock))
+	rt.BaseDelay = 0
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+	resp, err := rt.RoundTrip(req)
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	var budgetErr *ratelimit.CallBudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("err = %v, want call budget error", err)
+	}
+	if mock.calls != 2 {
+		t.Fatalf("calls = %d, want 2", mock.calls)
+	}
+}
+
+func TestRateLimitedTransport_NoLimitsKeepsBase(t *testing.T) {
+	base := &mockTransport{}
+	if got := rateLimitedTransport(context.Background(), "drive", "me@example.com", base); got != base {
+		t.Fatalf("transport = %T, want base", got)
+	}
+}
@@ -13,6 +13,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/steipete/gogcli/internal/audit"
+	"github.com/steipete/gogcli/internal/telemetry"
 )
 
 const (
@@ -43,7 +46,9 @@ func retriesDisabled(ctx context.Context) bool {
 // RetryTransport wraps an http.RoundTripper with retry logic for
 // rate limits (429) and server errors (5xx).
 type RetryTransport struct {
-	Base           http.RoundTripper
+	Base http.RoundTripper
+	// Service labels the telemetry spans for requests, e.g. "drive".
+	Service        string
 	MaxRetries429  int
 	MaxRetries5xx  int
 	BaseDelay      time.Duration
@@ -66,9 +71,26 @@ func NewRetryTransport(base http.RoundTripper) *RetryTransport {
 	}
 }
 
+func newServiceRetryTransport(serviceLabel string, base http.RoundTripper) *RetryTransport {
+	t := NewRetryTransport(base)
+	t.Service = serviceLabel
+
+	return t
+}
+
 // RoundTrip implements http.RoundTripper with retry logic.
 func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	apiMethod, _ := audit.DescribePath(req.URL.Path)
+	span := telemetry.StartAPIRequest(req.Context(), t.Service, req.Method, apiMethod)
+	resp, err := t.roundTrip(req, span)
+	span.End(resp, err)
+
+	return resp, err
+}
+
+func (t *RetryTransport) roundTrip(req *http.Request, span *telemetry.APIRequest) (*http.Response, error) {
 	if t.CircuitBreaker != nil && t.CircuitBreaker.IsOpen() {
+		span.CircuitOpen()
 		return nil, &CircuitBreakerError{}
 	}
 	retryDisabled := retriesDisabled(req.Context())
@@ -125,6 +147,8 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 			drainAndClose(resp.Body)
 
+			span.Retry("429", resp.StatusCode, delay)
+
 			if err := t.sleep(req.Context(), delay); err != nil {
 				return nil, err
 			}
@@ -150,6 +174,8 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 			drainAndClose(resp.Body)
 
+			span.Retry("5xx", resp.StatusCode, ServerErrorRetryDelay)
+
 			if err := t.sleep(req.Context(), ServerErrorRetryDelay); err != nil {
 				return nil, err
 			}
@@ -176,6 +202,7 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 				}
 
 				drainAndClose(resp.Body)
+				span.Retry("insufficient_scopes", resp.StatusCode, 0)
 
 				retriedAuth = true
 
@@ -0,0 +1,110 @@
+package googleapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func recordSpans(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		_ = tp.Shutdown(context.Background())
+	})
+
+	return exporter
+}
+
+func spanAttrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	out := map[attribute.Key]attribute.Value{}
+	for _, kv := range span.Attributes {
+		out[kv.Key] = kv.Value
+	}
+
+	return out
+}
+
+func TestRetryTransport_TracesRetries(t *testing.T) {
+	exporter := recordSpans(t)
+	mock := &mockTransport{
+		responses: []*http.Response{
+			{StatusCode: 429, Body: io.NopCloser(strings.NewReader("rate limited"))},
+			{StatusCode: 429, Body: io.NopCloser(strings.NewReader("rate limited"))},
+			{StatusCode: 200, Body: io.NopCloser(strings.NewReader("ok"))},
+		},
+	}
+
+	rt := newServiceRetryTransport("drive", mock)
+	rt.BaseDelay = time.Millisecond
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://www.googleapis.com/drive/v3/files/abc123/permissions", nil)
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(spans))
+	}
+	span := spans[0]
+	if span.Name != "GET drive/v3/files/{id}/permissions" {
+		t.Fatalf("span name = %q", span.Name)
+	}
+	attrs := spanAttrs(span)
+	if got := attrs["gog.service"].AsString(); got != "drive" {
+		t.Fatalf("gog.service = %q", got)
+	}
+	if got := attrs["gog.retry.count"].AsInt64(); got != 2 {
+		t.Fatalf("gog.retry.count = %d, want 2", got)
+	}
+	if got := attrs["http.response.status_code"].AsInt64(); got != 200 {
+		t.Fatalf("status = %d", got)
+	}
+	if span.Status.Code != codes.Ok {
+		t.Fatalf("status code = %v", span.Status)
+	}
+	if len(span.Events) != 2 || span.Events[0].Name != "retry" {
+		t.Fatalf("events = %+v", span.Events)
+	}
+}
+
+func TestRetryTransport_TracesOpenCircuitBreaker(t *testing.T) {
+	exporter := recordSpans(t)
+
+	rt := newServiceRetryTransport("gmail", &mockTransport{})
+	for i := 0; i < CircuitBreakerThreshold; i++ {
+		rt.CircuitBreaker.RecordFailure()
+	}
+
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://gmail.googleapis.com/gmail/v1/users/me/messages", nil)
+	var cbErr *CircuitBreakerError
+	if _, err := rt.RoundTrip(req); !errors.As(err, &cbErr) {
+		t.Fatalf("err = %v, want CircuitBreakerError", err)
+	}
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("spans = %d, want 1", len(spans))
+	}
+	if !spanAttrs(spans[0])["gog.circuit_breaker.open"].AsBool() || spans[0].Status.Code != codes.Error {
+		t.Fatalf("span = %+v", spans[0])
+	}
+}
@@ -39,12 +39,12 @@ func NewYouTubeWithAPIKey(ctx context.Context, apiKey string) (*youtube.Service,
 }
 
 func newYouTubeAPIKeyHTTPClient(ctx context.Context, apiKey string) (*http.Client, error) {
-	transport, err := httptransport.NewTransport(ctx, newBaseTransport(), option.WithAPIKey(apiKey))
+	transport, err := httptransport.NewTransport(ctx, baseTransport(ctx), option.WithAPIKey(apiKey))
 	if err != nil {
 		return nil, fmt.Errorf("youtube API key transport: %w", err)
 	}
 
-	return &http.Client{Transport: NewRetryTransport(transport)}, nil
+	return &http.Client{Transport: auditedTransport(ctx, "youtube", "", newServiceRetryTransport("youtube", rateLimitedTransport(ctx, "youtube", "", transport)))}, nil
 }
 
 // NewYouTubeForAccount creates a YouTube Data API v3 service client using OAuth for the given account.
@@ -95,3 +95,15 @@ func NewYouTubeWriteForAccount(ctx context.Context, email string) (*youtube.Serv
 
 	return svc, nil
 }
+
+// NewYouTubeUploadHTTPClient returns a raw client for the resumable upload
+// protocol, which needs the session URI that the generated client keeps
+// private. It uses the same youtube.force-ssl grant as account mutations.
+func NewYouTubeUploadHTTPClient(ctx context.Context, email string) (*http.Client, error) {
+	client, err := NewHTTPClientForScopes(ctx, string(googleauth.ServiceYouTube), email, []string{scopeYouTubeForceSSL})
+	if err != nil {
+		return nil, fmt.Errorf("youtube upload OAuth client: %w", err)
+	}
+
+	return client, nil
+}
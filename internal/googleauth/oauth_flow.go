@@ -24,6 +24,7 @@ type AuthorizeOptions struct {
 	Services                    []Service
 	Scopes                      []string
 	Manual                      bool
+	Device                      bool
 	ForceConsent                bool
 	DisableIncludeGrantedScopes bool
 	Timeout                     time.Duration
@@ -108,6 +109,10 @@ func Authorize(ctx context.Context, opts AuthorizeOptions) (string, error) {
 		return "", errMissingScopes
 	}
 
+	if opts.Device && (opts.Manual || strings.TrimSpace(opts.AuthURL) != "" || strings.TrimSpace(opts.AuthCode) != "") {
+		return "", errDeviceWithManual
+	}
+
 	if opts.Manual && opts.ManualStateStore == nil {
 		return "", errManualStateStore
 	}
@@ -120,6 +125,10 @@ func Authorize(ctx context.Context, opts AuthorizeOptions) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
 	defer cancel()
 
+	if opts.Device {
+		return authorizeDevice(ctx, opts, creds)
+	}
+
 	if opts.Manual {
 		return authorizeManual(ctx, opts, creds)
 	}
@@ -0,0 +1,61 @@
+package googleauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+var (
+	errDeviceWithManual = errors.New("device flow cannot be combined with manual or remote auth options")
+
+	deviceOutput io.Writer = os.Stderr
+)
+
+// authorizeDevice runs the OAuth 2.0 device authorization grant (RFC 8628).
+// Google only issues device codes to "TVs and Limited Input devices" clients
+// and only for a short list of scopes, so errors carry that hint.
+func authorizeDevice(ctx context.Context, opts AuthorizeOptions, creds config.ClientCredentials) (string, error) {
+	cfg := oauth2.Config{
+		ClientID:     creds.ClientID,
+		ClientSecret: creds.ClientSecret,
+		Endpoint:     oauthEndpoint,
+		Scopes:       opts.Scopes,
+	}
+
+	da, err := cfg.DeviceAuth(ctx)
+	if err != nil {
+		return "", fmt.Errorf("request device code: %w (the OAuth client must be of type \"TVs and Limited Input devices\", and Google allows only some scopes in the device flow)", err)
+	}
+
+	fmt.Fprintln(deviceOutput, "On any device with a browser, open:")
+	fmt.Fprintln(deviceOutput, "  "+da.VerificationURI)
+	fmt.Fprintln(deviceOutput, "and enter the code:")
+	fmt.Fprintln(deviceOutput, "  "+da.UserCode)
+	if da.VerificationURIComplete != "" {
+		fmt.Fprintln(deviceOutput, "Or open this URL with the code filled in:")
+		fmt.Fprintln(deviceOutput, "  "+da.VerificationURIComplete)
+	}
+	fmt.Fprintln(deviceOutput)
+	fmt.Fprintln(deviceOutput, "Waiting for authorization...")
+
+	tok, err := cfg.DeviceAccessToken(ctx, da)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return "", fmt.Errorf("device authorization timed out: %w", ctxErr)
+		}
+		return "", fmt.Errorf("device authorization: %w", err)
+	}
+
+	if tok.RefreshToken == "" {
+		return "", errNoRefreshToken
+	}
+
+	return tok.RefreshToken, nil
+}
@@ -0,0 +1,111 @@
+package googleauth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/oauth2"
+
+	"github.com/steipete/gogcli/internal/config"
+)
+
+func useDeviceTestServer(t *testing.T, handler http.HandlerFunc) *bytes.Buffer {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	origRead := readClientCredentials
+	origEndpoint := oauthEndpoint
+	origOutput := deviceOutput
+	var out bytes.Buffer
+	readClientCredentials = func(string) (config.ClientCredentials, error) {
+		return config.ClientCredentials{ClientID: "id", ClientSecret: "secret"}, nil
+	}
+	oauthEndpoint = oauth2.Endpoint{
+		AuthURL:       srv.URL + "/auth",
+		TokenURL:      srv.URL + "/token",
+		DeviceAuthURL: srv.URL + "/device/code",
+	}
+	deviceOutput = &out
+	t.Cleanup(func() {
+		srv.Close()
+		readClientCredentials = origRead
+		oauthEndpoint = origEndpoint
+		deviceOutput = origOutput
+	})
+	return &out
+}
+
+func TestAuthorize_Device_PollsUntilApproved(t *testing.T) {
+	polls := 0
+	out := useDeviceTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/device/code":
+			if r.Form.Get("scope") != "openid https://www.googleapis.com/auth/drive.file" {
+				t.Errorf("scope = %q", r.Form.Get("scope"))
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"device_code":      "dev-123",
+				"user_code":        "ABCD-EFGH",
+				"verification_url": "https://www.google.com/device",
+				"expires_in":       1800,
+				"interval":         1,
+			})
+		case "/token":
+			if r.Form.Get("grant_type") != "urn:ietf:params:oauth:grant-type:device_code" || r.Form.Get("device_code") != "dev-123" {
+				t.Errorf("token form = %v", r.Form)
+			}
+			polls++
+			if polls == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]any{"error": "authorization_pending"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"access_token":  "at",
+				"refresh_token": "rt",
+				"token_type":    "Bearer",
+				"expires_in":    3600,
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	rt, err := Authorize(context.Background(), AuthorizeOptions{
+		Device: true,
+		Scopes: []string{"openid", "https://www.googleapis.com/auth/drive.file"},
+	})
+	if err != nil {
+		t.Fatalf("Authorize: %v", err)
+	}
+	if rt != "rt" || polls != 2 {
+		t.Fatalf("refresh token = %q, polls = %d", rt, polls)
+	}
+	if !strings.Contains(out.String(), "https://www.google.com/device") || !strings.Contains(out.String(), "ABCD-EFGH") {
+		t.Fatalf("device prompt = %q", out.String())
+	}
+}
+
+func TestAuthorize_Device_ExplainsRejectedClient(t *testing.T) {
+	useDeviceTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		_ = json.NewEncoder(w).Encode(map[string]any{"error": "invalid_client", "error_description": "Invalid client type."})
+	})
+
+	_, err := Authorize(context.Background(), AuthorizeOptions{Device: true, Scopes: []string{"openid"}})
+	if err == nil || !strings.Contains(err.Error(), "TVs and Limited Input devices") {
+		t.Fatalf("err = %v", err)
+	}
+
+	_, err = Authorize(context.Background(), AuthorizeOptions{Device: true, Manual: true, Scopes: []string{"openid"}})
+	if err == nil || !strings.Contains(err.Error(), "device flow cannot be combined") {
+		t.Fatalf("device+manual err = %v", err)
+	}
+}
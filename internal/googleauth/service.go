@@ -537,6 +537,12 @@ func ScopesForManageWithOptions(services []Service, opts ScopeOptions) ([]string
 	return merged, nil
 }
 
+// ScopesForServicesWithOptions is ScopesForServices honoring opts, e.g.
+// read-only variants; it adds no identity or extra scopes.
+func ScopesForServicesWithOptions(services []Service, opts ScopeOptions) ([]string, error) {
+	return scopesForServicesWithOptions(services, opts)
+}
+
 func scopesForServicesWithOptions(services []Service, opts ScopeOptions) ([]string, error) {
 	set := make(map[string]struct{})
 
@@ -0,0 +1,279 @@
+package outfmt
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Format is an --output format beyond the default table, --json, and --plain.
+type Format string
+
+const (
+	FormatCSV      Format = "csv"
+	FormatYAML     Format = "yaml"
+	FormatNDJSON   Format = "ndjson"
+	FormatTemplate Format = "template"
+)
+
+// OutputFormats lists the values --output accepts.
+func OutputFormats() []string {
+	return []string{"table", "plain", "json", "csv", "yaml", "ndjson", "template"}
+}
+
+// ParseOutput parses an --output value. yaml, ndjson, and template set JSON
+// because commands build the same payload for them and WriteJSON encodes it;
+// csv sets Plain because it re-encodes table output.
+func ParseOutput(value string) (Mode, error) {
+	name, text, hasText := strings.Cut(strings.TrimSpace(value), "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+	if hasText && name != string(FormatTemplate) {
+		return Mode{}, &ParseError{msg: fmt.Sprintf("invalid --output %q (only template takes a value)", value)}
+	}
+
+	switch name {
+	case "", "table":
+		return Mode{}, nil
+	case "json":
+		return Mode{JSON: true}, nil
+	case "plain", "tsv":
+		return Mode{Plain: true}, nil
+	case string(FormatCSV):
+		return Mode{Plain: true, Format: FormatCSV}, nil
+	case string(FormatYAML), "yml":
+		return Mode{JSON: true, Format: FormatYAML}, nil
+	case string(FormatNDJSON), "jsonl":
+		return Mode{JSON: true, Format: FormatNDJSON}, nil
+	case string(FormatTemplate):
+		if strings.TrimSpace(text) == "" {
+			return Mode{}, &ParseError{msg: "--output template= requires a Go template, e.g. --output 'template={{.id}}'"}
+		}
+		tmpl, err := template.New("output").Funcs(templateFuncs).Parse(text)
+		if err != nil {
+			return Mode{}, &ParseError{msg: fmt.Sprintf("invalid --output template: %v", err)}
+		}
+		return Mode{JSON: true, Format: FormatTemplate, Template: tmpl}, nil
+	default:
+		return Mode{}, &ParseError{msg: fmt.Sprintf("invalid --output %q (use %s)", value, strings.Join(OutputFormats(), "|"))}
+	}
+}
+
+func IsCSV(ctx context.Context) bool    { return FromContext(ctx).Format == FormatCSV }
+func IsNDJSON(ctx context.Context) bool { return FromContext(ctx).Format == FormatNDJSON }
+
+type ndjsonStreamKey struct{}
+
+// ndjsonStream records whether a command already streamed its results.
+type ndjsonStream struct {
+	started bool
+}
+
+func ndjsonStreamed(ctx context.Context) bool {
+	s, ok := ctx.Value(ndjsonStreamKey{}).(*ndjsonStream)
+	return ok && s.started
+}
+
+// StreamItems writes items as NDJSON lines right away when the output format
+// is ndjson, so paginated lists print each page as it arrives. Once a command
+// streams, its final WriteJSON call is skipped. Other formats are a no-op.
+func StreamItems[T any](ctx context.Context, w io.Writer, items []T) error {
+	s, ok := ctx.Value(ndjsonStreamKey{}).(*ndjsonStream)
+	if !ok {
+		return nil
+	}
+	s.started = true
+
+	t, _ := JSONTransformFromContext(ctx)
+	t.ResultsOnly = false
+	lines := make([]any, 0, len(items))
+	for _, item := range items {
+		v, err := applyJSONTransform(item, t)
+		if err != nil {
+			return fmt.Errorf("transform json: %w", err)
+		}
+		lines = append(lines, v)
+	}
+	return writeNDJSON(ctx, w, lines)
+}
+
+// writeFormatted encodes v, already transformed, for yaml and template
+// output. It reports false for JSON.
+func writeFormatted(ctx context.Context, w io.Writer, v any) (bool, error) {
+	mode := FromContext(ctx)
+	switch mode.Format {
+	case FormatYAML:
+		return true, writeYAML(w, v)
+	case FormatTemplate:
+		return true, writeTemplate(w, mode.Template, v)
+	default:
+		return false, nil
+	}
+}
+
+func writeNDJSON(ctx context.Context, w io.Writer, v any) error {
+	lines, ok := v.([]any)
+	if !ok {
+		lines = []any{v}
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, line := range lines {
+		if opts, ok := UntrustedWrapperFromContext(ctx); ok {
+			wrapped, err := wrapUntrustedJSONValue(line, opts)
+			if err != nil {
+				return fmt.Errorf("wrap untrusted json: %w", err)
+			}
+			line = wrapped
+		}
+		if err := enc.Encode(line); err != nil {
+			return fmt.Errorf("encode ndjson: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func writeYAML(w io.Writer, v any) error {
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(yamlNumbers(generic)); err != nil {
+		return fmt.Errorf("encode yaml: %w", err)
+	}
+
+	return enc.Close()
+}
+
+// yamlNumbers turns json.Number leaves into ints or floats so YAML does not
+// quote them as strings.
+func yamlNumbers(v any) any {
+	switch vv := v.(type) {
+	case map[string]any:
+		for k, item := range vv {
+			vv[k] = yamlNumbers(item)
+		}
+		return vv
+	case []any:
+		for i, item := range vv {
+			vv[i] = yamlNumbers(item)
+		}
+		return vv
+	case json.Number:
+		if i, err := vv.Int64(); err == nil {
+			return i
+		}
+		if f, err := vv.Float64(); err == nil {
+			return f
+		}
+		return vv.String()
+	default:
+		return v
+	}
+}
+
+func writeTemplate(w io.Writer, tmpl *template.Template, v any) error {
+	if tmpl == nil {
+		return fmt.Errorf("execute template: no template")
+	}
+	generic, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+	if err := tmpl.Execute(w, generic); err != nil {
+		return fmt.Errorf("execute template: %w", err)
+	}
+
+	return nil
+}
+
+// toGeneric converts typed values into maps and slices keyed by their JSON
+// names, keeping numbers exact.
+func toGeneric(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal: %w", err)
+	}
+
+	var out any
+	dec := json.NewDecoder(strings.NewReader(string(b)))
+	dec.UseNumber()
+	if err := dec.Decode(&out); err != nil {
+		return nil, fmt.Errorf("unmarshal: %w", err)
+	}
+
+	return out, nil
+}
+
+// CSVLineWriter re-encodes tab-separated lines as CSV records, for tables
+// written line by line. Call Flush when done.
+type CSVLineWriter struct {
+	out     *csv.Writer
+	pending []byte
+}
+
+func NewCSVLineWriter(w io.Writer) *CSVLineWriter {
+	return &CSVLineWriter{out: csv.NewWriter(w)}
+}
+
+func (w *CSVLineWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		i := strings.IndexByte(string(w.pending), '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := strings.TrimSuffix(string(w.pending[:i]), "\r")
+		w.pending = w.pending[i+1:]
+		if err := w.out.Write(strings.Split(line, "\t")); err != nil {
+			return 0, fmt.Errorf("write csv: %w", err)
+		}
+	}
+}
+
+func (w *CSVLineWriter) Flush() error {
+	if len(w.pending) > 0 {
+		line := string(w.pending)
+		w.pending = nil
+		if err := w.out.Write(strings.Split(line, "\t")); err != nil {
+			return fmt.Errorf("write csv: %w", err)
+		}
+	}
+	w.out.Flush()
+	return w.out.Error()
+}
+
+func writeCSVRecord(w *csv.Writer, values []string) error {
+	if err := w.Write(values); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+	return nil
+}
+
+func numberString(v any) (string, bool) {
+	switch vv := v.(type) {
+	case json.Number:
+		return vv.String(), true
+	case string:
+		return vv, true
+	case int:
+		return strconv.Itoa(vv), true
+	case int64:
+		return strconv.FormatInt(vv, 10), true
+	case float64:
+		return strconv.FormatFloat(vv, 'f', -1, 64), true
+	default:
+		return "", false
+	}
+}
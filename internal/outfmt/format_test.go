@@ -0,0 +1,163 @@
+package outfmt
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func formatContext(t *testing.T, output string) context.Context {
+	t.Helper()
+
+	mode, err := ParseOutput(output)
+	if err != nil {
+		t.Fatalf("ParseOutput(%q): %v", output, err)
+	}
+
+	return WithMode(context.Background(), mode)
+}
+
+func TestParseOutput(t *testing.T) {
+	tests := []struct {
+		value  string
+		json   bool
+		plain  bool
+		format Format
+	}{
+		{"", false, false, ""},
+		{"table", false, false, ""},
+		{"JSON", true, false, ""},
+		{"tsv", false, true, ""},
+		{"csv", false, true, FormatCSV},
+		{"yml", true, false, FormatYAML},
+		{"jsonl", true, false, FormatNDJSON},
+		{"template={{.id}}", true, false, FormatTemplate},
+	}
+	for _, tt := range tests {
+		got, err := ParseOutput(tt.value)
+		if err != nil || got.JSON != tt.json || got.Plain != tt.plain || got.Format != tt.format {
+			t.Errorf("ParseOutput(%q) = %+v, %v", tt.value, got, err)
+		}
+	}
+
+	for _, bad := range []string{"xml", "csv=x", "template=", "template={{.id"} {
+		if _, err := ParseOutput(bad); err == nil {
+			t.Errorf("ParseOutput(%q): expected error", bad)
+		}
+	}
+}
+
+func TestWriteJSON_YAML(t *testing.T) {
+	var buf bytes.Buffer
+	payload := struct {
+		Files []map[string]any `json:"files"`
+		Count int64            `json:"count"`
+	}{Files: []map[string]any{{"id": "f1", "size": 12345678901234}}, Count: 1}
+	if err := WriteJSON(formatContext(t, "yaml"), &buf, payload); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	const want = "count: 1\nfiles:\n  - id: f1\n    size: 12345678901234\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("yaml = %q, want %q", got, want)
+	}
+}
+
+func TestWriteJSON_NDJSONEmitsPrimaryResults(t *testing.T) {
+	ctx := WithJSONTransform(formatContext(t, "ndjson"), JSONTransform{Select: []string{"id"}})
+
+	var buf bytes.Buffer
+	if err := WriteJSON(ctx, &buf, map[string]any{
+		"files":         []map[string]any{{"id": "a", "name": "A"}, {"id": "b", "name": "B"}},
+		"nextPageToken": "tok",
+	}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if got := buf.String(); got != "{\"id\":\"a\"}\n{\"id\":\"b\"}\n" {
+		t.Fatalf("ndjson = %q", got)
+	}
+
+	buf.Reset()
+	if err := WriteJSON(ctx, &buf, map[string]any{"id": "x", "name": "X", "size": 1}); err != nil {
+		t.Fatalf("WriteJSON object: %v", err)
+	}
+	if got := buf.String(); got != "{\"id\":\"x\"}\n" {
+		t.Fatalf("ndjson object = %q", got)
+	}
+}
+
+func TestStreamItems_SkipsFinalWrite(t *testing.T) {
+	ctx := formatContext(t, "ndjson")
+
+	var buf bytes.Buffer
+	for _, page := range [][]map[string]string{{{"id": "1"}}, {{"id": "2"}, {"id": "3"}}} {
+		if err := StreamItems(ctx, &buf, page); err != nil {
+			t.Fatalf("StreamItems: %v", err)
+		}
+	}
+	if err := WriteJSON(ctx, &buf, map[string]any{"items": []string{"1", "2", "3"}}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if got := buf.String(); got != "{\"id\":\"1\"}\n{\"id\":\"2\"}\n{\"id\":\"3\"}\n" {
+		t.Fatalf("stream = %q", got)
+	}
+
+	buf.Reset()
+	if err := StreamItems(formatContext(t, "json"), &buf, []string{"x"}); err != nil || buf.Len() != 0 {
+		t.Fatalf("json stream wrote %q, %v", buf.String(), err)
+	}
+}
+
+func TestWriteJSON_Template(t *testing.T) {
+	created := time.Date(2026, 3, 4, 5, 6, 7, 0, time.Local).UTC().Format(time.RFC3339)
+	ctx := formatContext(t, `template={{range .files}}{{.name | upper}} {{size .size}} {{date "2006-01-02" .createdTime}} {{join "," .parents}} {{default "-" .owner}}
+{{end}}`)
+
+	var buf bytes.Buffer
+	if err := WriteJSON(ctx, &buf, map[string]any{"files": []map[string]any{
+		{"name": "report", "size": "1572864", "createdTime": created, "parents": []string{"p1", "p2"}},
+	}}); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	if got := buf.String(); got != "REPORT 1.5 MB 2026-03-04 p1,p2 -\n" {
+		t.Fatalf("template = %q", got)
+	}
+}
+
+func TestWriteTableCSV(t *testing.T) {
+	var buf bytes.Buffer
+	err := WriteTable(formatContext(t, "csv"), &buf, []tableTestRow{
+		{ID: "1", Name: "one, two"},
+		{ID: "2", Name: `say "hi"`},
+	}, tableTestColumns())
+	if err != nil {
+		t.Fatalf("WriteTable: %v", err)
+	}
+
+	const want = "ID,NAME\n1,\"one, two\"\n2,\"say \"\"hi\"\"\"\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("csv = %q, want %q", got, want)
+	}
+}
+
+func TestCSVLineWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewCSVLineWriter(&buf)
+	for _, chunk := range []string{"ID\tNA", "ME\n1\ta,b\n", "2\tlast"} {
+		if _, err := w.Write([]byte(chunk)); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush: %v", err)
+	}
+
+	if got := buf.String(); got != "ID,NAME\n1,\"a,b\"\n2,last\n" {
+		t.Fatalf("csv = %q", got)
+	}
+	if strings.Contains(buf.String(), "\t") {
+		t.Fatalf("tab left in %q", buf.String())
+	}
+}
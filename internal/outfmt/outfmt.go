@@ -9,11 +9,16 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"text/template"
 )
 
+// Mode is the output mode. JSON is also set for the yaml, ndjson, and
+// template formats and Plain for csv; Format names the encoding.
 type Mode struct {
-	JSON  bool
-	Plain bool
+	JSON     bool
+	Plain    bool
+	Format   Format
+	Template *template.Template
 }
 
 type ParseError struct{ msg string }
@@ -45,6 +50,10 @@ func FromEnv() Mode {
 type ctxKey struct{}
 
 func WithMode(ctx context.Context, mode Mode) context.Context {
+	if mode.Format == FormatNDJSON {
+		ctx = context.WithValue(ctx, ndjsonStreamKey{}, &ndjsonStream{})
+	}
+
 	return context.WithValue(ctx, ctxKey{}, mode)
 }
 
@@ -99,6 +108,10 @@ func JSONTransformFromContext(ctx context.Context) (JSONTransform, bool) {
 }
 
 func WriteJSON(ctx context.Context, w io.Writer, v any) error {
+	if ndjsonStreamed(ctx) {
+		return nil
+	}
+
 	alreadyPrimary := false
 
 	if result, ok := v.(primaryResult); ok {
@@ -106,7 +119,12 @@ func WriteJSON(ctx context.Context, w io.Writer, v any) error {
 		alreadyPrimary = true
 	}
 
-	if t, ok := JSONTransformFromContext(ctx); ok && (t.ResultsOnly || len(t.Select) > 0) {
+	t, _ := JSONTransformFromContext(ctx)
+	if IsNDJSON(ctx) {
+		// NDJSON emits one line per result, so the envelope always goes.
+		t.ResultsOnly = true
+	}
+	if t.ResultsOnly || len(t.Select) > 0 {
 		if alreadyPrimary {
 			t.ResultsOnly = false
 		}
@@ -118,6 +136,11 @@ func WriteJSON(ctx context.Context, w io.Writer, v any) error {
 		v = transformed
 	}
 
+	if IsNDJSON(ctx) {
+		// Wrapped per line.
+		return writeNDJSON(ctx, w, v)
+	}
+
 	if opts, ok := UntrustedWrapperFromContext(ctx); ok {
 		wrapped, err := wrapUntrustedJSONValue(v, opts)
 		if err != nil {
@@ -126,6 +149,10 @@ func WriteJSON(ctx context.Context, w io.Writer, v any) error {
 		v = wrapped
 	}
 
+	if ok, err := writeFormatted(ctx, w, v); ok {
+		return err
+	}
+
 	enc := json.NewEncoder(w)
 	enc.SetEscapeHTML(false)
 	enc.SetIndent("", "  ")
@@ -139,17 +166,9 @@ func WriteJSON(ctx context.Context, w io.Writer, v any) error {
 
 func applyJSONTransform(v any, t JSONTransform) (any, error) {
 	// Convert typed structs into a generic representation so we can manipulate them.
-	b, err := json.Marshal(v)
+	anyV, err := toGeneric(v)
 	if err != nil {
-		return nil, fmt.Errorf("marshal: %w", err)
-	}
-
-	var anyV any
-	dec := json.NewDecoder(strings.NewReader(string(b)))
-	dec.UseNumber()
-
-	if err := dec.Decode(&anyV); err != nil {
-		return nil, fmt.Errorf("unmarshal: %w", err)
+		return nil, err
 	}
 
 	if t.ResultsOnly {
@@ -2,6 +2,7 @@ package outfmt
 
 import (
 	"context"
+	"encoding/csv"
 	"errors"
 	"fmt"
 	"io"
@@ -21,6 +22,10 @@ func WriteTable[T any](ctx context.Context, w io.Writer, rows []T, columns []Col
 		return errNoTableColumns
 	}
 
+	if IsCSV(ctx) {
+		return writeCSVTable(w, rows, columns)
+	}
+
 	target := w
 
 	var aligned *tabwriter.Writer
@@ -58,6 +63,34 @@ func WriteTable[T any](ctx context.Context, w io.Writer, rows []T, columns []Col
 	return nil
 }
 
+func writeCSVTable[T any](w io.Writer, rows []T, columns []Column[T]) error {
+	out := csv.NewWriter(w)
+
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		values[i] = column.Header
+	}
+	if err := writeCSVRecord(out, values); err != nil {
+		return fmt.Errorf("write table header: %w", err)
+	}
+
+	for _, row := range rows {
+		for i, column := range columns {
+			values[i] = column.Value(row)
+		}
+		if err := writeCSVRecord(out, values); err != nil {
+			return fmt.Errorf("write table row: %w", err)
+		}
+	}
+
+	out.Flush()
+	if err := out.Error(); err != nil {
+		return fmt.Errorf("flush table: %w", err)
+	}
+
+	return nil
+}
+
 func writeTableLine(w io.Writer, values []string) error {
 	_, err := io.WriteString(w, strings.Join(values, "\t")+"\n")
 	if err != nil {
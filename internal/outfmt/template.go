@@ -0,0 +1,109 @@
+package outfmt
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateFuncs are the helpers available to --output template=.
+var templateFuncs = template.FuncMap{
+	"date":    templateDate,
+	"size":    templateSize,
+	"json":    templateJSON,
+	"join":    templateJoin,
+	"default": templateDefault,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+}
+
+var templateTimeLayouts = []string{time.RFC3339Nano, time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// templateDate formats an RFC3339 timestamp, a date, or epoch milliseconds
+// with a Go time layout in local time. Values it cannot parse are returned
+// unchanged.
+func templateDate(layout string, v any) string {
+	s, ok := numberString(v)
+	if !ok || strings.TrimSpace(s) == "" {
+		return templateString(v)
+	}
+	for _, candidate := range templateTimeLayouts {
+		if t, err := time.Parse(candidate, s); err == nil {
+			return t.Local().Format(layout)
+		}
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms).Local().Format(layout)
+	}
+
+	return s
+}
+
+// templateSize formats a byte count as B, KB, MB, GB, or TB.
+func templateSize(v any) string {
+	s, ok := numberString(v)
+	if !ok {
+		return templateString(v)
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	if err != nil {
+		return s
+	}
+
+	const unit = 1024.0
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	i := 0
+	for n >= unit && i < len(units)-1 {
+		n /= unit
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d B", int64(n))
+	}
+
+	return fmt.Sprintf("%.1f %s", n, units[i])
+}
+
+func templateJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", fmt.Errorf("json: %w", err)
+	}
+
+	return string(b), nil
+}
+
+func templateJoin(sep string, v any) string {
+	items, ok := v.([]any)
+	if !ok {
+		return templateString(v)
+	}
+	parts := make([]string, 0, len(items))
+	for _, item := range items {
+		parts = append(parts, templateString(item))
+	}
+
+	return strings.Join(parts, sep)
+}
+
+func templateDefault(fallback any, v any) any {
+	if v == nil {
+		return fallback
+	}
+	if s, ok := v.(string); ok && s == "" {
+		return fallback
+	}
+
+	return v
+}
+
+func templateString(v any) string {
+	if v == nil {
+		return ""
+	}
+
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,340 @@
+// Package ratelimit throttles Google API calls per account and service with
+// token buckets and daily budgets shared by every gog process through a
+// small state file.
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/steipete/gogcli/internal/filelock"
+)
+
+const (
+	stateFileName = "ratelimit.json"
+	lockTimeout   = 5 * time.Second
+	// Google resets daily API quotas at midnight Pacific time.
+	quotaTimezone = "America/Los_Angeles"
+)
+
+// Limit configures one service. Zero values mean unlimited.
+type Limit struct {
+	// QPS is the sustained request rate.
+	QPS float64
+	// Burst is the bucket size; it defaults to max(1, QPS).
+	Burst int
+	// Daily caps requests per quota day.
+	Daily int64
+}
+
+func (l Limit) unlimited() bool {
+	return l.QPS <= 0 && l.Daily <= 0
+}
+
+// refill is how long an idle bucket takes to fill up again.
+func (l Limit) refill() time.Duration {
+	if l.QPS <= 0 {
+		return 0
+	}
+	return time.Duration(l.burst() / l.QPS * float64(time.Second))
+}
+
+func (l Limit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return max(1, l.QPS)
+}
+
+// LimitsFunc returns the limit for a service such as "gmail" or "drive".
+type LimitsFunc func(service string) Limit
+
+// DailyBudgetError reports that an account used its daily budget for a service.
+type DailyBudgetError struct {
+	Account string
+	Service string
+	Daily   int64
+}
+
+func (e *DailyBudgetError) Error() string {
+	return fmt.Sprintf("daily %s API budget of %d calls for %s is used up (resets at midnight Pacific time; see rate_limits in config.json)", e.Service, e.Daily, e.Account)
+}
+
+// Limiter waits for a token before each call. Its state is shared across
+// processes through a file guarded by filelock.
+type Limiter struct {
+	dir    func() (string, error)
+	limits LimitsFunc
+	now    func() time.Time
+	sleep  func(context.Context, time.Duration) error
+
+	once    sync.Once
+	path    string
+	lock    *filelock.Lock
+	openErr error
+}
+
+type state struct {
+	Buckets map[string]*bucket `json:"buckets"`
+}
+
+type bucket struct {
+	Tokens  float64   `json:"tokens"`
+	Updated time.Time `json:"updated"`
+	Day     string    `json:"day"`
+	Used    int64     `json:"used"`
+}
+
+// New returns a limiter that keeps its state in the directory returned by
+// dir, which is resolved on first use.
+func New(dir func() (string, error), limits LimitsFunc) *Limiter {
+	if limits == nil {
+		limits = func(string) Limit { return Limit{} }
+	}
+	return &Limiter{dir: dir, limits: limits, now: time.Now, sleep: sleepContext}
+}
+
+func (l *Limiter) open() error {
+	l.once.Do(func() {
+		dir, err := l.dir()
+		if err != nil {
+			l.openErr = err
+			return
+		}
+		l.path = filepath.Join(dir, stateFileName)
+		l.lock = filelock.Shared(l.path+".lock", lockTimeout)
+	})
+	return l.openErr
+}
+
+// Wait blocks until account may call service, counting the call against the
+// daily budget. Services without a configured limit return at once without
+// touching the state file. Problems with the state file are logged and do not
+// block calls; only a used-up budget or a cancelled ctx returns an error.
+func (l *Limiter) Wait(ctx context.Context, account, service string) error {
+	if l == nil {
+		return nil
+	}
+	limit := l.limits(service)
+	if limit.unlimited() {
+		return nil
+	}
+	key := bucketKey(account, service)
+	for {
+		var wait time.Duration
+		var budgetErr error
+		err := l.update(func(s *state) {
+			now := l.now()
+			l.prune(s, now)
+			b := s.bucket(key, limit, now)
+			if limit.Daily > 0 && b.Used >= limit.Daily {
+				budgetErr = &DailyBudgetError{Account: account, Service: service, Daily: limit.Daily}
+				return
+			}
+			if limit.QPS > 0 {
+				elapsed := now.Sub(b.Updated).Seconds()
+				b.Tokens = min(limit.burst(), b.Tokens+max(0, elapsed)*limit.QPS)
+				b.Updated = now
+				if b.Tokens < 1 {
+					wait = time.Duration((1 - b.Tokens) / limit.QPS * float64(time.Second))
+					return
+				}
+				b.Tokens--
+			}
+			b.Used++
+		})
+		if err != nil {
+			slog.Debug("rate limit state unavailable; not throttling", "path", l.path, "err", err)
+			return nil
+		}
+		if budgetErr != nil || wait <= 0 {
+			return budgetErr
+		}
+		if err := l.sleep(ctx, wait); err != nil {
+			return err
+		}
+	}
+}
+
+// Usage is one account/service entry for the current quota day.
+type Usage struct {
+	Service string  `json:"service"`
+	Used    int64   `json:"used"`
+	Daily   int64   `json:"daily,omitempty"`
+	QPS     float64 `json:"qps,omitempty"`
+	Day     string  `json:"day"`
+}
+
+// Usage returns today's call counts for account, sorted by service.
+func (l *Limiter) Usage(account string) ([]Usage, error) {
+	if l == nil {
+		return nil, nil
+	}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(l.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []Usage{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read rate limit state: %w", err)
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse rate limit state: %w", err)
+	}
+	today := quotaDay(l.now())
+	prefix := strings.ToLower(account) + "|"
+	usage := []Usage{}
+	for key, b := range s.Buckets {
+		if !strings.HasPrefix(key, prefix) || b == nil || b.Day != today {
+			continue
+		}
+		service := strings.TrimPrefix(key, prefix)
+		limit := l.limits(service)
+		usage = append(usage, Usage{Service: service, Used: b.Used, Daily: limit.Daily, QPS: limit.QPS, Day: b.Day})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Service < usage[j].Service })
+	return usage, nil
+}
+
+func (l *Limiter) update(fn func(*state)) error {
+	if err := l.open(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0o700); err != nil {
+		return err
+	}
+	return l.lock.WithExclusive(func() error {
+		s := state{}
+		data, err := os.ReadFile(l.path)
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+		case err != nil:
+			return err
+		default:
+			// A corrupt file is replaced rather than blocking every call.
+			_ = json.Unmarshal(data, &s)
+		}
+		if s.Buckets == nil {
+			s.Buckets = map[string]*bucket{}
+		}
+		fn(&s)
+		out, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		tmp := l.path + ".tmp"
+		if err := os.WriteFile(tmp, out, 0o600); err != nil {
+			return err
+		}
+		return os.Rename(tmp, l.path)
+	})
+}
+
+// prune drops buckets that no longer carry state: their quota day is over and
+// they have been idle long enough to refill.
+func (l *Limiter) prune(s *state, now time.Time) {
+	today := quotaDay(now)
+	for key, b := range s.Buckets {
+		if b == nil {
+			delete(s.Buckets, key)
+			continue
+		}
+		_, service, _ := strings.Cut(key, "|")
+		if b.Day != today && now.Sub(b.Updated) >= l.limits(service).refill() {
+			delete(s.Buckets, key)
+		}
+	}
+}
+
+func (s *state) bucket(key string, limit Limit, now time.Time) *bucket {
+	b := s.Buckets[key]
+	if b == nil {
+		b = &bucket{Tokens: limit.burst(), Updated: now}
+		s.Buckets[key] = b
+	}
+	if day := quotaDay(now); b.Day != day {
+		b.Day, b.Used = day, 0
+	}
+	return b
+}
+
+func bucketKey(account, service string) string {
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		account = "-"
+	}
+	return account + "|" + service
+}
+
+func quotaDay(now time.Time) string {
+	if loc, err := time.LoadLocation(quotaTimezone); err == nil {
+		now = now.In(loc)
+	}
+	return now.Format(time.DateOnly)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// CallBudgetError reports that this invocation reached --max-api-calls.
+type CallBudgetError struct {
+	Max int64
+}
+
+func (e *CallBudgetError) Error() string {
+	return fmt.Sprintf("API call budget exhausted: --max-api-calls=%d reached", e.Max)
+}
+
+// CallBudget caps the Google API calls one gog invocation may make.
+type CallBudget struct {
+	max  int64
+	used atomic.Int64
+}
+
+// NewCallBudget returns nil (no cap) when maxCalls is not positive.
+func NewCallBudget(maxCalls int64) *CallBudget {
+	if maxCalls <= 0 {
+		return nil
+	}
+	return &CallBudget{max: maxCalls}
+}
+
+// Take counts one call, failing once the budget is spent.
+func (b *CallBudget) Take() error {
+	if b == nil {
+		return nil
+	}
+	if b.used.Add(1) > b.max {
+		return &CallBudgetError{Max: b.max}
+	}
+	return nil
+}
+
+// Used returns the calls counted so far, including refused ones.
+func (b *CallBudget) Used() int64 {
+	if b == nil {
+		return 0
+	}
+	return b.used.Load()
+}
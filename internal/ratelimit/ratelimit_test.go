@@ -0,0 +1,158 @@
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type fakeClock struct {
+	now    time.Time
+	slept  []time.Duration
+	limits map[string]Limit
+}
+
+func (c *fakeClock) limiter(dir string) *Limiter {
+	l := New(func() (string, error) { return dir, nil }, func(service string) Limit { return c.limits[service] })
+	l.now = func() time.Time { return c.now }
+	l.sleep = func(_ context.Context, d time.Duration) error {
+		c.slept = append(c.slept, d)
+		c.now = c.now.Add(d)
+		return nil
+	}
+	return l
+}
+
+func TestLimiter_TokenBucketIsSharedAcrossInstances(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{
+		now:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		limits: map[string]Limit{"gmail": {QPS: 2, Burst: 2}, "drive": {Daily: 100}},
+	}
+	// Two limiters on one directory stand in for two gog processes.
+	a, b := clock.limiter(dir), clock.limiter(dir)
+	ctx := context.Background()
+	for _, l := range []*Limiter{a, b, a} {
+		if err := l.Wait(ctx, "Me@Example.com", "gmail"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(clock.slept) != 1 || clock.slept[0] != 500*time.Millisecond {
+		t.Fatalf("slept = %v, want one 500ms wait after the burst", clock.slept)
+	}
+	// Other services and accounts have their own buckets.
+	if err := b.Wait(ctx, "me@example.com", "drive"); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.Wait(ctx, "other@example.com", "gmail"); err != nil {
+		t.Fatal(err)
+	}
+	if len(clock.slept) != 1 {
+		t.Fatalf("unexpected waits: %v", clock.slept)
+	}
+
+	usage, err := a.Usage("me@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(usage) != 2 || usage[0].Service != "drive" || usage[1].Service != "gmail" || usage[1].Used != 3 || usage[1].QPS != 2 {
+		t.Fatalf("usage = %+v", usage)
+	}
+}
+
+func TestLimiter_DailyBudgetResetsAtPacificMidnight(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{
+		// 06:00 UTC is 23:00 the previous day in Los Angeles.
+		now:    time.Date(2026, 10, 18, 6, 0, 0, 0, time.UTC),
+		limits: map[string]Limit{"drive": {Daily: 2}},
+	}
+	l := clock.limiter(dir)
+	ctx := context.Background()
+	for range 2 {
+		if err := l.Wait(ctx, "me@example.com", "drive"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := clock.limiter(dir).Wait(ctx, "me@example.com", "drive")
+	var budgetErr *DailyBudgetError
+	if !errors.As(err, &budgetErr) || budgetErr.Daily != 2 {
+		t.Fatalf("third call err = %v", err)
+	}
+
+	clock.now = clock.now.Add(2 * time.Hour)
+	if err := l.Wait(ctx, "me@example.com", "drive"); err != nil {
+		t.Fatalf("after reset: %v", err)
+	}
+	usage, _ := l.Usage("me@example.com")
+	if len(usage) != 1 || usage[0].Used != 1 || usage[0].Day != "2026-10-18" || usage[0].Daily != 2 {
+		t.Fatalf("usage = %+v", usage)
+	}
+}
+
+func TestLimiter_UnlimitedServiceSkipsStateFile(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{now: time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)}
+	if err := clock.limiter(dir).Wait(context.Background(), "me@example.com", "gmail"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, stateFileName)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("state file written without limits: %v", err)
+	}
+}
+
+func TestLimiter_PrunesBucketsFromPastDays(t *testing.T) {
+	dir := t.TempDir()
+	clock := &fakeClock{
+		now:    time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		limits: map[string]Limit{"gmail": {QPS: 1}, "drive": {Daily: 10}},
+	}
+	l := clock.limiter(dir)
+	ctx := context.Background()
+	for _, account := range []string{"a@example.com", "b@example.com"} {
+		if err := l.Wait(ctx, account, "drive"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	clock.now = clock.now.Add(24 * time.Hour)
+	if err := l.Wait(ctx, "a@example.com", "gmail"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, stateFileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var s state
+	if err := json.Unmarshal(data, &s); err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Buckets) != 1 || s.Buckets["a@example.com|gmail"] == nil {
+		t.Fatalf("buckets = %+v", s.Buckets)
+	}
+}
+
+func TestLimiter_UnavailableStateDoesNotBlock(t *testing.T) {
+	l := New(func() (string, error) { return "", errors.New("no state dir") }, nil)
+	if err := l.Wait(context.Background(), "me@example.com", "gmail"); err != nil {
+		t.Fatalf("Wait = %v", err)
+	}
+}
+
+func TestCallBudget(t *testing.T) {
+	if NewCallBudget(0) != nil {
+		t.Fatal("zero budget should be unlimited")
+	}
+	b := NewCallBudget(2)
+	if b.Take() != nil || b.Take() != nil {
+		t.Fatal("first two calls should pass")
+	}
+	var budgetErr *CallBudgetError
+	if err := b.Take(); !errors.As(err, &budgetErr) || budgetErr.Max != 2 {
+		t.Fatalf("third Take = %v", err)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/99designs/keyring"
 
 	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/telemetry"
 )
 
 const (
@@ -304,7 +305,9 @@ func openKeyringWithOptions(options OpenOptions) (keyring.Keyring, error) {
 		return prepareKeyring(timeoutRing, backendInfo, wrapFileKeys, keychainTrustApplication, options), nil
 	}
 
+	endSpan := telemetry.StartKeyringOpen(keyringBackendsLabel(cfg), 0)
 	ring, err := open(cfg)
+	endSpan(false, err)
 	if err != nil {
 		return nil, fmt.Errorf("open keyring: %w", err)
 	}
@@ -338,6 +341,20 @@ func prepareKeyring(
 	return ring
 }
 
+// keyringBackendsLabel names the backends keyring.Open may pick, for telemetry.
+func keyringBackendsLabel(cfg keyring.Config) string {
+	if len(cfg.AllowedBackends) == 0 {
+		return "auto"
+	}
+
+	names := make([]string, 0, len(cfg.AllowedBackends))
+	for _, backend := range cfg.AllowedBackends {
+		names = append(names, string(backend))
+	}
+
+	return strings.Join(names, ",")
+}
+
 type keyringResult struct {
 	ring keyring.Keyring
 	err  error
@@ -352,6 +369,7 @@ func openKeyringWithTimeoutFunc(
 	hint string,
 	open func(keyring.Config) (keyring.Keyring, error),
 ) (keyring.Keyring, error) {
+	endSpan := telemetry.StartKeyringOpen(keyringBackendsLabel(cfg), timeout)
 	ch := make(chan keyringResult, 1)
 
 	go func() {
@@ -361,13 +379,17 @@ func openKeyringWithTimeoutFunc(
 
 	select {
 	case res := <-ch:
+		endSpan(false, res.err)
 		if res.err != nil {
 			return nil, fmt.Errorf("open keyring: %w", res.err)
 		}
 
 		return res.ring, nil
 	case <-time.After(timeout):
-		return nil, keyringTimeoutError("opening keyring", timeout, hint)
+		err := keyringTimeoutError("opening keyring", timeout, hint)
+		endSpan(true, err)
+
+		return nil, err
 	}
 }
 
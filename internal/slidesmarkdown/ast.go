@@ -4,6 +4,7 @@ package slidesmarkdown
 type SlideFrontmatter struct {
 	Layout  string            // "title"|"hero"|"center"|"default"|"two-cols"|"three-cols"|"statement"|""
 	Content string            // "wide"|"narrow"|"" — parsed but not rendered this PR
+	ID      string            // stable slide key used by update-from-markdown
 	Raw     map[string]string // forward-compat for unknown keys
 }
 
@@ -63,6 +64,17 @@ type DiagramBlock struct {
 	ID     string // stable ID assigned by the parser; used as AssetMap.Diagrams key
 }
 
+// TableBlock is a GFM pipe table. Rows[0] is the header row.
+type TableBlock struct {
+	Rows [][]string
+}
+
+// ImageBlock is a paragraph that holds exactly one image.
+type ImageBlock struct {
+	Alt string
+	URL string
+}
+
 func (ParagraphBlock) isBlock() {}
 func (BulletsBlock) isBlock()   {}
 func (CodeBlock) isBlock()      {}
@@ -70,6 +82,8 @@ func (HeadingBlock) isBlock()   {}
 func (ColumnsBlock) isBlock()   {}
 func (IconRowsBlock) isBlock()  {}
 func (DiagramBlock) isBlock()   {}
+func (TableBlock) isBlock()     {}
+func (ImageBlock) isBlock()     {}
 
 // Inline is an inline run inside text.
 type Inline interface{ isInline() }
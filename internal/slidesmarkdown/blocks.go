@@ -7,11 +7,19 @@ import (
 
 	"github.com/yuin/goldmark"
 	gast "github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/parser"
 	gtext "github.com/yuin/goldmark/text"
 )
 
 var headingRE = regexp.MustCompile(`^(#{1,6})(?:\s+(.*))?$`)
 
+// exportedMarkdownParser is CommonMark plus GFM pipe tables, which `slides
+// export --format md` emits for native Slides tables. Other markdown keeps
+// the plain CommonMark parser.
+var exportedMarkdownParser parser.Parser = goldmark.New(goldmark.WithExtensions(extension.Table)).Parser()
+
 type blockIDGenerator struct {
 	next uint64
 }
@@ -42,10 +50,10 @@ const (
 // is delegated to goldmark; slidey-specific directive blocks are recognized
 // by a thin line scanner before each ordinary markdown chunk is parsed.
 func parseBlocks(body string) []Block {
-	return parseBlocksWithIDs(body, "solid", &blockIDGenerator{})
+	return parseBlocksWithIDs(body, ParseOptions{DefaultFAStyle: "solid"}, &blockIDGenerator{})
 }
 
-func parseBlocksWithIDs(body string, defaultFAStyle string, ids *blockIDGenerator) []Block {
+func parseBlocksWithIDs(body string, opts ParseOptions, ids *blockIDGenerator) []Block {
 	lines := strings.Split(body, "\n")
 	var out []Block
 	var chunk []string
@@ -57,7 +65,7 @@ func parseBlocksWithIDs(body string, defaultFAStyle string, ids *blockIDGenerato
 			return
 		}
 
-		out = append(out, parseGoldmarkBlocks(text, defaultFAStyle, ids)...)
+		out = append(out, parseGoldmarkBlocks(text, opts, ids)...)
 	}
 
 	i := 0
@@ -83,7 +91,7 @@ func parseBlocksWithIDs(body string, defaultFAStyle string, ids *blockIDGenerato
 
 				i++
 
-				cols, consumed := consumeColumnsBlock(lines[i:], defaultFAStyle, ids)
+				cols, consumed := consumeColumnsBlock(lines[i:], opts, ids)
 				i += consumed
 
 				out = append(out, cols)
@@ -101,7 +109,7 @@ func parseBlocksWithIDs(body string, defaultFAStyle string, ids *blockIDGenerato
 					closeMarker = arrowsClose
 				}
 
-				rows, consumed := consumeIconRowsBlock(lines[i+1:], kind, closeMarker, defaultFAStyle)
+				rows, consumed := consumeIconRowsBlock(lines[i+1:], kind, closeMarker, opts.DefaultFAStyle)
 				i += consumed + 1
 
 				out = append(out, rows)
@@ -119,26 +127,36 @@ func parseBlocksWithIDs(body string, defaultFAStyle string, ids *blockIDGenerato
 	return out
 }
 
-func parseGoldmarkBlocks(markdown string, defaultFAStyle string, ids *blockIDGenerator) []Block {
+func parseGoldmarkBlocks(markdown string, opts ParseOptions, ids *blockIDGenerator) []Block {
 	source := []byte(markdown)
-	doc := goldmark.DefaultParser().Parse(gtext.NewReader(source))
+	p := goldmark.DefaultParser()
+	if opts.Exported {
+		p = exportedMarkdownParser
+	}
+	doc := p.Parse(gtext.NewReader(source))
 	var out []Block
 
 	for n := doc.FirstChild(); n != nil; n = n.NextSibling() {
-		out = append(out, goldmarkBlockToBlocks(n, source, defaultFAStyle, ids)...)
+		out = append(out, goldmarkBlockToBlocks(n, source, opts, ids)...)
 	}
 
 	return out
 }
 
-func goldmarkBlockToBlocks(n gast.Node, source []byte, defaultFAStyle string, ids *blockIDGenerator) []Block {
+func goldmarkBlockToBlocks(n gast.Node, source []byte, opts ParseOptions, ids *blockIDGenerator) []Block {
 	switch v := n.(type) {
 	case *gast.Heading:
-		return []Block{HeadingBlock{Level: v.Level, Inlines: goldmarkInlines(v, source, defaultFAStyle, false, false)}}
+		return []Block{HeadingBlock{Level: v.Level, Inlines: goldmarkInlines(v, source, opts, false, false)}}
 	case *gast.Paragraph:
-		return []Block{ParagraphBlock{Inlines: goldmarkInlines(v, source, defaultFAStyle, false, false)}}
+		if img, ok := v.FirstChild().(*gast.Image); ok && opts.Exported && v.ChildCount() == 1 {
+			return []Block{ImageBlock{Alt: unescapeMarkdown(goldmarkInlinePlainText(img, source)), URL: string(img.Destination)}}
+		}
+
+		return []Block{ParagraphBlock{Inlines: goldmarkInlines(v, source, opts, false, false)}}
+	case *east.Table:
+		return []Block{goldmarkTableToBlock(v, source)}
 	case *gast.TextBlock:
-		return []Block{ParagraphBlock{Inlines: goldmarkInlines(v, source, defaultFAStyle, false, false)}}
+		return []Block{ParagraphBlock{Inlines: goldmarkInlines(v, source, opts, false, false)}}
 	case *gast.FencedCodeBlock:
 		lang := string(v.Language(source))
 		code := strings.TrimSuffix(string(v.Lines().Value(source)), "\n")
@@ -151,11 +169,11 @@ func goldmarkBlockToBlocks(n gast.Node, source []byte, defaultFAStyle string, id
 	case *gast.CodeBlock:
 		return []Block{CodeBlock{Source: strings.TrimSuffix(string(v.Lines().Value(source)), "\n")}}
 	case *gast.List:
-		return []Block{goldmarkListToBlock(v, source, defaultFAStyle)}
+		return []Block{goldmarkListToBlock(v, source, opts)}
 	case *gast.Blockquote:
 		var out []Block
 		for c := v.FirstChild(); c != nil; c = c.NextSibling() {
-			out = append(out, goldmarkBlockToBlocks(c, source, defaultFAStyle, ids)...)
+			out = append(out, goldmarkBlockToBlocks(c, source, opts, ids)...)
 		}
 
 		return out
@@ -166,7 +184,7 @@ func goldmarkBlockToBlocks(n gast.Node, source []byte, defaultFAStyle string, id
 			var out []Block
 
 			for c := n.FirstChild(); c != nil; c = c.NextSibling() {
-				out = append(out, goldmarkBlockToBlocks(c, source, defaultFAStyle, ids)...)
+				out = append(out, goldmarkBlockToBlocks(c, source, opts, ids)...)
 			}
 
 			return out
@@ -175,7 +193,7 @@ func goldmarkBlockToBlocks(n gast.Node, source []byte, defaultFAStyle string, id
 		if n.Lines() != nil && n.Lines().Len() > 0 {
 			text := strings.TrimSpace(string(n.Lines().Value(source)))
 			if text != "" {
-				return []Block{ParagraphBlock{Inlines: parseInlines(text, defaultFAStyle)}}
+				return []Block{ParagraphBlock{Inlines: parseInlines(text, opts.DefaultFAStyle)}}
 			}
 		}
 
@@ -183,14 +201,29 @@ func goldmarkBlockToBlocks(n gast.Node, source []byte, defaultFAStyle string, id
 	}
 }
 
-func goldmarkListToBlock(list *gast.List, source []byte, defaultFAStyle string) BulletsBlock {
+func goldmarkTableToBlock(table *east.Table, source []byte) TableBlock {
+	var block TableBlock
+
+	for row := table.FirstChild(); row != nil; row = row.NextSibling() {
+		var cells []string
+		for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+			cells = append(cells, strings.TrimSpace(unescapeMarkdown(goldmarkInlinePlainText(cell, source))))
+		}
+
+		block.Rows = append(block.Rows, cells)
+	}
+
+	return block
+}
+
+func goldmarkListToBlock(list *gast.List, source []byte, opts ParseOptions) BulletsBlock {
 	var items []BulletItem
-	appendGoldmarkListItems(list, source, defaultFAStyle, 0, &items)
+	appendGoldmarkListItems(list, source, opts, 0, &items)
 
 	return BulletsBlock{Ordered: list.IsOrdered(), Items: items}
 }
 
-func appendGoldmarkListItems(list *gast.List, source []byte, defaultFAStyle string, indent int, items *[]BulletItem) {
+func appendGoldmarkListItems(list *gast.List, source []byte, opts ParseOptions, indent int, items *[]BulletItem) {
 	for n := list.FirstChild(); n != nil; n = n.NextSibling() {
 		item, ok := n.(*gast.ListItem)
 		if !ok {
@@ -206,12 +239,12 @@ func appendGoldmarkListItems(list *gast.List, source []byte, defaultFAStyle stri
 				if len(inlines) > 0 {
 					inlines = append(inlines, TextRun{Text: " "})
 				}
-				inlines = append(inlines, goldmarkInlines(v, source, defaultFAStyle, false, false)...)
+				inlines = append(inlines, goldmarkInlines(v, source, opts, false, false)...)
 			case *gast.TextBlock:
 				if len(inlines) > 0 {
 					inlines = append(inlines, TextRun{Text: " "})
 				}
-				inlines = append(inlines, goldmarkInlines(v, source, defaultFAStyle, false, false)...)
+				inlines = append(inlines, goldmarkInlines(v, source, opts, false, false)...)
 			case *gast.List:
 				nested = append(nested, v)
 			}
@@ -222,12 +255,12 @@ func appendGoldmarkListItems(list *gast.List, source []byte, defaultFAStyle stri
 		}
 
 		for _, nestedList := range nested {
-			appendGoldmarkListItems(nestedList, source, defaultFAStyle, indent+1, items)
+			appendGoldmarkListItems(nestedList, source, opts, indent+1, items)
 		}
 	}
 }
 
-func goldmarkInlines(parent gast.Node, source []byte, defaultFAStyle string, bold, italic bool) []Inline {
+func goldmarkInlines(parent gast.Node, source []byte, opts ParseOptions, bold, italic bool) []Inline {
 	var out []Inline
 
 	for n := parent.FirstChild(); n != nil; n = n.NextSibling() {
@@ -240,16 +273,16 @@ func goldmarkInlines(parent gast.Node, source []byte, defaultFAStyle string, bol
 				text += "\n"
 			}
 
-			out = append(out, styleTextRuns(parseInlines(text, defaultFAStyle), bold, italic, false)...)
+			out = append(out, styleTextRuns(goldmarkTextInlines(text, opts), bold, italic, false)...)
 		case *gast.String:
-			out = append(out, styleTextRuns(parseInlines(string(v.Value), defaultFAStyle), bold, italic, v.IsCode())...)
+			out = append(out, styleTextRuns(goldmarkTextInlines(string(v.Value), opts), bold, italic, v.IsCode())...)
 		case *gast.CodeSpan:
 			out = append(out, TextRun{Text: goldmarkInlinePlainText(v, source), Code: true})
 		case *gast.Emphasis:
-			out = append(out, goldmarkInlines(v, source, defaultFAStyle, bold || v.Level >= 2, italic || v.Level == 1)...)
+			out = append(out, goldmarkInlines(v, source, opts, bold || v.Level >= 2, italic || v.Level == 1)...)
 		default:
 			if n.HasChildren() {
-				out = append(out, goldmarkInlines(n, source, defaultFAStyle, bold, italic)...)
+				out = append(out, goldmarkInlines(n, source, opts, bold, italic)...)
 			}
 		}
 	}
@@ -257,6 +290,16 @@ func goldmarkInlines(parent gast.Node, source []byte, defaultFAStyle string, bol
 	return out
 }
 
+// goldmarkTextInlines parses a goldmark text segment. Only exported decks
+// resolve backslash escapes, so hand-written markdown renders as it always has.
+func goldmarkTextInlines(text string, opts ParseOptions) []Inline {
+	if opts.Exported {
+		return parseEscapedInlines(text, opts.DefaultFAStyle)
+	}
+
+	return parseInlines(text, opts.DefaultFAStyle)
+}
+
 func goldmarkInlinePlainText(parent gast.Node, source []byte) string {
 	var b strings.Builder
 	_ = gast.Walk(parent, func(n gast.Node, entering bool) (gast.WalkStatus, error) {
@@ -299,7 +342,7 @@ func styleTextRuns(in []Inline, bold, italic, code bool) []Inline {
 	return out
 }
 
-func consumeColumnsBlock(lines []string, defaultFAStyle string, ids *blockIDGenerator) (ColumnsBlock, int) {
+func consumeColumnsBlock(lines []string, opts ParseOptions, ids *blockIDGenerator) (ColumnsBlock, int) {
 	var current []string
 	var columns [][]string
 	flush := func() {
@@ -326,7 +369,7 @@ func consumeColumnsBlock(lines []string, defaultFAStyle string, ids *blockIDGene
 
 				consumed++
 
-				return columnsBlockFromRaw(columns, defaultFAStyle, ids), consumed
+				return columnsBlockFromRaw(columns, opts, ids), consumed
 			case colMarker2, colMarker3, colMarkerAlt:
 				flush()
 
@@ -343,15 +386,15 @@ func consumeColumnsBlock(lines []string, defaultFAStyle string, ids *blockIDGene
 	// EOF without close — still flush what we have.
 	flush()
 
-	return columnsBlockFromRaw(columns, defaultFAStyle, ids), consumed
+	return columnsBlockFromRaw(columns, opts, ids), consumed
 }
 
-func columnsBlockFromRaw(raw [][]string, defaultFAStyle string, ids *blockIDGenerator) ColumnsBlock {
+func columnsBlockFromRaw(raw [][]string, opts ParseOptions, ids *blockIDGenerator) ColumnsBlock {
 	cb := ColumnsBlock{}
 
 	for _, col := range raw {
 		body := strings.Join(col, "\n")
-		cb.Columns = append(cb.Columns, parseBlocksWithIDs(body, defaultFAStyle, ids))
+		cb.Columns = append(cb.Columns, parseBlocksWithIDs(body, opts, ids))
 	}
 
 	return cb
@@ -174,6 +174,7 @@ func parseSlideFrontmatter(yamlText string) (SlideFrontmatter, error) {
 	return SlideFrontmatter{
 		Layout:  raw["layout"],
 		Content: raw["content"],
+		ID:      raw["id"],
 		Raw:     raw,
 	}, nil
 }
@@ -96,6 +96,66 @@ func parseEmphasis(s string) []Inline {
 	return out
 }
 
+// escapeSentinelBase is the start of a Unicode private-use block that
+// backslash-escaped ASCII punctuation is parked in while the regex emphasis
+// pass runs, so `\*literal\*` stays literal instead of turning italic.
+const escapeSentinelBase = '\uE000'
+
+// parseEscapedInlines is parseInlines for goldmark text segments, which still
+// carry CommonMark backslash escapes verbatim.
+func parseEscapedInlines(text string, defaultFAStyle string) []Inline {
+	out := parseInlines(protectMarkdownEscapes(text), defaultFAStyle)
+	for i, in := range out {
+		if tr, ok := in.(TextRun); ok {
+			tr.Text = restoreMarkdownEscapes(tr.Text)
+			out[i] = tr
+		}
+	}
+
+	return out
+}
+
+func protectMarkdownEscapes(text string) string {
+	if !strings.Contains(text, `\`) {
+		return text
+	}
+
+	var b strings.Builder
+
+	for i := 0; i < len(text); i++ {
+		if text[i] == '\\' && i+1 < len(text) && isASCIIPunct(text[i+1]) {
+			b.WriteRune(escapeSentinelBase + rune(text[i+1]))
+			i++
+
+			continue
+		}
+
+		b.WriteByte(text[i])
+	}
+
+	return b.String()
+}
+
+func restoreMarkdownEscapes(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= escapeSentinelBase && r < escapeSentinelBase+128 {
+			return r - escapeSentinelBase
+		}
+
+		return r
+	}, text)
+}
+
+// unescapeMarkdown drops CommonMark backslash escapes from plain text such
+// as table cells and image alt text.
+func unescapeMarkdown(text string) string {
+	return restoreMarkdownEscapes(protectMarkdownEscapes(text))
+}
+
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
 // stripFAShortcodes removes :fa*-name: tokens from text (used for speaker
 // notes which can't render images).
 func stripFAShortcodes(text string) string {
@@ -9,6 +9,7 @@ const layoutTitle = "title"
 // ParseOptions configures the markdown parser.
 type ParseOptions struct {
 	DefaultFAStyle string // "solid"|"regular"|"brands"; empty → "solid"
+	Exported       bool   // read GFM tables, image-only paragraphs, and escapes as `slides export --format md` writes them
 }
 
 // Parse parses a slidey-flavored markdown deck into Slide AST nodes.
@@ -34,7 +35,7 @@ func Parse(markdown string, opts ParseOptions) ([]Slide, error) {
 func parseSlideFromBlock(b slideBlock, opts ParseOptions, ids *blockIDGenerator) Slide {
 	body, notesText := splitOutNotes(b.Body)
 	body = normalizeShorthandColumns(body, b.Frontmatter.Layout)
-	parsed := parseBlocksWithIDs(body, opts.DefaultFAStyle, ids)
+	parsed := parseBlocksWithIDs(body, opts, ids)
 
 	slide := Slide{
 		Frontmatter: b.Frontmatter,
@@ -0,0 +1,347 @@
+package slidesmarkdown
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Render serializes slides back into slidey-flavored markdown. The output
+// parses back into the same AST for every construct Render emits, so a deck
+// exported with `slides export --format md` can be fed to
+// `create-from-markdown` again.
+func Render(slides []Slide) string {
+	var b strings.Builder
+
+	for i, slide := range slides {
+		frontmatter := renderFrontmatter(slide.Frontmatter)
+		switch {
+		case i == 0 && frontmatter != "":
+			b.WriteString(markdownTripleDash + "\n" + frontmatter + markdownTripleDash + "\n\n")
+		case i > 0 && frontmatter != "":
+			// The opening "---" of the frontmatter doubles as the separator.
+			b.WriteString("\n" + markdownTripleDash + "\n" + frontmatter + markdownTripleDash + "\n\n")
+		case i > 0:
+			b.WriteString("\n" + markdownTripleDash + "\n\n")
+		}
+
+		b.WriteString(renderSlideBody(slide))
+	}
+
+	return b.String()
+}
+
+func renderFrontmatter(fm SlideFrontmatter) string {
+	var b strings.Builder
+
+	if fm.Layout != "" {
+		fmt.Fprintf(&b, "layout: %s\n", fm.Layout)
+	}
+
+	if fm.Content != "" {
+		fmt.Fprintf(&b, "content: %s\n", fm.Content)
+	}
+
+	if fm.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", fm.ID)
+	}
+
+	keys := make([]string, 0, len(fm.Raw))
+	for k := range fm.Raw {
+		if k == "layout" || k == "content" || k == "id" || fm.Raw[k] == "" {
+			continue
+		}
+
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s: %s\n", k, fm.Raw[k])
+	}
+
+	return b.String()
+}
+
+func renderSlideBody(slide Slide) string {
+	var parts []string
+
+	if slide.Title != "" {
+		parts = append(parts, "# "+escapeMarkdownText(slide.Title))
+	}
+
+	parts = append(parts, renderBlocks(slide.Body)...)
+
+	if notes := strings.TrimSpace(slide.Notes); notes != "" {
+		parts = append(parts, "## Notes", notes)
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+
+	return strings.Join(parts, "\n\n") + "\n"
+}
+
+func renderBlocks(blocks []Block) []string {
+	out := make([]string, 0, len(blocks))
+
+	for _, block := range blocks {
+		if text := renderBlock(block); text != "" {
+			out = append(out, text)
+		}
+	}
+
+	return out
+}
+
+func renderBlock(block Block) string {
+	switch v := block.(type) {
+	case ParagraphBlock:
+		return RenderInlines(v.Inlines)
+	case HeadingBlock:
+		level := min(max(v.Level, 1), 6)
+		return strings.Repeat("#", level) + " " + RenderInlines(v.Inlines)
+	case BulletsBlock:
+		return renderBullets(v)
+	case CodeBlock:
+		return renderFence(v.Lang, v.Source)
+	case DiagramBlock:
+		return renderFence(v.Kind, v.Source)
+	case ColumnsBlock:
+		return renderColumns(v)
+	case IconRowsBlock:
+		return renderIconRows(v)
+	case TableBlock:
+		return renderTable(v)
+	case ImageBlock:
+		return fmt.Sprintf("![%s](%s)", escapeMarkdownText(v.Alt), markdownDestination(v.URL))
+	default:
+		return ""
+	}
+}
+
+func renderBullets(block BulletsBlock) string {
+	marker := "- "
+	if block.Ordered {
+		marker = "1. "
+	}
+
+	lines := make([]string, 0, len(block.Items))
+	for _, item := range block.Items {
+		// Nested items must start under the parent's content column, which
+		// is the marker width for both "- " and "1. ".
+		indent := strings.Repeat(" ", max(item.Indent, 0)*len(marker))
+		lines = append(lines, indent+marker+RenderInlines(item.Inlines))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func renderFence(lang, source string) string {
+	fence := "```"
+	for strings.Contains(source, fence) {
+		fence += "`"
+	}
+
+	return fence + lang + "\n" + source + "\n" + fence
+}
+
+func renderColumns(block ColumnsBlock) string {
+	parts := []string{colsOpen}
+
+	for i, col := range block.Columns {
+		switch i {
+		case 0:
+		case 1:
+			parts = append(parts, colMarker2)
+		default:
+			parts = append(parts, colMarker3)
+		}
+
+		parts = append(parts, renderBlocks(col)...)
+	}
+
+	parts = append(parts, colsClose)
+
+	return strings.Join(parts, "\n\n")
+}
+
+func renderIconRows(block IconRowsBlock) string {
+	open, closeMarker := boxesOpen, boxesClose
+	if block.Kind == "arrows" {
+		open, closeMarker = arrowsOpen, arrowsClose
+	}
+
+	lines := []string{open}
+	for _, row := range block.Rows {
+		text := row.Text
+		if row.Icon != nil {
+			text = strings.TrimSpace(renderIcon(*row.Icon) + " " + text)
+		}
+
+		lines = append(lines, text)
+	}
+
+	lines = append(lines, closeMarker)
+
+	return strings.Join(lines, "\n")
+}
+
+func renderTable(block TableBlock) string {
+	width := 0
+	for _, row := range block.Rows {
+		width = max(width, len(row))
+	}
+
+	if width == 0 {
+		return ""
+	}
+
+	lines := make([]string, 0, len(block.Rows)+1)
+	for i, row := range block.Rows {
+		cells := make([]string, width)
+		for j := range cells {
+			if j < len(row) {
+				cells[j] = escapeTableCell(row[j])
+			}
+		}
+
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+
+		if i == 0 {
+			lines = append(lines, "|"+strings.Repeat(" --- |", width))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// RenderInlines serializes inline runs with CommonMark emphasis markers.
+// Leading and trailing whitespace stays outside the markers so the
+// emphasis still parses.
+func RenderInlines(inlines []Inline) string {
+	var b strings.Builder
+
+	for _, in := range inlines {
+		switch v := in.(type) {
+		case TextRun:
+			b.WriteString(renderTextRun(v))
+		case IconRef:
+			b.WriteString(renderIcon(v))
+		}
+	}
+
+	return b.String()
+}
+
+func renderTextRun(run TextRun) string {
+	if run.Code {
+		return renderCodeSpan(run.Text)
+	}
+
+	trimmed := strings.TrimSpace(run.Text)
+	if trimmed == "" || (!run.Bold && !run.Italic) {
+		return escapeMarkdownText(run.Text)
+	}
+
+	marker := "*"
+	if run.Bold {
+		marker = "**"
+	}
+
+	if run.Bold && run.Italic {
+		marker = "***"
+	}
+
+	start := strings.Index(run.Text, trimmed)
+	lead, trail := run.Text[:start], run.Text[start+len(trimmed):]
+
+	return lead + marker + escapeMarkdownText(trimmed) + marker + trail
+}
+
+func renderCodeSpan(text string) string {
+	fence := "`"
+	for strings.Contains(text, fence) {
+		fence += "`"
+	}
+
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") {
+		return fence + " " + text + " " + fence
+	}
+
+	return fence + text + fence
+}
+
+func renderIcon(icon IconRef) string {
+	prefix := "fas"
+
+	switch icon.Style {
+	case "regular":
+		prefix = "far"
+	case "brands":
+		prefix = "fab"
+	}
+
+	return ":" + prefix + "-" + icon.Name + ":"
+}
+
+var markdownTextEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"*", `\*`,
+	"_", `\_`,
+	"`", "\\`",
+	"[", `\[`,
+	"]", `\]`,
+	"<", `\<`,
+	"\n", " ",
+)
+
+// escapeMarkdownText escapes characters that would otherwise start inline
+// markup, plus a leading block marker ("#", "-", "+", "1.") that would turn
+// a plain paragraph into a heading or list.
+func escapeMarkdownText(text string) string {
+	text = markdownTextEscaper.Replace(text)
+
+	trimmed := strings.TrimLeft(text, " ")
+	lead := text[:len(text)-len(trimmed)]
+
+	switch {
+	case strings.HasPrefix(trimmed, "#"), strings.HasPrefix(trimmed, "- "),
+		strings.HasPrefix(trimmed, "+ "), strings.HasPrefix(trimmed, ">"):
+		return lead + `\` + trimmed
+	case orderedMarkerPrefix(trimmed) > 0:
+		n := orderedMarkerPrefix(trimmed)
+		return lead + trimmed[:n] + `\` + trimmed[n:]
+	}
+
+	return text
+}
+
+// orderedMarkerPrefix returns the digit count of an ordered-list marker
+// ("12. ") at the start of s, or 0.
+func orderedMarkerPrefix(s string) int {
+	n := 0
+	for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+		n++
+	}
+
+	if n == 0 || n+1 >= len(s) || (s[n] != '.' && s[n] != ')') || s[n+1] != ' ' {
+		return 0
+	}
+
+	return n
+}
+
+func escapeTableCell(text string) string {
+	return strings.ReplaceAll(escapeMarkdownText(strings.TrimSpace(text)), "|", `\|`)
+}
+
+func markdownDestination(url string) string {
+	if strings.ContainsAny(url, " ()<>") {
+		return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+	}
+
+	return url
+}
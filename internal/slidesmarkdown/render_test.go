@@ -0,0 +1,88 @@
+package slidesmarkdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_RoundTripsDeck(t *testing.T) {
+	deck := []Slide{
+		{
+			Frontmatter: SlideFrontmatter{Layout: "title"},
+			Body: []Block{
+				HeadingBlock{Level: 1, Inlines: []Inline{TextRun{Text: "Quarterly Review"}}},
+				ParagraphBlock{Inlines: []Inline{TextRun{Text: "Platform team"}}},
+			},
+		},
+		{
+			Frontmatter: SlideFrontmatter{ID: "agenda"},
+			Title:       "Agenda",
+			Body: []Block{
+				BulletsBlock{Items: []BulletItem{
+					{Inlines: []Inline{TextRun{Text: "Wins"}}},
+					{Indent: 1, Inlines: []Inline{TextRun{Text: "Latency "}, TextRun{Text: "halved", Bold: true}}},
+					{Inlines: []Inline{TextRun{Text: "Risks", Italic: true}}},
+				}},
+			},
+			Notes: "Keep this under two minutes.",
+		},
+		{
+			Frontmatter: SlideFrontmatter{Layout: "two-cols"},
+			Title:       "Compare",
+			Body: []Block{
+				ColumnsBlock{Columns: [][]Block{
+					{ParagraphBlock{Inlines: []Inline{TextRun{Text: "Before"}}}},
+					{ParagraphBlock{Inlines: []Inline{TextRun{Text: "After"}}}},
+				}},
+			},
+		},
+		{
+			Title: "Numbers",
+			Body: []Block{
+				TableBlock{Rows: [][]string{{"Metric", "Value"}, {"p99", "120ms"}, {"a|b", ""}}},
+				ImageBlock{Alt: "chart", URL: "assets/slide-04-chart.png"},
+			},
+		},
+	}
+
+	md := Render(deck)
+	got, err := Parse(md, ParseOptions{Exported: true})
+	require.NoError(t, err, md)
+	require.Len(t, got, len(deck), md)
+
+	for i := range deck {
+		assert.Equal(t, deck[i].Frontmatter.Layout, got[i].Frontmatter.Layout, "slide %d layout", i+1)
+		assert.Equal(t, deck[i].Frontmatter.ID, got[i].Frontmatter.ID, "slide %d id", i+1)
+		assert.Equal(t, deck[i].Title, got[i].Title, "slide %d title", i+1)
+		assert.Equal(t, deck[i].Notes, got[i].Notes, "slide %d notes", i+1)
+		assert.Equal(t, deck[i].Body, got[i].Body, "slide %d body\n%s", i+1, md)
+	}
+}
+
+func TestRender_EscapesLiteralMarkup(t *testing.T) {
+	deck := []Slide{{
+		Title: "Costs",
+		Body: []Block{
+			ParagraphBlock{Inlines: []Inline{TextRun{Text: "# not a heading *or* emphasis"}}},
+			ParagraphBlock{Inlines: []Inline{TextRun{Text: "1. not a list"}}},
+		},
+	}}
+
+	got, err := Parse(Render(deck), ParseOptions{Exported: true})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Len(t, got[0].Body, 2)
+	assert.Equal(t, "# not a heading *or* emphasis", InlineText(got[0].Body[0].(ParagraphBlock).Inlines))
+	assert.Equal(t, "1. not a list", InlineText(got[0].Body[1].(ParagraphBlock).Inlines))
+}
+
+func TestRender_FrontmatterOnlyWhenLayoutSet(t *testing.T) {
+	md := Render([]Slide{
+		{Title: "One"},
+		{Frontmatter: SlideFrontmatter{Layout: "hero"}, Body: []Block{HeadingBlock{Level: 1, Inlines: []Inline{TextRun{Text: "Two"}}}}},
+	})
+
+	assert.Equal(t, "# One\n\n---\nlayout: hero\n---\n\n# Two\n", md)
+}
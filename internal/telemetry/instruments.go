@@ -0,0 +1,205 @@
+package telemetry
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type meterInstruments struct {
+	commandDuration metric.Float64Histogram
+	apiRequests     metric.Int64Counter
+	apiDuration     metric.Float64Histogram
+	apiRetries      metric.Int64Counter
+	pages           metric.Int64Counter
+	keyringOpen     metric.Float64Histogram
+}
+
+var currentInstruments = newInstruments(noop.NewMeterProvider().Meter(instrumentationName))
+
+func instruments() *meterInstruments {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return currentInstruments
+}
+
+func setInstruments(m metric.Meter) {
+	stateMu.Lock()
+	currentInstruments = newInstruments(m)
+	stateMu.Unlock()
+}
+
+// newInstruments ignores creation errors: the SDK returns a usable no-op
+// instrument alongside them.
+func newInstruments(m metric.Meter) *meterInstruments {
+	var i meterInstruments
+	i.commandDuration, _ = m.Float64Histogram("gog.command.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of a gog command"))
+	i.apiRequests, _ = m.Int64Counter("gog.api.requests",
+		metric.WithDescription("Google API requests, after retries"))
+	i.apiDuration, _ = m.Float64Histogram("gog.api.duration",
+		metric.WithUnit("s"), metric.WithDescription("Duration of a Google API request including retries"))
+	i.apiRetries, _ = m.Int64Counter("gog.api.retries",
+		metric.WithDescription("Google API request retries"))
+	i.pages, _ = m.Int64Counter("gog.pagination.pages",
+		metric.WithDescription("Result pages fetched"))
+	i.keyringOpen, _ = m.Float64Histogram("gog.keyring.open.duration",
+		metric.WithUnit("s"), metric.WithDescription("Time to open the keyring"))
+	return &i
+}
+
+// APIRequest traces one Google API request through RetryTransport.
+type APIRequest struct {
+	ctx     context.Context
+	span    trace.Span
+	start   time.Time
+	attrs   []attribute.KeyValue
+	retries int
+	wait    time.Duration
+}
+
+// StartAPIRequest starts a span for a request to service. apiMethod is the
+// request path with resource IDs replaced, e.g. "drive/v3/files/{id}".
+func StartAPIRequest(ctx context.Context, service, httpMethod, apiMethod string) *APIRequest {
+	attrs := []attribute.KeyValue{
+		AttrService.String(service),
+		AttrHTTPMethod.String(httpMethod),
+		AttrAPIMethod.String(apiMethod),
+	}
+	ctx, span := tracer().Start(nestUnderPagination(ctx), httpMethod+" "+apiMethod,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+	return &APIRequest{ctx: ctx, span: span, start: time.Now(), attrs: attrs}
+}
+
+// Retry records that the request is retried after wait because of reason,
+// e.g. "429", "5xx", or "insufficient_scopes".
+func (r *APIRequest) Retry(reason string, status int, wait time.Duration) {
+	r.retries++
+	r.wait += wait
+	r.span.AddEvent("retry", trace.WithAttributes(
+		attribute.String("gog.retry.reason", reason),
+		AttrHTTPStatus.Int(status),
+		AttrRetryWait.Int64(wait.Milliseconds()),
+		AttrRetryCount.Int(r.retries),
+	))
+	instruments().apiRetries.Add(r.ctx, 1, metric.WithAttributes(
+		commandAttr(), r.attrs[0], attribute.String("gog.retry.reason", reason),
+	))
+}
+
+// CircuitOpen records that the circuit breaker rejected the request.
+func (r *APIRequest) CircuitOpen() {
+	r.span.SetAttributes(AttrCircuitOpen.Bool(true))
+}
+
+// End finishes the span with the final response or error.
+func (r *APIRequest) End(resp *http.Response, err error) {
+	status := 0
+	if resp != nil {
+		status = resp.StatusCode
+	}
+	r.span.SetAttributes(
+		AttrRetryCount.Int(r.retries),
+		AttrRetryWait.Int64(r.wait.Milliseconds()),
+	)
+	if status != 0 {
+		r.span.SetAttributes(AttrHTTPStatus.Int(status))
+	}
+	if err == nil && status >= 400 {
+		err = httpStatusError(status)
+	}
+	setStatus(r.span, err)
+	r.span.End()
+
+	attrs := metric.WithAttributes(commandAttr(), r.attrs[0], r.attrs[1], AttrHTTPStatus.Int(status))
+	instruments().apiRequests.Add(r.ctx, 1, attrs)
+	instruments().apiDuration.Record(r.ctx, time.Since(r.start).Seconds(), attrs)
+}
+
+type httpStatusError int
+
+func (e httpStatusError) Error() string {
+	return "HTTP " + strconv.Itoa(int(e))
+}
+
+// StartKeyringOpen starts a span for opening the keyring backend. Call end
+// with the open error; timedOut marks opens abandoned after timeout.
+func StartKeyringOpen(backend string, timeout time.Duration) func(timedOut bool, err error) {
+	ctx, span := tracer().Start(Context(), "keyring.open", trace.WithAttributes(
+		attribute.String("gog.keyring.backend", backend),
+		AttrKeyringTimeout.Int64(timeout.Milliseconds()),
+	))
+	start := time.Now()
+	return func(timedOut bool, err error) {
+		span.SetAttributes(AttrTimedOut.Bool(timedOut))
+		setStatus(span, err)
+		span.End()
+		instruments().keyringOpen.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+			commandAttr(), attribute.String("gog.keyring.backend", backend), AttrTimedOut.Bool(timedOut),
+		))
+	}
+}
+
+// Pagination traces a loop that fetches every result page.
+type Pagination struct {
+	ctx    context.Context
+	span   trace.Span
+	parent context.Context
+	pages  int
+	items  int
+}
+
+// StartPagination starts a span under the running command. Until End, API
+// requests made with the command context are parented on it.
+func StartPagination() *Pagination {
+	ctx, span := tracer().Start(Context(), "paginate")
+	stateMu.Lock()
+	parent := paginationCtx
+	paginationCtx = ctx
+	stateMu.Unlock()
+	return &Pagination{ctx: ctx, span: span, parent: parent}
+}
+
+// Page records one fetched page of n items.
+func (p *Pagination) Page(n int, d time.Duration) {
+	p.pages++
+	p.items += n
+	p.span.AddEvent("page", trace.WithAttributes(
+		attribute.Int("gog.page", p.pages),
+		AttrItems.Int(n),
+		attribute.Int64("gog.page.duration_ms", d.Milliseconds()),
+	))
+	instruments().pages.Add(p.ctx, 1, metric.WithAttributes(commandAttr()))
+}
+
+// End finishes the span with the totals and err.
+func (p *Pagination) End(err error) {
+	p.span.SetAttributes(AttrPages.Int(p.pages), AttrItems.Int(p.items))
+	setStatus(p.span, err)
+	p.span.End()
+	stateMu.Lock()
+	paginationCtx = p.parent
+	stateMu.Unlock()
+}
+
+// nestUnderPagination swaps the command span in ctx for an active pagination
+// span, since the page fetch callbacks only carry the command context.
+func nestUnderPagination(ctx context.Context) context.Context {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	if paginationCtx == nil || commandCtx == nil {
+		return ctx
+	}
+	if trace.SpanContextFromContext(ctx).SpanID() != trace.SpanContextFromContext(commandCtx).SpanID() {
+		return ctx
+	}
+	return trace.ContextWithSpan(ctx, trace.SpanFromContext(paginationCtx))
+}
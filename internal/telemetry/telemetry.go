@@ -0,0 +1,287 @@
+// Package telemetry exports OpenTelemetry traces and metrics for one gog
+// command to an OTLP/HTTP collector or a local JSON file. It is off unless
+// GOG_OTEL_ENDPOINT or GOG_OTEL_FILE is set.
+package telemetry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/steipete/gogcli"
+	defaultServiceName  = "gog"
+	exportTimeout       = 5 * time.Second
+)
+
+// Attribute keys set on gog spans and metrics.
+const (
+	AttrCommand        = attribute.Key("gog.command")
+	AttrService        = attribute.Key("gog.service")
+	AttrRetryCount     = attribute.Key("gog.retry.count")
+	AttrRetryWait      = attribute.Key("gog.retry.wait_ms")
+	AttrCircuitOpen    = attribute.Key("gog.circuit_breaker.open")
+	AttrExitCode       = attribute.Key("gog.exit_code")
+	AttrAPIMethod      = attribute.Key("gog.api_method")
+	AttrHTTPMethod     = attribute.Key("http.request.method")
+	AttrHTTPStatus     = attribute.Key("http.response.status_code")
+	AttrKeyringTimeout = attribute.Key("gog.keyring.timeout_ms")
+	AttrTimedOut       = attribute.Key("gog.timed_out")
+	AttrPages          = attribute.Key("gog.pages")
+	AttrItems          = attribute.Key("gog.items")
+)
+
+// Config selects where telemetry goes. Endpoint and File may both be set.
+type Config struct {
+	// Endpoint is an OTLP/HTTP base URL such as http://localhost:4318; the
+	// /v1/traces and /v1/metrics paths are appended.
+	Endpoint string
+	Headers  map[string]string
+	// File receives spans and metrics as JSON lines, appended.
+	File        string
+	ServiceName string
+}
+
+// Enabled reports whether any exporter is configured.
+func (c Config) Enabled() bool {
+	return c.Endpoint != "" || c.File != ""
+}
+
+// ConfigFromEnv reads GOG_OTEL_ENDPOINT, GOG_OTEL_HEADERS (k=v,k=v),
+// GOG_OTEL_FILE, and GOG_OTEL_SERVICE_NAME.
+func ConfigFromEnv() (Config, error) {
+	cfg := Config{
+		Endpoint:    strings.TrimSpace(os.Getenv("GOG_OTEL_ENDPOINT")),
+		File:        strings.TrimSpace(os.Getenv("GOG_OTEL_FILE")),
+		ServiceName: strings.TrimSpace(os.Getenv("GOG_OTEL_SERVICE_NAME")),
+	}
+	headers, err := parseHeaders(os.Getenv("GOG_OTEL_HEADERS"))
+	if err != nil {
+		return Config{}, err
+	}
+	cfg.Headers = headers
+	if cfg.Endpoint != "" && !strings.HasPrefix(cfg.Endpoint, "http://") && !strings.HasPrefix(cfg.Endpoint, "https://") {
+		return Config{}, fmt.Errorf("GOG_OTEL_ENDPOINT must be an http:// or https:// URL, got %q", cfg.Endpoint)
+	}
+	return cfg, nil
+}
+
+func parseHeaders(value string) (map[string]string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, nil
+	}
+	headers := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(pair, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return nil, fmt.Errorf("invalid GOG_OTEL_HEADERS entry %q (want key=value)", pair)
+		}
+		headers[k] = strings.TrimSpace(v)
+	}
+	return headers, nil
+}
+
+var (
+	stateMu       sync.RWMutex
+	command       string
+	commandCtx    context.Context
+	paginationCtx context.Context
+)
+
+// Setup installs global trace and meter providers for command. The returned
+// function flushes and stops them; call it before the process exits.
+func Setup(ctx context.Context, cfg Config, commandName, version string) (func(context.Context) error, error) {
+	serviceName := cfg.ServiceName
+	if serviceName == "" {
+		serviceName = defaultServiceName
+	}
+	res := resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		attribute.String("service.version", version),
+	)
+
+	var (
+		traceOpts  = []sdktrace.TracerProviderOption{sdktrace.WithResource(res), sdktrace.WithSpanProcessor(commandSpanProcessor{command: commandName})}
+		meterOpts  = []sdkmetric.Option{sdkmetric.WithResource(res)}
+		closers    []io.Closer
+		haveExport bool
+	)
+	if cfg.Endpoint != "" {
+		base := strings.TrimRight(cfg.Endpoint, "/")
+		spans, err := otlptracehttp.New(ctx,
+			otlptracehttp.WithEndpointURL(base+"/v1/traces"),
+			otlptracehttp.WithHeaders(cfg.Headers),
+			otlptracehttp.WithTimeout(exportTimeout),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("otlp trace exporter: %w", err)
+		}
+		points, err := otlpmetrichttp.New(ctx,
+			otlpmetrichttp.WithEndpointURL(base+"/v1/metrics"),
+			otlpmetrichttp.WithHeaders(cfg.Headers),
+			otlpmetrichttp.WithTimeout(exportTimeout),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("otlp metric exporter: %w", err)
+		}
+		// A CLI run is short; batch so export happens once at shutdown.
+		traceOpts = append(traceOpts, sdktrace.WithBatcher(spans))
+		meterOpts = append(meterOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(points)))
+		haveExport = true
+	}
+	if cfg.File != "" {
+		f, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600) //nolint:gosec // user-chosen trace file
+		if err != nil {
+			return nil, fmt.Errorf("open telemetry file: %w", err)
+		}
+		closers = append(closers, f)
+		w := &lockedWriter{w: f}
+		spans, err := stdouttrace.New(stdouttrace.WithWriter(w))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("file trace exporter: %w", err)
+		}
+		points, err := stdoutmetric.New(stdoutmetric.WithEncoder(json.NewEncoder(w)))
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("file metric exporter: %w", err)
+		}
+		traceOpts = append(traceOpts, sdktrace.WithSyncer(spans))
+		meterOpts = append(meterOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(points)))
+		haveExport = true
+	}
+	if !haveExport {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	tp := sdktrace.NewTracerProvider(traceOpts...)
+	mp := sdkmetric.NewMeterProvider(meterOpts...)
+	otel.SetTracerProvider(tp)
+	otel.SetMeterProvider(mp)
+	otel.SetErrorHandler(otel.ErrorHandlerFunc(func(err error) {
+		slog.Warn("telemetry export failed", "err", err)
+	}))
+	setInstruments(mp.Meter(instrumentationName))
+	stateMu.Lock()
+	command = commandName
+	stateMu.Unlock()
+
+	return func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, exportTimeout)
+		defer cancel()
+		err := errors.Join(tp.Shutdown(ctx), mp.Shutdown(ctx))
+		for _, c := range closers {
+			err = errors.Join(err, c.Close())
+		}
+		setInstruments(noop.NewMeterProvider().Meter(instrumentationName))
+		stateMu.Lock()
+		command, commandCtx, paginationCtx = "", nil, nil
+		stateMu.Unlock()
+		return err
+	}, nil
+}
+
+// lockedWriter serializes the trace and metric exporters sharing one file.
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(p []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(p)
+}
+
+// commandSpanProcessor stamps every span with the command path.
+type commandSpanProcessor struct {
+	command string
+}
+
+func (p commandSpanProcessor) OnStart(_ context.Context, s sdktrace.ReadWriteSpan) {
+	if p.command != "" {
+		s.SetAttributes(AttrCommand.String(p.command))
+	}
+}
+
+func (commandSpanProcessor) OnEnd(sdktrace.ReadOnlySpan)      {}
+func (commandSpanProcessor) Shutdown(context.Context) error   { return nil }
+func (commandSpanProcessor) ForceFlush(context.Context) error { return nil }
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// StartCommand starts the root span for a command. Code paths without a
+// context of their own parent their spans on it through Context. end records
+// the exit code and error.
+func StartCommand(ctx context.Context, commandName string) (context.Context, func(exitCode int, err error)) {
+	start := time.Now()
+	ctx, span := tracer().Start(ctx, "gog "+commandName, trace.WithAttributes(AttrCommand.String(commandName)))
+	stateMu.Lock()
+	commandCtx = ctx
+	stateMu.Unlock()
+
+	return ctx, func(exitCode int, err error) {
+		span.SetAttributes(AttrExitCode.Int(exitCode))
+		setStatus(span, err)
+		span.End()
+		instruments().commandDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+			AttrCommand.String(commandName),
+			AttrExitCode.Int(exitCode),
+		))
+	}
+}
+
+// Context returns the running command's span context, or Background before
+// StartCommand.
+func Context() context.Context {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	if commandCtx == nil {
+		return context.Background()
+	}
+	return commandCtx
+}
+
+func commandAttr() attribute.KeyValue {
+	stateMu.RLock()
+	defer stateMu.RUnlock()
+	return AttrCommand.String(command)
+}
+
+func setStatus(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return
+	}
+	span.SetStatus(codes.Ok, "")
+}
@@ -0,0 +1,225 @@
+package telemetry
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// collector is a minimal OTLP/HTTP receiver.
+type collector struct {
+	mu      sync.Mutex
+	spans   []*tracepb.Span
+	metrics int
+	headers http.Header
+}
+
+func newCollector(t *testing.T) (*collector, *httptest.Server) {
+	t.Helper()
+
+	c := &collector{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.headers = r.Header.Clone()
+		switch r.URL.Path {
+		case "/v1/traces":
+			var req coltracepb.ExportTraceServiceRequest
+			if err := proto.Unmarshal(body, &req); err != nil {
+				t.Errorf("decode traces: %v", err)
+			}
+			for _, rs := range req.GetResourceSpans() {
+				for _, ss := range rs.GetScopeSpans() {
+					c.spans = append(c.spans, ss.GetSpans()...)
+				}
+			}
+		case "/v1/metrics":
+			c.metrics++
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	return c, srv
+}
+
+func spanAttr(span *tracepb.Span, key string) (string, int64, bool) {
+	for _, kv := range span.GetAttributes() {
+		if kv.GetKey() == key {
+			return kv.GetValue().GetStringValue(), kv.GetValue().GetIntValue(), true
+		}
+	}
+	return "", 0, false
+}
+
+// runCommand emits the spans of a paginated list whose second page fetch
+// was retried once.
+func runCommand(t *testing.T, cfg Config) {
+	t.Helper()
+
+	shutdown, err := Setup(context.Background(), cfg, "drive ls", "test")
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	ctx, end := StartCommand(context.Background(), "drive ls")
+
+	pages := StartPagination()
+	for page := 0; page < 2; page++ {
+		req := StartAPIRequest(ctx, "drive", http.MethodGet, "drive/v3/files")
+		if page == 1 {
+			req.Retry("429", http.StatusTooManyRequests, 10*time.Millisecond)
+		}
+		req.End(&http.Response{StatusCode: http.StatusOK}, nil)
+		pages.Page(3, time.Millisecond)
+	}
+	pages.End(nil)
+
+	endKeyring := StartKeyringOpen("file", time.Second)
+	endKeyring(true, errors.New("timed out"))
+
+	end(3, nil)
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+}
+
+func TestSetup_ExportsOTLP(t *testing.T) {
+	c, srv := newCollector(t)
+	runCommand(t, Config{Endpoint: srv.URL, Headers: map[string]string{"X-Api-Key": "secret"}})
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.headers.Get("X-Api-Key") != "secret" {
+		t.Fatalf("headers = %v", c.headers)
+	}
+	if c.metrics == 0 {
+		t.Fatalf("no metrics exported")
+	}
+
+	byName := map[string][]*tracepb.Span{}
+	for _, span := range c.spans {
+		byName[span.GetName()] = append(byName[span.GetName()], span)
+		if command, _, _ := spanAttr(span, "gog.command"); command != "drive ls" {
+			t.Fatalf("span %q gog.command = %q", span.GetName(), command)
+		}
+	}
+
+	root := byName["gog drive ls"]
+	if len(root) != 1 {
+		t.Fatalf("spans = %v", byName)
+	}
+	if _, code, _ := spanAttr(root[0], "gog.exit_code"); code != 3 {
+		t.Fatalf("exit code = %d", code)
+	}
+
+	paginate := byName["paginate"]
+	if len(paginate) != 1 || string(paginate[0].GetParentSpanId()) != string(root[0].GetSpanId()) {
+		t.Fatalf("paginate spans = %v", paginate)
+	}
+	if _, pages, _ := spanAttr(paginate[0], "gog.pages"); pages != 2 {
+		t.Fatalf("gog.pages = %d", pages)
+	}
+
+	requests := byName["GET drive/v3/files"]
+	if len(requests) != 2 {
+		t.Fatalf("request spans = %d", len(requests))
+	}
+	for i, req := range requests {
+		if string(req.GetParentSpanId()) != string(paginate[0].GetSpanId()) {
+			t.Fatalf("request %d not nested under paginate", i)
+		}
+		if service, _, _ := spanAttr(req, "gog.service"); service != "drive" {
+			t.Fatalf("gog.service = %q", service)
+		}
+	}
+	if _, retries, _ := spanAttr(requests[1], "gog.retry.count"); retries != 1 {
+		t.Fatalf("gog.retry.count = %d", retries)
+	}
+
+	keyring := byName["keyring.open"]
+	if len(keyring) != 1 || keyring[0].GetStatus().GetCode() != tracepb.Status_STATUS_CODE_ERROR {
+		t.Fatalf("keyring spans = %v", keyring)
+	}
+}
+
+func TestSetup_WritesJSONFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trace.jsonl")
+	runCommand(t, Config{File: path})
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer f.Close()
+
+	names := map[string]bool{}
+	sawMetrics := false
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), 4<<20)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("line %q: %v", scanner.Text(), err)
+		}
+		if name, ok := line["Name"].(string); ok {
+			names[name] = true
+		}
+		if _, ok := line["ScopeMetrics"]; ok {
+			sawMetrics = true
+		}
+	}
+	for _, want := range []string{"gog drive ls", "paginate", "GET drive/v3/files", "keyring.open"} {
+		if !names[want] {
+			t.Fatalf("missing span %q in %v", want, names)
+		}
+	}
+	if !sawMetrics {
+		t.Fatalf("no metrics in %s", path)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv("GOG_OTEL_ENDPOINT", "http://localhost:4318")
+	t.Setenv("GOG_OTEL_HEADERS", "authorization=Bearer x, x-team = ops")
+	t.Setenv("GOG_OTEL_FILE", "")
+
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("ConfigFromEnv: %v", err)
+	}
+	if !cfg.Enabled() || cfg.Headers["authorization"] != "Bearer x" || cfg.Headers["x-team"] != "ops" {
+		t.Fatalf("cfg = %+v", cfg)
+	}
+
+	t.Setenv("GOG_OTEL_HEADERS", "novalue")
+	if _, err := ConfigFromEnv(); err == nil || !strings.Contains(err.Error(), "GOG_OTEL_HEADERS") {
+		t.Fatalf("err = %v", err)
+	}
+
+	t.Setenv("GOG_OTEL_HEADERS", "")
+	t.Setenv("GOG_OTEL_ENDPOINT", "localhost:4318")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for endpoint without scheme")
+	}
+}
@@ -0,0 +1,317 @@
+// Package undo keeps a per-account journal of the inverse of reversible
+// mutations so `gog undo` can replay them.
+package undo
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steipete/gogcli/internal/config"
+	"github.com/steipete/gogcli/internal/filelock"
+)
+
+// Inverse operation kinds. Each names the API call `gog undo` makes.
+const (
+	KindGmailModify           = "gmail.modify"
+	KindDriveUpdate           = "drive.update"
+	KindDrivePermissionCreate = "drive.permission.create"
+	KindDrivePermissionDelete = "drive.permission.delete"
+	KindCalendarMove          = "calendar.move"
+	KindTaskStatus            = "tasks.status"
+)
+
+const (
+	// DefaultMaxEntries is how many entries a journal keeps per account.
+	DefaultMaxEntries = 500
+	lockTimeout       = 5 * time.Second
+)
+
+var ErrNotFound = errors.New("undo entry not found")
+
+// Op is one inverse API call. Only the fields for its Kind are set.
+type Op struct {
+	Kind string `json:"kind"`
+
+	// gmail.modify: label changes on messages, or threads when Threads is set.
+	IDs          []string `json:"ids,omitempty"`
+	Threads      bool     `json:"threads,omitempty"`
+	AddLabels    []string `json:"add_labels,omitempty"`
+	RemoveLabels []string `json:"remove_labels,omitempty"`
+
+	// drive.update and drive.permission.*.
+	FileID        string      `json:"file_id,omitempty"`
+	Name          string      `json:"name,omitempty"`
+	AddParents    []string    `json:"add_parents,omitempty"`
+	RemoveParents []string    `json:"remove_parents,omitempty"`
+	PermissionID  string      `json:"permission_id,omitempty"`
+	Permission    *Permission `json:"permission,omitempty"`
+
+	// calendar.move.
+	CalendarID  string `json:"calendar_id,omitempty"`
+	EventID     string `json:"event_id,omitempty"`
+	Destination string `json:"destination,omitempty"`
+
+	// tasks.status.
+	TasklistID string `json:"tasklist_id,omitempty"`
+	TaskID     string `json:"task_id,omitempty"`
+	Status     string `json:"status,omitempty"`
+}
+
+// Permission is the Drive permission re-created by drive.permission.create.
+type Permission struct {
+	Type               string `json:"type"`
+	Role               string `json:"role"`
+	EmailAddress       string `json:"email_address,omitempty"`
+	Domain             string `json:"domain,omitempty"`
+	AllowFileDiscovery bool   `json:"allow_file_discovery,omitempty"`
+}
+
+// Describe returns a one-line preview of op.
+func (op Op) Describe() string {
+	switch op.Kind {
+	case KindGmailModify:
+		target := "message"
+		if op.Threads {
+			target = "thread"
+		}
+		var changes []string
+		if len(op.AddLabels) > 0 {
+			changes = append(changes, "add "+strings.Join(op.AddLabels, ","))
+		}
+		if len(op.RemoveLabels) > 0 {
+			changes = append(changes, "remove "+strings.Join(op.RemoveLabels, ","))
+		}
+		return fmt.Sprintf("gmail: %s on %d %s%s", strings.Join(changes, ", "), len(op.IDs), target, plural(len(op.IDs)))
+	case KindDriveUpdate:
+		var changes []string
+		if op.Name != "" {
+			changes = append(changes, fmt.Sprintf("rename %s to %q", op.FileID, op.Name))
+		}
+		if len(op.AddParents) > 0 || len(op.RemoveParents) > 0 {
+			move := "move " + op.FileID
+			if len(op.AddParents) > 0 {
+				move += " into " + strings.Join(op.AddParents, ",")
+			}
+			if len(op.RemoveParents) > 0 {
+				move += " out of " + strings.Join(op.RemoveParents, ",")
+			}
+			changes = append(changes, move)
+		}
+		return "drive: " + strings.Join(changes, ", ")
+	case KindDrivePermissionCreate:
+		p := op.Permission
+		if p == nil {
+			return "drive: re-share " + op.FileID
+		}
+		who := p.Type
+		switch {
+		case p.EmailAddress != "":
+			who = p.EmailAddress
+		case p.Domain != "":
+			who = p.Domain
+		}
+		return fmt.Sprintf("drive: share %s with %s as %s", op.FileID, who, p.Role)
+	case KindDrivePermissionDelete:
+		return fmt.Sprintf("drive: remove permission %s from %s", op.PermissionID, op.FileID)
+	case KindCalendarMove:
+		return fmt.Sprintf("calendar: move event %s from %s to %s", op.EventID, op.CalendarID, op.Destination)
+	case KindTaskStatus:
+		return fmt.Sprintf("tasks: set %s in %s to %s", op.TaskID, op.TasklistID, op.Status)
+	default:
+		return op.Kind
+	}
+}
+
+// Entry is one journaled command and the ops that reverse it, in the order
+// they must run.
+type Entry struct {
+	ID       string     `json:"id"`
+	Time     time.Time  `json:"time"`
+	Account  string     `json:"account"`
+	Command  string     `json:"command"`
+	Summary  string     `json:"summary"`
+	Inverse  []Op       `json:"inverse"`
+	UndoneAt *time.Time `json:"undone_at,omitempty"`
+}
+
+// Journal stores entries in one JSON file per account under Dir.
+type Journal struct {
+	Dir        string
+	MaxEntries int
+	Now        func() time.Time
+}
+
+type journalFile struct {
+	Entries []Entry `json:"entries"`
+}
+
+// Record appends entry, assigning its ID and time, and drops the oldest
+// entries beyond MaxEntries.
+func (j Journal) Record(entry Entry) (Entry, error) {
+	if len(entry.Inverse) == 0 {
+		return entry, errors.New("undo entry has no inverse operations")
+	}
+	entry.Time = j.now()
+	entry.ID = newID(entry.Time)
+	err := j.update(entry.Account, func(f *journalFile) {
+		f.Entries = append(f.Entries, entry)
+		if limit := j.maxEntries(); len(f.Entries) > limit {
+			f.Entries = f.Entries[len(f.Entries)-limit:]
+		}
+	})
+	return entry, err
+}
+
+// List returns account's entries, newest first.
+func (j Journal) List(account string) ([]Entry, error) {
+	f, err := j.read(j.path(account))
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Entry, 0, len(f.Entries))
+	for i := len(f.Entries) - 1; i >= 0; i-- {
+		out = append(out, f.Entries[i])
+	}
+	return out, nil
+}
+
+// Get returns the entry with id.
+func (j Journal) Get(account, id string) (Entry, error) {
+	entries, err := j.List(account)
+	if err != nil {
+		return Entry{}, err
+	}
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, nil
+		}
+	}
+	return Entry{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+}
+
+// Pending returns up to n entries that have not been undone, newest first.
+func (j Journal) Pending(account string, n int) ([]Entry, error) {
+	entries, err := j.List(account)
+	if err != nil {
+		return nil, err
+	}
+	var out []Entry
+	for _, entry := range entries {
+		if len(out) >= n {
+			break
+		}
+		if entry.UndoneAt == nil {
+			out = append(out, entry)
+		}
+	}
+	return out, nil
+}
+
+// MarkUndone records that the entry with id has been reversed.
+func (j Journal) MarkUndone(account, id string) error {
+	at := j.now()
+	found := false
+	err := j.update(account, func(f *journalFile) {
+		for i := range f.Entries {
+			if f.Entries[i].ID == id {
+				f.Entries[i].UndoneAt = &at
+				found = true
+			}
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+	return nil
+}
+
+func (j Journal) update(account string, fn func(*journalFile)) error {
+	path := j.path(account)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("create undo journal directory: %w", err)
+	}
+	return filelock.Shared(path+".lock", lockTimeout).WithExclusive(func() error {
+		f, err := j.read(path)
+		if err != nil {
+			return err
+		}
+		fn(&f)
+		data, err := json.MarshalIndent(f, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode undo journal: %w", err)
+		}
+		return config.WriteFileAtomic(path, append(data, '\n'), 0o600)
+	})
+}
+
+func (j Journal) read(path string) (journalFile, error) {
+	var f journalFile
+	data, err := os.ReadFile(path) //nolint:gosec // journal path under the state directory
+	if errors.Is(err, os.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return f, fmt.Errorf("read undo journal: %w", err)
+	}
+	if err := json.Unmarshal(data, &f); err != nil {
+		return f, fmt.Errorf("decode undo journal %s: %w", path, err)
+	}
+	return f, nil
+}
+
+func (j Journal) path(account string) string {
+	return filepath.Join(j.Dir, fileName(account)+".json")
+}
+
+func (j Journal) now() time.Time {
+	if j.Now != nil {
+		return j.Now().UTC()
+	}
+	return time.Now().UTC()
+}
+
+func (j Journal) maxEntries() int {
+	if j.MaxEntries > 0 {
+		return j.MaxEntries
+	}
+	return DefaultMaxEntries
+}
+
+// fileName maps an account to a safe file name.
+func fileName(account string) string {
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		return "default"
+	}
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '@', r == '.', r == '-', r == '_', r == '+':
+			return r
+		default:
+			return '_'
+		}
+	}, account)
+}
+
+func newID(t time.Time) string {
+	var b [3]byte
+	_, _ = rand.Read(b[:])
+	return t.Format("20060102-150405") + "-" + hex.EncodeToString(b[:])
+}
+
+func plural(n int) string {
+	if n == 1 {
+		return ""
+	}
+	return "s"
+}
@@ -0,0 +1,89 @@
+package undo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJournalRecordListAndMarkUndone(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	j := Journal{Dir: t.TempDir(), MaxEntries: 2, Now: func() time.Time { return now }}
+
+	var ids []string
+	for i, summary := range []string{"first", "second", "third"} {
+		now = now.Add(time.Duration(i) * time.Minute)
+		entry, err := j.Record(Entry{Account: "A@B.com", Command: "gmail archive", Summary: summary, Inverse: []Op{{Kind: KindGmailModify, IDs: []string{"m1"}, AddLabels: []string{"INBOX"}}}})
+		if err != nil {
+			t.Fatalf("Record: %v", err)
+		}
+		ids = append(ids, entry.ID)
+	}
+
+	entries, err := j.List("a@b.com")
+	if err != nil || len(entries) != 2 || entries[0].Summary != "third" || entries[1].Summary != "second" {
+		t.Fatalf("List = %+v, %v", entries, err)
+	}
+	if _, err := j.Get("a@b.com", ids[0]); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("trimmed entry err = %v", err)
+	}
+
+	if err := j.MarkUndone("a@b.com", ids[2]); err != nil {
+		t.Fatalf("MarkUndone: %v", err)
+	}
+	pending, err := j.Pending("a@b.com", 5)
+	if err != nil || len(pending) != 1 || pending[0].ID != ids[1] {
+		t.Fatalf("Pending = %+v, %v", pending, err)
+	}
+
+	if others, err := j.List("c@d.com"); err != nil || len(others) != 0 {
+		t.Fatalf("other account = %+v, %v", others, err)
+	}
+	if _, err := os.Stat(filepath.Join(j.Dir, "a@b.com.json")); err != nil {
+		t.Fatalf("journal file: %v", err)
+	}
+}
+
+func TestJournalRejectsEntriesWithoutInverse(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (Journal{Dir: t.TempDir()}).Record(Entry{Account: "a@b.com"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestFileNameSanitizesAccount(t *testing.T) {
+	t.Parallel()
+
+	if got := fileName("../Evil/../x@b.com"); strings.ContainsAny(got, "/\\") || got != ".._evil_.._x@b.com" {
+		t.Fatalf("fileName = %q", got)
+	}
+	if got := fileName(" "); got != "default" {
+		t.Fatalf("empty fileName = %q", got)
+	}
+}
+
+func TestOpDescribe(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		op   Op
+		want string
+	}{
+		{Op{Kind: KindGmailModify, IDs: []string{"t1", "t2"}, Threads: true, AddLabels: []string{"INBOX"}}, "gmail: add INBOX on 2 threads"},
+		{Op{Kind: KindDriveUpdate, FileID: "f1", Name: "Old"}, `drive: rename f1 to "Old"`},
+		{Op{Kind: KindDriveUpdate, FileID: "f1", AddParents: []string{"p0"}, RemoveParents: []string{"np"}}, "drive: move f1 into p0 out of np"},
+		{Op{Kind: KindDrivePermissionCreate, FileID: "f1", Permission: &Permission{Type: "user", Role: "writer", EmailAddress: "x@b.com"}}, "drive: share f1 with x@b.com as writer"},
+		{Op{Kind: KindTaskStatus, TasklistID: "l1", TaskID: "t1", Status: "needsAction"}, "tasks: set t1 in l1 to needsAction"},
+	}
+	for _, tt := range tests {
+		if got := tt.op.Describe(); got != tt.want {
+			t.Errorf("Describe() = %q, want %q", got, tt.want)
+		}
+	}
+}
@@ -53,6 +53,7 @@ function loadSchema() {
   for (const key of Object.keys(env)) {
     if (key.toUpperCase().startsWith("GOG_")) delete env[key];
   }
+  env.GOG_NO_PLUGINS = "1";
   try {
     return JSON.parse(execFileSync(bin, ["schema", "--json"], { encoding: "utf8", env, maxBuffer: 32 * 1024 * 1024 }));
   } finally {
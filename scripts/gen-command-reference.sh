@@ -25,6 +25,7 @@ trap cleanup EXIT
 env \
   -u GOG_KEYRING_BACKEND \
   -u GOG_KEYRING_PASSWORD \
+  GOG_NO_PLUGINS=1 \
   XDG_CONFIG_HOME="$schema_config_home" \
   "$BIN" schema --json >"$schema_file"
 